@@ -17,10 +17,13 @@ limitations under the License.
 package main
 
 import (
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/signals"
 
 	consolidatedmessaging "knative.dev/eventing-kafka/pkg/channel/consolidated/apis/messaging"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/controller"
+	resetoffset "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller"
 )
 
 const component = "kafkachannel-controller"
@@ -30,5 +33,18 @@ func init() {
 }
 
 func main() {
-	sharedmain.Main(component, controller.NewController)
+
+	// Shutdown / Cleanup Hook For The ResetOffset Controller
+	defer resetoffset.Shutdown()
+
+	ctx := signals.NewContext()
+
+	// Create A control-protocol ControlPlaneConnectionPool For The ResetOffset Controller
+	connectionPool := ctrlreconciler.NewInsecureControlPlaneConnectionPool()
+	defer connectionPool.Close(ctx)
+
+	sharedmain.MainWithContext(ctx, component,
+		controller.NewController,
+		resetoffset.NewControllerFactory(controller.NewResetOffsetRefMapperFactory(), connectionPool),
+	)
 }
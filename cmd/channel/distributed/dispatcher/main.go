@@ -24,6 +24,7 @@ import (
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -49,8 +50,10 @@ import (
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	"knative.dev/eventing-kafka/pkg/client/informers/externalversions"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
 	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
 	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 )
 
@@ -148,21 +151,46 @@ func main() {
 	}
 	defer controlProtocolServer.Shutdown(5 * time.Second)
 
+	// Create A New Kafka Client From The K8S Config
+	kafkaClient := kafkaclientset.NewForConfigOrDie(k8sConfig)
+
+	// Connect To The KafkaCluster Of The Channel If It References One (Instead Of The ConfigMap's Kafka Cluster)
+	brokers := strings.Split(ekConfig.Kafka.Brokers, ",")
+	saramaConfig := ekConfig.Sarama.Config
+	if environment.KafkaClusterName != "" {
+		cluster, err := kafkaClient.KafkaV1alpha1().KafkaClusters(environment.KafkaClusterNamespace).Get(ctx, environment.KafkaClusterName, metav1.GetOptions{})
+		if err != nil {
+			logger.Fatal("Failed To Get The KafkaCluster Of The Channel - Terminating", zap.Error(err))
+		}
+		brokers, saramaConfig, err = kafkacluster.NewConfig(ctx, k8sClient, cluster, ekConfig.Sarama.Config)
+		if err != nil {
+			logger.Fatal("Failed To Configure The KafkaCluster Of The Channel - Terminating", zap.Error(err))
+		}
+	}
+
 	// Create The Dispatcher With Specified Configuration
+	// Parse The Delivery Ordering Of The Dispatcher
+	ordering, err := commonconsumer.ParseDeliveryOrdering(ekConfig.Channel.Dispatcher.DeliveryOrdering)
+	if err != nil {
+		logger.Fatal("Invalid Delivery Ordering - Terminating", zap.Error(err))
+	}
+
 	dispatcherConfig := dispatch.DispatcherConfig{
 		Logger:          logger,
 		ClientId:        constants.Component,
-		Brokers:         strings.Split(ekConfig.Kafka.Brokers, ","),
+		Brokers:         brokers,
 		Topic:           environment.KafkaTopic,
 		ChannelKey:      environment.ChannelKey,
 		StatsReporter:   statsReporter,
-		MetricsRegistry: ekConfig.Sarama.Config.MetricRegistry,
-		SaramaConfig:    ekConfig.Sarama.Config,
+		MetricsRegistry: saramaConfig.MetricRegistry,
+		SaramaConfig:    saramaConfig,
+		Ordering:        ordering,
+		MaxInFlight:     ekConfig.Channel.Dispatcher.MaxInFlight,
+		RetryTopics:     ekConfig.Channel.Dispatcher.RetryTopics,
 	}
 	dispatcher, managerEvents := dispatch.NewDispatcher(dispatcherConfig, controlProtocolServer, func(ref types.NamespacedName) {})
 
 	// Create KafkaChannel Informer
-	kafkaClient := kafkaclientset.NewForConfigOrDie(k8sConfig)
 	kafkaInformerFactory := externalversions.NewSharedInformerFactory(kafkaClient, environment.ResyncPeriod)
 	kafkaChannelInformer := kafkaInformerFactory.Messaging().V1beta1().KafkaChannels()
 
@@ -179,11 +207,13 @@ func main() {
 		managerEvents,
 	)
 
-	// Watch The Secret For Changes
-	secretObserver := NewSecretObserver(kcController, environment.ChannelKey, dispatcher)
-	err = distributedcommonconfig.InitializeSecretWatcher(ctx, environment.KafkaSecretNamespace, environment.KafkaSecretName, environment.ResyncPeriod, secretObserver)
-	if err != nil {
-		logger.Fatal("Failed To Start Secret Watcher", zap.Error(err))
+	// Watch The Secret For Changes (The Changes Of A KafkaCluster Restart The Dispatcher Instead)
+	if environment.KafkaClusterName == "" {
+		secretObserver := NewSecretObserver(kcController, environment.ChannelKey, dispatcher)
+		err = distributedcommonconfig.InitializeSecretWatcher(ctx, environment.KafkaSecretNamespace, environment.KafkaSecretName, environment.ResyncPeriod, secretObserver)
+		if err != nil {
+			logger.Fatal("Failed To Start Secret Watcher", zap.Error(err))
+		}
 	}
 
 	// Start The Informers
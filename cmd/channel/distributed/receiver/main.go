@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/google/uuid"
@@ -36,6 +37,7 @@ import (
 	eventingmetrics "knative.dev/pkg/metrics"
 	"knative.dev/pkg/signals"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	distributedmessaging "knative.dev/eventing-kafka/pkg/channel/distributed/apis/messaging"
 	distributedcommonconfig "knative.dev/eventing-kafka/pkg/channel/distributed/common/config"
 	commonk8s "knative.dev/eventing-kafka/pkg/channel/distributed/common/k8s"
@@ -48,6 +50,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/producer"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	commonproducer "knative.dev/eventing-kafka/pkg/common/kafka/producer"
 	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 )
@@ -153,6 +156,9 @@ func main() {
 	logger.Info("Registering receiver as alive")
 	healthServer.SetAlive(true)
 
+	// Batch The Events Of Concurrent Requests According To The Producer Configuration
+	commonproducer.ConfigureBatching(ekConfig.Sarama.Config, ekConfig.Channel.Producer.BatchSize, time.Duration(ekConfig.Channel.Producer.LingerMs)*time.Millisecond)
+
 	// Initialize The Kafka Producer In Order To Start Processing Status Events
 	kafkaProducer, err = producer.NewProducer(logger, ekConfig.Sarama.Config, strings.Split(ekConfig.Kafka.Brokers, ","), statsReporter, healthServer)
 	if err != nil {
@@ -160,6 +166,11 @@ func main() {
 	}
 	defer closeProducer()
 
+	// Produce The Events Of The KafkaChannels Referencing A KafkaCluster To That Cluster
+	kafkaProducer.SetClusterResolver(func(channelReference eventingchannel.ChannelReference) (*kafkav1alpha1.KafkaCluster, error) {
+		return channel.GetKafkaCluster(channelReference, environment.SystemNamespace)
+	}, k8sClient)
+
 	channelReporter := eventingchannel.NewStatsReporter(environment.ContainerName, kmeta.ChildName(environment.PodName, uuid.New().String()))
 
 	// Create A New Knative Eventing MessageReceiver (Parses The Channel From The Host Header)
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -38,9 +39,12 @@ import (
 
 	"knative.dev/eventing-kafka/pkg/apis/bindings"
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/apis/sources"
 	kafkasourcedefaultconfig "knative.dev/eventing-kafka/pkg/apis/sources/config"
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkaclusterinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
+	resetoffset "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller"
 	"knative.dev/eventing-kafka/pkg/source/reconciler/binding"
 	"knative.dev/eventing-kafka/pkg/source/reconciler/source"
 )
@@ -53,6 +57,10 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// v1beta1
 	sourcesv1beta1.SchemeGroupVersion.WithKind("KafkaSource"):   &sourcesv1beta1.KafkaSource{},
 	bindingsv1beta1.SchemeGroupVersion.WithKind("KafkaBinding"): &bindingsv1beta1.KafkaBinding{},
+
+	// v1alpha1
+	kafkav1alpha1.SchemeGroupVersion.WithKind("ResetOffset"):  &kafkav1alpha1.ResetOffset{},
+	kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster"): &kafkav1alpha1.KafkaCluster{},
 }
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{}
@@ -127,11 +135,7 @@ func NewKafkaBindingWebhook(opts ...psbinding.ReconcilerOption) injection.Contro
 			binding.ListAll,
 
 			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
-			func(ctx context.Context, _ psbinding.Bindable) (context.Context, error) {
-				// Here is where you would infuse the context with state
-				// (e.g. attach a store with configmap data)
-				return ctx, nil
-			},
+			binding.NewBindableContext(kafkaclusterinformer.Get(ctx).Lister()),
 			opts...,
 		)
 	}
@@ -186,6 +190,11 @@ func main() {
 		kfkSelector = psbinding.WithSelector(psbinding.InclusionSelector)
 	}
 
+	// Create A control-protocol ControlPlaneConnectionPool For The ResetOffset Controller
+	connectionPool := ctrlreconciler.NewInsecureControlPlaneConnectionPool()
+	defer connectionPool.Close(ctx)
+	defer resetoffset.Shutdown()
+
 	sharedmain.WebhookMainWithContext(ctx, component,
 		certificates.NewController,
 		NewDefaultingAdmissionController,
@@ -196,5 +205,8 @@ func main() {
 		binding.NewController, NewKafkaBindingWebhook(kfkSelector),
 
 		source.NewController,
+
+		// The ResetOffset controller handling the ResetOffsets referencing KafkaSources.
+		resetoffset.NewControllerFactory(source.NewResetOffsetRefMapperFactory(), connectionPool),
 	)
 }
@@ -23,6 +23,7 @@ import (
 	"knative.dev/pkg/webhook/resourcesemantics/conversion"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 
 	source "knative.dev/eventing-kafka/pkg/source/reconciler/mtsource"
 	"knative.dev/pkg/configmap"
@@ -40,8 +41,11 @@ import (
 
 	"knative.dev/eventing-kafka/pkg/apis/bindings"
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/apis/sources"
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkaclusterinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
+	resetoffset "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller"
 	"knative.dev/eventing-kafka/pkg/source/reconciler/binding"
 
 	kafkasourcedefaultconfig "knative.dev/eventing-kafka/pkg/apis/sources/config"
@@ -55,6 +59,10 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// v1beta1
 	sourcesv1beta1.SchemeGroupVersion.WithKind("KafkaSource"):   &sourcesv1beta1.KafkaSource{},
 	bindingsv1beta1.SchemeGroupVersion.WithKind("KafkaBinding"): &bindingsv1beta1.KafkaBinding{},
+
+	// v1alpha1
+	kafkav1alpha1.SchemeGroupVersion.WithKind("ResetOffset"):  &kafkav1alpha1.ResetOffset{},
+	kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster"): &kafkav1alpha1.KafkaCluster{},
 }
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{}
@@ -130,11 +138,7 @@ func NewKafkaBindingWebhook(opts ...psbinding.ReconcilerOption) injection.Contro
 			binding.ListAll,
 
 			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
-			func(ctx context.Context, _ psbinding.Bindable) (context.Context, error) {
-				// Here is where you would infuse the context with state
-				// (e.g. attach a store with configmap data)
-				return ctx, nil
-			},
+			binding.NewBindableContext(kafkaclusterinformer.Get(ctx).Lister()),
 			opts...,
 		)
 	}
@@ -189,6 +193,13 @@ func main() {
 		kfkSelector = psbinding.WithSelector(psbinding.InclusionSelector)
 	}
 
+	// Create A control-protocol ControlPlaneConnectionPool Shared By The ResetOffset And KafkaSource Controllers,
+	// The Latter Receiving The Resource Usage Reported By The Multi-Tenant Receive Adapter Pods
+	resourceUsage := source.NewResourceUsageStore()
+	connectionPool := ctrlreconciler.NewInsecureControlPlaneConnectionPool(ctrlreconciler.WithServiceWrapper(resourceUsage.ServiceWrapper))
+	defer connectionPool.Close(ctx)
+	defer resetoffset.Shutdown()
+
 	sharedmain.WebhookMainWithContext(ctx, component,
 		certificates.NewController,
 		NewDefaultingAdmissionController,
@@ -198,6 +209,9 @@ func main() {
 		// For each binding we have a controller and a binding webhook.
 		binding.NewController, NewKafkaBindingWebhook(kfkSelector),
 
-		source.NewController,
+		source.NewControllerFactory(connectionPool, resourceUsage),
+
+		// The ResetOffset controller handling the ResetOffsets referencing KafkaSources.
+		resetoffset.NewControllerFactory(source.NewResetOffsetRefMapperFactory(), connectionPool),
 	)
 }
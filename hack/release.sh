@@ -32,6 +32,7 @@ COMPONENTS=(
   ["source-crds.yaml"]="config/source/common/resources"
   ["source-crd.yaml"]="config/source/common/resources/source"
   ["binding-crd.yaml"]="config/source/common/resources/binding"
+  ["kafkacluster.yaml"]="config/kafkacluster"
 )
 readonly COMPONENTS
 
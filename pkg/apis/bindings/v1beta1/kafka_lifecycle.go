@@ -82,51 +82,54 @@ func (kfb *KafkaBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	// First undo so that we can just unconditionally append below.
 	kfb.Undo(ctx, ps)
 
+	auth := kfb.kafkaAuth(ctx)
+
 	spec := ps.Spec.Template.Spec
 	for i := range spec.InitContainers {
 		spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, corev1.EnvVar{
 			Name:  "KAFKA_BOOTSTRAP_SERVERS",
-			Value: strings.Join(kfb.Spec.BootstrapServers, ","),
+			Value: strings.Join(auth.BootstrapServers, ","),
 		})
-		if kfb.Spec.Net.SASL.Enable {
+		if auth.Net.SASL.Enable {
 			spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, corev1.EnvVar{
 				Name:  "KAFKA_NET_SASL_ENABLE",
 				Value: "true",
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_SASL_USER",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.SASL.User.SecretKeyRef,
+					SecretKeyRef: auth.Net.SASL.User.SecretKeyRef,
 				},
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_SASL_PASSWORD",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.SASL.Password.SecretKeyRef,
+					SecretKeyRef: auth.Net.SASL.Password.SecretKeyRef,
 				},
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_SASL_TYPE",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.SASL.Type.SecretKeyRef,
+					SecretKeyRef: auth.Net.SASL.Type.SecretKeyRef,
 				},
 			})
+			spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, auth.saslMechanismEnv()...)
 		}
-		if kfb.Spec.Net.TLS.Enable {
+		if auth.Net.TLS.Enable {
 			spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, corev1.EnvVar{
 				Name:  "KAFKA_NET_TLS_ENABLE",
 				Value: "true",
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_TLS_CERT",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.TLS.Cert.SecretKeyRef,
+					SecretKeyRef: auth.Net.TLS.Cert.SecretKeyRef,
 				},
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_TLS_KEY",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.TLS.Key.SecretKeyRef,
+					SecretKeyRef: auth.Net.TLS.Key.SecretKeyRef,
 				},
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_TLS_CA_CERT",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.TLS.CACert.SecretKeyRef,
+					SecretKeyRef: auth.Net.TLS.CACert.SecretKeyRef,
 				},
 			})
 		}
@@ -135,48 +138,49 @@ func (kfb *KafkaBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	for i := range spec.Containers {
 		spec.Containers[i].Env = append(spec.Containers[i].Env, corev1.EnvVar{
 			Name:  "KAFKA_BOOTSTRAP_SERVERS",
-			Value: strings.Join(kfb.Spec.BootstrapServers, ","),
+			Value: strings.Join(auth.BootstrapServers, ","),
 		})
 
-		if kfb.Spec.Net.SASL.Enable {
+		if auth.Net.SASL.Enable {
 			spec.Containers[i].Env = append(spec.Containers[i].Env, corev1.EnvVar{
 				Name:  "KAFKA_NET_SASL_ENABLE",
 				Value: "true",
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_SASL_USER",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.SASL.User.SecretKeyRef,
+					SecretKeyRef: auth.Net.SASL.User.SecretKeyRef,
 				},
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_SASL_PASSWORD",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.SASL.Password.SecretKeyRef,
+					SecretKeyRef: auth.Net.SASL.Password.SecretKeyRef,
 				},
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_SASL_TYPE",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.SASL.Type.SecretKeyRef,
+					SecretKeyRef: auth.Net.SASL.Type.SecretKeyRef,
 				},
 			})
+			spec.Containers[i].Env = append(spec.Containers[i].Env, auth.saslMechanismEnv()...)
 		}
-		if kfb.Spec.Net.TLS.Enable {
+		if auth.Net.TLS.Enable {
 			spec.Containers[i].Env = append(spec.Containers[i].Env, corev1.EnvVar{
 				Name:  "KAFKA_NET_TLS_ENABLE",
 				Value: "true",
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_TLS_CERT",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.TLS.Cert.SecretKeyRef,
+					SecretKeyRef: auth.Net.TLS.Cert.SecretKeyRef,
 				},
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_TLS_KEY",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.TLS.Key.SecretKeyRef,
+					SecretKeyRef: auth.Net.TLS.Key.SecretKeyRef,
 				},
 			}, corev1.EnvVar{
 				Name: "KAFKA_NET_TLS_CA_CERT",
 				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: kfb.Spec.Net.TLS.CACert.SecretKeyRef,
+					SecretKeyRef: auth.Net.TLS.CACert.SecretKeyRef,
 				},
 			})
 		}
@@ -195,6 +199,9 @@ func (kfb *KafkaBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 			switch ev.Name {
 			case "KAFKA_NET_TLS_ENABLE", "KAFKA_NET_TLS_CERT", "KAFKA_NET_TLS_KEY", "KAFKA_NET_TLS_CA_CERT",
 				"KAFKA_NET_SASL_ENABLE", "KAFKA_NET_SASL_USER", "KAFKA_NET_SASL_PASSWORD", "KAFKA_NET_SASL_TYPE",
+				"KAFKA_NET_SASL_OAUTH_TOKEN_URL", "KAFKA_NET_SASL_OAUTH_SCOPES",
+				"KAFKA_NET_SASL_KERBEROS_SERVICE_NAME", "KAFKA_NET_SASL_KERBEROS_REALM",
+				"KAFKA_NET_SASL_KERBEROS_KEYTAB", "KAFKA_NET_SASL_KERBEROS_CONFIG",
 				"KAFKA_BOOTSTRAP_SERVERS":
 
 				continue
@@ -214,6 +221,9 @@ func (kfb *KafkaBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 			switch ev.Name {
 			case "KAFKA_NET_TLS_ENABLE", "KAFKA_NET_TLS_CERT", "KAFKA_NET_TLS_KEY", "KAFKA_NET_TLS_CA_CERT",
 				"KAFKA_NET_SASL_ENABLE", "KAFKA_NET_SASL_USER", "KAFKA_NET_SASL_PASSWORD", "KAFKA_NET_SASL_TYPE",
+				"KAFKA_NET_SASL_OAUTH_TOKEN_URL", "KAFKA_NET_SASL_OAUTH_SCOPES",
+				"KAFKA_NET_SASL_KERBEROS_SERVICE_NAME", "KAFKA_NET_SASL_KERBEROS_REALM",
+				"KAFKA_NET_SASL_KERBEROS_KEYTAB", "KAFKA_NET_SASL_KERBEROS_CONFIG",
 				"KAFKA_BOOTSTRAP_SERVERS":
 				continue
 			default:
@@ -223,3 +233,47 @@ func (kfb *KafkaBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 		spec.Containers[i].Env = env
 	}
 }
+
+// saslMechanismEnv returns the environment variables of the OAUTHBEARER / GSSAPI
+// specific settings, limited to the ones referencing a secret.
+func (kas *KafkaAuthSpec) saslMechanismEnv() []corev1.EnvVar {
+	sasl := kas.Net.SASL
+	refs := []struct {
+		name string
+		ref  *corev1.SecretKeySelector
+	}{
+		{name: "KAFKA_NET_SASL_OAUTH_TOKEN_URL", ref: sasl.OAuth.TokenURL.SecretKeyRef},
+		{name: "KAFKA_NET_SASL_OAUTH_SCOPES", ref: sasl.OAuth.Scopes.SecretKeyRef},
+		{name: "KAFKA_NET_SASL_KERBEROS_SERVICE_NAME", ref: sasl.Kerberos.ServiceName.SecretKeyRef},
+		{name: "KAFKA_NET_SASL_KERBEROS_REALM", ref: sasl.Kerberos.Realm.SecretKeyRef},
+		{name: "KAFKA_NET_SASL_KERBEROS_KEYTAB", ref: sasl.Kerberos.Keytab.SecretKeyRef},
+		{name: "KAFKA_NET_SASL_KERBEROS_CONFIG", ref: sasl.Kerberos.Config.SecretKeyRef},
+	}
+
+	var env []corev1.EnvVar
+	for _, r := range refs {
+		if r.ref != nil {
+			env = append(env, corev1.EnvVar{
+				Name:      r.name,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: r.ref},
+			})
+		}
+	}
+	return env
+}
+
+type kafkaAuthKey struct{}
+
+// WithKafkaAuth notes on the context the bootstrap servers and authentication of the KafkaCluster
+// referenced by a KafkaBinding, which are applied by Do in place of the ones of its spec.
+func WithKafkaAuth(ctx context.Context, auth *KafkaAuthSpec) context.Context {
+	return context.WithValue(ctx, kafkaAuthKey{}, auth)
+}
+
+// kafkaAuth returns the KafkaAuthSpec noted on the context, or the one of the spec of the KafkaBinding.
+func (kfb *KafkaBinding) kafkaAuth(ctx context.Context) *KafkaAuthSpec {
+	if auth, ok := ctx.Value(kafkaAuthKey{}).(*KafkaAuthSpec); ok && auth != nil {
+		return auth
+	}
+	return &kfb.Spec.KafkaAuthSpec
+}
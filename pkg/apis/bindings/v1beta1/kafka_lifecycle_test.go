@@ -561,6 +561,137 @@ func TestKafkaBindingDoSASL(t *testing.T) {
 	}
 }
 
+func TestKafkaBindingDoSASLMechanism(t *testing.T) {
+	secretName := "ssssshhhh-dont-tell"
+	secretRef := func(key string) SecretValueFromSource {
+		return SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		}
+	}
+	envFromSecret := func(name string, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name:      name,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: secretRef(key).SecretKeyRef},
+		}
+	}
+
+	vsb := &KafkaBinding{
+		Spec: KafkaBindingSpec{
+			KafkaAuthSpec: KafkaAuthSpec{
+				BootstrapServers: []string{"kafka:9092"},
+				Net: KafkaNetSpec{
+					SASL: KafkaSASLSpec{
+						Enable:   true,
+						User:     secretRef("user"),
+						Password: secretRef("password"),
+						Type:     secretRef("saslType"),
+						OAuth: KafkaSASLOAuthSpec{
+							TokenURL: secretRef("tokenURL"),
+						},
+						Kerberos: KafkaSASLKerberosSpec{
+							Keytab: secretRef("keytab"),
+							Config: secretRef("krb5.conf"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "blah",
+						Image: "busybox",
+						Env: []corev1.EnvVar{{
+							Name:  "KAFKA_NET_SASL_OAUTH_SCOPES",
+							Value: "stale",
+						}},
+					}},
+				},
+			},
+		},
+	}
+	want := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "blah",
+						Image: "busybox",
+						Env: []corev1.EnvVar{
+							{Name: "KAFKA_BOOTSTRAP_SERVERS", Value: "kafka:9092"},
+							{Name: "KAFKA_NET_SASL_ENABLE", Value: "true"},
+							envFromSecret("KAFKA_NET_SASL_USER", "user"),
+							envFromSecret("KAFKA_NET_SASL_PASSWORD", "password"),
+							envFromSecret("KAFKA_NET_SASL_TYPE", "saslType"),
+							envFromSecret("KAFKA_NET_SASL_OAUTH_TOKEN_URL", "tokenURL"),
+							envFromSecret("KAFKA_NET_SASL_KERBEROS_KEYTAB", "keytab"),
+							envFromSecret("KAFKA_NET_SASL_KERBEROS_CONFIG", "krb5.conf"),
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	vsb.Do(context.Background(), got)
+	if !cmp.Equal(got, want) {
+		t.Errorf("Do (-want, +got): %s", cmp.Diff(want, got))
+	}
+
+	vsb.Undo(context.Background(), got)
+	if len(got.Spec.Template.Spec.Containers[0].Env) != 0 {
+		t.Errorf("Undo left environment variables: %v", got.Spec.Template.Spec.Containers[0].Env)
+	}
+}
+
+func TestKafkaBindingDoKafkaCluster(t *testing.T) {
+	vsb := &KafkaBinding{
+		Spec: KafkaBindingSpec{
+			KafkaAuthSpec: KafkaAuthSpec{
+				ClusterRef: &KafkaClusterReference{Name: "cluster"},
+			},
+		},
+	}
+	auth := &KafkaAuthSpec{
+		BootstrapServers: []string{"kafka-1:9093", "kafka-2:9093"},
+		Net: KafkaNetSpec{
+			TLS: KafkaTLSSpec{Enable: true},
+		},
+	}
+
+	got := &duckv1.WithPod{
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "blah",
+						Image: "busybox",
+					}},
+				},
+			},
+		},
+	}
+	want := []corev1.EnvVar{
+		{Name: "KAFKA_BOOTSTRAP_SERVERS", Value: "kafka-1:9093,kafka-2:9093"},
+		{Name: "KAFKA_NET_TLS_ENABLE", Value: "true"},
+		{Name: "KAFKA_NET_TLS_CERT", ValueFrom: &corev1.EnvVarSource{}},
+		{Name: "KAFKA_NET_TLS_KEY", ValueFrom: &corev1.EnvVarSource{}},
+		{Name: "KAFKA_NET_TLS_CA_CERT", ValueFrom: &corev1.EnvVarSource{}},
+	}
+
+	vsb.Do(WithKafkaAuth(context.Background(), auth), got)
+	if env := got.Spec.Template.Spec.Containers[0].Env; !cmp.Equal(env, want) {
+		t.Errorf("Do (-want, +got): %s", cmp.Diff(want, env))
+	}
+}
+
 func TestKafkaBindingDoTLS(t *testing.T) {
 	url := apis.URL{
 		Scheme: "http",
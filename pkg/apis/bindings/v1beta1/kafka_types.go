@@ -57,9 +57,47 @@ type KafkaSASLSpec struct {
 	// +optional
 	Password SecretValueFromSource `json:"password,omitempty"`
 
-	// Type of saslType, defaults to plain (vs SCRAM-SHA-512 or SCRAM-SHA-256)
+	// Type of saslType, defaults to plain (vs SCRAM-SHA-512, SCRAM-SHA-256, OAUTHBEARER or GSSAPI)
 	// +optional
 	Type SecretValueFromSource `json:"type,omitempty"`
+
+	// OAuth contains the settings of the OAUTHBEARER saslType, for which the User and
+	// Password are the client ID and secret of the OAuth client credentials flow.
+	// +optional
+	OAuth KafkaSASLOAuthSpec `json:"oauth,omitempty"`
+
+	// Kerberos contains the settings of the GSSAPI saslType, for which the User is the
+	// Kerberos principal and the Password is only used if no Keytab is provided.
+	// +optional
+	Kerberos KafkaSASLKerberosSpec `json:"kerberos,omitempty"`
+}
+
+type KafkaSASLOAuthSpec struct {
+	// TokenURL is the Kubernetes secret containing the token endpoint of the OAuth server.
+	// +optional
+	TokenURL SecretValueFromSource `json:"tokenURL,omitempty"`
+
+	// Scopes is the Kubernetes secret containing the comma separated list of OAuth scopes to request.
+	// +optional
+	Scopes SecretValueFromSource `json:"scopes,omitempty"`
+}
+
+type KafkaSASLKerberosSpec struct {
+	// ServiceName is the Kubernetes secret containing the Kerberos service name of the brokers (e.g. kafka).
+	// +optional
+	ServiceName SecretValueFromSource `json:"serviceName,omitempty"`
+
+	// Realm is the Kubernetes secret containing the Kerberos realm.
+	// +optional
+	Realm SecretValueFromSource `json:"realm,omitempty"`
+
+	// Keytab is the Kubernetes secret containing the base64 encoded keytab of the principal.
+	// +optional
+	Keytab SecretValueFromSource `json:"keytab,omitempty"`
+
+	// Config is the Kubernetes secret containing the krb5.conf Kerberos configuration.
+	// +optional
+	Config SecretValueFromSource `json:"config,omitempty"`
 }
 
 type KafkaTLSSpec struct {
@@ -89,10 +127,26 @@ type KafkaNetSpec struct {
 
 type KafkaAuthSpec struct {
 	// Bootstrap servers are the Kafka servers the consumer will connect to.
-	// +required
-	BootstrapServers []string `json:"bootstrapServers"`
+	// Required unless a ClusterRef is specified.
+	// +optional
+	BootstrapServers []string `json:"bootstrapServers,omitempty"`
 
 	Net KafkaNetSpec `json:"net,omitempty"`
+
+	// ClusterRef references the KafkaCluster providing the bootstrap servers and authentication,
+	// in which case BootstrapServers and Net must not be specified.
+	// +optional
+	ClusterRef *KafkaClusterReference `json:"clusterRef,omitempty"`
+}
+
+// KafkaClusterReference references a KafkaCluster (kafka.eventing.knative.dev/v1alpha1).
+type KafkaClusterReference struct {
+	// Name of the KafkaCluster.
+	Name string `json:"name"`
+
+	// Namespace of the KafkaCluster, which defaults to the namespace of the referencing resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // KafkaBindingSpec defines the desired state of the KafkaBinding.
@@ -24,5 +24,40 @@ import (
 
 // Validate ensures KafkaBinding is properly configured.
 func (r *KafkaBinding) Validate(ctx context.Context) *apis.FieldError {
+	ctx = apis.WithinParent(ctx, r.ObjectMeta)
+	return r.Spec.KafkaAuthSpec.Validate(ctx).ViaField("spec")
+}
+
+// Validate ensures the KafkaAuthSpec either specifies the bootstrap servers or references a KafkaCluster.
+// The referenced KafkaCluster must be in the namespace of the parent resource, whose workloads consume its
+// Secrets.
+func (kas *KafkaAuthSpec) Validate(ctx context.Context) *apis.FieldError {
+	if kas.ClusterRef == nil {
+		if len(kas.BootstrapServers) == 0 {
+			return apis.ErrMissingOneOf("bootstrapServers", "clusterRef")
+		}
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if len(kas.BootstrapServers) > 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("bootstrapServers", "clusterRef"))
+	}
+	if kas.Net != (KafkaNetSpec{}) {
+		errs = errs.Also(apis.ErrDisallowedFields("net"))
+	}
+	errs = errs.Also(kas.ClusterRef.Validate(ctx).ViaField("clusterRef"))
+	if namespace := apis.ParentMeta(ctx).Namespace; kas.ClusterRef.Namespace != "" && kas.ClusterRef.Namespace != namespace {
+		errs = errs.Also(apis.ErrInvalidValue(kas.ClusterRef.Namespace, "clusterRef.namespace",
+			"the KafkaCluster must be in the same namespace"))
+	}
+	return errs
+}
+
+// Validate ensures the KafkaClusterReference names a KafkaCluster.
+func (ref *KafkaClusterReference) Validate(_ context.Context) *apis.FieldError {
+	if ref.Name == "" {
+		return apis.ErrMissingField("name")
+	}
 	return nil
 }
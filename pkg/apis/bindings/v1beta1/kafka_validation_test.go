@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKafkaBindingValidate(t *testing.T) {
+	testCases := map[string]struct {
+		spec    KafkaAuthSpec
+		allowed bool
+	}{
+		"bootstrap servers": {
+			spec:    KafkaAuthSpec{BootstrapServers: []string{"servers"}},
+			allowed: true,
+		},
+		"missing bootstrap servers": {
+			spec:    KafkaAuthSpec{},
+			allowed: false,
+		},
+		"cluster ref": {
+			spec:    KafkaAuthSpec{ClusterRef: &KafkaClusterReference{Name: "cluster"}},
+			allowed: true,
+		},
+		"cluster ref in the same namespace": {
+			spec:    KafkaAuthSpec{ClusterRef: &KafkaClusterReference{Name: "cluster", Namespace: "ns"}},
+			allowed: true,
+		},
+		"cluster ref to another namespace": {
+			spec:    KafkaAuthSpec{ClusterRef: &KafkaClusterReference{Name: "cluster", Namespace: "other"}},
+			allowed: false,
+		},
+		"cluster ref and bootstrap servers": {
+			spec: KafkaAuthSpec{
+				BootstrapServers: []string{"servers"},
+				ClusterRef:       &KafkaClusterReference{Name: "cluster"},
+			},
+			allowed: false,
+		},
+		"cluster ref and net": {
+			spec: KafkaAuthSpec{
+				Net:        KafkaNetSpec{SASL: KafkaSASLSpec{Enable: true}},
+				ClusterRef: &KafkaClusterReference{Name: "cluster"},
+			},
+			allowed: false,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			binding := &KafkaBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "ns"},
+				Spec:       KafkaBindingSpec{KafkaAuthSpec: tc.spec},
+			}
+			err := binding.Validate(context.Background())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Validate() = %v, allowed %v", err, tc.allowed)
+			}
+		})
+	}
+}
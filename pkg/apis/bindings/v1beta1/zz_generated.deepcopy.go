@@ -35,6 +35,11 @@ func (in *KafkaAuthSpec) DeepCopyInto(out *KafkaAuthSpec) {
 		copy(*out, *in)
 	}
 	in.Net.DeepCopyInto(&out.Net)
+	if in.ClusterRef != nil {
+		in, out := &in.ClusterRef, &out.ClusterRef
+		*out = new(KafkaClusterReference)
+		**out = **in
+	}
 	return
 }
 
@@ -144,6 +149,22 @@ func (in *KafkaBindingStatus) DeepCopy() *KafkaBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaClusterReference) DeepCopyInto(out *KafkaClusterReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaClusterReference.
+func (in *KafkaClusterReference) DeepCopy() *KafkaClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaNetSpec) DeepCopyInto(out *KafkaNetSpec) {
 	*out = *in
@@ -162,12 +183,52 @@ func (in *KafkaNetSpec) DeepCopy() *KafkaNetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSASLKerberosSpec) DeepCopyInto(out *KafkaSASLKerberosSpec) {
+	*out = *in
+	in.ServiceName.DeepCopyInto(&out.ServiceName)
+	in.Realm.DeepCopyInto(&out.Realm)
+	in.Keytab.DeepCopyInto(&out.Keytab)
+	in.Config.DeepCopyInto(&out.Config)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASLKerberosSpec.
+func (in *KafkaSASLKerberosSpec) DeepCopy() *KafkaSASLKerberosSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSASLKerberosSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSASLOAuthSpec) DeepCopyInto(out *KafkaSASLOAuthSpec) {
+	*out = *in
+	in.TokenURL.DeepCopyInto(&out.TokenURL)
+	in.Scopes.DeepCopyInto(&out.Scopes)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASLOAuthSpec.
+func (in *KafkaSASLOAuthSpec) DeepCopy() *KafkaSASLOAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSASLOAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaSASLSpec) DeepCopyInto(out *KafkaSASLSpec) {
 	*out = *in
 	in.User.DeepCopyInto(&out.User)
 	in.Password.DeepCopyInto(&out.Password)
 	in.Type.DeepCopyInto(&out.Type)
+	in.OAuth.DeepCopyInto(&out.OAuth)
+	in.Kerberos.DeepCopyInto(&out.Kerberos)
 	return
 }
 
@@ -28,4 +28,10 @@ var (
 		Group:    GroupName,
 		Resource: "resetoffsets",
 	}
+
+	// KafkaClustersResource represents a KafkaCluster
+	KafkaClustersResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "kafkaclusters",
+	}
 )
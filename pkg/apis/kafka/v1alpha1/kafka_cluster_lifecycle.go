@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+var kafkaClusterCondSet = apis.NewLivingConditionSet(KafkaClusterConditionConnectionEstablished)
+
+const (
+	// KafkaClusterConditionReady has status True when all sub-conditions below have been set to True.
+	KafkaClusterConditionReady = apis.ConditionReady
+
+	// KafkaClusterConditionConnectionEstablished has status True when the controller successfully
+	// connected to the cluster with the bootstrap servers and authentication of the KafkaCluster.
+	KafkaClusterConditionConnectionEstablished apis.ConditionType = "ConnectionEstablished"
+)
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*KafkaCluster) GetConditionSet() apis.ConditionSet {
+	return kafkaClusterCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (kcs *KafkaClusterStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return kafkaClusterCondSet.Manage(kcs).GetCondition(t)
+}
+
+// IsReady returns true if the KafkaClusterConditionReady status is true.
+func (kcs *KafkaClusterStatus) IsReady() bool {
+	return kafkaClusterCondSet.Manage(kcs).IsHappy()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (kcs *KafkaClusterStatus) InitializeConditions() {
+	kafkaClusterCondSet.Manage(kcs).InitializeConditions()
+}
+
+// MarkConnectionEstablished records the brokers and controller of the cluster, which has been connected to.
+func (kcs *KafkaClusterStatus) MarkConnectionEstablished(brokers []string, controllerID int32) {
+	kcs.Brokers = brokers
+	kcs.ControllerID = &controllerID
+	kafkaClusterCondSet.Manage(kcs).MarkTrue(KafkaClusterConditionConnectionEstablished)
+}
+
+func (kcs *KafkaClusterStatus) MarkConnectionNotEstablished(reason, messageFormat string, messageA ...interface{}) {
+	kafkaClusterCondSet.Manage(kcs).MarkFalse(KafkaClusterConditionConnectionEstablished, reason, messageFormat, messageA...)
+}
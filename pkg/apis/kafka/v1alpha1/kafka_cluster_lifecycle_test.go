@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestKafkaCluster_GetConditionSet(t *testing.T) {
+	kc := &KafkaCluster{}
+	if got, want := kc.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestKafkaClusterStatus_Lifecycle(t *testing.T) {
+	status := &KafkaClusterStatus{}
+	status.InitializeConditions()
+	assert.False(t, status.IsReady())
+	assert.Equal(t, corev1.ConditionUnknown, status.GetCondition(KafkaClusterConditionConnectionEstablished).Status)
+
+	status.MarkConnectionNotEstablished("ClientCreationFailed", "unable to connect: %s", "timeout")
+	assert.False(t, status.IsReady())
+	condition := status.GetCondition(KafkaClusterConditionReady)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "ClientCreationFailed", condition.Reason)
+	assert.Equal(t, "unable to connect: timeout", condition.Message)
+
+	status.MarkConnectionEstablished([]string{"kafka-0:9092", "kafka-1:9092"}, 1)
+	assert.True(t, status.IsReady())
+	assert.Equal(t, []string{"kafka-0:9092", "kafka-1:9092"}, status.Brokers)
+	assert.Equal(t, int32(1), *status.ControllerID)
+}
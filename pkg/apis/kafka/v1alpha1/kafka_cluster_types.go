@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaCluster is a resource representing the connection to a Kafka cluster, which can be
+// referenced by KafkaSources, KafkaBindings and KafkaChannels instead of specifying the brokers
+// and authentication of the cluster themselves.
+type KafkaCluster struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the KafkaCluster.
+	Spec KafkaClusterSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the KafkaCluster.
+	// This data may be out of date.
+	// +optional
+	Status KafkaClusterStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that this resource can be validated and defaulted.
+	_ apis.Validatable = (*KafkaCluster)(nil)
+	_ apis.Defaultable = (*KafkaCluster)(nil)
+
+	_ runtime.Object = (*KafkaCluster)(nil)
+
+	// Check that we can create OwnerReferences to an this resource.
+	_ kmeta.OwnerRefable = (*KafkaCluster)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*KafkaCluster)(nil)
+)
+
+// KafkaClusterSpec defines the specification for a KafkaCluster.
+type KafkaClusterSpec struct {
+
+	// BootstrapServers are the Kafka brokers used to connect to the cluster.
+	BootstrapServers []string `json:"bootstrapServers"`
+
+	// Net is the TLS and SASL configuration of the connections to the cluster.  The referenced
+	// Secrets are read from the namespace of the KafkaCluster.
+	// +optional
+	Net bindingsv1beta1.KafkaNetSpec `json:"net,omitempty"`
+
+	// Sarama is a YAML string of Sarama settings overriding, for the connections to this cluster,
+	// the ones of the config-kafka ConfigMap (e.g. the Version of the cluster).  It uses the same
+	// format as the "config" entry of the "sarama" field of the ConfigMap.
+	// +optional
+	Sarama string `json:"sarama,omitempty"`
+}
+
+// KafkaClusterStatus represents the current state of a KafkaCluster.
+type KafkaClusterStatus struct {
+
+	// Brokers are the addresses of the brokers of the cluster, as discovered when last connected.
+	// +optional
+	Brokers []string `json:"brokers,omitempty"`
+
+	// ControllerID is the ID of the controller broker of the cluster when last connected.
+	// +optional
+	ControllerID *int32 `json:"controllerID,omitempty"`
+
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	// * Annotations - optional status information to be conveyed to users.
+	duckv1.Status `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaClusterList is a collection of KafkaClusters.
+type KafkaClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaCluster `json:"items"`
+}
+
+// GetGroupVersionKind returns GroupVersionKind for KafkaCluster
+func (kc *KafkaCluster) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("KafkaCluster")
+}
+
+// GetStatus retrieves the duck status for this resource. Implements the KRShaped interface.
+func (kc *KafkaCluster) GetStatus() *duckv1.Status {
+	return &kc.Status.Status
+}
+
+// KafkaAuthSpec returns the bootstrap servers and authentication of the KafkaCluster, in the
+// form used by the KafkaSources and KafkaBindings.
+func (kcs *KafkaClusterSpec) KafkaAuthSpec() bindingsv1beta1.KafkaAuthSpec {
+	return bindingsv1beta1.KafkaAuthSpec{
+		BootstrapServers: kcs.BootstrapServers,
+		Net:              kcs.Net,
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+)
+
+func TestKafkaCluster_GetGroupVersionKind(t *testing.T) {
+	kafkaCluster := KafkaCluster{}
+	gvk := kafkaCluster.GetGroupVersionKind()
+	if gvk.Kind != "KafkaCluster" {
+		t.Errorf("Should be 'KafkaCluster'.")
+	}
+}
+
+func TestKafkaCluster_GetStatus(t *testing.T) {
+	status := &duckv1.Status{}
+	kafkaCluster := KafkaCluster{
+		Status: KafkaClusterStatus{
+			Status: *status,
+		},
+	}
+	if !cmp.Equal(kafkaCluster.GetStatus(), status) {
+		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", kafkaCluster.GetStatus(), status)
+	}
+}
+
+func TestKafkaClusterSpec_KafkaAuthSpec(t *testing.T) {
+	spec := KafkaClusterSpec{
+		BootstrapServers: []string{"kafka:9092"},
+		Net: bindingsv1beta1.KafkaNetSpec{
+			TLS: bindingsv1beta1.KafkaTLSSpec{Enable: true},
+		},
+		Sarama: "Net:\n  KeepAlive: 30s",
+	}
+	assert.Equal(t, bindingsv1beta1.KafkaAuthSpec{
+		BootstrapServers: spec.BootstrapServers,
+		Net:              spec.Net,
+	}, spec.KafkaAuthSpec())
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/yaml"
+)
+
+// Validate verifies the KafkaCluster and returns errors for any invalid fields.
+func (kc *KafkaCluster) Validate(ctx context.Context) *apis.FieldError {
+	return kc.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate verifies the KafkaClusterSpec and returns errors for any invalid fields.
+func (kcs *KafkaClusterSpec) Validate(_ context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if len(kcs.BootstrapServers) == 0 {
+		errs = errs.Also(apis.ErrMissingField("bootstrapServers"))
+	}
+	for i, server := range kcs.BootstrapServers {
+		if server == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(server, "bootstrapServers", i))
+		}
+	}
+
+	// The settings themselves are only applied (and verified) when connecting to the cluster
+	if kcs.Sarama != "" {
+		var settings map[string]interface{}
+		if err := yaml.Unmarshal([]byte(kcs.Sarama), &settings); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(kcs.Sarama, "sarama", err.Error()))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKafkaCluster_Validate(t *testing.T) {
+	tests := map[string]struct {
+		spec    KafkaClusterSpec
+		allowed bool
+	}{
+		"bootstrap servers": {
+			spec:    KafkaClusterSpec{BootstrapServers: []string{"kafka:9092"}},
+			allowed: true,
+		},
+		"sarama settings": {
+			spec: KafkaClusterSpec{
+				BootstrapServers: []string{"kafka:9092"},
+				Sarama:           "Version: 2.8.0\nNet:\n  KeepAlive: 30s",
+			},
+			allowed: true,
+		},
+		"missing bootstrap servers": {
+			spec:    KafkaClusterSpec{},
+			allowed: false,
+		},
+		"empty bootstrap server": {
+			spec:    KafkaClusterSpec{BootstrapServers: []string{"kafka:9092", ""}},
+			allowed: false,
+		},
+		"invalid sarama settings": {
+			spec: KafkaClusterSpec{
+				BootstrapServers: []string{"kafka:9092"},
+				Sarama:           "Net: [KeepAlive",
+			},
+			allowed: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kc := &KafkaCluster{Spec: test.spec}
+			kc.SetDefaults(context.Background())
+			err := kc.Validate(context.Background())
+			if test.allowed != (err == nil) {
+				t.Errorf("Validate() = %v, allowed %v", err, test.allowed)
+			}
+		})
+	}
+}
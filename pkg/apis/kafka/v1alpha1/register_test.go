@@ -46,4 +46,8 @@ func TestAddKnownTypes(t *testing.T) {
 	assert.NotNil(t, roType)
 	roListType := types["ResetOffsetList"]
 	assert.NotNil(t, roListType)
+	kcType := types["KafkaCluster"]
+	assert.NotNil(t, kcType)
+	kcListType := types["KafkaClusterList"]
+	assert.NotNil(t, kcListType)
 }
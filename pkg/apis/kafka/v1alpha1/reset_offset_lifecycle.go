@@ -56,6 +56,10 @@ const (
 	// ResetOffsetConditionConsumerGroupsStarted has status True when all of the ConsumerGroups
 	// associated with the referenced object (Subscription, Trigger, etc.) have been restarted.
 	ResetOffsetConditionConsumerGroupsStarted apis.ConditionType = "ConsumerGroupsStarted"
+
+	// ResetOffsetReasonDryRun is the reason of the OffsetsUpdated condition when the new
+	// offsets have only been computed because the ResetOffset.Spec.DryRun is enabled.
+	ResetOffsetReasonDryRun = "DryRun"
 )
 
 // RegisterAlternateResetOffsetConditionSet register a different apis.ConditionSet.
@@ -131,6 +135,12 @@ func (ros *ResetOffsetStatus) MarkOffsetsUpdatedTrue() {
 	ros.GetConditionSet().Manage(ros).MarkTrue(ResetOffsetConditionOffsetsUpdated)
 }
 
+// MarkOffsetsUpdatedDryRun leaves the OffsetsUpdated condition Unknown as the new offsets were only computed,
+// so that the ResetOffset can still be executed once its DryRun is disabled.
+func (ros *ResetOffsetStatus) MarkOffsetsUpdatedDryRun() {
+	ros.GetConditionSet().Manage(ros).MarkUnknown(ResetOffsetConditionOffsetsUpdated, ResetOffsetReasonDryRun, "Offsets computed without being updated (dry run)")
+}
+
 func (ros *ResetOffsetStatus) MarkConsumerGroupsStartedFailed(reason, messageFormat string, messageA ...interface{}) {
 	ros.GetConditionSet().Manage(ros).MarkFalse(ResetOffsetConditionConsumerGroupsStarted, reason, messageFormat, messageA...)
 }
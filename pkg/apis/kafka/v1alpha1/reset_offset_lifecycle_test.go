@@ -373,6 +373,19 @@ func TestResetOffsetStatus_IsSucceeded(t *testing.T) {
 	}
 }
 
+func TestResetOffsetStatus_MarkOffsetsUpdatedDryRun(t *testing.T) {
+	resetOffsetStatus := &ResetOffsetStatus{}
+	resetOffsetStatus.InitializeConditions()
+	resetOffsetStatus.MarkOffsetsUpdatedDryRun()
+
+	condition := resetOffsetStatus.GetCondition(ResetOffsetConditionOffsetsUpdated)
+	assert.NotNil(t, condition)
+	assert.True(t, condition.IsUnknown())
+	assert.Equal(t, ResetOffsetReasonDryRun, condition.Reason)
+	assert.False(t, resetOffsetStatus.IsOffsetsUpdated())
+	assert.False(t, resetOffsetStatus.IsSucceeded())
+}
+
 func TestRegisterAlternateResetOffsetConditionSet(t *testing.T) {
 	conditionSet := apis.NewLivingConditionSet(apis.ConditionReady, "test")
 	RegisterAlternateResetOffsetConditionSet(conditionSet)
@@ -17,9 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/rickb777/date/period"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -70,9 +73,8 @@ var (
 // ResetOffsetSpec defines the specification for a ResetOffset.
 type ResetOffsetSpec struct {
 
-	// Offset is an object representing the desired offset position to which all partitions
-	// will be reset. It provides for future extensibility in supporting various types of
-	// offset information (time based, explicit offset numbers, etc.)
+	// Offset is an object representing the desired offset position to which the partitions
+	// will be reset, either time based or as explicit offset numbers for specific partitions.
 	Offset OffsetSpec `json:"offset"`
 
 	// Ref is a KReference specifying the Knative resource, related to a Kafka ConsumerGroup,
@@ -83,20 +85,50 @@ type ResetOffsetSpec struct {
 	// (KafkaChannel vs KafkaBroker, etc).  Failure to provide a valid value will result in
 	// the ResetOffset operation being rejected as failed.
 	Ref duckv1.KReference `json:"ref"`
+
+	// DryRun indicates the new offsets should only be computed and reported in the
+	// ResetOffsetStatus.Partitions, without stopping the ConsumerGroups or committing
+	// the offsets.  The ResetOffset may then be executed by setting DryRun to false.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // OffsetSpec defines the intended values to move the offsets to.
-// Note: This simple wrapper might seem unnecessary, but is provided to allow future extension
-//       in order to support specifying explicit offset (int64) values for each Partition.
 type OffsetSpec struct {
 
-	// Time is a string representing the desired offset position to which all partitions
-	// will be reset.  Supported values include "earliest", "latest", or a valid date / time
-	// string in the time.RFC3339 format. The "earliest" and "latest" values indicate the
-	// beginning and end, respectively, of the persistence window of the Topic.  There is no
-	// default value, and invalid values will result in the ResetOffset operation being
-	// rejected as failed.
-	Time string `json:"time"`
+	// Time is a string representing the desired offset position to which the partitions
+	// will be reset.  Supported values include "earliest", "latest", a valid date / time
+	// string in the time.RFC3339 format, or a negative ISO-8601 duration (e.g. "-PT2H") relative
+	// to the time at which the offsets are repositioned. The "earliest" and "latest" values
+	// indicate the beginning and end, respectively, of the persistence window of the Topic.
+	// There is no default value, and invalid values will result in the ResetOffset operation
+	// being rejected as failed.  Time may only be omitted if an explicit Offset is specified
+	// for each of the Partitions.
+	// +optional
+	Time string `json:"time,omitempty"`
+
+	// Partitions optionally restricts the reset to a subset of the partitions, and optionally
+	// specifies an explicit offset for each of them.  All partitions are reset to the Time when
+	// no Partitions are specified.
+	// +optional
+	Partitions []PartitionOffset `json:"partitions,omitempty"`
+}
+
+// PartitionOffset identifies a single Kafka Partition to be reset, and its optional explicit offset.
+type PartitionOffset struct {
+
+	// Topic is the Kafka Topic of the Partition.  The Partition of every Topic related
+	// to the ResetOffsetSpec.Ref is reset if not specified.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+
+	// Partition is the Kafka Partition number.
+	Partition int32 `json:"partition"`
+
+	// Offset is the explicit offset to which the Partition will be reset.  The Partition is
+	// reset to the OffsetSpec.Time if not specified.
+	// +optional
+	Offset *int64 `json:"offset,omitempty"`
 }
 
 // IsOffsetEarliest returns True if the Offset value is "earliest"
@@ -109,11 +141,34 @@ func (ros *ResetOffsetSpec) IsOffsetLatest() bool {
 	return ros.Offset.Time == OffsetLatest
 }
 
+// IsOffsetRelative returns True if the Offset value is a negative ISO-8601 duration (e.g. "-PT2H")
+func (ros *ResetOffsetSpec) IsOffsetRelative() bool {
+	return strings.HasPrefix(ros.Offset.Time, "-P")
+}
+
+// HasOffsetTime returns True if the Offset Time is specified
+func (ros *ResetOffsetSpec) HasOffsetTime() bool {
+	return ros.Offset.Time != ""
+}
+
 // ParseOffsetTime returns the parsed Offset Time if valid (RFC3339 format) or an error for invalid content.
 func (ros *ResetOffsetSpec) ParseOffsetTime() (time.Time, error) {
 	return time.Parse(time.RFC3339, ros.Offset.Time)
 }
 
+// ParseOffsetDuration returns the parsed relative Offset duration if valid (negative ISO-8601 duration) or an error for invalid content.
+func (ros *ResetOffsetSpec) ParseOffsetDuration() (time.Duration, error) {
+	offsetPeriod, err := period.Parse(ros.Offset.Time)
+	if err != nil {
+		return 0, err
+	}
+	offsetDuration, _ := offsetPeriod.Duration() // Ignore precision flag and accept ISO8601 estimation
+	if offsetDuration >= 0 {
+		return 0, fmt.Errorf("relative offset duration must be negative: %s", ros.Offset.Time)
+	}
+	return offsetDuration, nil
+}
+
 // ParseSaramaOffsetTime returns the Sarama Offset Time (millis since epoch) int64 as parsed from the specified ResetOffset.Spec
 func (ros *ResetOffsetSpec) ParseSaramaOffsetTime() (int64, error) {
 	var saramaOffsetTime int64
@@ -121,6 +176,12 @@ func (ros *ResetOffsetSpec) ParseSaramaOffsetTime() (int64, error) {
 		saramaOffsetTime = sarama.OffsetOldest
 	} else if ros.IsOffsetLatest() {
 		saramaOffsetTime = sarama.OffsetNewest
+	} else if ros.IsOffsetRelative() {
+		offsetDuration, err := ros.ParseOffsetDuration()
+		if err != nil {
+			return 0, err
+		}
+		saramaOffsetTime = time.Now().Add(offsetDuration).UnixNano() / 1000000 // Convert Nanos To Millis For Sarama
 	} else {
 		offsetTime, err := ros.ParseOffsetTime()
 		if err != nil {
@@ -134,7 +195,8 @@ func (ros *ResetOffsetSpec) ParseSaramaOffsetTime() (int64, error) {
 // ResetOffsetStatus represents the current state of a ResetOffset.
 type ResetOffsetStatus struct {
 
-	// Topic is a string representing the Kafka Topic name associated with the ResetOffsetSpec.Ref
+	// Topic is a string representing the Kafka Topic name associated with the ResetOffsetSpec.Ref,
+	// or a comma separated list of Topic names when the Ref consumes multiple Topics (e.g. KafkaSource)
 	// +optional
 	Topic string `json:"topic,omitempty"`
 
@@ -143,7 +205,7 @@ type ResetOffsetStatus struct {
 	Group string `json:"group,omitempty"`
 
 	// Partitions is an array of OffsetMapping structs which represent the Offsets (old / new) of
-	// all Kafka Partitions associated with the ResetOffsetSpec.Ref
+	// the Kafka Partitions associated with the ResetOffsetSpec.Ref which have been reset
 	// +optional
 	Partitions []OffsetMapping `json:"partitions,omitempty"`
 
@@ -176,7 +238,13 @@ func (ro *ResetOffset) GetStatus() *duckv1.Status {
 
 // OffsetMapping represents a single Kafka Partition's Offset values before and after repositioning.
 type OffsetMapping struct {
-	Partition int32 `json:"partition"`
-	OldOffset int64 `json:"oldOffset"`
-	NewOffset int64 `json:"newOffset"`
+	Topic     string `json:"topic,omitempty"`
+	Partition int32  `json:"partition"`
+	OldOffset int64  `json:"oldOffset"`
+	NewOffset int64  `json:"newOffset"`
+
+	// EstimatedReplay is the estimated number of messages which will be re-delivered (positive)
+	// or skipped (negative) as a result of moving the Partition from the OldOffset to the NewOffset.
+	// +optional
+	EstimatedReplay int64 `json:"estimatedReplay,omitempty"`
 }
@@ -166,6 +166,71 @@ func TestResetOffsetSpec_ParseOffsetTime(t *testing.T) {
 	}
 }
 
+func TestResetOffsetSpec_IsOffsetRelative(t *testing.T) {
+	assert.True(t, (&ResetOffsetSpec{Offset: OffsetSpec{Time: "-PT2H"}}).IsOffsetRelative())
+	assert.False(t, (&ResetOffsetSpec{Offset: OffsetSpec{Time: "PT2H"}}).IsOffsetRelative())
+	assert.False(t, (&ResetOffsetSpec{Offset: OffsetSpec{Time: OffsetEarliest}}).IsOffsetRelative())
+	assert.False(t, (&ResetOffsetSpec{Offset: OffsetSpec{Time: "2021-05-04T05:04:01Z"}}).IsOffsetRelative())
+}
+
+func TestResetOffsetSpec_ParseOffsetDuration(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		offset         string
+		expectDuration time.Duration
+		expectErr      bool
+	}{
+		{
+			name:           "valid hours",
+			offset:         "-PT2H",
+			expectDuration: -2 * time.Hour,
+		},
+		{
+			name:           "valid minutes",
+			offset:         "-PT90M",
+			expectDuration: -90 * time.Minute,
+		},
+		{
+			name:      "positive",
+			offset:    "PT2H",
+			expectErr: true,
+		},
+		{
+			name:      "zero",
+			offset:    "-PT0S",
+			expectErr: true,
+		},
+		{
+			name:      "invalid",
+			offset:    "-foo",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetOffsetSpec := &ResetOffsetSpec{Offset: OffsetSpec{Time: test.offset}}
+			offsetDuration, err := resetOffsetSpec.ParseOffsetDuration()
+			assert.Equal(t, test.expectErr, err != nil)
+			assert.Equal(t, test.expectDuration, offsetDuration)
+		})
+	}
+}
+
+func TestResetOffsetSpec_ParseSaramaOffsetTimeRelative(t *testing.T) {
+	before := time.Now().Add(-2*time.Hour).UnixNano() / 1000000
+	resetOffsetSpec := &ResetOffsetSpec{Offset: OffsetSpec{Time: "-PT2H"}}
+	saramaOffsetTime, err := resetOffsetSpec.ParseSaramaOffsetTime()
+	after := time.Now().Add(-2*time.Hour).UnixNano() / 1000000
+	assert.Nil(t, err)
+	assert.True(t, saramaOffsetTime >= before && saramaOffsetTime <= after)
+
+	resetOffsetSpec = &ResetOffsetSpec{Offset: OffsetSpec{Time: "-Pfoo"}}
+	_, err = resetOffsetSpec.ParseSaramaOffsetTime()
+	assert.NotNil(t, err)
+}
+
 func TestResetOffsetSpec_ParseSaramaOffsetTime(t *testing.T) {
 
 	offsetRFC3339 := time.Now().UTC().Add(-1 * time.Hour).Format(time.RFC3339)
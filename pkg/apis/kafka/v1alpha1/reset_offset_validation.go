@@ -18,8 +18,10 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
 )
@@ -41,39 +43,91 @@ func (ros *ResetOffsetSpec) Validate(ctx context.Context) *apis.FieldError {
 
 	var errs *apis.FieldError
 
-	// Validate The Offset String ("earliest", "latest", or valid date string)
-	if !ros.IsOffsetEarliest() && !ros.IsOffsetLatest() {
-		offsetTime, err := ros.ParseOffsetTime()
-		if err != nil || offsetTime.After(time.Now()) {
-			errs = errs.Also(apis.ErrInvalidValue(ros.Offset.Time, "offset"))
+	// Validate The Offset String ("earliest", "latest", relative duration, or valid date string)
+	if ros.HasOffsetTime() {
+		if ros.IsOffsetRelative() {
+			if _, err := ros.ParseOffsetDuration(); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(ros.Offset.Time, "offset"))
+			}
+		} else if !ros.IsOffsetEarliest() && !ros.IsOffsetLatest() {
+			offsetTime, err := ros.ParseOffsetTime()
+			if err != nil || offsetTime.After(time.Now()) {
+				errs = errs.Also(apis.ErrInvalidValue(ros.Offset.Time, "offset"))
+			}
 		}
 	}
 
+	// Validate The Partitions (The Time Is Required Unless All Partitions Specify An Explicit Offset)
+	errs = errs.Also(ros.Offset.validatePartitions().ViaField("offset"))
+	if !ros.HasOffsetTime() && !ros.Offset.hasExplicitOffsets() {
+		errs = errs.Also(apis.ErrMissingField("offset.time"))
+	}
+
 	// Validate The Ref KReference Basics (Kafka Topic relation which is expected to be done in Controllers!)
 	errs = errs.Also(ros.Ref.Validate(ctx))
 
 	return errs
 }
 
+// validatePartitions verifies the Partitions are valid and unique.
+func (ofs *OffsetSpec) validatePartitions() *apis.FieldError {
+	var errs *apis.FieldError
+	partitions := make(map[string]bool, len(ofs.Partitions))
+	for index, partitionOffset := range ofs.Partitions {
+		if partitionOffset.Partition < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(partitionOffset.Partition, "partition").ViaFieldIndex("partitions", index))
+		}
+		if partitionOffset.Offset != nil && *partitionOffset.Offset < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*partitionOffset.Offset, "offset").ViaFieldIndex("partitions", index))
+		}
+		key := fmt.Sprintf("%s/%d", partitionOffset.Topic, partitionOffset.Partition)
+		if partitions[key] {
+			errs = errs.Also(apis.ErrGeneric("duplicate partition", "partition").ViaFieldIndex("partitions", index))
+		}
+		partitions[key] = true
+	}
+	return errs
+}
+
+// hasExplicitOffsets returns true if an explicit Offset is specified for each of the (at least one) Partitions.
+func (ofs *OffsetSpec) hasExplicitOffsets() bool {
+	for _, partitionOffset := range ofs.Partitions {
+		if partitionOffset.Offset == nil {
+			return false
+		}
+	}
+	return len(ofs.Partitions) > 0
+}
+
 // CheckImmutableFields verifies the immutable spec fields have not been changed from the original.
 func (ro *ResetOffset) CheckImmutableFields(_ context.Context, original *ResetOffset) *apis.FieldError {
 	if original == nil {
 		return nil
 	}
 
-	if diff, err := kmp.ShortDiff(original.Spec, ro.Spec); err != nil {
-		return &apis.FieldError{
+	// A dry-run ResetOffset may be executed by setting DryRun to false, but not the other way around.
+	var errs *apis.FieldError
+	if ro.Spec.DryRun && !original.Spec.DryRun {
+		errs = errs.Also(&apis.FieldError{
+			Message: "An executed ResetOffset can not be changed to a dry-run (-old +new)",
+			Paths:   []string{"spec.dryRun"},
+			Details: fmt.Sprintf("-: %t\n+: %t", original.Spec.DryRun, ro.Spec.DryRun),
+		})
+	}
+
+	if diff, err := kmp.ShortDiff(original.Spec, ro.Spec, cmpopts.IgnoreFields(ResetOffsetSpec{}, "DryRun")); err != nil {
+		return errs.Also(&apis.FieldError{
 			Message: "Failed to diff ResetOffset",
 			Paths:   []string{"spec"},
 			Details: err.Error(),
-		}
+		})
 	} else if diff != "" {
-		return &apis.FieldError{
+		return errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
 			Details: diff,
-		}
+		})
 	}
 
-	return nil
+	return errs
 }
@@ -36,6 +36,9 @@ var (
 
 	futureTime = time.Now().Add(1 * time.Hour).Format(time.RFC3339)
 	pastTime   = time.Now().Add(-1 * time.Hour).Format(time.RFC3339)
+
+	explicitOffset = int64(100)
+	negativeOffset = int64(-1)
 )
 
 func TestResetOffset_Validate(t *testing.T) {
@@ -94,6 +97,77 @@ func TestResetOffset_Validate(t *testing.T) {
 				return errs
 			}(),
 		},
+		{
+			name: "valid offset relative",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Time: "-PT2H"}, Ref: reference},
+			},
+		},
+		{
+			name: "invalid offset relative positive",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Time: "-PT0S"}, Ref: reference},
+			},
+			want: apis.ErrInvalidValue("-PT0S", "spec.offset"),
+		},
+		{
+			name: "invalid offset relative string",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Time: "-Pfoo"}, Ref: reference},
+			},
+			want: apis.ErrInvalidValue("-Pfoo", "spec.offset"),
+		},
+		{
+			name: "valid partitions subset",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{
+					Offset: OffsetSpec{Time: OffsetEarliest, Partitions: []PartitionOffset{{Partition: 0}, {Topic: "topic", Partition: 1}}},
+					Ref:    reference,
+				},
+			},
+		},
+		{
+			name: "valid explicit offsets",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{
+					Offset: OffsetSpec{Partitions: []PartitionOffset{{Partition: 0, Offset: &explicitOffset}, {Partition: 1, Offset: &explicitOffset}}},
+					Ref:    reference,
+				},
+			},
+		},
+		{
+			name: "invalid missing time",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{
+					Offset: OffsetSpec{Partitions: []PartitionOffset{{Partition: 0, Offset: &explicitOffset}, {Partition: 1}}},
+					Ref:    reference,
+				},
+			},
+			want: apis.ErrMissingField("spec.offset.time"),
+		},
+		{
+			name: "invalid missing time and partitions",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Ref: reference},
+			},
+			want: apis.ErrMissingField("spec.offset.time"),
+		},
+		{
+			name: "invalid partitions",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{
+					Offset: OffsetSpec{Time: OffsetLatest, Partitions: []PartitionOffset{{Partition: -1}, {Partition: 1, Offset: &negativeOffset}, {Partition: 1}}},
+					Ref:    reference,
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				errs = errs.Also(apis.ErrInvalidValue(-1, "spec.offset.partitions[0].partition"))
+				errs = errs.Also(apis.ErrInvalidValue(negativeOffset, "spec.offset.partitions[1].offset"))
+				errs = errs.Also(apis.ErrGeneric("duplicate partition", "spec.offset.partitions[2].partition"))
+				return errs
+			}(),
+		},
 		{
 			name: "invalid ref nil",
 			cr: &ResetOffset{
@@ -250,6 +324,44 @@ func TestResetOffsetImmutability(t *testing.T) {
 				}
 			}(),
 		},
+		{
+			name: "executing a dry-run",
+			original: &ResetOffset{
+				Spec: ResetOffsetSpec{
+					Offset: OffsetSpec{Time: OffsetEarliest},
+					Ref:    duckv1.KReference{APIVersion: refAPIVersion, Kind: refKind, Namespace: refNamespace, Name: refName},
+					DryRun: true,
+				},
+			},
+			updated: &ResetOffset{
+				Spec: ResetOffsetSpec{
+					Offset: OffsetSpec{Time: OffsetEarliest},
+					Ref:    duckv1.KReference{APIVersion: refAPIVersion, Kind: refKind, Namespace: refNamespace, Name: refName},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "changing to a dry-run",
+			original: &ResetOffset{
+				Spec: ResetOffsetSpec{
+					Offset: OffsetSpec{Time: OffsetEarliest},
+					Ref:    duckv1.KReference{APIVersion: refAPIVersion, Kind: refKind, Namespace: refNamespace, Name: refName},
+				},
+			},
+			updated: &ResetOffset{
+				Spec: ResetOffsetSpec{
+					Offset: OffsetSpec{Time: OffsetEarliest},
+					Ref:    duckv1.KReference{APIVersion: refAPIVersion, Kind: refKind, Namespace: refNamespace, Name: refName},
+					DryRun: true,
+				},
+			},
+			want: &apis.FieldError{
+				Message: "An executed ResetOffset can not be changed to a dry-run (-old +new)",
+				Paths:   []string{"spec.dryRun"},
+				Details: "-: false\n+: true",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -23,10 +23,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 )
 
 // +genclient
@@ -65,9 +68,11 @@ var (
 // KafkaChannelSpec defines the specification for a KafkaChannel.
 type KafkaChannelSpec struct {
 	// NumPartitions is the number of partitions of a Kafka topic. By default, it is set to 1.
+	// It can be increased, but not decreased, once the KafkaChannel is created.
 	NumPartitions int32 `json:"numPartitions"`
 
 	// ReplicationFactor is the replication factor of a Kafka topic. By default, it is set to 1.
+	// It is immutable once the KafkaChannel is created.
 	ReplicationFactor int16 `json:"replicationFactor"`
 
 	// RetentionDuration is the duration for which events will be retained in the Kafka Topic.
@@ -77,6 +82,20 @@ type KafkaChannelSpec struct {
 	//  - https://en.wikipedia.org/wiki/ISO_8601
 	RetentionDuration string `json:"retentionDuration"`
 
+	// TopicConfig is the set of additional Kafka topic configs of the topic of the channel, which are
+	// applied when the topic is created and updated in place when they change or drift. Only the
+	// min.insync.replicas, compression.type, max.message.bytes, cleanup.policy, segment.bytes and
+	// segment.ms topic configs are supported (the retention is configured by the RetentionDuration).
+	// +optional
+	TopicConfig map[string]string `json:"topicConfig,omitempty"`
+
+	// ClusterRef references the KafkaCluster hosting the topic of the channel, which is either in the
+	// namespace of the channel (by default) or in the system namespace of the channel implementation.
+	// Without it, the channel uses the Kafka cluster of the config-kafka ConfigMap. It is immutable
+	// once the KafkaChannel is created.
+	// +optional
+	ClusterRef *bindingsv1beta1.KafkaClusterReference `json:"clusterRef,omitempty"`
+
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableSpec `json:",inline"`
 }
@@ -96,6 +115,39 @@ func (kcs *KafkaChannelSpec) ParseRetentionDuration() (time.Duration, error) {
 type KafkaChannelStatus struct {
 	// Channel conforms to Duck type ChannelableStatus.
 	eventingduck.ChannelableStatus `json:",inline"`
+
+	// SubscribersLag is the lag of the consumer group of each subscriber,
+	// periodically computed by the controller.
+	// +optional
+	SubscribersLag []SubscriberLagStatus `json:"subscribersLag,omitempty"`
+}
+
+// SubscriberLagStatus is the lag of the consumer group of a subscriber.
+type SubscriberLagStatus struct {
+	// UID is the UID of the subscriber.
+	UID types.UID `json:"uid"`
+
+	// ConsumerGroup is the consumer group of the subscriber.
+	ConsumerGroup string `json:"consumerGroup"`
+
+	// Total is the lag of the consumer group on all the partitions.
+	Total int64 `json:"total"`
+
+	// Partitions is the lag of the consumer group on each partition.
+	// +optional
+	Partitions []PartitionLagStatus `json:"partitions,omitempty"`
+}
+
+// PartitionLagStatus is the lag of a consumer group on a partition.
+type PartitionLagStatus struct {
+	Partition int32 `json:"partition"`
+
+	// Offset is the committed offset of the consumer group, or -1 if no
+	// offset is committed yet.
+	Offset int64 `json:"offset"`
+
+	// Lag is the number of records after the committed offset.
+	Lag int64 `json:"lag"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
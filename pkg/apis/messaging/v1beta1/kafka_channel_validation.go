@@ -19,6 +19,9 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -69,6 +72,12 @@ func (kcs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(fe)
 	}
 
+	errs = errs.Also(kcs.validateTopicConfig())
+
+	if kcs.ClusterRef != nil {
+		errs = errs.Also(kcs.ClusterRef.Validate(ctx).ViaField("clusterRef"))
+	}
+
 	for i, subscriber := range kcs.SubscribableSpec.Subscribers {
 		if subscriber.ReplyURI == nil && subscriber.SubscriberURI == nil {
 			fe := apis.ErrMissingField("replyURI", "subscriberURI")
@@ -79,37 +88,111 @@ func (kcs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 	return errs
 }
 
+// topicConfigValidators are the validation functions of the Kafka topic configs supported by the
+// TopicConfig of a KafkaChannel, keyed by topic config name.
+var topicConfigValidators = map[string]func(value string) bool{
+	"min.insync.replicas": isIntAtLeast(1),
+	"compression.type":    isOneOf("uncompressed", "zstd", "lz4", "snappy", "gzip", "producer"),
+	"max.message.bytes":   isIntAtLeast(0),
+	"cleanup.policy":      isListOf("delete", "compact"),
+	"segment.bytes":       isIntAtLeast(14),
+	"segment.ms":          isIntAtLeast(1),
+}
+
+func (kcs *KafkaChannelSpec) validateTopicConfig() *apis.FieldError {
+	var errs *apis.FieldError
+	for name, value := range kcs.TopicConfig {
+		validator, ok := topicConfigValidators[name]
+		if !ok {
+			fe := apis.ErrInvalidKeyName(name, "topicConfig")
+			fe.Details = fmt.Sprintf("supported topic configs are %s", strings.Join(supportedTopicConfigs(), ", "))
+			errs = errs.Also(fe)
+		} else if !validator(value) {
+			errs = errs.Also(apis.ErrInvalidValue(value, name).ViaField("topicConfig"))
+		}
+	}
+
+	// The topic would reject all the produce requests if it could not have enough in-sync replicas
+	if value, ok := kcs.TopicConfig["min.insync.replicas"]; ok && kcs.ReplicationFactor > 0 {
+		if minInsyncReplicas, err := strconv.Atoi(value); err == nil && minInsyncReplicas > int(kcs.ReplicationFactor) {
+			fe := apis.ErrInvalidValue(value, "min.insync.replicas").ViaField("topicConfig")
+			fe.Details = "expected at most the replicationFactor"
+			errs = errs.Also(fe)
+		}
+	}
+	return errs
+}
+
+func supportedTopicConfigs() []string {
+	names := make([]string, 0, len(topicConfigValidators))
+	for name := range topicConfigValidators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isIntAtLeast(min int64) func(string) bool {
+	return func(value string) bool {
+		i, err := strconv.ParseInt(value, 10, 64)
+		return err == nil && i >= min
+	}
+}
+
+func isOneOf(allowed ...string) func(string) bool {
+	return func(value string) bool {
+		for _, a := range allowed {
+			if value == a {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isListOf(allowed ...string) func(string) bool {
+	isAllowed := isOneOf(allowed...)
+	return func(value string) bool {
+		for _, item := range strings.Split(value, ",") {
+			if !isAllowed(strings.TrimSpace(item)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func (kc *KafkaChannel) CheckImmutableFields(_ context.Context, original *KafkaChannel) *apis.FieldError {
 	if original == nil {
 		return nil
 	}
 
-	ignoreArguments := []cmp.Option{cmpopts.IgnoreFields(KafkaChannelSpec{}, "ChannelableSpec")}
+	// The RetentionDuration and TopicConfig are mutable and the NumPartitions can be increased, they
+	// are all applied in place to the existing Kafka topic by the reconcilers.
+	ignoreArguments := []cmp.Option{cmpopts.IgnoreFields(KafkaChannelSpec{}, "ChannelableSpec", "NumPartitions", "RetentionDuration", "TopicConfig")}
 
-	// In the specific case of the original RetentionDuration being an empty string, allow it
-	// as an exception to the immutability requirement.
-	//
-	// KafkaChannels created pre-v0.26 will not have a RetentionDuration field (thus an empty
-	// string), and in v0.26 there is a post-install job that updates this to its proper value.
-	// This immutability check was added after the post-install job, and without this exception
-	// it will fail attempting to upgrade those pre-v0.26 channels.
-	if original.Spec.RetentionDuration == "" && kc.Spec.RetentionDuration != "" {
-		ignoreArguments = append(ignoreArguments, cmpopts.IgnoreFields(KafkaChannelSpec{}, "RetentionDuration"))
+	var errs *apis.FieldError
+	if kc.Spec.NumPartitions < original.Spec.NumPartitions {
+		errs = errs.Also(&apis.FieldError{
+			Message: "The number of partitions can not be decreased (-old +new)",
+			Paths:   []string{"spec.numPartitions"},
+			Details: fmt.Sprintf("-: %d\n+: %d", original.Spec.NumPartitions, kc.Spec.NumPartitions),
+		})
 	}
 
 	if diff, err := kmp.ShortDiff(original.Spec, kc.Spec, ignoreArguments...); err != nil {
-		return &apis.FieldError{
+		return errs.Also(&apis.FieldError{
 			Message: "Failed to diff KafkaChannel",
 			Paths:   []string{"spec"},
 			Details: err.Error(),
-		}
+		})
 	} else if diff != "" {
-		return &apis.FieldError{
+		return errs.Also(&apis.FieldError{
 			Message: "Immutable fields changed (-old +new)",
 			Paths:   []string{"spec"},
 			Details: diff,
-		}
+		})
 	}
 
-	return nil
+	return errs
 }
@@ -28,6 +28,7 @@ import (
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/webhook/resourcesemantics"
 
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/constants"
 )
 
@@ -113,6 +114,74 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return fe
 			}(),
 		},
+		"valid topicConfig": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 3,
+					RetentionDuration: "P1D",
+					TopicConfig: map[string]string{
+						"min.insync.replicas": "2",
+						"compression.type":    "zstd",
+						"max.message.bytes":   "2097152",
+						"cleanup.policy":      "compact,delete",
+						"segment.bytes":       "536870912",
+						"segment.ms":          "3600000",
+					},
+				},
+			},
+		},
+		"unsupported topicConfig": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					TopicConfig:       map[string]string{"retention.ms": "1000"},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidKeyName("retention.ms", "spec.topicConfig")
+				fe.Details = "supported topic configs are cleanup.policy, compression.type, max.message.bytes, min.insync.replicas, segment.bytes, segment.ms"
+				return fe
+			}(),
+		},
+		"invalid topicConfig values": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					TopicConfig: map[string]string{
+						"compression.type": "brotli",
+						"cleanup.policy":   "compact,archive",
+						"segment.ms":       "0",
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				errs = errs.Also(apis.ErrInvalidValue("brotli", "spec.topicConfig.compression.type"))
+				errs = errs.Also(apis.ErrInvalidValue("compact,archive", "spec.topicConfig.cleanup.policy"))
+				errs = errs.Also(apis.ErrInvalidValue("0", "spec.topicConfig.segment.ms"))
+				return errs
+			}(),
+		},
+		"min.insync.replicas greater than replicationFactor": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					TopicConfig:       map[string]string{"min.insync.replicas": "2"},
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("2", "spec.topicConfig.min.insync.replicas")
+				fe.Details = "expected at most the replicationFactor"
+				return fe
+			}(),
+		},
 		"valid subscribers array": {
 			cr: &KafkaChannel{
 				Spec: KafkaChannelSpec{
@@ -175,6 +244,27 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return errs
 			}(),
 		},
+		"valid cluster reference": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					ClusterRef:        &bindingsv1beta1.KafkaClusterReference{Name: "cluster", Namespace: "knative-eventing"},
+				},
+			},
+		},
+		"cluster reference without name": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					ClusterRef:        &bindingsv1beta1.KafkaClusterReference{},
+				},
+			},
+			want: apis.ErrMissingField("spec.clusterRef.name"),
+		},
 		"invalid scope annotation": {
 			cr: &KafkaChannel{
 				ObjectMeta: metav1.ObjectMeta{
@@ -261,7 +351,7 @@ func TestKafkaChannelImmutability(t *testing.T) {
 				},
 			},
 		},
-		"updating immutable numPartitions": {
+		"increasing mutable numPartitions": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
 					NumPartitions:     1,
@@ -276,11 +366,27 @@ func TestKafkaChannelImmutability(t *testing.T) {
 					RetentionDuration: "P1D",
 				},
 			},
+		},
+		"decreasing numPartitions": {
+			original: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     2,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
+			updated: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
 			want: func() *apis.FieldError {
 				return &apis.FieldError{
-					Message: "Immutable fields changed (-old +new)",
-					Paths:   []string{"spec"},
-					Details: "{v1beta1.KafkaChannelSpec}.NumPartitions:\n\t-: \"1\"\n\t+: \"2\"\n",
+					Message: "The number of partitions can not be decreased (-old +new)",
+					Paths:   []string{"spec.numPartitions"},
+					Details: "-: 2\n+: 1",
 				}
 			}(),
 		},
@@ -307,7 +413,7 @@ func TestKafkaChannelImmutability(t *testing.T) {
 				}
 			}(),
 		},
-		"updating immutable retentionDuration": {
+		"updating mutable retentionDuration": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
 					NumPartitions:     1,
@@ -322,15 +428,8 @@ func TestKafkaChannelImmutability(t *testing.T) {
 					RetentionDuration: "P2D",
 				},
 			},
-			want: func() *apis.FieldError {
-				return &apis.FieldError{
-					Message: "Immutable fields changed (-old +new)",
-					Paths:   []string{"spec"},
-					Details: "{v1beta1.KafkaChannelSpec}.RetentionDuration:\n\t-: \"P1D\"\n\t+: \"P2D\"\n",
-				}
-			}(),
 		},
-		"updating immutable retentionDuration (empty to default, immutability exception)": {
+		"updating mutable retentionDuration (empty to default)": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
 					NumPartitions:     1,
@@ -346,7 +445,7 @@ func TestKafkaChannelImmutability(t *testing.T) {
 				},
 			},
 		},
-		"updating immutable retentionDuration (empty to canonical zero P0D, immutability exception)": {
+		"updating mutable retentionDuration (empty to canonical zero P0D)": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
 					NumPartitions:     1,
@@ -362,7 +461,7 @@ func TestKafkaChannelImmutability(t *testing.T) {
 				},
 			},
 		},
-		"updating immutable retentionDuration (non-empty to default)": {
+		"updating mutable retentionDuration (non-empty to default)": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
 					NumPartitions:     1,
@@ -377,15 +476,8 @@ func TestKafkaChannelImmutability(t *testing.T) {
 					RetentionDuration: constants.DefaultRetentionISO8601Duration,
 				},
 			},
-			want: func() *apis.FieldError {
-				return &apis.FieldError{
-					Message: "Immutable fields changed (-old +new)",
-					Paths:   []string{"spec"},
-					Details: "{v1beta1.KafkaChannelSpec}.RetentionDuration:\n\t-: \"PT1H\"\n\t+: \"PT168H\"\n",
-				}
-			}(),
 		},
-		"updating immutable retentionDuration (empty to non-default, immutability exception)": {
+		"updating mutable retentionDuration (empty to non-default)": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
 					NumPartitions:     1,
@@ -401,7 +493,7 @@ func TestKafkaChannelImmutability(t *testing.T) {
 				},
 			},
 		},
-		"updating immutable retentionDuration (immutability exception) and numPartitions": {
+		"updating mutable retentionDuration and numPartitions": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
 					NumPartitions:     1,
@@ -416,15 +508,49 @@ func TestKafkaChannelImmutability(t *testing.T) {
 					RetentionDuration: "PT100H",
 				},
 			},
+		},
+		"updating mutable topicConfig": {
+			original: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
+			updated: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					TopicConfig:       map[string]string{"cleanup.policy": "compact"},
+				},
+			},
+		},
+		"updating immutable clusterRef": {
+			original: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
+			updated: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					ClusterRef:        &bindingsv1beta1.KafkaClusterReference{Name: "cluster"},
+				},
+			},
 			want: func() *apis.FieldError {
 				return &apis.FieldError{
 					Message: "Immutable fields changed (-old +new)",
 					Paths:   []string{"spec"},
-					Details: "{v1beta1.KafkaChannelSpec}.NumPartitions:\n\t-: \"1\"\n\t+: \"2\"\n",
+					Details: "{v1beta1.KafkaChannelSpec}.ClusterRef:\n\t-: \"<nil>\"\n\t+: \"&{Name:cluster Namespace:}\"\n",
 				}
 			}(),
 		},
-		"updating immutable retentionDuration (immutability exception) and numPartitions and replicationFactor": {
+		"updating mutable retentionDuration and numPartitions and immutable replicationFactor": {
 			original: &KafkaChannel{
 				Spec: KafkaChannelSpec{
 					NumPartitions:     1,
@@ -443,7 +569,7 @@ func TestKafkaChannelImmutability(t *testing.T) {
 				return &apis.FieldError{
 					Message: "Immutable fields changed (-old +new)",
 					Paths:   []string{"spec"},
-					Details: "{v1beta1.KafkaChannelSpec}.NumPartitions:\n\t-: \"1\"\n\t+: \"2\"\n{v1beta1.KafkaChannelSpec}.ReplicationFactor:\n\t-: \"1\"\n\t+: \"3\"\n",
+					Details: "{v1beta1.KafkaChannelSpec}.ReplicationFactor:\n\t-: \"1\"\n\t+: \"3\"\n",
 				}
 			}(),
 		},
@@ -49,6 +49,10 @@ const (
 	// KedaAutoscalingClass is the class name for KEDA
 	KedaAutoscalingClass = "keda.autoscaling.knative.dev"
 
+	// KafkaAutoscalingClass is the built-in autoscaler of the KafkaSource controller, scaling
+	// the receive adapter according to the lag of the consumer group.
+	KafkaAutoscalingClass = "kafka.autoscaling.knative.dev"
+
 	// DefaultMinScaleValue is the default value for DefaultMinScaleKey
 	DefaultMinScaleValue = int64(1)
 
@@ -73,8 +77,8 @@ func NewKafkaDefaultsConfigFromMap(data map[string]string) (*KafkaSourceDefaults
 	if !present || value == "" {
 		return nc, nil
 	}
-	if value != "keda.autoscaling.knative.dev" {
-		return nil, fmt.Errorf("invalid value %q for %s. Only keda.autoscaling.knative.dev is allowed", value, DefaultAutoscalingClassKey)
+	if value != KedaAutoscalingClass && value != KafkaAutoscalingClass {
+		return nil, fmt.Errorf("invalid value %q for %s. Only %s and %s are allowed", value, DefaultAutoscalingClassKey, KedaAutoscalingClass, KafkaAutoscalingClass)
 	}
 	nc.AutoscalingClass = value
 
@@ -126,6 +126,27 @@ func TestKafkaDefaultsConfiguration(t *testing.T) {
 				"autoscalingClass": "keda.autoscaling.knative.dev",
 			},
 		},
+	}, {
+		name:    "built-in autoscaler class",
+		wantErr: false,
+		wantDefault: KafkaSourceDefaults{
+			AutoscalingClass:  "kafka.autoscaling.knative.dev",
+			MinScale:          DefaultMinScaleValue,
+			MaxScale:          5,
+			PollingInterval:   DefaultPollingIntervalValue,
+			CooldownPeriod:    DefaultCooldownPeriodValue,
+			KafkaLagThreshold: DefaultKafkaLagThresholdValue,
+		},
+		config: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: system.Namespace(),
+				Name:      KafkaDefaultsConfigName,
+			},
+			Data: map[string]string{
+				"autoscalingClass": "kafka.autoscaling.knative.dev",
+				"maxScale":         "5",
+			},
+		},
 	}, {
 		name:    "invalid autoscaler class",
 		wantErr: true,
@@ -36,6 +36,22 @@ const (
 	pollingIntervalAnnotation   = "keda.autoscaling.knative.dev/pollingInterval"
 	cooldownPeriodAnnotation    = "keda.autoscaling.knative.dev/cooldownPeriod"
 	kafkaLagThresholdAnnotation = "keda.autoscaling.knative.dev/kafkaLagThreshold"
+
+	// AutoscalingClassAnnotation is the annotation of the autoscaler class of a KafkaSource
+	AutoscalingClassAnnotation = classAnnotation
+	// AutoscalingMinScaleAnnotation is the annotation of the minimum scale of a KafkaSource
+	AutoscalingMinScaleAnnotation = minScaleAnnotation
+	// AutoscalingMaxScaleAnnotation is the annotation of the maximum scale of a KafkaSource
+	AutoscalingMaxScaleAnnotation = maxScaleAnnotation
+	// AutoscalingPollingIntervalAnnotation is the annotation of the interval, in seconds, between
+	// two evaluations of the lag by the built-in autoscaler
+	AutoscalingPollingIntervalAnnotation = "kafka.autoscaling.knative.dev/pollingInterval"
+	// AutoscalingCooldownPeriodAnnotation is the annotation of the period, in seconds, the built-in
+	// autoscaler waits after the last scaling before scaling down
+	AutoscalingCooldownPeriodAnnotation = "kafka.autoscaling.knative.dev/cooldownPeriod"
+	// AutoscalingLagThresholdAnnotation is the annotation of the lag handled by each replica of the
+	// built-in autoscaler
+	AutoscalingLagThresholdAnnotation = "kafka.autoscaling.knative.dev/kafkaLagThreshold"
 )
 
 // SetDefaults ensures KafkaSource reflects the default values.
@@ -68,7 +84,24 @@ func (k *KafkaSource) SetDefaults(ctx context.Context) {
 		k.Annotations[pollingIntervalAnnotation] = strconv.FormatInt(kafkaDefaults.PollingInterval, 10)
 		k.Annotations[cooldownPeriodAnnotation] = strconv.FormatInt(kafkaDefaults.CooldownPeriod, 10)
 		k.Annotations[kafkaLagThresholdAnnotation] = strconv.FormatInt(kafkaDefaults.KafkaLagThreshold, 10)
+	} else if kafkaDefaults.AutoscalingClass == config.KafkaAutoscalingClass {
+		if k.Annotations == nil {
+			k.Annotations = map[string]string{}
+		}
+		// The built-in autoscaler is configured per source, so keep the annotations already set
+		setAnnotationDefault(k.Annotations, AutoscalingClassAnnotation, kafkaDefaults.AutoscalingClass)
+		setAnnotationDefault(k.Annotations, AutoscalingMinScaleAnnotation, strconv.FormatInt(kafkaDefaults.MinScale, 10))
+		setAnnotationDefault(k.Annotations, AutoscalingMaxScaleAnnotation, strconv.FormatInt(kafkaDefaults.MaxScale, 10))
+		setAnnotationDefault(k.Annotations, AutoscalingPollingIntervalAnnotation, strconv.FormatInt(kafkaDefaults.PollingInterval, 10))
+		setAnnotationDefault(k.Annotations, AutoscalingCooldownPeriodAnnotation, strconv.FormatInt(kafkaDefaults.CooldownPeriod, 10))
+		setAnnotationDefault(k.Annotations, AutoscalingLagThresholdAnnotation, strconv.FormatInt(kafkaDefaults.KafkaLagThreshold, 10))
 	}
 
 	k.Spec.Sink.SetDefaults(ctx)
 }
+
+func setAnnotationDefault(annotations map[string]string, key string, value string) {
+	if _, ok := annotations[key]; !ok {
+		annotations[key] = value
+	}
+}
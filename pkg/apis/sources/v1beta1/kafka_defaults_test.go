@@ -156,6 +156,30 @@ func TestSetDefaults(t *testing.T) {
 				kafkaLagThresholdAnnotation: "100",
 			},
 			AssertFuncs: []assertFnType{assertAnnotations},
+		}, {
+			Name: "built-in autoscaling config",
+			Defaults: config.KafkaSourceDefaults{
+				AutoscalingClass:  "kafka.autoscaling.knative.dev",
+				MinScale:          1,
+				MaxScale:          10,
+				PollingInterval:   30,
+				CooldownPeriod:    300,
+				KafkaLagThreshold: 100,
+			},
+			Initial: KafkaSource{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					AutoscalingMaxScaleAnnotation: "4",
+				},
+			}},
+			Expected: map[string]string{
+				AutoscalingClassAnnotation:           "kafka.autoscaling.knative.dev",
+				AutoscalingMinScaleAnnotation:        "1",
+				AutoscalingMaxScaleAnnotation:        "4",
+				AutoscalingPollingIntervalAnnotation: "30",
+				AutoscalingCooldownPeriodAnnotation:  "300",
+				AutoscalingLagThresholdAnnotation:    "100",
+			},
+			AssertFuncs: []assertFnType{assertAnnotations},
 		},
 	}
 
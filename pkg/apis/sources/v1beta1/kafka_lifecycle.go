@@ -45,6 +45,10 @@ const (
 	// KafkaConditionInitialOffsetsCommitted is True when the KafkaSource has committed the
 	// initial offset of all claims
 	KafkaConditionInitialOffsetsCommitted apis.ConditionType = "InitialOffsetsCommitted"
+
+	// KafkaConditionPaused is True when the KafkaSource is paused and doesn't consume its topics.
+	// It doesn't affect the readiness of the KafkaSource.
+	KafkaConditionPaused apis.ConditionType = "Paused"
 )
 
 var (
@@ -158,6 +162,21 @@ func (s *KafkaSourceStatus) MarkInitialOffsetNotCommitted(reason, messageFormat
 	KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionInitialOffsetsCommitted, reason, messageFormat, messageA...)
 }
 
+// MarkPaused sets the condition that the source is paused.
+func (s *KafkaSourceStatus) MarkPaused() {
+	KafkaSourceCondSet.Manage(s).MarkTrueWithReason(KafkaConditionPaused, "Paused", "The consumption of the topics is suspended")
+}
+
+// MarkNotPaused removes the condition that the source is paused.
+func (s *KafkaSourceStatus) MarkNotPaused() {
+	_ = KafkaSourceCondSet.Manage(s).ClearCondition(KafkaConditionPaused)
+}
+
 func (s *KafkaSourceStatus) UpdateConsumerGroupStatus(status string) {
 	s.Claims = status
 }
+
+// UpdateConsumerClaims sets the partitions claimed by the consumers of the receive adapter pods.
+func (s *KafkaSourceStatus) UpdateConsumerClaims(claims []PodClaimsStatus) {
+	s.ConsumerClaims = claims
+}
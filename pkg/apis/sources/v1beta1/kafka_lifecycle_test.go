@@ -215,6 +215,49 @@ func TestKafkaSourceStatusGetCondition(t *testing.T) {
 			Type:   KafkaConditionReady,
 			Status: corev1.ConditionTrue,
 		},
+	}, {
+		name: "ready and paused",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("example"))
+			s.MarkDeployed(availableDeployment)
+			s.MarkConnectionEstablished()
+			s.MarkInitialOffsetCommitted()
+			s.MarkPaused()
+			return s
+		}(),
+		condQuery: KafkaConditionReady,
+		want: &apis.Condition{
+			Type:   KafkaConditionReady,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "mark paused",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkPaused()
+			return s
+		}(),
+		condQuery: KafkaConditionPaused,
+		want: &apis.Condition{
+			Type:    KafkaConditionPaused,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Paused",
+			Message: "The consumption of the topics is suspended",
+		},
+	}, {
+		name: "mark paused then not paused",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkPaused()
+			s.MarkNotPaused()
+			return s
+		}(),
+		condQuery: KafkaConditionPaused,
+		want:      nil,
 	}}
 
 	for _, test := range tests {
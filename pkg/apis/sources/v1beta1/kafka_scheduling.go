@@ -29,15 +29,19 @@ func (k *KafkaSource) GetKey() types.NamespacedName {
 }
 
 func (k *KafkaSource) GetVReplicas() int32 {
-	if k.Spec.Consumers == nil {
-		return 1
+	consumers := int32(1)
+	if k.Status.Autoscaling != nil {
+		// The built-in autoscaler overrides the number of consumers
+		consumers = k.Status.Autoscaling.Scale
+	} else if k.Spec.Consumers != nil {
+		consumers = *k.Spec.Consumers
 	}
 	if k.Status.MaxAllowedVReplicas != nil {
-		if *k.Spec.Consumers > *k.Status.MaxAllowedVReplicas {
+		if consumers > *k.Status.MaxAllowedVReplicas {
 			return *k.Status.MaxAllowedVReplicas
 		}
 	}
-	return *k.Spec.Consumers
+	return consumers
 }
 
 func (k *KafkaSource) GetPlacements() []v1alpha1.Placement {
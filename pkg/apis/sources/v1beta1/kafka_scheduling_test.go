@@ -67,6 +67,18 @@ func TestScheduling(t *testing.T) {
 			},
 			rsrcversion: "12345",
 		},
+		"autoscaled": {
+			source: KafkaSource{
+				Spec: KafkaSourceSpec{
+					Consumers: pointer.Int32Ptr(4),
+				},
+				Status: KafkaSourceStatus{
+					Autoscaling: &KafkaSourceAutoscalingStatus{Scale: 6},
+				},
+			},
+			key:       types.NamespacedName{},
+			vreplicas: int32(6),
+		},
 	}
 
 	for n, tc := range testCases {
@@ -18,7 +18,13 @@ package v1beta1
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/Shopify/sarama"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/duck/v1alpha1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -64,19 +70,71 @@ type KafkaSourceSpec struct {
 
 	bindingsv1beta1.KafkaAuthSpec `json:",inline"`
 
-	// Topic topics to consume messages from
-	// +required
+	// Topic topics to consume messages from, unless a TopicPattern is set
+	// +optional
 	Topics []string `json:"topics"`
 
+	// TopicPattern is a regular expression matching the whole names of the
+	// topics to consume messages from, instead of a fixed list of Topics.
+	// The topics created or deleted later are picked up periodically.
+	// +optional
+	TopicPattern string `json:"topicPattern,omitempty"`
+
 	// ConsumerGroupID is the consumer group ID.
 	// +optional
 	ConsumerGroup string `json:"consumerGroup,omitempty"`
 
 	// InitialOffset is the Initial Offset for the consumer group.
-	// should be earliest or latest
+	// should be earliest, latest or a RFC3339 timestamp (e.g. 2021-06-01T00:00:00Z)
 	// +optional
 	InitialOffset Offset `json:"initialOffset,omitempty"`
 
+	// Ordering is the delivery ordering of the events of a partition.
+	// should be ordered (default), key-ordered or unordered
+	// +optional
+	Ordering DeliveryOrdering `json:"ordering,omitempty"`
+
+	// MaxInFlight is the maximum number of events of a partition being
+	// delivered at the same time with the key-ordered and unordered orderings.
+	// +optional
+	MaxInFlight *int32 `json:"maxInFlight,omitempty"`
+
+	// Delivery contains the delivery spec (retries, backoff and dead letter sink)
+	// applied when sending events to the sink.
+	// +optional
+	Delivery *eventingduck.DeliverySpec `json:"delivery,omitempty"`
+
+	// SchemaRegistry enables the decoding to JSON of the records serialized with
+	// the Confluent Schema Registry wire format (Avro, Protobuf or JSON Schema).
+	// +optional
+	SchemaRegistry *SchemaRegistrySpec `json:"schemaRegistry,omitempty"`
+
+	// Paused suspends the consumption of the topics, without removing the
+	// receive adapter nor changing the offsets committed by the consumer group.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Reply is where the events returned by the sink are sent. When not set,
+	// the responses of the sink are discarded.
+	// +optional
+	Reply *ReplySpec `json:"reply,omitempty"`
+
+	// CloudEventAttributes maps the headers, key and value of the records which
+	// aren't CloudEvents to the attributes and extensions of the events sent to
+	// the sink.
+	// +optional
+	CloudEventAttributes *CloudEventAttributesSpec `json:"cloudEventAttributes,omitempty"`
+
+	// Batching enables the delivery of the records of a partition to the sink in
+	// batches, each batch being sent as a single CloudEvents batch request.
+	// +optional
+	Batching *BatchingSpec `json:"batching,omitempty"`
+
+	// RateLimit bounds the rate of the events sent to the sink, to protect
+	// sinks which can't handle the throughput of the topics.
+	// +optional
+	RateLimit *RateLimitSpec `json:"rateLimit,omitempty"`
+
 	// inherits duck/v1 SourceSpec, which currently provides:
 	// * Sink - a reference to an object that will resolve to a domain name or
 	//   a URI directly to use as the sink.
@@ -85,8 +143,166 @@ type KafkaSourceSpec struct {
 	duckv1.SourceSpec `json:",inline"`
 }
 
+// SchemaRegistrySpec defines the schema registry used to decode the records.
+type SchemaRegistrySpec struct {
+	// URL of the schema registry.
+	// +required
+	URL string `json:"url"`
+
+	// Decode is the part of the records decoded with the schema registry.
+	// should be value (default), key or all
+	// +optional
+	Decode SchemaRegistryDecode `json:"decode,omitempty"`
+
+	// User is the basic authentication user of the schema registry.
+	// +optional
+	User bindingsv1beta1.SecretValueFromSource `json:"user,omitempty"`
+
+	// Password is the basic authentication password of the schema registry.
+	// +optional
+	Password bindingsv1beta1.SecretValueFromSource `json:"password,omitempty"`
+}
+
+// ReplySpec defines where the events returned by the sink are sent.
+// Exactly one of Topic or Destination must be set.
+type ReplySpec struct {
+	// Topic is the topic of the Kafka cluster of the KafkaSource where the events
+	// are produced, with the key of the records they reply to.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+
+	// Destination is the addressable receiving the events.
+	// +optional
+	Destination *duckv1.Destination `json:"destination,omitempty"`
+}
+
+// BatchingSpec defines how the records of a partition are grouped in batches.
+// The offsets of the records of a batch are committed once the batch is
+// acknowledged by the sink.
+type BatchingSpec struct {
+	// MaxSize is the maximum number of records of a batch.
+	// +required
+	MaxSize int32 `json:"maxSize"`
+
+	// MaxWaitMs is the maximum time in milliseconds a record waits for its batch
+	// to be full before the batch is sent, defaulting to 1000.
+	// +optional
+	MaxWaitMs int32 `json:"maxWaitMs,omitempty"`
+}
+
+// RateLimitSpec defines the maximum rate of the events sent to the sink by a KafkaSource,
+// across all its partitions.
+type RateLimitSpec struct {
+	// EventsPerSecond is the maximum sustained number of events per second.
+	// +required
+	EventsPerSecond int32 `json:"eventsPerSecond"`
+
+	// Burst is the maximum number of events sent at once above the sustained rate,
+	// defaulting to EventsPerSecond.
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+}
+
+// LimitsFor returns the events per second and burst of the share of the consumers of
+// a KafkaSource among the total number of its consumers, so that the rate limit holds
+// for all the consumers together. The burst is at least 1.
+func (rls *RateLimitSpec) LimitsFor(share int32, total int32) (float64, int) {
+	burst := rls.EventsPerSecond
+	if rls.Burst != nil {
+		burst = *rls.Burst
+	}
+	if total < 1 {
+		total = 1
+	}
+	ratio := float64(share) / float64(total)
+	return float64(rls.EventsPerSecond) * ratio, int(math.Max(1, math.Ceil(float64(burst)*ratio)))
+}
+
+// CloudEventAttributesSpec defines how the attributes of the events are set from the
+// records which aren't CloudEvents. The attributes which aren't mapped, or whose mapped
+// value is missing from a record, take their default value.
+type CloudEventAttributesSpec struct {
+	// ID maps the id attribute, defaulting to the partition and offset of the record.
+	// +optional
+	ID *AttributeMappingSpec `json:"id,omitempty"`
+
+	// Type maps the type attribute, defaulting to dev.knative.kafka.event.
+	// +optional
+	Type *AttributeMappingSpec `json:"type,omitempty"`
+
+	// Source maps the source attribute, defaulting to the KafkaSource and topic.
+	// +optional
+	Source *AttributeMappingSpec `json:"source,omitempty"`
+
+	// Subject maps the subject attribute, defaulting to the partition and offset of the record.
+	// +optional
+	Subject *AttributeMappingSpec `json:"subject,omitempty"`
+
+	// Time maps the time attribute to a RFC3339 timestamp, defaulting to the timestamp of the record.
+	// +optional
+	Time *AttributeMappingSpec `json:"time,omitempty"`
+
+	// Headers selects the headers of the records propagated as kafkaheader extensions.
+	// +optional
+	Headers *HeadersPropagationSpec `json:"headers,omitempty"`
+}
+
+// AttributeMappingSpec defines the part of a record an attribute is taken from.
+// Exactly one of Header, Key or ValuePointer must be set.
+type AttributeMappingSpec struct {
+	// Header is the name of the header holding the attribute (case-insensitive).
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Key takes the attribute from the key of the record.
+	// +optional
+	Key bool `json:"key,omitempty"`
+
+	// ValuePointer is the JSON pointer (RFC 6901) to the attribute in the JSON
+	// value of the record, e.g. /metadata/id.
+	// +optional
+	ValuePointer string `json:"valuePointer,omitempty"`
+}
+
+// HeadersPropagationSpec defines the headers propagated as extensions of the events.
+// The header names are case-insensitive.
+type HeadersPropagationSpec struct {
+	// Allow lists the only headers propagated. All the headers are propagated when empty.
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny lists the headers never propagated.
+	// +optional
+	Deny []string `json:"deny,omitempty"`
+}
+
+// Propagates returns true if the header is propagated as an extension of the events.
+func (hps *HeadersPropagationSpec) Propagates(header string) bool {
+	if hps == nil {
+		return true
+	}
+	for _, denied := range hps.Deny {
+		if strings.EqualFold(denied, header) {
+			return false
+		}
+	}
+	if len(hps.Allow) == 0 {
+		return true
+	}
+	for _, allowed := range hps.Allow {
+		if strings.EqualFold(allowed, header) {
+			return true
+		}
+	}
+	return false
+}
+
 type Offset string
 
+type DeliveryOrdering string
+
+type SchemaRegistryDecode string
+
 const (
 	// KafkaEventType is the Kafka CloudEvent type.
 	KafkaEventType = "dev.knative.kafka.event"
@@ -98,6 +314,25 @@ const (
 
 	// OffsetLatest denotes the latest offset in the kafka partition
 	OffsetLatest Offset = "latest"
+
+	// Ordered delivers the events of a partition one at a time
+	Ordered DeliveryOrdering = "ordered"
+
+	// KeyOrdered delivers the events of a partition concurrently, except
+	// for the events sharing the same key which are delivered one at a time
+	KeyOrdered DeliveryOrdering = "key-ordered"
+
+	// Unordered delivers the events of a partition concurrently
+	Unordered DeliveryOrdering = "unordered"
+
+	// DecodeValue decodes the values of the records with the schema registry
+	DecodeValue SchemaRegistryDecode = "value"
+
+	// DecodeKey decodes the keys of the records with the schema registry
+	DecodeKey SchemaRegistryDecode = "key"
+
+	// DecodeAll decodes both the keys and the values of the records with the schema registry
+	DecodeAll SchemaRegistryDecode = "all"
 )
 
 var KafkaKeyTypeAllowed = []string{"string", "int", "float", "byte-array"}
@@ -107,6 +342,44 @@ func KafkaEventSource(namespace, kafkaSourceName, topic string) string {
 	return fmt.Sprintf("/apis/v1/namespaces/%s/kafkasources/%s#%s", namespace, kafkaSourceName, topic)
 }
 
+// TopicRegexp returns the compiled TopicPattern, or nil if no TopicPattern is set.
+func (kss *KafkaSourceSpec) TopicRegexp() (*regexp.Regexp, error) {
+	if kss.TopicPattern == "" {
+		return nil, nil
+	}
+	return CompileTopicPattern(kss.TopicPattern)
+}
+
+// CompileTopicPattern compiles a topic pattern, which matches the whole topic names.
+func CompileTopicPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// ParseSaramaOffsetTime returns the Sarama offset time of the Offset: sarama.OffsetOldest for earliest,
+// sarama.OffsetNewest for latest (the default), or the millis since epoch of a RFC3339 timestamp.
+func (o Offset) ParseSaramaOffsetTime() (int64, error) {
+	switch o {
+	case OffsetEarliest:
+		return sarama.OffsetOldest, nil
+	case "", OffsetLatest:
+		return sarama.OffsetNewest, nil
+	}
+	offsetTime, err := time.Parse(time.RFC3339, string(o))
+	if err != nil {
+		return 0, err
+	}
+	return offsetTime.UnixNano() / 1000000, nil // Convert Nanos To Millis For Sarama
+}
+
+// ConsumedTopics returns the topics consumed by the KafkaSource, i.e. its topics, or the topics
+// matching its topic pattern when last resolved by the controller.
+func (k *KafkaSource) ConsumedTopics() []string {
+	if k.Spec.TopicPattern != "" {
+		return k.Status.Topics
+	}
+	return k.Spec.Topics
+}
+
 // KafkaSourceStatus defines the observed state of KafkaSource.
 type KafkaSourceStatus struct {
 	// inherits duck/v1 SourceStatus, which currently provides:
@@ -118,6 +391,16 @@ type KafkaSourceStatus struct {
 	//   Source.
 	duckv1.SourceStatus `json:",inline"`
 
+	// DeadLetterSinkURI is the resolved URI of the dead letter sink
+	// configured in spec.delivery, if any.
+	// +optional
+	DeadLetterSinkURI *apis.URL `json:"deadLetterSinkUri,omitempty"`
+
+	// ReplyURI is the resolved URI of the destination configured in
+	// spec.reply, if any.
+	// +optional
+	ReplyURI *apis.URL `json:"replyUri,omitempty"`
+
 	// Total number of consumers actually running in the consumer group.
 	// +optional
 	Consumers int32 `json:"consumers,omitempty"`
@@ -127,14 +410,106 @@ type KafkaSourceStatus struct {
 	Selector string `json:"selector,omitempty"`
 
 	// Claims consumed by this KafkaSource instance
+	// Deprecated: use ConsumerClaims instead.
 	// +optional
 	Claims string `json:"claims,omitempty"`
 
+	// ConsumerClaims are the partitions claimed by the consumers of each
+	// receive adapter pod.
+	// +optional
+	ConsumerClaims []PodClaimsStatus `json:"consumerClaims,omitempty"`
+
+	// Topics is the list of the topics matching the topicPattern of the
+	// KafkaSource, when last resolved by the controller.
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+
+	// ConsumerLag is the lag of the consumer group of the KafkaSource,
+	// periodically computed by the controller.
+	// +optional
+	ConsumerLag *ConsumerLagStatus `json:"consumerLag,omitempty"`
+
+	// Autoscaling is the state of the built-in autoscaler, when the
+	// KafkaSource uses the kafka.autoscaling.knative.dev autoscaling class.
+	// +optional
+	Autoscaling *KafkaSourceAutoscalingStatus `json:"autoscaling,omitempty"`
+
 	// Implement Placeable.
 	// +optional
 	v1alpha1.Placeable `json:",inline"`
 }
 
+// ConsumerLagStatus is the lag of a consumer group, i.e. the number of
+// records between its committed offsets and the end of the partitions.
+type ConsumerLagStatus struct {
+	// Total is the lag of the consumer group on all the partitions.
+	Total int64 `json:"total"`
+
+	// Partitions is the lag of the consumer group on each partition.
+	// +optional
+	Partitions []PartitionLagStatus `json:"partitions,omitempty"`
+}
+
+// PartitionLagStatus is the lag of a consumer group on a partition.
+type PartitionLagStatus struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+
+	// Offset is the committed offset of the consumer group, or -1 if no
+	// offset is committed yet.
+	Offset int64 `json:"offset"`
+
+	// Lag is the number of records after the committed offset.
+	Lag int64 `json:"lag"`
+}
+
+// PodClaimsStatus are the partitions claimed by the consumers of a receive
+// adapter pod.
+type PodClaimsStatus struct {
+	// Pod identifies the receive adapter pod, by its address for the
+	// single-tenant source or by its name for the multi-tenant source.
+	Pod string `json:"pod"`
+
+	// Partitions are the partitions claimed by the consumers of the pod.
+	// +optional
+	Partitions []PartitionClaimStatus `json:"partitions,omitempty"`
+
+	// LastRebalanceTime is the last time the claims of the pod changed.
+	// +optional
+	LastRebalanceTime *metav1.Time `json:"lastRebalanceTime,omitempty"`
+}
+
+// PartitionClaimStatus is a partition claimed by a consumer.
+type PartitionClaimStatus struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+
+	// CommittedOffset is the committed offset of the consumer group, or -1
+	// if no offset is committed yet.
+	CommittedOffset int64 `json:"committedOffset"`
+}
+
+// KafkaSourceAutoscalingStatus is the scale chosen by the built-in autoscaler
+// according to the lag of the consumer group.
+type KafkaSourceAutoscalingStatus struct {
+	// Scale is the number of receive adapter replicas (or vreplicas for the
+	// multi-tenant source) chosen by the autoscaler.
+	Scale int32 `json:"scale"`
+
+	// Lag is the total lag of the consumer group on the topics when the
+	// scale was last evaluated.
+	Lag int64 `json:"lag"`
+
+	// Partitions is the total number of partitions of the topics, which
+	// caps the scale.
+	// +optional
+	Partitions int32 `json:"partitions,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler changed the scale.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+}
+
 func (*KafkaSource) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("KafkaSource")
 }
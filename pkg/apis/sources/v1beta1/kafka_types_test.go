@@ -19,8 +19,10 @@ package v1beta1
 import (
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestKafkaSource_GetGroupVersionKind(t *testing.T) {
@@ -44,3 +46,153 @@ func TestKafkaSourceGetStatus(t *testing.T) {
 		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", config.GetStatus(), status)
 	}
 }
+
+func TestKafkaSourceSpecTopicRegexp(t *testing.T) {
+	spec := KafkaSourceSpec{Topics: []string{"orders-1"}}
+	if re, err := spec.TopicRegexp(); re != nil || err != nil {
+		t.Errorf("TopicRegexp without pattern = %v, %v, want nil, nil", re, err)
+	}
+
+	spec = KafkaSourceSpec{TopicPattern: "orders-.*|invoices"}
+	re, err := spec.TopicRegexp()
+	if err != nil {
+		t.Fatalf("TopicRegexp failed: %v", err)
+	}
+	for topic, want := range map[string]bool{
+		"orders-1":     true,
+		"invoices":     true,
+		"old-orders-1": false,
+		"invoices-1":   false,
+	} {
+		if got := re.MatchString(topic); got != want {
+			t.Errorf("TopicRegexp matches %q = %v, want %v", topic, got, want)
+		}
+	}
+}
+
+func TestKafkaSourceConsumedTopics(t *testing.T) {
+	src := KafkaSource{
+		Spec:   KafkaSourceSpec{Topics: []string{"orders-1"}},
+		Status: KafkaSourceStatus{Topics: []string{"stale"}},
+	}
+	if got := src.ConsumedTopics(); !cmp.Equal(got, []string{"orders-1"}) {
+		t.Errorf("ConsumedTopics = %v, want [orders-1]", got)
+	}
+
+	src.Spec = KafkaSourceSpec{TopicPattern: "orders-.*"}
+	src.Status.Topics = []string{"orders-1", "orders-2"}
+	if got := src.ConsumedTopics(); !cmp.Equal(got, []string{"orders-1", "orders-2"}) {
+		t.Errorf("ConsumedTopics = %v, want [orders-1 orders-2]", got)
+	}
+}
+
+func TestOffsetParseSaramaOffsetTime(t *testing.T) {
+	testCases := map[string]struct {
+		offset  Offset
+		want    int64
+		wantErr bool
+	}{
+		"empty":     {offset: "", want: sarama.OffsetNewest},
+		"latest":    {offset: OffsetLatest, want: sarama.OffsetNewest},
+		"earliest":  {offset: OffsetEarliest, want: sarama.OffsetOldest},
+		"timestamp": {offset: "2021-06-01T00:00:00Z", want: 1622505600000},
+		"invalid":   {offset: "yesterday", wantErr: true},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got, err := tc.offset.ParseSaramaOffsetTime()
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("ParseSaramaOffsetTime error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseSaramaOffsetTime = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHeadersPropagationSpecPropagates(t *testing.T) {
+	testCases := map[string]struct {
+		spec *HeadersPropagationSpec
+		want map[string]bool
+	}{
+		"nil": {
+			want: map[string]bool{"a": true, "b": true},
+		},
+		"allow": {
+			spec: &HeadersPropagationSpec{Allow: []string{"a"}},
+			want: map[string]bool{"a": true, "b": false},
+		},
+		"deny": {
+			spec: &HeadersPropagationSpec{Deny: []string{"A"}},
+			want: map[string]bool{"a": false, "b": true},
+		},
+		"allow and deny": {
+			spec: &HeadersPropagationSpec{Allow: []string{"a", "b"}, Deny: []string{"b"}},
+			want: map[string]bool{"a": true, "b": false, "c": false},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			for header, want := range tc.want {
+				if got := tc.spec.Propagates(header); got != want {
+					t.Errorf("Propagates(%q) = %v, want %v", header, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitSpecLimitsFor(t *testing.T) {
+	testCases := map[string]struct {
+		spec      *RateLimitSpec
+		share     int32
+		total     int32
+		wantRate  float64
+		wantBurst int
+	}{
+		"default burst": {
+			spec:      &RateLimitSpec{EventsPerSecond: 10},
+			share:     1,
+			total:     1,
+			wantRate:  10,
+			wantBurst: 10,
+		},
+		"burst": {
+			spec:      &RateLimitSpec{EventsPerSecond: 10, Burst: ptr.Int32(50)},
+			share:     1,
+			total:     1,
+			wantRate:  10,
+			wantBurst: 50,
+		},
+		"share": {
+			spec:      &RateLimitSpec{EventsPerSecond: 10, Burst: ptr.Int32(5)},
+			share:     1,
+			total:     4,
+			wantRate:  2.5,
+			wantBurst: 2,
+		},
+		"minimum burst": {
+			spec:      &RateLimitSpec{EventsPerSecond: 1},
+			share:     1,
+			total:     3,
+			wantRate:  1.0 / 3,
+			wantBurst: 1,
+		},
+		"no consumer": {
+			spec:      &RateLimitSpec{EventsPerSecond: 10},
+			share:     1,
+			total:     0,
+			wantRate:  10,
+			wantBurst: 10,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			rate, burst := tc.spec.LimitsFor(tc.share, tc.total)
+			if rate != tc.wantRate || burst != tc.wantBurst {
+				t.Errorf("LimitsFor() = %v, %v, want %v, %v", rate, burst, tc.wantRate, tc.wantBurst)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"context"
+	"math"
+	"strings"
 
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
@@ -25,6 +27,7 @@ import (
 
 // Validate ensures KafkaSource is properly configured.
 func (ks *KafkaSource) Validate(ctx context.Context) *apis.FieldError {
+	ctx = apis.WithinParent(ctx, ks.ObjectMeta)
 	errs := ks.Spec.Validate(ctx).ViaField("spec")
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*KafkaSource)
@@ -40,21 +43,156 @@ func (kss *KafkaSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 	errs = errs.Also(kss.SourceSpec.Validate(ctx))
 
 	// Check for mandatory fields
-	if len(kss.Topics) <= 0 {
-		errs = errs.Also(apis.ErrMissingField("topics"))
+	if kss.TopicPattern != "" {
+		if len(kss.Topics) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("topics", "topicPattern"))
+		}
+		if _, err := kss.TopicRegexp(); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(kss.TopicPattern, "topicPattern", err.Error()))
+		}
+	} else if len(kss.Topics) <= 0 {
+		errs = errs.Also(apis.ErrMissingOneOf("topics", "topicPattern"))
 	}
-	if len(kss.BootstrapServers) <= 0 {
-		errs = errs.Also(apis.ErrMissingField("bootstrapServers"))
+	errs = errs.Also(kss.KafkaAuthSpec.Validate(ctx))
+	if _, err := kss.InitialOffset.ParseSaramaOffsetTime(); err != nil || kss.InitialOffset == "" {
+		errs = errs.Also(apis.ErrInvalidValue(kss.InitialOffset, "initialOffset"))
 	}
-	switch kss.InitialOffset {
-	case OffsetEarliest, OffsetLatest:
+
+	switch kss.Ordering {
+	case "", Ordered, KeyOrdered, Unordered:
 	default:
-		errs = errs.Also(apis.ErrInvalidValue(kss.InitialOffset, "initialOffset"))
+		errs = errs.Also(apis.ErrInvalidValue(kss.Ordering, "ordering"))
+	}
+	if kss.MaxInFlight != nil && *kss.MaxInFlight < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*kss.MaxInFlight, 1, math.MaxInt32, "maxInFlight"))
+	}
+
+	if kss.Delivery != nil {
+		errs = errs.Also(kss.Delivery.Validate(ctx).ViaField("delivery"))
+	}
+
+	if kss.SchemaRegistry != nil {
+		errs = errs.Also(kss.SchemaRegistry.Validate(ctx).ViaField("schemaRegistry"))
+	}
+	if kss.Reply != nil {
+		errs = errs.Also(kss.Reply.Validate(ctx).ViaField("reply"))
+	}
+	if kss.CloudEventAttributes != nil {
+		errs = errs.Also(kss.CloudEventAttributes.Validate(ctx).ViaField("cloudEventAttributes"))
+	}
+	if kss.Batching != nil {
+		if kss.Reply != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("batching", "reply"))
+		}
+		errs = errs.Also(kss.Batching.Validate(ctx).ViaField("batching"))
+	}
+	if kss.RateLimit != nil {
+		errs = errs.Also(kss.RateLimit.Validate(ctx).ViaField("rateLimit"))
+	}
+
+	return errs
+}
+
+func (srs *SchemaRegistrySpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if srs.URL == "" {
+		errs = errs.Also(apis.ErrMissingField("url"))
+	} else if u, err := apis.ParseURL(srs.URL); err != nil || !u.URL().IsAbs() {
+		errs = errs.Also(apis.ErrInvalidValue(srs.URL, "url"))
+	}
+
+	switch srs.Decode {
+	case "", DecodeValue, DecodeKey, DecodeAll:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(srs.Decode, "decode"))
+	}
+
+	return errs
+}
+
+func (rs *ReplySpec) Validate(ctx context.Context) *apis.FieldError {
+	switch {
+	case rs.Topic != "" && rs.Destination != nil:
+		return apis.ErrMultipleOneOf("topic", "destination")
+	case rs.Destination != nil:
+		return rs.Destination.Validate(ctx).ViaField("destination")
+	case rs.Topic == "":
+		return apis.ErrMissingOneOf("topic", "destination")
+	}
+	return nil
+}
+
+func (bs *BatchingSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if bs.MaxSize < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(bs.MaxSize, 1, math.MaxInt32, "maxSize"))
+	}
+	if bs.MaxWaitMs < 0 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(bs.MaxWaitMs, 0, math.MaxInt32, "maxWaitMs"))
+	}
+
+	return errs
+}
+
+func (rls *RateLimitSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if rls.EventsPerSecond < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(rls.EventsPerSecond, 1, math.MaxInt32, "eventsPerSecond"))
+	}
+	if rls.Burst != nil && *rls.Burst < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*rls.Burst, 1, math.MaxInt32, "burst"))
+	}
+
+	return errs
+}
+
+func (ceas *CloudEventAttributesSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	mappings := map[string]*AttributeMappingSpec{
+		"id":      ceas.ID,
+		"type":    ceas.Type,
+		"source":  ceas.Source,
+		"subject": ceas.Subject,
+		"time":    ceas.Time,
+	}
+	for field, mapping := range mappings {
+		if mapping != nil {
+			errs = errs.Also(mapping.Validate(ctx).ViaField(field))
+		}
 	}
 
 	return errs
 }
 
+func (ams *AttributeMappingSpec) Validate(ctx context.Context) *apis.FieldError {
+	var set []string
+	if ams.Header != "" {
+		set = append(set, "header")
+	}
+	if ams.Key {
+		set = append(set, "key")
+	}
+	if ams.ValuePointer != "" {
+		set = append(set, "valuePointer")
+		if !strings.HasPrefix(ams.ValuePointer, "/") {
+			return apis.ErrInvalidValue(ams.ValuePointer, "valuePointer")
+		}
+	}
+
+	switch len(set) {
+	case 0:
+		return apis.ErrMissingOneOf("header", "key", "valuePointer")
+	case 1:
+		return nil
+	default:
+		return apis.ErrMultipleOneOf(set...)
+	}
+}
+
 func (ks *KafkaSource) CheckImmutableFields(ctx context.Context, original *KafkaSource) *apis.FieldError {
 	if original == nil {
 		return nil
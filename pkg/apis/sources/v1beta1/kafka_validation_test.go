@@ -21,8 +21,10 @@ import (
 	"testing"
 
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 var (
@@ -83,6 +85,63 @@ func TestKafkaSourceCheckRequiredFields(t *testing.T) {
 			},
 			allowed: false,
 		},
+		"cluster ref": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+					ClusterRef: &bindingsv1beta1.KafkaClusterReference{Name: "cluster"},
+				},
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: true,
+		},
+		"cluster ref and bootstrapServers": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+					BootstrapServers: fullSpec.BootstrapServers,
+					ClusterRef:       &bindingsv1beta1.KafkaClusterReference{Name: "cluster"},
+				},
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: false,
+		},
+		"cluster ref and net": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+					Net:        bindingsv1beta1.KafkaNetSpec{TLS: bindingsv1beta1.KafkaTLSSpec{Enable: true}},
+					ClusterRef: &bindingsv1beta1.KafkaClusterReference{Name: "cluster"},
+				},
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: false,
+		},
+		"cluster ref without name": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+					ClusterRef: &bindingsv1beta1.KafkaClusterReference{},
+				},
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: false,
+		},
+		"cluster ref to another namespace": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+					ClusterRef: &bindingsv1beta1.KafkaClusterReference{Name: "cluster", Namespace: "other"},
+				},
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: false,
+		},
 		"min required fields": {
 			orig: &KafkaSourceSpec{
 				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
@@ -96,6 +155,299 @@ func TestKafkaSourceCheckRequiredFields(t *testing.T) {
 			orig:    &fullSpec,
 			allowed: true,
 		},
+		"topic pattern": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				TopicPattern:  "orders-.*",
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: true,
+		},
+		"topics and topic pattern": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				TopicPattern:  "orders-.*",
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: false,
+		},
+		"invalid topic pattern": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				TopicPattern:  "orders-(",
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: false,
+		},
+		"reply topic": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Reply:         &ReplySpec{Topic: "replies"},
+			},
+			allowed: true,
+		},
+		"reply destination": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Reply:         &ReplySpec{Destination: &fullSpec.Sink},
+			},
+			allowed: true,
+		},
+		"reply topic and destination": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Reply:         &ReplySpec{Topic: "replies", Destination: &fullSpec.Sink},
+			},
+			allowed: false,
+		},
+		"empty reply": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Reply:         &ReplySpec{},
+			},
+			allowed: false,
+		},
+		"batching": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Batching:      &BatchingSpec{MaxSize: 100, MaxWaitMs: 500},
+			},
+			allowed: true,
+		},
+		"batching without max size": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Batching:      &BatchingSpec{MaxWaitMs: 500},
+			},
+			allowed: false,
+		},
+		"batching with negative max wait": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Batching:      &BatchingSpec{MaxSize: 100, MaxWaitMs: -1},
+			},
+			allowed: false,
+		},
+		"batching and reply": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Batching:      &BatchingSpec{MaxSize: 100},
+				Reply:         &ReplySpec{Topic: "replies"},
+			},
+			allowed: false,
+		},
+		"rate limit": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				RateLimit:     &RateLimitSpec{EventsPerSecond: 10, Burst: ptr.Int32(20)},
+			},
+			allowed: true,
+		},
+		"rate limit without events per second": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				RateLimit:     &RateLimitSpec{},
+			},
+			allowed: false,
+		},
+		"rate limit with zero burst": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				RateLimit:     &RateLimitSpec{EventsPerSecond: 10, Burst: ptr.Int32(0)},
+			},
+			allowed: false,
+		},
+		"cloud event attributes": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				CloudEventAttributes: &CloudEventAttributesSpec{
+					ID:      &AttributeMappingSpec{Key: true},
+					Type:    &AttributeMappingSpec{Header: "event-type"},
+					Subject: &AttributeMappingSpec{ValuePointer: "/order/id"},
+					Headers: &HeadersPropagationSpec{Deny: []string{"authorization"}},
+				},
+			},
+			allowed: true,
+		},
+		"cloud event attribute without mapping": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				CloudEventAttributes: &CloudEventAttributesSpec{
+					Type: &AttributeMappingSpec{},
+				},
+			},
+			allowed: false,
+		},
+		"cloud event attribute with several mappings": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				CloudEventAttributes: &CloudEventAttributesSpec{
+					Source: &AttributeMappingSpec{Header: "source", Key: true},
+				},
+			},
+			allowed: false,
+		},
+		"cloud event attribute with invalid value pointer": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				CloudEventAttributes: &CloudEventAttributesSpec{
+					Time: &AttributeMappingSpec{ValuePointer: "order.time"},
+				},
+			},
+			allowed: false,
+		},
+		"valid delivery": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Delivery: &eventingduck.DeliverySpec{
+					Retry:          ptr.Int32(3),
+					DeadLetterSink: &fullSpec.Sink,
+				},
+			},
+			allowed: true,
+		},
+		"valid ordering": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Ordering:      KeyOrdered,
+				MaxInFlight:   ptr.Int32(10),
+			},
+			allowed: true,
+		},
+		"invalid ordering": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Ordering:      "random",
+			},
+			allowed: false,
+		},
+		"invalid max in flight": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Ordering:      Unordered,
+				MaxInFlight:   ptr.Int32(0),
+			},
+			allowed: false,
+		},
+		"invalid delivery backoff delay": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				Delivery: &eventingduck.DeliverySpec{
+					BackoffDelay: ptr.String("not a duration"),
+				},
+			},
+			allowed: false,
+		},
+		"valid schema registry": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				SchemaRegistry: &SchemaRegistrySpec{
+					URL:    "https://schema-registry.example.com",
+					Decode: DecodeAll,
+				},
+			},
+			allowed: true,
+		},
+		"missing schema registry url": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec:  fullSpec.KafkaAuthSpec,
+				Topics:         fullSpec.Topics,
+				SourceSpec:     fullSpec.SourceSpec,
+				InitialOffset:  OffsetLatest,
+				SchemaRegistry: &SchemaRegistrySpec{},
+			},
+			allowed: false,
+		},
+		"relative schema registry url": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec:  fullSpec.KafkaAuthSpec,
+				Topics:         fullSpec.Topics,
+				SourceSpec:     fullSpec.SourceSpec,
+				InitialOffset:  OffsetLatest,
+				SchemaRegistry: &SchemaRegistrySpec{URL: "schema-registry"},
+			},
+			allowed: false,
+		},
+		"invalid schema registry decode": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				Topics:        fullSpec.Topics,
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+				SchemaRegistry: &SchemaRegistrySpec{
+					URL:    "https://schema-registry.example.com",
+					Decode: "headers",
+				},
+			},
+			allowed: false,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -325,6 +677,14 @@ func TestKafkaSourceOffset(t *testing.T) {
 			allowed: false,
 			offset:  "invalid",
 		},
+		"timestamp offset": {
+			allowed: true,
+			offset:  "2021-06-01T00:00:00Z",
+		},
+		"invalid timestamp offset": {
+			allowed: false,
+			offset:  "2021-06-01 00:00:00",
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -16,11 +16,32 @@ limitations under the License.
 
 package dispatcher
 
+import "github.com/Shopify/sarama"
+
 type ChannelConfig struct {
 	Namespace     string
 	Name          string
 	HostName      string
 	Subscriptions []Subscription
+	// Cluster is the Kafka cluster of a channel referencing a KafkaCluster (nil for the one of config-kafka)
+	Cluster *ClusterConfig
+}
+
+// ClusterConfig is the connection to the Kafka cluster of a KafkaCluster.
+type ClusterConfig struct {
+	// Key identifies the connection settings, the producer and consumer groups of
+	// the channels are recreated when it changes
+	Key     string
+	Brokers []string
+	Config  *sarama.Config
+}
+
+// ClusterKey returns the key of the Kafka cluster of the channel, empty for the one of config-kafka.
+func (cc ChannelConfig) ClusterKey() string {
+	if cc.Cluster == nil {
+		return ""
+	}
+	return cc.Cluster.Key
 }
 
 func (cc ChannelConfig) SubscriptionsUIDs() []string {
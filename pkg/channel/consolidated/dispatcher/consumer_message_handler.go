@@ -19,6 +19,7 @@ package dispatcher
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Shopify/sarama"
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
@@ -29,6 +30,7 @@ import (
 	"knative.dev/eventing/pkg/kncloudevents"
 
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
@@ -40,9 +42,12 @@ type consumerMessageHandler struct {
 	consumerGroup     string
 	reporter          eventingchannels.StatsReporter
 	channelNs         string
+	// retry schedules the retries of the failed events in the retry topic of the subscription, if enabled
+	retry *retry.Dispatcher
 }
 
 var _ consumer.KafkaConsumerHandler = (*consumerMessageHandler)(nil)
+var _ consumer.KafkaDelayedConsumerHandler = (*consumerMessageHandler)(nil)
 
 func (c consumerMessageHandler) GetConsumerGroup() string {
 	return c.consumerGroup
@@ -52,6 +57,14 @@ func (c consumerMessageHandler) SetReady(partition int32, ready bool) {
 	c.kafkaSubscription.SetReady(c.sub.UID, partition, ready)
 }
 
+// NotBefore returns the time before which a message of the retry topic must not be dispatched
+func (c consumerMessageHandler) NotBefore(consumerMessage *sarama.ConsumerMessage) time.Time {
+	if c.retry == nil {
+		return time.Time{}
+	}
+	return c.retry.NotBefore(consumerMessage)
+}
+
 func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMessage) (bool, error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -73,24 +86,31 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 
 	// Convert ConsumerMessage.Headers Into HTTP Header Struct For Dispatching (Passing-Through of "Additional Headers")
 	// Using Sarama RecordHeaders instead of CloudEvent Message.Headers to support multi-value HTTP Headers without
-	// serialization.  Also, filtering CloudEvent "ce" headers which are already taken from the Message.
-	httpHeader := tracing.ConvertRecordHeadersToHttpHeader(tracing.FilterCeRecordHeaders(consumerMessage.Headers))
+	// serialization.  Also, filtering CloudEvent "ce" headers which are already taken from the Message, and the
+	// headers of the retry topic.
+	httpHeader := tracing.ConvertRecordHeadersToHttpHeader(tracing.FilterCeRecordHeaders(retry.FilterRecordHeaders(consumerMessage.Headers)))
 
 	ctx, span := tracing.StartTraceFromMessage(c.logger, ctx, message, "kafkachannel-"+consumerMessage.Topic)
 	defer span.End()
 
 	te := kncloudevents.TypeExtractorTransformer("")
 
-	dispatchExecutionInfo, err := c.dispatcher.DispatchMessageWithRetries(
-		ctx,
-		message,
-		httpHeader,
-		c.sub.Subscriber,
-		c.sub.Reply,
-		c.sub.DeadLetter,
-		c.sub.RetryConfig,
-		&te,
-	)
+	var dispatchExecutionInfo *eventingchannels.DispatchExecutionInfo
+	var err error
+	if c.retry != nil {
+		dispatchExecutionInfo, err = c.retry.Dispatch(ctx, consumerMessage, message, httpHeader, &te)
+	} else {
+		dispatchExecutionInfo, err = c.dispatcher.DispatchMessageWithRetries(
+			ctx,
+			message,
+			httpHeader,
+			c.sub.Subscriber,
+			c.sub.Reply,
+			c.sub.DeadLetter,
+			c.sub.RetryConfig,
+			&te,
+		)
+	}
 
 	args := eventingchannels.ReportArgs{
 		Ns:        c.channelNs,
@@ -98,6 +118,7 @@ func (c consumerMessageHandler) Handle(ctx context.Context, consumerMessage *sar
 	}
 	_ = fanout.ParseDispatchResultAndReportMetrics(fanout.NewDispatchResult(err, dispatchExecutionInfo), c.reporter, args)
 
-	// NOTE: only return `true` here if DispatchMessage actually delivered the message.
+	// NOTE: only return `true` here if DispatchMessage actually delivered the message (or, with the retry topic,
+	// scheduled its retry or sent it to the dead letter sink).
 	return err == nil, err
 }
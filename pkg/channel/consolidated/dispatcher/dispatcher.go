@@ -21,6 +21,7 @@ import (
 	nethttp "net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
@@ -35,11 +36,16 @@ import (
 	"knative.dev/eventing-kafka/pkg/common/config"
 
 	eventingchannels "knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/pkg/kmeta"
 
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/kafka/producer"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
@@ -58,39 +64,80 @@ type KafkaDispatcher struct {
 
 	// Receiver data structures
 	// map[string]eventingchannels.ChannelReference
-	hostToChannelMap  sync.Map
-	kafkaSyncProducer sarama.SyncProducer
+	hostToChannelMap sync.Map
+	kafkaProducer    *producer.AsyncProducer
+
+	// Producers of the channels referencing a KafkaCluster
+	// clusterProducerLock must be used to update all the below maps
+	clusterProducerLock sync.Mutex
+	clusterProducers    map[string]*clusterProducer
+	channelClusters     map[eventingchannels.ChannelReference]string
+	newClusterProducer  func(cluster *ClusterConfig) (*producer.AsyncProducer, error)
 
 	// Dispatcher data structures
 	// consumerUpdateLock must be used to update all the below maps
 	consumerUpdateLock   sync.Mutex
 	channelSubscriptions map[types.NamespacedName]*KafkaSubscription
-	subsConsumerGroups   map[types.UID]sarama.ConsumerGroup
 	subscriptions        map[types.UID]Subscription
-	kafkaConsumerFactory consumer.KafkaConsumerGroupFactory
+	consumerMgr          consumer.KafkaConsumerGroupManager
+	consumerOptions      []consumer.SaramaConsumerHandlerOption
+
+	// Retry topics of the subscriptions (when enabled), created on the Kafka cluster of their channel
+	retryTopics     bool
+	brokers         []string
+	saramaConfig    *sarama.Config
+	newClusterAdmin func(brokers []string, config *sarama.Config) (sarama.ClusterAdmin, error)
 
 	topicFunc TopicFunc
 	logger    *zap.SugaredLogger
 }
 
-// NewDispatcher creates a new dispatcher struct. enqueue argument is a function that is used to
-// requeue a KafkaChannel instance via the reconciler which is used when creating the consumer.
-func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs, enqueue func(ref types.NamespacedName)) (*KafkaDispatcher, error) {
+// NewDispatcher creates a new dispatcher struct. The consumer groups of the subscriptions are managed
+// through the control-protocol server, so that they can be stopped and started (e.g. by the ResetOffset
+// controller). enqueue argument is a function that is used to requeue a KafkaChannel instance via the
+// reconciler which is used when creating the consumer.
+func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs, controlServer controlprotocol.ServerHandler, enqueue func(ref types.NamespacedName)) (*KafkaDispatcher, error) {
 
-	producer, err := sarama.NewSyncProducer(args.Brokers, args.Config.Sarama.Config)
+	ordering, err := consumer.ParseDeliveryOrdering(args.Config.Channel.Dispatcher.DeliveryOrdering)
+	if err != nil {
+		return nil, err
+	}
+
+	// The events of concurrent requests are batched together by the async producer
+	saramaConfig := args.Config.Sarama.Config
+	if saramaConfig == nil {
+		saramaConfig = sarama.NewConfig()
+	}
+	producerConfig := args.Config.Channel.Producer
+	producer.ConfigureBatching(saramaConfig, producerConfig.BatchSize, time.Duration(producerConfig.LingerMs)*time.Millisecond)
+	asyncProducer, err := sarama.NewAsyncProducer(args.Brokers, saramaConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create kafka producer against Kafka bootstrap servers %v : %v", args.Brokers, err)
 	}
 
 	dispatcher := &KafkaDispatcher{
 		dispatcher:           eventingchannels.NewMessageDispatcher(logging.FromContext(ctx).Desugar()),
-		kafkaConsumerFactory: consumer.NewConsumerGroupFactory(args.Brokers, args.Config.Sarama.Config, &consumer.KafkaConsumerGroupOffsetsChecker{}, enqueue),
+		consumerMgr:          consumer.NewConsumerGroupManager(logging.FromContext(ctx).Desugar(), controlServer, args.Brokers, args.Config.Sarama.Config, &consumer.KafkaConsumerGroupOffsetsChecker{}, enqueue),
+		consumerOptions:      []consumer.SaramaConsumerHandlerOption{consumer.WithDeliveryOrdering(ordering, args.Config.Channel.Dispatcher.MaxInFlight)},
 		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
-		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
-		kafkaSyncProducer:    producer,
-		logger:               logging.FromContext(ctx),
-		topicFunc:            args.TopicFunc,
+		kafkaProducer:        producer.NewAsyncProducer(asyncProducer),
+		clusterProducers:     make(map[string]*clusterProducer),
+		channelClusters:      make(map[eventingchannels.ChannelReference]string),
+		newClusterProducer: func(cluster *ClusterConfig) (*producer.AsyncProducer, error) {
+			producer.ConfigureBatching(cluster.Config, producerConfig.BatchSize, time.Duration(producerConfig.LingerMs)*time.Millisecond)
+			asyncProducer, err := sarama.NewAsyncProducer(cluster.Brokers, cluster.Config)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create kafka producer against Kafka bootstrap servers %v : %v", cluster.Brokers, err)
+			}
+			return producer.NewAsyncProducer(asyncProducer), nil
+		},
+		retryTopics:     args.Config.Channel.Dispatcher.RetryTopics,
+		brokers:         args.Brokers,
+		saramaConfig:    saramaConfig,
+		newClusterAdmin: sarama.NewClusterAdmin,
+		logger:          logging.FromContext(ctx),
+		topicFunc:       args.TopicFunc,
 	}
 
 	podName, err := env.GetRequiredConfigValue(logging.FromContext(ctx).Desugar(), env.PodNameEnvVarKey)
@@ -118,10 +165,14 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs, enqueue func(
 			kafkaProducerMessage.Headers = append(kafkaProducerMessage.Headers, tracing.SerializeTrace(trace.FromContext(ctx).SpanContext())...)
 			kafkaProducerMessage.Headers = append(kafkaProducerMessage.Headers, tracing.ConvertHttpHeaderToRecordHeaders(httpHeader)...)
 
-			partition, offset, err := dispatcher.kafkaSyncProducer.SendMessage(&kafkaProducerMessage)
+			start := time.Now()
+			partition, offset, err := dispatcher.sendChannelMessage(ctx, channel, &kafkaProducerMessage)
 
 			if err == nil {
 				dispatcher.logger.Debugw("message sent", zap.Int32("partition", partition), zap.Int64("offset", offset))
+				if reportErr := metrics.ReportProduceLatency(ctx, "KafkaChannel", channel.Namespace, channel.Name, time.Since(start)); reportErr != nil {
+					dispatcher.logger.Warnw("failed to report the produce latency", zap.Error(reportErr))
+				}
 			} else {
 				dispatcher.logger.Warnw("message not sent", zap.Error(err))
 			}
@@ -174,6 +225,21 @@ func (d *KafkaDispatcher) ReconcileConsumers(ctx context.Context, config *Channe
 	d.consumerUpdateLock.Lock()
 	defer d.consumerUpdateLock.Unlock()
 
+	// The consumer groups of the channel are recreated against its new Kafka cluster when it changes, and
+	// the retry topics of its subscriptions are deleted from the previous cluster
+	if thisChannelKafkaSubscriptions, ok := d.channelSubscriptions[channelNamespacedName]; ok && thisChannelKafkaSubscriptions.clusterKey() != config.ClusterKey() {
+		d.logger.Infow("Kafka cluster of the channel changed, recreating its consumer groups", zap.Any("channel", channelNamespacedName))
+		for _, subUid := range thisChannelKafkaSubscriptions.subs.UnsortedList() {
+			if err := d.unsubscribe(channelNamespacedName, d.subscriptions[types.UID(subUid)]); err != nil {
+				d.logger.Warnw("Error while unsubscribing", zap.Error(err))
+			}
+			if d.retryTopics {
+				d.deleteRetryTopics(channelNamespacedName, thisChannelKafkaSubscriptions.cluster, types.UID(subUid))
+			}
+		}
+		delete(d.channelSubscriptions, channelNamespacedName)
+	}
+
 	// This loop takes care of filling toAddSubs and toRemoveSubs for new and existing channels
 	thisChannelKafkaSubscriptions := d.channelSubscriptions[channelNamespacedName]
 
@@ -205,7 +271,7 @@ func (d *KafkaDispatcher) ReconcileConsumers(ctx context.Context, config *Channe
 
 	failedToSubscribe := make(UpdateError)
 	for subUid, subSpec := range toAddSubs {
-		if err := d.subscribe(ctx, channelNamespacedName, subSpec); err != nil {
+		if err := d.subscribe(ctx, channelNamespacedName, config.Cluster, subSpec); err != nil {
 			failedToSubscribe[subUid] = err
 		}
 	}
@@ -216,6 +282,10 @@ func (d *KafkaDispatcher) ReconcileConsumers(ctx context.Context, config *Channe
 		if err := d.unsubscribe(channelNamespacedName, d.subscriptions[types.UID(subUid)]); err != nil {
 			d.logger.Warnw("Error while unsubscribing", zap.Error(err))
 		}
+		// The retry topics of the deleted channels are deleted by the controller
+		if d.retryTopics {
+			d.deleteRetryTopics(channelNamespacedName, config.Cluster, types.UID(subUid))
+		}
 	}
 
 	if len(failedToSubscribe) == 0 {
@@ -247,6 +317,112 @@ func (d *KafkaDispatcher) RegisterChannelHost(channelConfig *ChannelConfig) erro
 	return nil
 }
 
+// clusterProducer is the producer of a Kafka cluster, shared by the channels of the cluster. Its refs
+// count both the channels using it and the messages being sent with it, so that it is only closed once
+// it is no longer used by any of them.
+type clusterProducer struct {
+	*producer.AsyncProducer
+	key  string
+	refs int
+}
+
+// RegisterChannelCluster sets the producer of the channel to the one of its Kafka cluster, creating
+// it if no other channel of the cluster has done it already.
+func (d *KafkaDispatcher) RegisterChannelCluster(channelConfig *ChannelConfig) error {
+	channel := eventingchannels.ChannelReference{Name: channelConfig.Name, Namespace: channelConfig.Namespace}
+
+	d.clusterProducerLock.Lock()
+	released, err := d.registerChannelCluster(channel, channelConfig.Cluster)
+	d.clusterProducerLock.Unlock()
+
+	d.closeClusterProducer(released)
+	return err
+}
+
+// registerChannelCluster sets the cluster of the channel, and returns the producer of its previous cluster
+// if it must be closed. registerChannelCluster must be called under clusterProducerLock.
+func (d *KafkaDispatcher) registerChannelCluster(channel eventingchannels.ChannelReference, cluster *ClusterConfig) (*clusterProducer, error) {
+	if cluster == nil {
+		return d.releaseChannelCluster(channel), nil
+	}
+	if key, ok := d.channelClusters[channel]; ok && key == cluster.Key {
+		return nil, nil
+	}
+	registered, ok := d.clusterProducers[cluster.Key]
+	if !ok {
+		kafkaProducer, err := d.newClusterProducer(cluster)
+		if err != nil {
+			return nil, err
+		}
+		registered = &clusterProducer{AsyncProducer: kafkaProducer, key: cluster.Key}
+		d.clusterProducers[cluster.Key] = registered
+	}
+	released := d.releaseChannelCluster(channel)
+	registered.refs++
+	d.channelClusters[channel] = cluster.Key
+	return released, nil
+}
+
+// releaseChannelCluster removes the cluster of the channel, and returns its producer if no other channel
+// nor message uses it, in which case it must be closed. releaseChannelCluster must be called under
+// clusterProducerLock.
+func (d *KafkaDispatcher) releaseChannelCluster(channel eventingchannels.ChannelReference) *clusterProducer {
+	key, ok := d.channelClusters[channel]
+	if !ok {
+		return nil
+	}
+	delete(d.channelClusters, channel)
+	return d.releaseClusterProducer(d.clusterProducers[key])
+}
+
+// releaseClusterProducer releases a reference of the producer, and returns it once unused, in which case
+// it must be closed. releaseClusterProducer must be called under clusterProducerLock.
+func (d *KafkaDispatcher) releaseClusterProducer(released *clusterProducer) *clusterProducer {
+	if released == nil {
+		return nil
+	}
+	released.refs--
+	if released.refs > 0 {
+		return nil
+	}
+	delete(d.clusterProducers, released.key)
+	return released
+}
+
+// closeClusterProducer closes the producer of an unused Kafka cluster, if any. It is called without
+// holding clusterProducerLock, as closing flushes the messages buffered by the producer.
+func (d *KafkaDispatcher) closeClusterProducer(released *clusterProducer) {
+	if released == nil {
+		return
+	}
+	d.logger.Infow("Closing the producer of an unused Kafka cluster", zap.String("cluster", released.key))
+	if err := released.Close(); err != nil {
+		d.logger.Errorw("Failed to close the producer of an unused Kafka cluster", zap.String("cluster", released.key), zap.Error(err))
+	}
+}
+
+// sendChannelMessage sends the message with the producer of the Kafka cluster of the channel, which is
+// not closed until the message is sent, even if the channel is deleted or moved to another cluster.
+func (d *KafkaDispatcher) sendChannelMessage(ctx context.Context, channel eventingchannels.ChannelReference, message *sarama.ProducerMessage) (int32, int64, error) {
+	d.clusterProducerLock.Lock()
+	key, ok := d.channelClusters[channel]
+	if !ok {
+		d.clusterProducerLock.Unlock()
+		return d.kafkaProducer.SendMessage(ctx, message)
+	}
+	acquired := d.clusterProducers[key]
+	acquired.refs++
+	d.clusterProducerLock.Unlock()
+
+	defer func() {
+		d.clusterProducerLock.Lock()
+		released := d.releaseClusterProducer(acquired)
+		d.clusterProducerLock.Unlock()
+		d.closeClusterProducer(released)
+	}()
+	return acquired.SendMessage(ctx, message)
+}
+
 func (d *KafkaDispatcher) CleanupChannel(name, namespace, hostname string) error {
 	channelRef := types.NamespacedName{
 		Name:      name,
@@ -256,6 +432,12 @@ func (d *KafkaDispatcher) CleanupChannel(name, namespace, hostname string) error
 	// Remove from the hostToChannel map the mapping with this channel
 	d.hostToChannelMap.Delete(hostname)
 
+	// Release the producer of the Kafka cluster of the channel
+	d.clusterProducerLock.Lock()
+	released := d.releaseChannelCluster(eventingchannels.ChannelReference{Name: name, Namespace: namespace})
+	d.clusterProducerLock.Unlock()
+	d.closeClusterProducer(released)
+
 	// Remove all subs
 	d.consumerUpdateLock.Lock()
 	defer d.consumerUpdateLock.Unlock()
@@ -276,11 +458,11 @@ func (d *KafkaDispatcher) CleanupChannel(name, namespace, hostname string) error
 
 // subscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
 // subscribe must be called under updateLock.
-func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.NamespacedName, sub Subscription) error {
+func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.NamespacedName, cluster *ClusterConfig, sub Subscription) error {
 	d.logger.Infow("Subscribing to Kafka Channel", zap.Any("channelRef", channelRef), zap.Any("subscription", sub.UID))
 
 	topicName := d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
-	groupID := fmt.Sprintf("kafka.%s.%s.%s", channelRef.Namespace, channelRef.Name, string(sub.UID))
+	groupID := utils.GroupID(channelRef.Namespace, channelRef.Name, sub.UID)
 
 	// Get or create the channel kafka subscription
 	kafkaSubscription, ok := d.channelSubscriptions[channelRef]
@@ -289,6 +471,20 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 		d.channelSubscriptions[channelRef] = kafkaSubscription
 	}
 
+	kafkaSubscription.cluster = cluster
+
+	// The consumer group also consumes the retry topics of the subscription, if enabled
+	topics := []string{topicName}
+	var retryDispatcher *retry.Dispatcher
+	if d.retryTopics {
+		retryDispatcher = d.newRetryDispatcher(channelRef, sub, topicName)
+		if err := d.createRetryTopics(cluster, topicName, retryDispatcher.Topics); err != nil {
+			d.logger.Infow("Could not create the retry topics", zap.Strings("topics", retryDispatcher.Topics), zap.Error(err))
+			return err
+		}
+		topics = append(topics, retryDispatcher.Topics...)
+	}
+
 	handler := &consumerMessageHandler{
 		d.logger,
 		sub,
@@ -297,10 +493,17 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 		groupID,
 		d.reporter,
 		channelRef.Namespace,
+		retryDispatcher,
 	}
 	d.logger.Debugw("Starting consumer group", zap.Any("channelRef", channelRef),
-		zap.Any("subscription", sub.UID), zap.String("topic", topicName), zap.String("consumer group", groupID))
-	consumerGroup, err := d.kafkaConsumerFactory.StartConsumerGroup(ctx, groupID, []string{topicName}, handler, channelRef)
+		zap.Any("subscription", sub.UID), zap.Strings("topics", topics), zap.String("consumer group", groupID))
+	var err error
+	if cluster != nil {
+		// The consumer group consumes from the Kafka cluster of the channel
+		err = d.consumerMgr.StartClusterConsumerGroup(ctx, cluster.Brokers, cluster.Config, groupID, topics, handler, channelRef, d.consumerOptions...)
+	} else {
+		err = d.consumerMgr.StartConsumerGroup(ctx, groupID, topics, handler, channelRef, d.consumerOptions...)
+	}
 
 	if err != nil {
 		// we can not create a consumer - logging that, with reason
@@ -308,10 +511,10 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 		return err
 	}
 
-	// sarama reports error in consumerGroup.Error() channel
-	// this goroutine logs errors incoming
+	// the manager reports the errors of the consumer group in a channel which remains
+	// open while the group is stopped and started, this goroutine logs errors incoming
 	go func() {
-		for err = range consumerGroup.Errors() {
+		for err := range d.consumerMgr.Errors(groupID) {
 			d.logger.Warnw("Error in consumer group", zap.Error(err))
 		}
 	}()
@@ -319,7 +522,6 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 	// Update the data structures that holds the reconciliation data
 	kafkaSubscription.subs.Insert(string(sub.UID))
 	d.subscriptions[sub.UID] = sub
-	d.subsConsumerGroups[sub.UID] = consumerGroup
 
 	return nil
 }
@@ -345,14 +547,82 @@ func (d *KafkaDispatcher) unsubscribe(channelRef types.NamespacedName, sub Subsc
 	}
 
 	// Delete the consumer group
-	if consumerGroup, ok := d.subsConsumerGroups[sub.UID]; ok {
-		delete(d.subsConsumerGroups, sub.UID)
-		d.logger.Debugw("Closing cached consumerGroup group", zap.Any("consumer group", consumerGroup))
-		return consumerGroup.Close()
+	groupID := utils.GroupID(channelRef.Namespace, channelRef.Name, sub.UID)
+	if d.consumerMgr.IsManaged(groupID) {
+		d.logger.Debugw("Closing managed consumer group", zap.String("consumer group", groupID))
+		return d.consumerMgr.CloseConsumerGroup(groupID)
 	}
 	return nil
 }
 
+// newRetryDispatcher returns the retry.Dispatcher of the subscription of the channel topic, producing its
+// retries to one retry topic per attempt with the producer of the Kafka cluster of the channel.
+func (d *KafkaDispatcher) newRetryDispatcher(channelRef types.NamespacedName, sub Subscription, topicName string) *retry.Dispatcher {
+	retryConfig := kncloudevents.NoRetries()
+	if sub.RetryConfig != nil {
+		retryConfig = *sub.RetryConfig
+	}
+	return &retry.Dispatcher{
+		MessageDispatcher: d.dispatcher,
+		Producer:          channelRetryProducer{dispatcher: d, channel: eventingchannels.ChannelReference{Namespace: channelRef.Namespace, Name: channelRef.Name}},
+		Topics:            retry.TopicNames(topicName, sub.UID, retryConfig.RetryMax),
+		Destination:       sub.Subscriber,
+		Reply:             sub.Reply,
+		DeadLetter:        sub.DeadLetter,
+		RetryConfig:       retryConfig,
+	}
+}
+
+// channelRetryProducer produces the retries of the subscriptions of a channel with the current producer
+// of its Kafka cluster
+type channelRetryProducer struct {
+	dispatcher *KafkaDispatcher
+	channel    eventingchannels.ChannelReference
+}
+
+func (p channelRetryProducer) SendMessage(ctx context.Context, message *sarama.ProducerMessage) (int32, int64, error) {
+	return p.dispatcher.sendChannelMessage(ctx, p.channel, message)
+}
+
+// clusterAdmin returns a cluster admin of the Kafka cluster, or of the one of config-kafka if nil.
+func (d *KafkaDispatcher) clusterAdmin(cluster *ClusterConfig) (sarama.ClusterAdmin, error) {
+	if cluster != nil {
+		return d.newClusterAdmin(cluster.Brokers, cluster.Config)
+	}
+	return d.newClusterAdmin(d.brokers, d.saramaConfig)
+}
+
+// createRetryTopics creates the retry topics of a subscription, unless they already exist.
+func (d *KafkaDispatcher) createRetryTopics(cluster *ClusterConfig, topicName string, retryTopics []string) error {
+	if len(retryTopics) == 0 {
+		return nil
+	}
+	admin, err := d.clusterAdmin(cluster)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+	return retry.CreateTopics(admin, topicName, retryTopics)
+}
+
+// deleteRetryTopics deletes the retry topics of a removed subscription. Failures are only logged, since
+// the retry topics don't affect the other subscriptions.
+func (d *KafkaDispatcher) deleteRetryTopics(channelRef types.NamespacedName, cluster *ClusterConfig, subUID types.UID) {
+	topicName := d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
+
+	admin, err := d.clusterAdmin(cluster)
+	if err != nil {
+		d.logger.Warnw("Could not delete the retry topics", zap.String("topic", topicName), zap.Any("subscription", subUID), zap.Error(err))
+		return
+	}
+	defer admin.Close()
+	if err := retry.DeleteTopics(admin, topicName, subUID); err != nil {
+		d.logger.Warnw("Could not delete the retry topics", zap.String("topic", topicName), zap.Any("subscription", subUID), zap.Error(err))
+		return
+	}
+	d.logger.Infow("Deleted the retry topics", zap.String("topic", topicName), zap.Any("subscription", subUID))
+}
+
 func (d *KafkaDispatcher) getChannelReferenceFromHost(host string) (eventingchannels.ChannelReference, error) {
 	cr, ok := d.hostToChannelMap.Load(host)
 	if !ok {
@@ -43,6 +43,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/common/config"
 	"knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
 )
 
 // This dispatcher tests the full integration of the dispatcher code with Kafka.
@@ -83,8 +84,14 @@ func TestDispatcher(t *testing.T) {
 		TopicFunc: utils.TopicName,
 	}
 
+	controlServer, err := controlprotocol.NewServerHandler(ctx, controlprotocol.ServerPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer controlServer.Shutdown(time.Second)
+
 	// Create the dispatcher. At this point, if Kafka is not up, this thing fails
-	dispatcher, err := NewDispatcher(context.Background(), &dispatcherArgs, func(ref types.NamespacedName) {})
+	dispatcher, err := NewDispatcher(context.Background(), &dispatcherArgs, controlServer, func(ref types.NamespacedName) {})
 	if err != nil {
 		t.Skipf("no dispatcher: %v", err)
 	}
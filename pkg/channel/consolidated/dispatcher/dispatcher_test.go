@@ -22,10 +22,12 @@ import (
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"knative.dev/eventing-kafka/pkg/common/config"
 
 	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
@@ -37,45 +39,100 @@ import (
 
 	eventingchannels "knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/kncloudevents"
 	klogtesting "knative.dev/pkg/logging/testing"
 	_ "knative.dev/pkg/system/testing"
 
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	controltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+	"knative.dev/eventing-kafka/pkg/common/kafka/producer"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
 )
 
 // ----- Mocks
 
-type mockKafkaConsumerFactory struct {
+type mockConsumerGroupManager struct {
 	// createErr will return an error when creating a consumer
 	createErr bool
+	lock      sync.Mutex
+	groups    sets.String
+	// starts counts the consumer groups started
+	starts int
+	// topics are the topics consumed by the consumer groups
+	topics map[string][]string
+	// brokers are the brokers of the consumer groups consuming from the Kafka cluster of their channel
+	brokers map[string][]string
 }
 
-func (c mockKafkaConsumerFactory) StartConsumerGroup(ctx context.Context, groupID string, topics []string, handler consumer.KafkaConsumerHandler, ref types.NamespacedName, options ...consumer.SaramaConsumerHandlerOption) (sarama.ConsumerGroup, error) {
-	if c.createErr {
-		return nil, errors.New("error creating consumer")
-	}
+func newMockConsumerGroupManager(createErr bool) *mockConsumerGroupManager {
+	return &mockConsumerGroupManager{createErr: createErr, groups: sets.NewString(), topics: make(map[string][]string)}
+}
 
-	return mockConsumerGroup{}, nil
+func (m *mockConsumerGroupManager) Reconfigure(_ []string, _ *sarama.Config) *consumer.ReconfigureError {
+	return nil
 }
 
-var _ consumer.KafkaConsumerGroupFactory = (*mockKafkaConsumerFactory)(nil)
+func (m *mockConsumerGroupManager) StartConsumerGroup(_ context.Context, groupId string, topics []string, _ consumer.KafkaConsumerHandler, _ types.NamespacedName, _ ...consumer.SaramaConsumerHandlerOption) error {
+	if m.createErr {
+		return errors.New("error creating consumer")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.groups.Insert(groupId)
+	m.topics[groupId] = topics
+	m.starts++
+	return nil
+}
 
-type mockConsumerGroup struct{}
+func (m *mockConsumerGroupManager) StartClusterConsumerGroup(ctx context.Context, brokers []string, _ *sarama.Config, groupId string, topics []string, handler consumer.KafkaConsumerHandler, ref types.NamespacedName, options ...consumer.SaramaConsumerHandlerOption) error {
+	if err := m.StartConsumerGroup(ctx, groupId, topics, handler, ref, options...); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.brokers == nil {
+		m.brokers = make(map[string][]string)
+	}
+	m.brokers[groupId] = brokers
+	return nil
+}
 
-func (m mockConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+func (m *mockConsumerGroupManager) CloseConsumerGroup(groupId string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.groups.Delete(groupId)
 	return nil
 }
 
-func (m mockConsumerGroup) Errors() <-chan error {
+func (m *mockConsumerGroupManager) Errors(_ string) <-chan error {
 	return nil
 }
 
-func (m mockConsumerGroup) Close() error {
+func (m *mockConsumerGroupManager) IsManaged(groupId string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.groups.Has(groupId)
+}
+
+func (m *mockConsumerGroupManager) IsStopped(_ string) bool {
+	return false
+}
+
+func (m *mockConsumerGroupManager) GetNotificationChannel() <-chan consumer.ManagerEvent {
 	return nil
 }
 
-var _ sarama.ConsumerGroup = (*mockConsumerGroup)(nil)
+func (m *mockConsumerGroupManager) ClearNotifications() {}
+
+func (m *mockConsumerGroupManager) managedGroups() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.groups.List()
+}
+
+var _ consumer.KafkaConsumerGroupManager = (*mockConsumerGroupManager)(nil)
 
 // ----- Tests
 
@@ -101,9 +158,8 @@ func TestKafkaDispatcher_RegisterChannelHost(t *testing.T) {
 	}
 
 	d := &KafkaDispatcher{
-		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		consumerMgr:          newMockConsumerGroupManager(false),
 		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
-		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
 		topicFunc:            utils.TopicName,
 		logger:               zaptest.NewLogger(t).Sugar(),
@@ -121,9 +177,8 @@ func TestKafkaDispatcher_RegisterSameChannelTwiceShouldNotFail(t *testing.T) {
 	}
 
 	d := &KafkaDispatcher{
-		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		consumerMgr:          newMockConsumerGroupManager(false),
 		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
-		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
 		topicFunc:            utils.TopicName,
 		logger:               zaptest.NewLogger(t).Sugar(),
@@ -260,9 +315,8 @@ func TestDispatcher_UpdateConsumers(t *testing.T) {
 			t.Parallel()
 			t.Logf("Running %s", t.Name())
 			d := &KafkaDispatcher{
-				kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+				consumerMgr:          newMockConsumerGroupManager(false),
 				channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
-				subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 				subscriptions:        make(map[types.UID]Subscription),
 				topicFunc:            utils.TopicName,
 				logger:               zaptest.NewLogger(t).Sugar(),
@@ -358,9 +412,8 @@ func TestDispatcher_MultipleChannelsInParallel(t *testing.T) {
 	}
 
 	d := &KafkaDispatcher{
-		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		consumerMgr:          newMockConsumerGroupManager(false),
 		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
-		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
 		topicFunc:            utils.TopicName,
 		logger:               zaptest.NewLogger(t).Sugar(),
@@ -408,16 +461,15 @@ func TestDispatcher_MultipleChannelsInParallel(t *testing.T) {
 	require.Empty(t, d.getHostToChannelMap())
 	require.Empty(t, d.subscriptions)
 	require.Empty(t, d.channelSubscriptions)
-	require.Empty(t, d.subsConsumerGroups)
+	require.Empty(t, d.consumerMgr.(*mockConsumerGroupManager).managedGroups())
 }
 
 func TestKafkaDispatcher_CleanupChannel(t *testing.T) {
 	subscriber, _ := url.Parse("http://test/subscriber")
 
 	d := &KafkaDispatcher{
-		kafkaConsumerFactory: &mockKafkaConsumerFactory{},
+		consumerMgr:          newMockConsumerGroupManager(false),
 		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
-		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
 		topicFunc:            utils.TopicName,
 		logger:               zaptest.NewLogger(t).Sugar(),
@@ -454,14 +506,252 @@ func TestKafkaDispatcher_CleanupChannel(t *testing.T) {
 		Namespace: "default",
 		Name:      "test-channel",
 	})
-	require.NotContains(t, d.subsConsumerGroups, "subscription-1")
-	require.NotContains(t, d.subsConsumerGroups, "subscription-2")
+	require.Empty(t, d.consumerMgr.(*mockConsumerGroupManager).managedGroups())
+}
+
+func TestKafkaDispatcher_ReconcileConsumersClusterChange(t *testing.T) {
+	d := &KafkaDispatcher{
+		consumerMgr:          newMockConsumerGroupManager(false),
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+	channelRef := types.NamespacedName{Namespace: "default", Name: "test-channel"}
+	channelConfig := &ChannelConfig{
+		Namespace:     channelRef.Namespace,
+		Name:          channelRef.Name,
+		HostName:      "a.b.c.d",
+		Subscriptions: []Subscription{{UID: "subscription-1"}, {UID: "subscription-2"}},
+	}
+	mgr := d.consumerMgr.(*mockConsumerGroupManager)
+
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, 2, mgr.starts)
+
+	// The same cluster keeps the consumer groups
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, 2, mgr.starts)
+
+	// A new cluster recreates them
+	channelConfig.Cluster = &ClusterConfig{Key: "cluster-1", Brokers: []string{"cluster:9092"}, Config: sarama.NewConfig()}
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, 4, mgr.starts)
+	require.Len(t, mgr.managedGroups(), 2)
+	require.Equal(t, "cluster-1", d.channelSubscriptions[channelRef].clusterKey())
+	require.Equal(t, []string{"cluster:9092"}, mgr.brokers[utils.GroupID("default", "test-channel", "subscription-1")])
+	require.Equal(t, sets.NewString("subscription-1", "subscription-2"), d.channelSubscriptions[channelRef].subs)
+}
+
+func TestKafkaDispatcher_ReconcileConsumersRetryTopics(t *testing.T) {
+	var adminBrokers, createdTopics, deletedTopics []string
+	d := &KafkaDispatcher{
+		consumerMgr:          newMockConsumerGroupManager(false),
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		retryTopics:          true,
+		brokers:              []string{"default:9092"},
+		newClusterAdmin: func(brokers []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+			adminBrokers = append(adminBrokers, brokers...)
+			return &commontesting.MockClusterAdmin{
+				MockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+					return []*sarama.TopicMetadata{{Name: topics[0], Partitions: []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{1}}}}}, nil
+				},
+				MockCreateTopicFunc: func(topic string, _ *sarama.TopicDetail, _ bool) error {
+					createdTopics = append(createdTopics, topic)
+					return nil
+				},
+				MockListTopicsFunc: func() (map[string]sarama.TopicDetail, error) {
+					topics := make(map[string]sarama.TopicDetail)
+					for _, topic := range createdTopics {
+						topics[topic] = sarama.TopicDetail{}
+					}
+					return topics, nil
+				},
+				MockDeleteTopicFunc: func(topic string) error {
+					deletedTopics = append(deletedTopics, topic)
+					return nil
+				},
+			}, nil
+		},
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	channelConfig := &ChannelConfig{
+		Namespace: "default",
+		Name:      "test-channel",
+		HostName:  "a.b.c.d",
+		Subscriptions: []Subscription{
+			{UID: "subscription-1", Subscription: fanout.Subscription{RetryConfig: &kncloudevents.RetryConfig{RetryMax: 2}}},
+			{UID: "subscription-2", Subscription: fanout.Subscription{RetryConfig: &kncloudevents.RetryConfig{RetryMax: 1}}},
+		},
+	}
+	topic := utils.TopicName(utils.KafkaChannelSeparator, "default", "test-channel")
+	mgr := d.consumerMgr.(*mockConsumerGroupManager)
+
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.ElementsMatch(t, []string{retry.TopicName(topic, "subscription-1", 1), retry.TopicName(topic, "subscription-1", 2), retry.TopicName(topic, "subscription-2", 1)}, createdTopics)
+	require.Equal(t, []string{topic, retry.TopicName(topic, "subscription-1", 1), retry.TopicName(topic, "subscription-1", 2)}, mgr.topics[utils.GroupID("default", "test-channel", "subscription-1")])
+	require.Equal(t, []string{"default:9092", "default:9092"}, adminBrokers)
+
+	// The retry topics of a removed subscription are deleted
+	channelConfig.Subscriptions = channelConfig.Subscriptions[:1]
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, []string{retry.TopicName(topic, "subscription-2", 1)}, deletedTopics)
+
+	// The retry topics are deleted from the previous Kafka cluster of the channel, and created in the new one
+	adminBrokers, createdTopics, deletedTopics = nil, []string{retry.TopicName(topic, "subscription-1", 1), retry.TopicName(topic, "subscription-1", 2)}, nil
+	channelConfig.Cluster = &ClusterConfig{Key: "cluster-1", Brokers: []string{"cluster:9092"}, Config: sarama.NewConfig()}
+	require.NoError(t, d.ReconcileConsumers(context.TODO(), channelConfig))
+	require.Equal(t, []string{"default:9092", "cluster:9092"}, adminBrokers)
+	require.ElementsMatch(t, []string{retry.TopicName(topic, "subscription-1", 1), retry.TopicName(topic, "subscription-1", 2)}, deletedTopics)
+}
+
+// channelProducer returns the producer with which the messages of the channel are sent
+func channelProducer(d *KafkaDispatcher, channel eventingchannels.ChannelReference) *producer.AsyncProducer {
+	d.clusterProducerLock.Lock()
+	defer d.clusterProducerLock.Unlock()
+	if key, ok := d.channelClusters[channel]; ok {
+		return d.clusterProducers[key].AsyncProducer
+	}
+	return d.kafkaProducer
+}
+
+func TestKafkaDispatcher_RegisterChannelCluster(t *testing.T) {
+	defaultProducer := producer.NewAsyncProducer(mocks.NewAsyncProducer(t, nil))
+	d := &KafkaDispatcher{
+		kafkaProducer:    defaultProducer,
+		clusterProducers: make(map[string]*clusterProducer),
+		channelClusters:  make(map[eventingchannels.ChannelReference]string),
+		newClusterProducer: func(cluster *ClusterConfig) (*producer.AsyncProducer, error) {
+			if cluster.Key == "invalid" {
+				return nil, errors.New("invalid cluster")
+			}
+			return producer.NewAsyncProducer(mocks.NewAsyncProducer(t, nil)), nil
+		},
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	channelA := eventingchannels.ChannelReference{Namespace: "default", Name: "a"}
+	channelB := eventingchannels.ChannelReference{Namespace: "default", Name: "b"}
+	cluster1 := &ClusterConfig{Key: "cluster-1"}
+
+	require.NoError(t, d.RegisterChannelCluster(&ChannelConfig{Namespace: "default", Name: "a"}))
+	require.Same(t, defaultProducer, channelProducer(d, channelA))
+
+	require.NoError(t, d.RegisterChannelCluster(&ChannelConfig{Namespace: "default", Name: "a", Cluster: cluster1}))
+	require.NoError(t, d.RegisterChannelCluster(&ChannelConfig{Namespace: "default", Name: "b", Cluster: cluster1}))
+	clusterProducer := channelProducer(d, channelA)
+	require.NotSame(t, defaultProducer, clusterProducer)
+	require.Same(t, clusterProducer, channelProducer(d, channelB))
+
+	// The producer of the cluster is kept while a channel uses it
+	require.NoError(t, d.CleanupChannel("a", "default", "a.svc"))
+	require.Same(t, defaultProducer, channelProducer(d, channelA))
+	require.Same(t, clusterProducer, d.clusterProducers["cluster-1"].AsyncProducer)
+
+	require.NoError(t, d.RegisterChannelCluster(&ChannelConfig{Namespace: "default", Name: "b", Cluster: &ClusterConfig{Key: "cluster-2"}}))
+	require.NotContains(t, d.clusterProducers, "cluster-1")
+	require.Contains(t, d.clusterProducers, "cluster-2")
+
+	require.Error(t, d.RegisterChannelCluster(&ChannelConfig{Namespace: "default", Name: "b", Cluster: &ClusterConfig{Key: "invalid"}}))
+	require.Contains(t, d.clusterProducers, "cluster-2")
+
+	require.NoError(t, d.RegisterChannelCluster(&ChannelConfig{Namespace: "default", Name: "b"}))
+	require.Same(t, defaultProducer, channelProducer(d, channelB))
+	require.Empty(t, d.clusterProducers)
+}
+
+// gatedProducer only acknowledges the messages of its input once its gate is opened
+type gatedProducer struct {
+	sarama.AsyncProducer
+	gate      chan struct{}
+	closed    chan struct{}
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func newGatedProducer() *gatedProducer {
+	p := &gatedProducer{
+		gate:      make(chan struct{}),
+		closed:    make(chan struct{}),
+		input:     make(chan *sarama.ProducerMessage),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+	go func() {
+		<-p.gate
+		for message := range p.input {
+			p.successes <- message
+		}
+		close(p.successes)
+		close(p.errors)
+	}()
+	return p
+}
+
+func (p *gatedProducer) Input() chan<- *sarama.ProducerMessage {
+	return p.input
+}
+
+func (p *gatedProducer) Successes() <-chan *sarama.ProducerMessage {
+	return p.successes
+}
+
+func (p *gatedProducer) Errors() <-chan *sarama.ProducerError {
+	return p.errors
+}
+
+func (p *gatedProducer) AsyncClose() {
+	close(p.input)
+	close(p.closed)
+}
+
+func TestKafkaDispatcher_SendChannelMessageDuringCleanup(t *testing.T) {
+	gated := newGatedProducer()
+	d := &KafkaDispatcher{
+		clusterProducers: make(map[string]*clusterProducer),
+		channelClusters:  make(map[eventingchannels.ChannelReference]string),
+		newClusterProducer: func(cluster *ClusterConfig) (*producer.AsyncProducer, error) {
+			return producer.NewAsyncProducer(gated), nil
+		},
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	channel := eventingchannels.ChannelReference{Namespace: "default", Name: "a"}
+	require.NoError(t, d.RegisterChannelCluster(&ChannelConfig{Namespace: "default", Name: "a", Cluster: &ClusterConfig{Key: "cluster-1"}}))
+
+	sent := make(chan error)
+	go func() {
+		_, _, err := d.sendChannelMessage(context.Background(), channel, &sarama.ProducerMessage{Topic: "test-topic"})
+		sent <- err
+	}()
+	require.Eventually(t, func() bool {
+		d.clusterProducerLock.Lock()
+		defer d.clusterProducerLock.Unlock()
+		return d.clusterProducers["cluster-1"].refs == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// The producer of the deleted channel is only closed once the message being sent is
+	require.NoError(t, d.CleanupChannel("a", "default", "a.svc"))
+	select {
+	case <-gated.closed:
+		t.Fatal("the producer was closed while sending a message")
+	default:
+	}
+
+	close(gated.gate)
+	require.NoError(t, <-sent)
+	select {
+	case <-gated.closed:
+	case <-time.After(time.Second):
+		t.Fatal("the unused producer was not closed")
+	}
+	require.Empty(t, d.clusterProducers)
 }
 
 func TestSubscribeError(t *testing.T) {
-	cf := &mockKafkaConsumerFactory{createErr: true}
 	d := &KafkaDispatcher{
-		kafkaConsumerFactory: cf,
+		consumerMgr:          newMockConsumerGroupManager(true),
 		logger:               zap.NewNop().Sugar(),
 		topicFunc:            utils.TopicName,
 		subscriptions:        map[types.UID]Subscription{},
@@ -479,17 +769,16 @@ func TestSubscribeError(t *testing.T) {
 		UID:          "test-sub",
 		Subscription: fanout.Subscription{},
 	}
-	err := d.subscribe(ctx, channelRef, subRef)
+	err := d.subscribe(ctx, channelRef, nil, subRef)
 	if err == nil {
 		t.Errorf("Expected error want %s, got %s", "error creating consumer", err)
 	}
 }
 
 func TestUnsubscribeUnknownSub(t *testing.T) {
-	cf := &mockKafkaConsumerFactory{createErr: true}
 	d := &KafkaDispatcher{
-		kafkaConsumerFactory: cf,
-		logger:               zap.NewNop().Sugar(),
+		consumerMgr: newMockConsumerGroupManager(true),
+		logger:      zap.NewNop().Sugar(),
 	}
 
 	channelRef := types.NamespacedName{
@@ -520,7 +809,7 @@ func TestNewDispatcher(t *testing.T) {
 		Brokers:   []string{"localhost:10000"},
 		TopicFunc: utils.TopicName,
 	}
-	_, err := NewDispatcher(context.TODO(), args, func(ref types.NamespacedName) {})
+	_, err := NewDispatcher(context.TODO(), args, controltesting.GetMockServerHandler(), func(ref types.NamespacedName) {})
 	if err == nil {
 		t.Errorf("Expected error want %s, got %s", "message receiver is not set", err)
 	}
@@ -28,11 +28,21 @@ import (
 type KafkaSubscription struct {
 	logger *zap.SugaredLogger
 	subs   sets.String
+	// cluster is the Kafka cluster the subscriptions consume from (nil for the one of config-kafka)
+	cluster *ClusterConfig
 	// readySubscriptionsLock must be used to synchronize access to channelReadySubscriptions
 	readySubscriptionsLock    sync.RWMutex
 	channelReadySubscriptions map[string]sets.Int32
 }
 
+// clusterKey returns the key of the Kafka cluster the subscriptions consume from ("" for the one of config-kafka)
+func (k *KafkaSubscription) clusterKey() string {
+	if k.cluster == nil {
+		return ""
+	}
+	return k.cluster.Key
+}
+
 func NewKafkaSubscription(logger *zap.SugaredLogger) *KafkaSubscription {
 	return &KafkaSubscription{
 		logger:                    logger,
@@ -26,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
@@ -44,7 +45,9 @@ import (
 	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/system"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	kafkaChannelClient "knative.dev/eventing-kafka/pkg/client/injection/client"
+	"knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
 	kafkaChannelReconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
@@ -74,6 +77,7 @@ func NewController(
 	roleBindingInformer := rolebinding.Get(ctx)
 	serviceInformer := service.Get(ctx)
 	podInformer := podinformer.Get(ctx)
+	kafkaClusterInformer := kafkacluster.Get(ctx)
 
 	r := &Reconciler{
 		systemNamespace:      system.Namespace(),
@@ -82,6 +86,7 @@ func NewController(
 		EventingClientSet:    eventingClient.Get(ctx),
 		kafkachannelLister:   kafkaChannelInformer.Lister(),
 		kafkachannelInformer: kafkaChannelInformer.Informer(),
+		kafkaClusterLister:   kafkaClusterInformer.Lister(),
 		deploymentLister:     deploymentInformer.Lister(),
 		serviceLister:        serviceInformer.Lister(),
 		endpointsLister:      endpointsInformer.Lister(),
@@ -106,6 +111,10 @@ func NewController(
 
 	impl := kafkaChannelReconciler.NewImpl(ctx, r)
 	r.resolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+	r.isLeaderFor = impl.Reconciler.(interface {
+		IsLeaderFor(types.NamespacedName) bool
+	}).IsLeaderFor
+	r.clusterTracker = impl.Tracker
 
 	// Call GlobalResync on kafkachannels.
 	grCh := func(interface{}) {
@@ -126,7 +135,19 @@ func NewController(
 	}
 
 	logger.Info("Setting up event handlers")
-	kafkaChannelInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+	kafkaChannelInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: impl.Enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// The lag of the subscribers is refreshed without reconciling the channel
+			if !subscribersLagUpdate(oldObj, newObj) {
+				impl.Enqueue(newObj)
+			}
+		},
+		DeleteFunc: impl.Enqueue,
+	})
+	kafkaClusterInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster")),
+	))
 
 	// Set up watches for dispatcher resources we care about, since any changes to these
 	// resources will affect our Channels. So, set up a watch here, that will cause
@@ -161,6 +182,8 @@ func NewController(
 		Handler: controller.HandleAll(grCh),
 	})
 
+	go r.refreshSubscribersLag(ctx)
+
 	return impl
 }
 
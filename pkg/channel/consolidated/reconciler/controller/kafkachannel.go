@@ -19,7 +19,10 @@ package controller
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
@@ -46,9 +49,12 @@ import (
 	"knative.dev/pkg/network"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
 
 	consolidatedmessaging "knative.dev/eventing-kafka/pkg/channel/consolidated/apis/messaging"
 	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
+	"knative.dev/eventing-kafka/pkg/common/kafka/topic"
 
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/controller/resources"
@@ -56,11 +62,13 @@ import (
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	kafkaScheme "knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
 	kafkaChannelReconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/client"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	"knative.dev/eventing-kafka/pkg/common/constants"
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 )
 
 const (
@@ -137,6 +145,7 @@ type Reconciler struct {
 	kafkaClusterAdmin    sarama.ClusterAdmin
 	kafkachannelLister   listers.KafkaChannelLister
 	kafkachannelInformer cache.SharedIndexInformer
+	kafkaClusterLister   kafkalisters.KafkaClusterLister
 	deploymentLister     appsv1listers.DeploymentLister
 	serviceLister        corev1listers.ServiceLister
 	endpointsLister      corev1listers.EndpointsLister
@@ -144,6 +153,24 @@ type Reconciler struct {
 	roleBindingLister    rbacv1listers.RoleBindingLister
 	controllerRef        metav1.OwnerReference
 	resolver             *resolver.URIResolver
+
+	// clusterTracker reconciles the channels again when the KafkaCluster they reference changes
+	clusterTracker tracker.Interface
+
+	// isLeaderFor returns whether the channel is reconciled by this controller, so that the lag of its
+	// subscribers is only refreshed by one controller
+	isLeaderFor func(key types.NamespacedName) bool
+
+	// appliedTopics are the last topic specs applied to the existing topics of the channels, by topic
+	// name, so that the topics are only described when the spec of their channel changes
+	appliedTopics sync.Map
+}
+
+// appliedTopic is the spec of a topic applied on a Kafka cluster
+type appliedTopic struct {
+	brokers       string
+	partitions    int32
+	configEntries map[string]string
 }
 
 type envConfig struct {
@@ -172,7 +199,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 		return r.kafkaConfigError
 	}
 
-	kafkaClient, err := r.createKafkaClient()
+	brokers, saramaConfig, err := r.clusterConfig(ctx, kc)
+	if err != nil {
+		logger.Errorw("Can't obtain the configuration of the KafkaCluster", zap.Any("channel", kc), zap.Error(err))
+		kc.Status.MarkConfigFailed("InvalidKafkaCluster", "Unable to configure the KafkaCluster of channel %s: %v", kc.Name, err)
+		return err
+	}
+
+	kafkaClient, err := r.createKafkaClient(brokers, saramaConfig)
 	if err != nil {
 		logger.Errorw("Can't obtain Kafka Client", zap.Any("channel", kc), zap.Error(err))
 		kc.Status.MarkConfigFailed("InvalidConfiguration", "Unable to build Kafka client for channel %s: %v", kc.Name, err)
@@ -180,7 +214,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 	}
 	defer kafkaClient.Close()
 
-	kafkaClusterAdmin, err := r.createClusterAdmin()
+	kafkaClusterAdmin, err := r.createClusterAdmin(brokers, saramaConfig)
 	if err != nil {
 		logger.Errorw("Can't obtain Kafka cluster admin", zap.Any("channel", kc), zap.Error(err))
 		kc.Status.MarkConfigFailed("InvalidConfiguration", "Unable to build Kafka admin client for channel %s: %v", kc.Name, err)
@@ -197,21 +231,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, kc *v1beta1.KafkaChannel
 	// 4. Dispatcher endpoints to ensure that there's something backing the Service.
 	// 5. K8s service representing the channel that will use ExternalName to point to the Dispatcher k8s service.
 
-	if err := r.reconcileTopic(ctx, kc, kafkaClusterAdmin); err != nil {
+	if err := r.reconcileTopic(ctx, kc, brokers, kafkaClusterAdmin); err != nil {
 		kc.Status.MarkTopicFailed("TopicCreateFailed", "error while creating topic: %s", err)
 		return err
 	}
 	kc.Status.MarkTopicTrue()
 
-	scope, ok := kc.Annotations[eventing.ScopeAnnotationKey]
-	if !ok {
-		scope = scopeCluster
-	}
-
-	dispatcherNamespace := r.systemNamespace
-	if scope == scopeNamespace {
-		dispatcherNamespace = kc.Namespace
-	}
+	scope := dispatcherScope(kc)
+	dispatcherNamespace := channelDispatcherNamespace(r.systemNamespace, kc)
 
 	// Make sure the dispatcher deployment exists and propagate the status to the Channel
 	err = r.reconcileDispatcher(ctx, scope, dispatcherNamespace, kc)
@@ -537,15 +564,31 @@ func (r *Reconciler) reconcileChannelService(ctx context.Context, dispatcherName
 	return svc, nil
 }
 
-func (r *Reconciler) createKafkaClient() (sarama.Client, error) {
+// clusterConfig returns the brokers and the Sarama config of the Kafka cluster of the channel, which is
+// either the KafkaCluster it references or the cluster of the config-kafka ConfigMap.
+func (r *Reconciler) clusterConfig(ctx context.Context, kc *v1beta1.KafkaChannel) ([]string, *sarama.Config, error) {
+	if kc.Spec.ClusterRef == nil {
+		return r.kafkaConfig.Brokers, r.kafkaConfig.EventingKafka.Sarama.Config, nil
+	}
+	if err := r.clusterTracker.TrackReference(kafkacluster.TrackerReference(kc.Spec.ClusterRef, kc.Namespace), kc); err != nil {
+		return nil, nil, fmt.Errorf("failed to track the KafkaCluster: %w", err)
+	}
+	cluster, err := kafkacluster.GetForChannel(r.kafkaClusterLister, kc, r.systemNamespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kafkacluster.NewConfig(ctx, r.KubeClientSet, cluster, r.kafkaConfig.EventingKafka.Sarama.Config)
+}
+
+func (r *Reconciler) createKafkaClient(brokers []string, saramaConfig *sarama.Config) (sarama.Client, error) {
 	kafkaClient := r.kafkaClient
 	if kafkaClient == nil {
 		var err error
 
-		if r.kafkaConfig.EventingKafka.Sarama.Config == nil {
+		if saramaConfig == nil {
 			return nil, fmt.Errorf("error creating Kafka client: Sarama config is nil")
 		}
-		kafkaClient, err = sarama.NewClient(r.kafkaConfig.Brokers, r.kafkaConfig.EventingKafka.Sarama.Config)
+		kafkaClient, err = sarama.NewClient(brokers, saramaConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -554,7 +597,7 @@ func (r *Reconciler) createKafkaClient() (sarama.Client, error) {
 	return kafkaClient, nil
 }
 
-func (r *Reconciler) createClusterAdmin() (sarama.ClusterAdmin, error) {
+func (r *Reconciler) createClusterAdmin(brokers []string, saramaConfig *sarama.Config) (sarama.ClusterAdmin, error) {
 	// We don't currently initialize r.kafkaClusterAdmin, hence we end up creating the cluster admin client every time.
 	// This is because of an issue with Shopify/sarama. See https://github.com/Shopify/sarama/issues/1162.
 	// Once the issue is fixed we should use a shared cluster admin client. Also, r.kafkaClusterAdmin is currently
@@ -563,10 +606,10 @@ func (r *Reconciler) createClusterAdmin() (sarama.ClusterAdmin, error) {
 	if kafkaClusterAdmin == nil {
 		var err error
 
-		if r.kafkaConfig.EventingKafka.Sarama.Config == nil {
+		if saramaConfig == nil {
 			return nil, fmt.Errorf("error creating admin client: Sarama config is nil")
 		}
-		kafkaClusterAdmin, err = sarama.NewClusterAdmin(r.kafkaConfig.Brokers, r.kafkaConfig.EventingKafka.Sarama.Config)
+		kafkaClusterAdmin, err = sarama.NewClusterAdmin(brokers, saramaConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -574,7 +617,7 @@ func (r *Reconciler) createClusterAdmin() (sarama.ClusterAdmin, error) {
 	return kafkaClusterAdmin, nil
 }
 
-func (r *Reconciler) reconcileTopic(ctx context.Context, channel *v1beta1.KafkaChannel, kafkaClusterAdmin sarama.ClusterAdmin) error {
+func (r *Reconciler) reconcileTopic(ctx context.Context, channel *v1beta1.KafkaChannel, brokers []string, kafkaClusterAdmin sarama.ClusterAdmin) error {
 	logger := logging.FromContext(ctx)
 
 	topicName := utils.TopicName(utils.KafkaChannelSeparator, channel.Namespace, channel.Name)
@@ -590,21 +633,86 @@ func (r *Reconciler) reconcileTopic(ctx context.Context, channel *v1beta1.KafkaC
 	}
 	retentionMillisString := strconv.FormatInt(retentionDuration.Milliseconds(), 10)
 
+	// The topic config overrides of the channel are validated by the webhook
+	configEntries := make(map[string]*string, len(channel.Spec.TopicConfig)+1)
+	spec := appliedTopic{
+		brokers:       strings.Join(brokers, ","),
+		partitions:    channel.Spec.NumPartitions,
+		configEntries: make(map[string]string, len(channel.Spec.TopicConfig)+1),
+	}
+	for name, value := range channel.Spec.TopicConfig {
+		value := value
+		configEntries[name] = &value
+		spec.configEntries[name] = value
+	}
+	configEntries[constants.KafkaTopicConfigRetentionMs] = &retentionMillisString
+	spec.configEntries[constants.KafkaTopicConfigRetentionMs] = retentionMillisString
+
 	err = kafkaClusterAdmin.CreateTopic(topicName, &sarama.TopicDetail{
 		NumPartitions:     channel.Spec.NumPartitions,
 		ReplicationFactor: channel.Spec.ReplicationFactor,
-		ConfigEntries: map[string]*string{
-			constants.KafkaTopicConfigRetentionMs: &retentionMillisString,
-		},
+		ConfigEntries:     configEntries,
 	}, false)
 	if e, ok := err.(*sarama.TopicError); ok && e.Err == sarama.ErrTopicAlreadyExists {
-		return nil
+		// The existing topic is only described and updated when the spec changed since it was last applied
+		if applied, ok := r.appliedTopics.Load(topicName); ok && reflect.DeepEqual(applied, spec) {
+			return nil
+		}
+		if err := r.updateTopic(ctx, channel, topicName, configEntries, kafkaClusterAdmin); err != nil {
+			return err
+		}
 	} else if err != nil {
 		logger.Errorw("Error creating topic", zap.String("topic", topicName), zap.Error(err))
+		return err
 	} else {
 		logger.Infow("Successfully created topic", zap.String("topic", topicName))
 	}
-	return err
+	r.appliedTopics.Store(topicName, spec)
+	return nil
+}
+
+// updateTopic increases the number of partitions and updates the config entries (retention and
+// overrides) of an existing topic to match the channel spec, when they changed or drifted.
+// Decreasing the number of partitions is rejected by the webhook.
+func (r *Reconciler) updateTopic(ctx context.Context, channel *v1beta1.KafkaChannel, topicName string, configEntries map[string]*string, kafkaClusterAdmin sarama.ClusterAdmin) error {
+	logger := logging.FromContext(ctx)
+
+	topicsMetadata, err := kafkaClusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		logger.Errorw("Error describing topic", zap.String("topic", topicName), zap.Error(err))
+		return err
+	}
+	for _, topicMetadata := range topicsMetadata {
+		if topicMetadata.Name != topicName || int32(len(topicMetadata.Partitions)) >= channel.Spec.NumPartitions {
+			continue
+		}
+		logger.Infow("Increasing topic partitions", zap.String("topic", topicName),
+			zap.Int("current", len(topicMetadata.Partitions)), zap.Int32("partitions", channel.Spec.NumPartitions))
+		if err := kafkaClusterAdmin.CreatePartitions(topicName, channel.Spec.NumPartitions, nil, false); err != nil {
+			logger.Errorw("Error creating topic partitions", zap.String("topic", topicName), zap.Error(err))
+			return err
+		}
+	}
+
+	currentEntries, err := kafkaClusterAdmin.DescribeConfig(sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName})
+	if err != nil {
+		logger.Errorw("Error describing topic config", zap.String("topic", topicName), zap.Error(err))
+		return err
+	}
+
+	// AlterConfig resets the topic config entries which are not specified, so the existing topic
+	// level overrides are preserved.
+	topicConfig, changed := topic.MergeConfigEntries(currentEntries, configEntries)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	logger.Infow("Updating topic config", zap.String("topic", topicName), zap.Strings("changed", changed))
+	if err := kafkaClusterAdmin.AlterConfig(sarama.TopicResource, topicName, topicConfig, false); err != nil {
+		logger.Errorw("Error updating topic config", zap.String("topic", topicName), zap.Error(err))
+		return err
+	}
+	return nil
 }
 
 func (r *Reconciler) reconcileInitialOffset(ctx context.Context, channel *v1beta1.KafkaChannel, sub v1.SubscriberSpec, kafkaClient sarama.Client, kafkaClusterAdmin sarama.ClusterAdmin) error {
@@ -615,8 +723,10 @@ func (r *Reconciler) reconcileInitialOffset(ctx context.Context, channel *v1beta
 	}
 
 	topicName := utils.TopicName(utils.KafkaChannelSeparator, channel.Namespace, channel.Name)
-	groupID := fmt.Sprintf("kafka.%s.%s.%s", channel.Namespace, channel.Name, string(sub.UID))
-	_, err := offset.InitOffsets(ctx, kafkaClient, kafkaClusterAdmin, []string{topicName}, groupID)
+	groupID := utils.GroupID(channel.Namespace, channel.Name, sub.UID)
+	// The offsets are initialized at the position the dispatcher consumers would start from
+	initialOffset := kafkaClient.Config().Consumer.Offsets.Initial
+	_, err := offset.InitOffsets(ctx, kafkaClient, kafkaClusterAdmin, []string{topicName}, groupID, initialOffset)
 	if err != nil {
 		logger := logging.FromContext(ctx)
 		logger.Errorw("error reconciling initial offset", zap.String("channel", fmt.Sprintf("%s.%s", channel.Namespace, channel.Name)), zap.Any("subscription", sub), zap.Error(err))
@@ -624,11 +734,41 @@ func (r *Reconciler) reconcileInitialOffset(ctx context.Context, channel *v1beta
 	return err
 }
 
+// dispatcherScope returns the scope of the dispatcher of the channel, which is either the
+// cluster (the default) or the namespace of the channel.
+func dispatcherScope(kc *v1beta1.KafkaChannel) string {
+	if scope, ok := kc.Annotations[eventing.ScopeAnnotationKey]; ok {
+		return scope
+	}
+	return scopeCluster
+}
+
+// channelDispatcherNamespace returns the namespace of the dispatcher of the channel.
+func channelDispatcherNamespace(systemNamespace string, kc *v1beta1.KafkaChannel) string {
+	if dispatcherScope(kc) == scopeNamespace {
+		return kc.Namespace
+	}
+	return systemNamespace
+}
+
 func (r *Reconciler) deleteTopic(ctx context.Context, channel *v1beta1.KafkaChannel, kafkaClusterAdmin sarama.ClusterAdmin) error {
 	logger := logging.FromContext(ctx)
 
 	topicName := utils.TopicName(utils.KafkaChannelSeparator, channel.Namespace, channel.Name)
+
+	// The retry topics of the subscribers are deleted along with the topic of the channel
+	if r.kafkaConfig.EventingKafka != nil && r.kafkaConfig.EventingKafka.Channel.Dispatcher.RetryTopics {
+		for _, sub := range channel.Spec.Subscribers {
+			logger.Infow("Deleting retry topics on Kafka Cluster", zap.String("topic", topicName), zap.Any("subscription", sub.UID))
+			if err := retry.DeleteTopics(kafkaClusterAdmin, topicName, sub.UID); err != nil {
+				logger.Errorw("Error deleting retry topics", zap.String("topic", topicName), zap.Any("subscription", sub.UID), zap.Error(err))
+				return err
+			}
+		}
+	}
+
 	logger.Infow("Deleting topic on Kafka Cluster", zap.String("topic", topicName))
+	r.appliedTopics.Delete(topicName)
 	err := kafkaClusterAdmin.DeleteTopic(topicName)
 	if err == sarama.ErrUnknownTopicOrPartition {
 		logger.Debugw("Received an unknown topic or partition response. Ignoring")
@@ -681,8 +821,17 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, kc *v1beta1.KafkaChannel)
 	channel := fmt.Sprintf("%s/%s", kc.GetNamespace(), kc.GetName())
 	logger.Debugw("FinalizeKind", zap.String("channel", channel))
 
-	kafkaClusterAdmin, err := r.createClusterAdmin()
-	if err != nil || r.kafkaConfig == nil {
+	if r.kafkaConfig == nil {
+		logger.Errorw("cannot obtain Kafka cluster admin without configuration", zap.String("channel", channel))
+		return newReconciledNormal(kc.Namespace, kc.Name)
+	}
+	brokers, saramaConfig, err := r.clusterConfig(ctx, kc)
+	if err != nil {
+		logger.Errorw("cannot obtain the configuration of the KafkaCluster", zap.String("channel", channel), zap.Error(err))
+		return newReconciledNormal(kc.Namespace, kc.Name)
+	}
+	kafkaClusterAdmin, err := r.createClusterAdmin(brokers, saramaConfig)
+	if err != nil {
 		logger.Errorw("cannot obtain Kafka cluster admin", zap.String("channel", channel), zap.Error(err))
 		// even in error case, we return `normal`, since we are fine with leaving the
 		// topic undeleted e.g. when we lose connection
@@ -22,13 +22,17 @@ import (
 	"testing"
 
 	"github.com/Shopify/sarama"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingClient "knative.dev/eventing/pkg/client/injection/client"
@@ -42,13 +46,17 @@ import (
 	"knative.dev/pkg/network"
 	. "knative.dev/pkg/reconciler/testing"
 
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/controller/resources"
 	reconcilertesting "knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/testing"
 	. "knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	fakekafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
 	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
 )
 
 const (
@@ -539,6 +547,255 @@ func TestTopicExists(t *testing.T) {
 	}, zap.L()))
 }
 
+func TestUpdateTopic(t *testing.T) {
+	topicName := TopicName(KafkaChannelSeparator, testNS, kcName)
+	currentRetention := "604800000"
+	newRetention := "86400000"
+	cleanupPolicy := "compact"
+	newCleanupPolicy := "compact,delete"
+	compressionType := "zstd"
+
+	testCases := map[string]struct {
+		numPartitions        int32
+		configEntries        map[string]*string
+		wantCreatePartitions bool
+		wantConfig           map[string]*string
+	}{
+		"unchanged topic": {
+			numPartitions: 2,
+			configEntries: map[string]*string{"retention.ms": &currentRetention, "cleanup.policy": &cleanupPolicy},
+		},
+		"increased partitions": {
+			numPartitions:        4,
+			configEntries:        map[string]*string{"retention.ms": &currentRetention},
+			wantCreatePartitions: true,
+		},
+		"changed retention": {
+			numPartitions: 2,
+			configEntries: map[string]*string{"retention.ms": &newRetention},
+			wantConfig: map[string]*string{
+				"retention.ms":   &newRetention,
+				"cleanup.policy": &cleanupPolicy,
+			},
+		},
+		"drifted topic config": {
+			numPartitions: 2,
+			configEntries: map[string]*string{"retention.ms": &currentRetention, "cleanup.policy": &newCleanupPolicy},
+			wantConfig: map[string]*string{
+				"retention.ms":   &currentRetention,
+				"cleanup.policy": &newCleanupPolicy,
+			},
+		},
+		"added topic config": {
+			numPartitions: 2,
+			configEntries: map[string]*string{"retention.ms": &currentRetention, "compression.type": &compressionType},
+			wantConfig: map[string]*string{
+				"retention.ms":     &currentRetention,
+				"cleanup.policy":   &cleanupPolicy,
+				"compression.type": &compressionType,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			createPartitionsCalled := false
+			var alteredConfig map[string]*string
+			clusterAdmin := &commontesting.MockClusterAdmin{
+				MockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+					return []*sarama.TopicMetadata{{
+						Name:       topicName,
+						Partitions: []*sarama.PartitionMetadata{{ID: 0}, {ID: 1}},
+					}}, nil
+				},
+				MockCreatePartitionsFunc: func(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+					createPartitionsCalled = true
+					if count != tc.numPartitions {
+						t.Errorf("unexpected partition count %d, want %d", count, tc.numPartitions)
+					}
+					return nil
+				},
+				MockDescribeConfigFunc: func(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+					return []sarama.ConfigEntry{
+						{Name: "retention.ms", Value: currentRetention, Source: sarama.SourceTopic},
+						{Name: "cleanup.policy", Value: cleanupPolicy, Source: sarama.SourceTopic},
+						{Name: "segment.bytes", Value: "1073741824", Source: sarama.SourceDefault, Default: true},
+					}, nil
+				},
+				MockAlterConfigFunc: func(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
+					alteredConfig = entries
+					return nil
+				},
+			}
+
+			channel := reconcilertesting.NewKafkaChannel(kcName, testNS)
+			channel.Spec.NumPartitions = tc.numPartitions
+
+			r := &Reconciler{}
+			if err := r.updateTopic(context.Background(), channel, topicName, tc.configEntries, clusterAdmin); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if createPartitionsCalled != tc.wantCreatePartitions {
+				t.Errorf("CreatePartitions called = %t, want %t", createPartitionsCalled, tc.wantCreatePartitions)
+			}
+			if diff := cmp.Diff(tc.wantConfig, alteredConfig); diff != "" {
+				t.Errorf("unexpected altered config (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileTopicAppliedSpec(t *testing.T) {
+	describeCalls := 0
+	clusterAdmin := &commontesting.MockClusterAdmin{
+		MockCreateTopicFunc: func(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+			return &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+		},
+		MockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+			describeCalls++
+			return []*sarama.TopicMetadata{{Name: topics[0], Partitions: []*sarama.PartitionMetadata{{ID: 0}}}}, nil
+		},
+		MockDescribeConfigFunc: func(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+			return nil, nil
+		},
+	}
+	channel := reconcilertesting.NewKafkaChannel(kcName, testNS)
+	channel.Spec.NumPartitions = 1
+	r := &Reconciler{kafkaConfig: &KafkaConfig{Brokers: []string{brokerName}}}
+
+	reconcileTopic := func(brokers []string, wantDescribeCalls int) {
+		t.Helper()
+		if err := r.reconcileTopic(context.Background(), channel, brokers, clusterAdmin); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if describeCalls != wantDescribeCalls {
+			t.Fatalf("topic described %d times, want %d", describeCalls, wantDescribeCalls)
+		}
+	}
+
+	reconcileTopic([]string{brokerName}, 1)
+	// The unchanged topic is not described again
+	reconcileTopic([]string{brokerName}, 1)
+	// A changed spec or cluster is applied
+	channel.Spec.TopicConfig = map[string]string{"cleanup.policy": "compact"}
+	reconcileTopic([]string{brokerName}, 2)
+	reconcileTopic([]string{"other:9092"}, 3)
+	// The deleted topic is described again once recreated
+	if err := r.deleteTopic(context.Background(), channel, clusterAdmin); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	reconcileTopic([]string{"other:9092"}, 4)
+}
+
+func TestClusterConfig(t *testing.T) {
+	baseConfig := sarama.NewConfig()
+	baseConfig.ClientID = "base-client"
+	baseConfig.Net.SASL.Enable = true
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, namespace := range []string{testNS, "other-namespace"} {
+		if err := indexer.Add(&kafkav1alpha1.KafkaCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "test-cluster"},
+			Spec:       kafkav1alpha1.KafkaClusterSpec{BootstrapServers: []string{"cluster-broker:9092"}},
+		}); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	r := &Reconciler{
+		systemNamespace:    "knative-testing",
+		KubeClientSet:      fakekubeclientset.NewSimpleClientset(),
+		kafkaConfig:        &KafkaConfig{Brokers: []string{brokerName}, EventingKafka: &config.EventingKafkaConfig{Sarama: config.EKSaramaConfig{Config: baseConfig}}},
+		kafkaClusterLister: kafkalisters.NewKafkaClusterLister(indexer),
+		clusterTracker:     &FakeTracker{},
+	}
+
+	tests := map[string]struct {
+		ref         *bindingsv1beta1.KafkaClusterReference
+		wantBrokers []string
+		wantErr     bool
+	}{
+		"config-kafka cluster": {
+			wantBrokers: []string{brokerName},
+		},
+		"cluster of the channel namespace": {
+			ref:         &bindingsv1beta1.KafkaClusterReference{Name: "test-cluster"},
+			wantBrokers: []string{"cluster-broker:9092"},
+		},
+		"cluster of another namespace": {
+			ref:     &bindingsv1beta1.KafkaClusterReference{Name: "test-cluster", Namespace: "other-namespace"},
+			wantErr: true,
+		},
+		"missing cluster": {
+			ref:     &bindingsv1beta1.KafkaClusterReference{Name: "missing"},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			channel := reconcilertesting.NewKafkaChannel(kcName, testNS, func(kc *v1beta1.KafkaChannel) {
+				kc.Spec.ClusterRef = tc.ref
+			})
+			brokers, saramaConfig, err := r.clusterConfig(context.Background(), channel)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.wantBrokers, brokers); diff != "" {
+				t.Error("unexpected brokers (-want, +got) =", diff)
+			}
+			if saramaConfig.ClientID != "base-client" {
+				t.Errorf("unexpected ClientID %q, want base-client", saramaConfig.ClientID)
+			}
+			// The authentication of the config-kafka cluster is not used for the other clusters
+			if tc.ref != nil && saramaConfig.Net.SASL.Enable {
+				t.Error("unexpected SASL enabled for the KafkaCluster")
+			}
+		})
+	}
+}
+
+func TestDeleteTopicWithRetryTopics(t *testing.T) {
+	eventingKafkaConfig := &config.EventingKafkaConfig{}
+	eventingKafkaConfig.Channel.Dispatcher.RetryTopics = true
+	r := &Reconciler{
+		kafkaConfig: &KafkaConfig{Brokers: []string{brokerName}, EventingKafka: eventingKafkaConfig},
+	}
+
+	topicName := TopicName(KafkaChannelSeparator, testNS, kcName)
+	var deletedTopics []string
+	clusterAdmin := &commontesting.MockClusterAdmin{
+		MockListTopicsFunc: func() (map[string]sarama.TopicDetail, error) {
+			return map[string]sarama.TopicDetail{
+				topicName:                                  {},
+				retry.TopicName(topicName, sub1UID, 1):     {},
+				retry.TopicName(topicName, sub1UID, 2):     {},
+				retry.TopicName(topicName, sub2UID, 1):     {},
+				retry.TopicName(topicName, "other-uid", 1): {},
+			}, nil
+		},
+		MockDeleteTopicFunc: func(topic string) error {
+			deletedTopics = append(deletedTopics, topic)
+			if topic == retry.TopicName(topicName, sub2UID, 1) {
+				return sarama.ErrUnknownTopicOrPartition
+			}
+			return nil
+		},
+	}
+
+	channel := reconcilertesting.NewKafkaChannel(kcName, testNS, reconcilertesting.WithKafkaChannelSubscribers(subscribers()))
+	if err := r.deleteTopic(context.Background(), channel, clusterAdmin); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	want := []string{retry.TopicName(topicName, sub1UID, 1), retry.TopicName(topicName, sub1UID, 2), retry.TopicName(topicName, sub2UID, 1), topicName}
+	if diff := cmp.Diff(want, deletedTopics, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Error("unexpected deleted topics (-want, +got) =", diff)
+	}
+}
+
 func TestDeploymentUpdatedOnImageChange(t *testing.T) {
 	kcKey := testNS + "/" + kcName
 	row := TableRow{
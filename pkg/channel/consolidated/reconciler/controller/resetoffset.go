@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
+	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+)
+
+// NewResetOffsetRefMapperFactory returns the RefMapper factory of the ResetOffsets referencing
+// the Subscriptions of consolidated KafkaChannels.
+func NewResetOffsetRefMapperFactory() refmappers.ResetOffsetRefMapperFactory {
+	return &resetOffsetRefMapperFactory{}
+}
+
+// resetOffsetRefMapperFactory defers the creation of the Subscription RefMapper until the KafkaChannel
+// Lister, needed to locate the dispatcher of a channel, is available from the injected Context.
+type resetOffsetRefMapperFactory struct{}
+
+// Create implements the ResetOffsetRefMapperFactory interface.
+func (f *resetOffsetRefMapperFactory) Create(ctx context.Context) refmappers.ResetOffsetRefMapper {
+	mapper := &dispatcherMapper{
+		systemNamespace:    system.Namespace(),
+		kafkachannelLister: kafkachannel.Get(ctx).Lister(),
+	}
+	return refmappers.NewSubscriptionRefMapper(ctx,
+		TopicNameMapper,
+		GroupIdMapper,
+		mapper.ConnectionPoolKeyMapper,
+		mapper.DataPlaneNamespaceMapper,
+		DataPlaneLabelsMapper)
+}
+
+// TopicNameMapper returns the Kafka Topic of the consolidated KafkaChannel of the Subscription.
+func TopicNameMapper(subscription *messagingv1.Subscription) (string, error) {
+	if subscription == nil {
+		return "", fmt.Errorf("unable to format topic name for nil Subscription")
+	}
+	channel := subscriptionChannel(subscription)
+	return utils.TopicName(utils.KafkaChannelSeparator, channel.Namespace, channel.Name), nil
+}
+
+// GroupIdMapper returns the Kafka ConsumerGroup ID of the Subscription used by the consolidated dispatcher.
+func GroupIdMapper(subscription *messagingv1.Subscription) (string, error) {
+	if subscription == nil {
+		return "", fmt.Errorf("unable to format group id for nil Subscription")
+	}
+	channel := subscriptionChannel(subscription)
+	return utils.GroupID(channel.Namespace, channel.Name, subscription.UID), nil
+}
+
+// DataPlaneLabelsMapper returns the labels of the consolidated dispatcher pods.
+func DataPlaneLabelsMapper(_ *messagingv1.Subscription) (map[string]string, error) {
+	return map[string]string{
+		channelLabelKey: channelLabelValue,
+		roleLabelKey:    dispatcherRoleLabelValue,
+	}, nil
+}
+
+// dispatcherMapper maps a Subscription to the dispatcher of its KafkaChannel, which runs either
+// in the system namespace or in the namespace of the channel, depending on its scope annotation.
+type dispatcherMapper struct {
+	systemNamespace    string
+	kafkachannelLister listers.KafkaChannelLister
+}
+
+// ConnectionPoolKeyMapper uses a ConnectionPool Key per dispatcher deployment, since a dispatcher
+// is shared by all the KafkaChannels of its scope and only accepts a single control-protocol connection.
+func (m *dispatcherMapper) ConnectionPoolKeyMapper(subscription *messagingv1.Subscription) (string, error) {
+	namespace, err := m.DataPlaneNamespaceMapper(subscription)
+	if err != nil {
+		return "", err
+	}
+	return types.NamespacedName{Namespace: namespace, Name: dispatcherName}.String(), nil
+}
+
+// DataPlaneNamespaceMapper returns the namespace of the dispatcher of the KafkaChannel of the Subscription.
+func (m *dispatcherMapper) DataPlaneNamespaceMapper(subscription *messagingv1.Subscription) (string, error) {
+	if subscription == nil {
+		return "", fmt.Errorf("unable to map the dispatcher namespace of nil Subscription")
+	}
+	channel := subscriptionChannel(subscription)
+	kc, err := m.kafkachannelLister.KafkaChannels(channel.Namespace).Get(channel.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the KafkaChannel %v of the Subscription: %w", channel, err)
+	}
+	return channelDispatcherNamespace(m.systemNamespace, kc), nil
+}
+
+// subscriptionChannel returns the reference of the channel of the Subscription, which defaults to
+// the namespace of the Subscription.
+func subscriptionChannel(subscription *messagingv1.Subscription) types.NamespacedName {
+	channel := types.NamespacedName{
+		Namespace: subscription.Spec.Channel.Namespace,
+		Name:      subscription.Spec.Channel.Name,
+	}
+	if channel.Namespace == "" {
+		channel.Namespace = subscription.Namespace
+	}
+	return channel
+}
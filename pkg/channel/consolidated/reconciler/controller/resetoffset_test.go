@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/eventing/pkg/apis/eventing"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	reconcilertesting "knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/testing"
+)
+
+func newTestSubscription(channelNamespace string) *messagingv1.Subscription {
+	return &messagingv1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sub", Namespace: testNS, UID: "test-uid"},
+		Spec: messagingv1.SubscriptionSpec{
+			Channel: duckv1.KReference{Kind: "KafkaChannel", Name: kcName, Namespace: channelNamespace},
+		},
+	}
+}
+
+func TestSubscriptionMappers(t *testing.T) {
+	subscription := newTestSubscription("")
+
+	topicName, err := TopicNameMapper(subscription)
+	assert.Nil(t, err)
+	assert.Equal(t, "knative-messaging-kafka.test-namespace.test-kc", topicName)
+
+	groupId, err := GroupIdMapper(subscription)
+	assert.Nil(t, err)
+	assert.Equal(t, "kafka.test-namespace.test-kc.test-uid", groupId)
+
+	labels, err := DataPlaneLabelsMapper(subscription)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"messaging.knative.dev/channel": "kafka-channel",
+		"messaging.knative.dev/role":    "dispatcher",
+	}, labels)
+
+	_, err = TopicNameMapper(nil)
+	assert.NotNil(t, err)
+	_, err = GroupIdMapper(nil)
+	assert.NotNil(t, err)
+}
+
+func TestDispatcherMapper(t *testing.T) {
+	tests := map[string]struct {
+		channel       *v1beta1.KafkaChannel
+		wantNamespace string
+		wantKey       string
+		wantErr       bool
+	}{
+		"cluster scoped channel": {
+			channel:       reconcilertesting.NewKafkaChannel(kcName, testNS),
+			wantNamespace: "knative-testing",
+			wantKey:       "knative-testing/kafka-ch-dispatcher",
+		},
+		"namespace scoped channel": {
+			channel: reconcilertesting.NewKafkaChannel(kcName, testNS, func(kc *v1beta1.KafkaChannel) {
+				kc.Annotations = map[string]string{eventing.ScopeAnnotationKey: scopeNamespace}
+			}),
+			wantNamespace: testNS,
+			wantKey:       testNS + "/kafka-ch-dispatcher",
+		},
+		"missing channel": {
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var objects []runtime.Object
+			if test.channel != nil {
+				objects = append(objects, test.channel)
+			}
+			listers := reconcilertesting.NewListers(objects)
+			mapper := &dispatcherMapper{
+				systemNamespace:    "knative-testing",
+				kafkachannelLister: listers.GetKafkaChannelLister(),
+			}
+			subscription := newTestSubscription(testNS)
+
+			namespace, err := mapper.DataPlaneNamespaceMapper(subscription)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.wantNamespace, namespace)
+
+			key, err := mapper.ConnectionPoolKeyMapper(subscription)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.wantKey, key)
+		})
+	}
+}
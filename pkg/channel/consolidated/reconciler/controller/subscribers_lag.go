@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+)
+
+// consumerLagRefreshPeriod is the period after which the lag of the subscribers is computed again
+const consumerLagRefreshPeriod = 30 * time.Second
+
+// refreshSubscribersLag computes the lag of the subscribers of the channels every consumerLagRefreshPeriod
+// until the context is done, patching it in their status and reporting it as metrics.  The channels are
+// not reconciled for this, and the Kafka clients of each channel are kept until its config changes.
+func (r *Reconciler) refreshSubscribersLag(ctx context.Context) {
+	clients := offset.NewClientsCache(r.createLagClients)
+	defer clients.Close()
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		r.refreshChannelsLag(ctx, clients)
+	}, consumerLagRefreshPeriod)
+}
+
+// refreshChannelsLag refreshes the lag of the subscribers of the ready channels reconciled by this controller,
+// and closes the Kafka clients of the other channels.
+func (r *Reconciler) refreshChannelsLag(ctx context.Context, clients *offset.ClientsCache) {
+	logger := logging.FromContext(ctx)
+
+	channels, err := r.kafkachannelLister.List(labels.Everything())
+	if err != nil {
+		logger.Errorw("Unable to list the channels to refresh the lag of their subscribers", zap.Error(err))
+		return
+	}
+
+	refreshed := sets.NewString()
+	for _, channel := range channels {
+		key := types.NamespacedName{Namespace: channel.Namespace, Name: channel.Name}
+		if r.kafkaConfig == nil || channel.DeletionTimestamp != nil || !r.isLeaderFor(key) ||
+			!channel.Status.GetCondition(v1beta1.KafkaChannelConditionTopicReady).IsTrue() {
+			continue
+		}
+		refreshed.Insert(key.String())
+
+		if err := r.refreshChannelLag(ctx, clients, key.String(), channel); err != nil {
+			logger.Warnw("Unable to refresh the lag of the subscribers", zap.String("channel", key.String()), zap.Error(err))
+		}
+	}
+	clients.Retain(refreshed)
+}
+
+// refreshChannelLag computes the lag of the subscribers of the channel and patches its status when it changed.
+// The Kafka clients of the channel are created again by the next refresh when the lag can't be computed.
+func (r *Reconciler) refreshChannelLag(ctx context.Context, clients *offset.ClientsCache, key string, channel *v1beta1.KafkaChannel) error {
+	configKey, err := r.lagClientsConfigKey(channel)
+	if err != nil {
+		return err
+	}
+	kafkaClient, kafkaClusterAdmin, err := clients.Get(key, configKey, func() ([]string, *sarama.Config, error) {
+		return r.clusterConfig(ctx, channel)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the Kafka clients: %w", err)
+	}
+
+	subscribersLag, err := r.subscribersLag(ctx, channel, kafkaClient, kafkaClusterAdmin)
+	if err != nil {
+		clients.Invalidate(key)
+	}
+	if equality.Semantic.DeepEqual(subscribersLag, channel.Status.SubscribersLag) {
+		return err
+	}
+
+	patch, patchErr := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"subscribersLag": subscribersLag},
+	})
+	if patchErr == nil {
+		_, patchErr = r.kafkaClientSet.MessagingV1beta1().KafkaChannels(channel.Namespace).
+			Patch(ctx, channel.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	}
+	if patchErr != nil {
+		return fmt.Errorf("failed to patch the status of the channel: %w", patchErr)
+	}
+	return err
+}
+
+// lagClientsConfigKey returns the key of the config of the Kafka clients of the channel, which changes with
+// the spec of the channel, the config-kafka ConfigMap and the KafkaCluster the channel references.
+func (r *Reconciler) lagClientsConfigKey(channel *v1beta1.KafkaChannel) (string, error) {
+	configKey := fmt.Sprintf("%d/%s", channel.Generation, r.kafkaConfigMapHash)
+	if channel.Spec.ClusterRef != nil {
+		cluster, err := kafkacluster.GetForChannel(r.kafkaClusterLister, channel, r.systemNamespace)
+		if err != nil {
+			return "", err
+		}
+		configKey += "/" + cluster.ResourceVersion
+	}
+	return configKey, nil
+}
+
+// createLagClients creates the Kafka clients computing the lag of the subscribers of a channel.
+func (r *Reconciler) createLagClients(brokers []string, saramaConfig *sarama.Config) (sarama.Client, sarama.ClusterAdmin, error) {
+	kafkaClient, err := r.createKafkaClient(brokers, saramaConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	kafkaClusterAdmin, err := r.createClusterAdmin(brokers, saramaConfig)
+	if err != nil {
+		kafkaClient.Close()
+		return nil, nil, err
+	}
+	return kafkaClient, kafkaClusterAdmin, nil
+}
+
+// subscribersLag computes the lag of the consumer group of each subscriber of the channel and reports it as
+// metrics.  The last lag of a subscriber is kept when its lag cannot be retrieved, and the error is returned.
+func (r *Reconciler) subscribersLag(ctx context.Context, channel *v1beta1.KafkaChannel, kafkaClient sarama.Client, kafkaClusterAdmin sarama.ClusterAdmin) ([]v1beta1.SubscriberLagStatus, error) {
+	logger := logging.FromContext(ctx)
+
+	var lagErr error
+	topicName := utils.TopicName(utils.KafkaChannelSeparator, channel.Namespace, channel.Name)
+	subscribersLag := make([]v1beta1.SubscriberLagStatus, 0, len(channel.Spec.Subscribers))
+	for _, sub := range channel.Spec.Subscribers {
+		groupID := utils.GroupID(channel.Namespace, channel.Name, sub.UID)
+		lags, err := offset.GetConsumerGroupPartitionLags(kafkaClient, kafkaClusterAdmin, []string{topicName}, groupID)
+		if err != nil {
+			lagErr = fmt.Errorf("unable to compute the lag of the consumer group %s: %w", groupID, err)
+			for _, previous := range channel.Status.SubscribersLag {
+				if previous.UID == sub.UID {
+					subscribersLag = append(subscribersLag, previous)
+				}
+			}
+			continue
+		}
+
+		if err := metrics.ReportConsumerGroupLag(ctx, "KafkaChannel", channel.Namespace, channel.Name, groupID, lags); err != nil {
+			logger.Warnw("unable to report the consumer group lag", zap.String("consumerGroup", groupID), zap.Error(err))
+		}
+
+		status := v1beta1.SubscriberLagStatus{UID: sub.UID, ConsumerGroup: groupID, Total: offset.TotalLag(lags)}
+		for _, lag := range lags {
+			status.Partitions = append(status.Partitions, v1beta1.PartitionLagStatus{Partition: lag.Partition, Offset: lag.Offset, Lag: lag.Lag})
+		}
+		subscribersLag = append(subscribersLag, status)
+	}
+
+	if len(subscribersLag) == 0 {
+		return nil, lagErr
+	}
+	return subscribersLag, lagErr
+}
+
+// subscribersLagUpdate returns whether the update of a channel only changes the lag of its subscribers, which
+// doesn't need the channel to be reconciled.
+func subscribersLagUpdate(oldObj, newObj interface{}) bool {
+	oldChannel, ok := oldObj.(*v1beta1.KafkaChannel)
+	if !ok {
+		return false
+	}
+	newChannel, ok := newObj.(*v1beta1.KafkaChannel)
+	if !ok {
+		return false
+	}
+	if equality.Semantic.DeepEqual(oldChannel.Status.SubscribersLag, newChannel.Status.SubscribersLag) {
+		return false
+	}
+
+	withoutLag := func(channel *v1beta1.KafkaChannel) *v1beta1.KafkaChannel {
+		channel = channel.DeepCopy()
+		channel.ResourceVersion = ""
+		channel.ManagedFields = nil
+		channel.Status.SubscribersLag = nil
+		return channel
+	}
+	return equality.Semantic.DeepEqual(withoutLag(oldChannel), withoutLag(newChannel))
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	reconcilertesting "knative.dev/eventing-kafka/pkg/channel/consolidated/reconciler/testing"
+	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
+	fakekafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+func TestRefreshChannelsLag(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	topic := utils.TopicName(utils.KafkaChannelSeparator, testNS, kcName)
+	group1 := utils.GroupID(testNS, kcName, sub1UID)
+	group2 := utils.GroupID(testNS, kcName, sub2UID)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+			SetOffset(topic, 0, -1, 50),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).SetError(sarama.ErrNoError).
+			SetOffset(group1, topic, 0, 10, "", sarama.ErrNoError).
+			SetOffset(group2, topic, 0, 50, "", sarama.ErrNoError),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, group1, broker).
+			SetCoordinator(sarama.CoordinatorGroup, group2, broker),
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+	})
+
+	readyChannel := reconcilertesting.NewKafkaChannel(kcName, testNS,
+		reconcilertesting.WithKafkaChannelSubscribers(subscribers()),
+		reconcilertesting.WithInitKafkaChannelConditions,
+		reconcilertesting.WithKafkaChannelTopicReady())
+	pendingChannel := reconcilertesting.NewKafkaChannel("pending-kc", testNS,
+		reconcilertesting.WithKafkaChannelSubscribers(subscribers()),
+		reconcilertesting.WithInitKafkaChannelConditions)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(readyChannel))
+	require.NoError(t, indexer.Add(pendingChannel))
+	kafkaClientSet := fakekafkaclientset.NewSimpleClientset(readyChannel, pendingChannel)
+
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = sarama.MaxVersion
+	r := &Reconciler{
+		kafkaConfig: &utils.KafkaConfig{
+			Brokers:       []string{broker.Addr()},
+			EventingKafka: &config.EventingKafkaConfig{Sarama: config.EKSaramaConfig{Config: saramaConfig}},
+		},
+		kafkachannelLister: listers.NewKafkaChannelLister(indexer),
+		kafkaClientSet:     kafkaClientSet,
+		isLeaderFor:        func(types.NamespacedName) bool { return true },
+	}
+
+	clients := offset.NewClientsCache(r.createLagClients)
+	defer clients.Close()
+	ctx := logtesting.TestContextWithLogger(t)
+	r.refreshChannelsLag(ctx, clients)
+
+	channel, err := kafkaClientSet.MessagingV1beta1().KafkaChannels(testNS).Get(context.Background(), kcName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []v1beta1.SubscriberLagStatus{{
+		UID:           sub1UID,
+		ConsumerGroup: group1,
+		Total:         40,
+		Partitions:    []v1beta1.PartitionLagStatus{{Partition: 0, Offset: 10, Lag: 40}},
+	}, {
+		UID:           sub2UID,
+		ConsumerGroup: group2,
+		Total:         0,
+		Partitions:    []v1beta1.PartitionLagStatus{{Partition: 0, Offset: 50, Lag: 0}},
+	}}, channel.Status.SubscribersLag)
+	// The conditions set by the reconciler are kept
+	assert.True(t, channel.Status.GetCondition(v1beta1.KafkaChannelConditionTopicReady).IsTrue())
+
+	// The lag of the channels whose topic isn't ready isn't computed
+	pending, err := kafkaClientSet.MessagingV1beta1().KafkaChannels(testNS).Get(context.Background(), "pending-kc", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, pending.Status.SubscribersLag)
+
+	// The channel isn't patched again while its lag is unchanged
+	readyChannel.Status.SubscribersLag = channel.Status.SubscribersLag
+	require.NoError(t, indexer.Update(readyChannel))
+	kafkaClientSet.ClearActions()
+	r.refreshChannelsLag(ctx, clients)
+	assert.Empty(t, kafkaClientSet.Actions())
+}
+
+func TestSubscribersLagUpdate(t *testing.T) {
+	channel := reconcilertesting.NewKafkaChannel(kcName, testNS,
+		reconcilertesting.WithKafkaChannelSubscribers(subscribers()),
+		reconcilertesting.WithInitKafkaChannelConditions)
+	channel.ResourceVersion = "1"
+
+	lagged := channel.DeepCopy()
+	lagged.ResourceVersion = "2"
+	lagged.Status.SubscribersLag = []v1beta1.SubscriberLagStatus{{UID: sub1UID, Total: 10}}
+	assert.True(t, subscribersLagUpdate(channel, lagged))
+
+	// Any other change is reconciled
+	assert.False(t, subscribersLagUpdate(channel, channel.DeepCopy()))
+	ready := lagged.DeepCopy()
+	ready.Status.MarkTopicTrue()
+	assert.False(t, subscribersLagUpdate(channel, ready))
+	assert.False(t, subscribersLagUpdate(lagged, ready))
+	resized := lagged.DeepCopy()
+	resized.Spec.NumPartitions++
+	assert.False(t, subscribersLagUpdate(channel, resized))
+}
@@ -19,33 +19,44 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/eventing/pkg/channel/fanout"
 	"knative.dev/eventing/pkg/kncloudevents"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	configmapinformer "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
 	"knative.dev/pkg/tracing"
+	"knative.dev/pkg/tracker"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/dispatcher"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	kafkaScheme "knative.dev/eventing-kafka/pkg/client/clientset/versioned/scheme"
 	kafkaclientsetinjection "knative.dev/eventing-kafka/pkg/client/injection/client"
+	kafkaclusterinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
 	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/configmaploader"
 	"knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 )
 
 const dispatcherClientId = "kafka-ch-dispatcher"
@@ -64,6 +75,13 @@ type Reconciler struct {
 	kafkachannelLister   listers.KafkaChannelLister
 	kafkachannelInformer cache.SharedIndexInformer
 	impl                 *controller.Impl
+
+	// The KafkaClusters referenced by the channels, resolved against the Secrets of their namespace
+	kubeClientSet      kubernetes.Interface
+	kafkaClusterLister kafkalisters.KafkaClusterLister
+	clusterTracker     tracker.Interface
+	systemNamespace    string
+	saramaConfig       *sarama.Config
 }
 
 var _ kafkachannelreconciler.Interface = (*Reconciler)(nil)
@@ -101,6 +119,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	})
 
 	kafkaChannelInformer := kafkachannel.Get(ctx)
+	kafkaClusterInformer := kafkaclusterinformer.Get(ctx)
 	args := &dispatcher.KafkaDispatcherArgs{
 		Brokers:   kafkaConfig.Brokers,
 		Config:    kafkaConfig.EventingKafka,
@@ -111,12 +130,23 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		kafkaClientSet:       kafkaclientsetinjection.Get(ctx),
 		kafkachannelLister:   kafkaChannelInformer.Lister(),
 		kafkachannelInformer: kafkaChannelInformer.Informer(),
+		kubeClientSet:        kubeclient.Get(ctx),
+		kafkaClusterLister:   kafkaClusterInformer.Lister(),
+		systemNamespace:      system.Namespace(),
+		saramaConfig:         kafkaConfig.EventingKafka.Sarama.Config,
 	}
 	r.impl = kafkachannelreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
 		return controller.Options{SkipStatusUpdates: true}
 	})
+	r.clusterTracker = r.impl.Tracker
 
-	kafkaDispatcher, err := dispatcher.NewDispatcher(ctx, args, r.impl.EnqueueKey)
+	// Serve the consumer group commands (e.g. from the ResetOffset controller) of the subscriptions
+	controlServer, err := controlprotocol.NewServerHandler(ctx, controlprotocol.ServerPort)
+	if err != nil {
+		logger.Fatalw("Unable to start the control-protocol server", zap.Error(err))
+	}
+
+	kafkaDispatcher, err := dispatcher.NewDispatcher(ctx, args, controlServer, r.impl.EnqueueKey)
 	if err != nil {
 		logger.Fatalw("Unable to create kafka dispatcher", zap.Error(err))
 	}
@@ -143,11 +173,17 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 			},
 		})
 
+	// Watch for the KafkaClusters referenced by the kafka channels.
+	kafkaClusterInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(r.impl.Tracker.OnChanged, kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster")),
+	))
+
 	logger.Info("Starting dispatcher.")
 	go func() {
 		if err := kafkaDispatcher.Start(ctx); err != nil {
 			logger.Errorw("Cannot start dispatcher", zap.Error(err))
 		}
+		controlServer.Shutdown(5 * time.Second)
 		tracer.Shutdown(context.Background())
 	}()
 
@@ -179,14 +215,25 @@ func (r *Reconciler) syncChannel(ctx context.Context, kc *v1beta1.KafkaChannel)
 
 	config := r.newConfigFromKafkaChannel(kc)
 
+	cluster, err := r.clusterConfig(ctx, kc)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Error resolving the KafkaCluster of the channel", zap.Error(err))
+		return err
+	}
+	config.Cluster = cluster
+
 	// Update receiver side
 	if err := r.kafkaDispatcher.RegisterChannelHost(config); err != nil {
 		logging.FromContext(ctx).Error("Error updating host to channel map in dispatcher")
 		return err
 	}
+	if err := r.kafkaDispatcher.RegisterChannelCluster(config); err != nil {
+		logging.FromContext(ctx).Errorw("Error creating the producer of the KafkaCluster of the channel", zap.Error(err))
+		return err
+	}
 
 	// Update dispatcher side
-	err := r.kafkaDispatcher.ReconcileConsumers(ctx, config)
+	err = r.kafkaDispatcher.ReconcileConsumers(ctx, config)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Some kafka subscriptions failed to subscribe", zap.Error(err))
 		return fmt.Errorf("some kafka subscriptions failed to subscribe: %v", err)
@@ -198,6 +245,31 @@ func (r *Reconciler) CleanupChannel(kc *v1beta1.KafkaChannel) pkgreconciler.Even
 	return r.kafkaDispatcher.CleanupChannel(kc.Name, kc.Namespace, kc.Status.Address.URL.Host)
 }
 
+// clusterConfig returns the connection to the KafkaCluster referenced by the channel, nil if it uses the
+// Kafka cluster of config-kafka.  The changes of the Secrets of the cluster are only applied when its spec
+// changes or the dispatcher restarts.
+func (r *Reconciler) clusterConfig(ctx context.Context, kc *v1beta1.KafkaChannel) (*dispatcher.ClusterConfig, error) {
+	if kc.Spec.ClusterRef == nil {
+		return nil, nil
+	}
+	if err := r.clusterTracker.TrackReference(kafkacluster.TrackerReference(kc.Spec.ClusterRef, kc.Namespace), kc); err != nil {
+		return nil, fmt.Errorf("failed to track the KafkaCluster: %w", err)
+	}
+	cluster, err := kafkacluster.GetForChannel(r.kafkaClusterLister, kc, r.systemNamespace)
+	if err != nil {
+		return nil, err
+	}
+	brokers, saramaConfig, err := kafkacluster.NewConfig(ctx, r.kubeClientSet, cluster, r.saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &dispatcher.ClusterConfig{
+		Key:     kafkacluster.Key(cluster),
+		Brokers: brokers,
+		Config:  saramaConfig,
+	}, nil
+}
+
 // newConfigFromKafkaChannel creates a new Config from the list of kafka channels.
 func (r *Reconciler) newConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatcher.ChannelConfig {
 	channelConfig := dispatcher.ChannelConfig{
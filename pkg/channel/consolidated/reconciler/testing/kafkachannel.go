@@ -148,6 +148,12 @@ func WithKafkaChannelSubscribers(subs []v1.SubscriberSpec) KafkaChannelOption {
 	}
 }
 
+func WithKafkaChannelSubscribersLag(lags []v1beta1.SubscriberLagStatus) KafkaChannelOption {
+	return func(nc *v1beta1.KafkaChannel) {
+		nc.Status.SubscribersLag = lags
+	}
+}
+
 func WithKafkaChannelStatusSubscribers() KafkaChannelOption {
 	return func(nc *v1beta1.KafkaChannel) {
 		ss := make([]v1.SubscriberStatus, len(nc.Spec.Subscribers))
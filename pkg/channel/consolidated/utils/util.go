@@ -24,6 +24,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/system"
 
@@ -115,6 +116,11 @@ func TopicName(separator, namespace, name string) string {
 	return strings.Join(topic, separator)
 }
 
+// GroupID returns the consumer group of the subscriber with the given UID of a KafkaChannel.
+func GroupID(namespace, name string, uid types.UID) string {
+	return fmt.Sprintf("kafka.%s.%s.%s", namespace, name, string(uid))
+}
+
 func FindContainer(d *appsv1.Deployment, containerName string) *corev1.Container {
 	for i := range d.Spec.Template.Spec.Containers {
 		if d.Spec.Template.Spec.Containers[i].Name == containerName {
@@ -67,6 +67,14 @@ func TestGenerateTopicNameWithHyphen(t *testing.T) {
 	}
 }
 
+func TestGenerateGroupID(t *testing.T) {
+	expected := "kafka.channel-namespace.channel-name.subscription-uid"
+	actual := GroupID("channel-namespace", "channel-name", "subscription-uid")
+	if expected != actual {
+		t.Errorf("Expected '%s'. Actual '%s'", expected, actual)
+	}
+}
+
 func TestGetKafkaConfig_BackwardsCompatibility(t *testing.T) {
 
 	api := &KubernetesAPI{
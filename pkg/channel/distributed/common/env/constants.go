@@ -35,6 +35,10 @@ const (
 	// Kafka Configuration
 	KafkaTopicEnvVarKey = "KAFKA_TOPIC"
 
+	// KafkaCluster Of The Channel (If Not The ConfigMap's Kafka Cluster)
+	KafkaClusterNameEnvVarKey      = "KAFKA_CLUSTER_NAME"
+	KafkaClusterNamespaceEnvVarKey = "KAFKA_CLUSTER_NAMESPACE"
+
 	// Dispatcher Configuration
 	ChannelKeyEnvVarKey  = "CHANNEL_KEY"
 	ServiceNameEnvVarKey = "SERVICE_NAME"
@@ -154,6 +154,62 @@ func (c *CustomAdminClient) DeleteTopic(_ context.Context, topicName string) *sa
 	return c.mapHttpResponse("delete", response)
 }
 
+// Custom REST Pass-Through Function For Increasing The Number Of Partitions Of A Topic
+func (c *CustomAdminClient) CreatePartitions(_ context.Context, topicName string, count int32) *sarama.TopicError {
+	topicDetail := &TopicDetail{NumPartitions: count}
+	return c.updateTopic(http.MethodPost, topicName, PartitionsPath, topicDetail, "create partitions")
+}
+
+// Custom REST Pass-Through Function For Altering The Config Of A Topic
+func (c *CustomAdminClient) AlterTopicConfig(_ context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	topicDetail := &TopicDetail{ConfigEntries: configEntries}
+	return c.updateTopic(http.MethodPut, topicName, ConfigPath, topicDetail, "alter config")
+}
+
+// Send The Specified TopicDetail To The Specified Sub-Path Of The Sidecar's Topic Endpoint
+func (c *CustomAdminClient) updateTopic(method string, topicName string, subPath string, topicDetail *TopicDetail, operation string) *sarama.TopicError {
+
+	// Create An Updated Logger With TopicName
+	logger := c.logger.With(zap.String("TopicName", topicName), zap.String("Operation", operation))
+
+	// Validate The Topic
+	if len(topicName) <= 0 {
+		logger.Warn("Received Empty/Nil Topic Configuration")
+		return util.NewTopicError(sarama.ErrInvalidRequest, "received empty/nil topic name")
+	}
+
+	// Create The Request Body From The Custom TopicDetail
+	requestBody, err := json.Marshal(topicDetail)
+	if err != nil {
+		logger.Error("Failed To Marshall Update Topic Request Body", zap.Any("TopicDetail", topicDetail), zap.Error(err))
+		return util.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("failed to marshal request body for '%s' of topic '%s'", operation, topicName))
+	}
+
+	// Create Topic URL For Sidecar Endpoint (TopicName & Sub-Path In URL!)
+	url := c.sidecarTopicsUrl(topicName) + subPath
+
+	// Create The HTTP Request
+	request, err := http.NewRequest(method, url, bytes.NewBuffer(requestBody))
+	if err != nil {
+		logger.Error("Failed To Create New HTTP Request", zap.String("URL", url), zap.Error(err))
+		return util.NewTopicError(sarama.ErrUnknown, fmt.Sprintf("failed to create new http request for '%s' of topic '%s'", operation, topicName))
+	}
+
+	// Populate Required Headers
+	request.Header.Set("Content-Type", "application/json")
+
+	// Make The HTTP Request
+	response, err := c.httpClient.Do(request)
+	defer c.safeCloseHTTPResponseBody(response)
+	if err != nil {
+		logger.Error("HTTP Request To Update Topic Failed", zap.Error(err))
+		return util.NewTopicError(sarama.ErrNetworkException, fmt.Sprintf("failed to make http request for '%s' of topic '%s'", operation, topicName))
+	}
+
+	// Map The HTTP Response Into A Sarama TopicError & Return
+	return c.mapHttpResponse(operation, response)
+}
+
 // Custom REST Pass-Through Function For Closing The Admin Client
 func (c *CustomAdminClient) Close() error {
 	return nil // Nothing to "close" in the Custom implementation (just a REST client) so this is just a compatibility no-op.
@@ -210,6 +266,8 @@ func (c *CustomAdminClient) mapHttpResponse(operation string, response *http.Res
 			return util.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
 		case statusCode == 409 && operation == "create": // 409 Conflict Indicates Topic Already Exists In Create Operation
 			return util.NewTopicError(sarama.ErrTopicAlreadyExists, fmt.Sprintf("custom sidecar topic '%s' operation returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
+		case (statusCode == 404 || statusCode == 405 || statusCode == 501) && operation != "create" && operation != "delete": // Update Operations Not Implemented By The Sidecar
+			return util.NewTopicError(sarama.ErrUnsupportedVersion, fmt.Sprintf("custom sidecar topic '%s' operation not supported, returned status code '%d' and body '%s'", operation, statusCode, responseBodyString))
 		default:
 			return util.NewTopicError(sarama.ErrInvalidRequest, fmt.Sprintf("custom sidecar topic '%s' operation failed with status code '%d' and body '%s'", operation, statusCode, responseBodyString))
 		}
@@ -223,6 +223,30 @@ func TestMapHttpResponse(t *testing.T) {
 			response:  &http.Response{StatusCode: 409, Body: ioutil.NopCloser(bytes.NewReader(bodyBytes))},
 			expected:  &sarama.TopicError{Err: sarama.ErrInvalidRequest},
 		},
+		{
+			name:      "Create Partitions 200",
+			operation: "create partitions",
+			response:  &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewReader(bodyBytes))},
+			expected:  &sarama.TopicError{Err: sarama.ErrNoError},
+		},
+		{
+			name:      "Create Partitions 404",
+			operation: "create partitions",
+			response:  &http.Response{StatusCode: 404, Body: ioutil.NopCloser(bytes.NewReader(bodyBytes))},
+			expected:  &sarama.TopicError{Err: sarama.ErrUnsupportedVersion},
+		},
+		{
+			name:      "Alter Config 405",
+			operation: "alter config",
+			response:  &http.Response{StatusCode: 405, Body: ioutil.NopCloser(bytes.NewReader(bodyBytes))},
+			expected:  &sarama.TopicError{Err: sarama.ErrUnsupportedVersion},
+		},
+		{
+			name:      "Alter Config 500",
+			operation: "alter config",
+			response:  &http.Response{StatusCode: 500, Body: ioutil.NopCloser(bytes.NewReader(bodyBytes))},
+			expected:  &sarama.TopicError{Err: sarama.ErrInvalidRequest},
+		},
 		{
 			name:      "Create 500",
 			operation: "create",
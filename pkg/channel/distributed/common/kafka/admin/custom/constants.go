@@ -28,6 +28,8 @@ const (
 	SidecarHost     = "localhost"      // The Host name used when making requests to the K8S sidecar.
 	SidecarPort     = "8888"           // The HTTP port on which the sidecar must be listening for POST / DELETE requests.
 	TopicsPath      = "/topics"        // The HTTP request path for Kafka Topic creation / deletion to be implemented by the sidecar.
+	PartitionsPath  = "/partitions"    // The HTTP request sub-path (of the topic path) for increasing the partitions of a Kafka Topic.
+	ConfigPath      = "/config"        // The HTTP request sub-path (of the topic path) for altering the config of a Kafka Topic.
 	TopicNameHeader = "Slug"           // The HTTP Header key used to identify the TopicName in the POST request.
 	SidecarTimeout  = 30 * time.Second // How long to wait for the sidecar's server to respond.
 )
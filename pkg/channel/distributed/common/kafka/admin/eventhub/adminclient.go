@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"sort"
 	"strconv"
 
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
@@ -100,6 +101,9 @@ func (c *EventHubAdminClient) CreateTopic(ctx context.Context, topicName string,
 	// Convert Kafka Retention Millis To Azure EventHub Retention Days
 	topicRetentionDays := convertMillisToDays(topicRetentionMillis)
 
+	// Azure EventHubs Only Support The Retention, Any Other Topic Config Is Ignored
+	c.warnUnsupportedConfigEntries(topicName, topicDetail.ConfigEntries)
+
 	// If The HubManager Is Not Valid Then Return Error
 	if c.hubManager == nil {
 		c.logger.Warn("Failed To Find EventHub Namespace With Valid HubManager - Skipping Topic Creation", zap.String("Topic", topicName))
@@ -159,11 +163,120 @@ func (c *EventHubAdminClient) DeleteTopic(ctx context.Context, topicName string)
 	return util.NewTopicError(sarama.ErrNoError, "successfully deleted topic")
 }
 
+// Increase The Partition Count Of A Single Topic (EventHub) Via The Azure EventHub API
+func (c *EventHubAdminClient) CreatePartitions(ctx context.Context, topicName string, count int32) *sarama.TopicError {
+
+	// Get The Current EventHub (Topic)
+	hub, topicError := c.getHub(ctx, topicName)
+	if topicError != nil {
+		return topicError
+	}
+
+	// Nothing To Do If The EventHub Already Has Enough Partitions
+	if hub.PartitionCount != nil && *hub.PartitionCount >= count {
+		return util.NewTopicError(sarama.ErrNoError, "eventhub already has the requested number of partitions")
+	}
+
+	// Update The EventHub (Topic) Via The PUT Rest Endpoint, Preserving The Current Retention
+	return c.updateHub(ctx, topicName, hub, eventhub.HubWithPartitionCount(count))
+}
+
+// Alter The Config Of A Single Topic (EventHub) Via The Azure EventHub API - Only The Retention Is Supported
+func (c *EventHubAdminClient) AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+
+	// Extract The Kafka Retention Millis (The Only Config Supported By Azure EventHubs)
+	c.warnUnsupportedConfigEntries(topicName, configEntries)
+	retentionMillisString, ok := configEntries[constants.TopicDetailConfigRetentionMs]
+	if !ok || retentionMillisString == nil {
+		c.logger.Debug("No Supported Config Entries To Alter - Skipping EventHub Update", zap.String("Topic", topicName))
+		return util.NewTopicError(sarama.ErrNoError, "no supported config entries to alter")
+	}
+	topicRetentionMillis, err := strconv.ParseInt(*retentionMillisString, 10, 64)
+	if err != nil {
+		c.logger.Error("Failed To Parse Retention Millis From Config Entries", zap.Error(err))
+		return util.NewTopicError(sarama.ErrInvalidConfig, "failed to parse retention millis from config entries")
+	}
+
+	// Convert Kafka Retention Millis To Azure EventHub Retention Days
+	topicRetentionDays := convertMillisToDays(topicRetentionMillis)
+
+	// Get The Current EventHub (Topic)
+	hub, topicError := c.getHub(ctx, topicName)
+	if topicError != nil {
+		return topicError
+	}
+
+	// Nothing To Do If The EventHub Already Has The Requested Retention
+	if hub.MessageRetentionInDays != nil && *hub.MessageRetentionInDays == topicRetentionDays {
+		return util.NewTopicError(sarama.ErrNoError, "eventhub already has the requested retention")
+	}
+
+	// Update The EventHub (Topic) Via The PUT Rest Endpoint, Preserving The Current Partition Count
+	return c.updateHub(ctx, topicName, hub, eventhub.HubWithMessageRetentionInDays(topicRetentionDays))
+}
+
+// Get The Specified EventHub (Topic), Mapping Failures To Sarama TopicErrors
+func (c *EventHubAdminClient) getHub(ctx context.Context, topicName string) (*eventhub.HubEntity, *sarama.TopicError) {
+
+	// If The HubManager Is Not Valid Then Return Error
+	if c.hubManager == nil {
+		c.logger.Warn("Failed To Find EventHub Namespace With Valid HubManager - Skipping Topic Update", zap.String("Topic", topicName))
+		return nil, util.NewTopicError(sarama.ErrInvalidConfig, fmt.Sprintf("azure namespace has invalid HubManager - unable to update EventHub '%s'", topicName))
+	}
+
+	// Get The EventHub (Nil When Not Found)
+	hub, err := c.hubManager.Get(ctx, topicName)
+	if err != nil {
+		c.logger.Error("Failed To Get EventHub", zap.String("TopicName", topicName), zap.Error(err))
+		return nil, util.NewTopicError(sarama.ErrUnknown, err.Error())
+	} else if hub == nil || hub.HubDescription == nil {
+		return nil, util.NewTopicError(sarama.ErrUnknownTopicOrPartition, fmt.Sprintf("eventhub '%s' not found", topicName))
+	}
+	return hub, nil
+}
+
+// Update The Specified EventHub (Topic) With The Specified Option, Preserving Its Current Partition Count & Retention
+func (c *EventHubAdminClient) updateHub(ctx context.Context, topicName string, hub *eventhub.HubEntity, option eventhub.HubManagementOption) *sarama.TopicError {
+
+	// The PUT Rest Endpoint Replaces The EventHub Description So Start With The Current Values
+	options := make([]eventhub.HubManagementOption, 0, 3)
+	if hub.PartitionCount != nil {
+		options = append(options, eventhub.HubWithPartitionCount(*hub.PartitionCount))
+	}
+	if hub.MessageRetentionInDays != nil {
+		options = append(options, eventhub.HubWithMessageRetentionInDays(*hub.MessageRetentionInDays))
+	}
+	options = append(options, option)
+
+	_, err := c.hubManager.Put(ctx, topicName, options...)
+	if err != nil {
+		c.logger.Error("Failed To Update EventHub", zap.String("TopicName", topicName), zap.Int("ErrorCode", getEventHubErrorCode(err)), zap.Error(err))
+		return util.NewTopicError(sarama.ErrUnknown, err.Error())
+	}
+
+	// Return Success!
+	return util.NewTopicError(sarama.ErrNoError, "successfully updated topic")
+}
+
 // Kafka AdminClient Close Implementation Using Azure EventHub API
 func (c *EventHubAdminClient) Close() error {
 	return nil // Nothing to "close" in the HubManager (just a REST client) so this is just a compatibility no-op.
 }
 
+// Log The Specified Topic Config Entries Which Are Not Supported By Azure EventHubs (And Thus Ignored)
+func (c *EventHubAdminClient) warnUnsupportedConfigEntries(topicName string, configEntries map[string]*string) {
+	unsupported := make([]string, 0, len(configEntries))
+	for name := range configEntries {
+		if name != constants.TopicDetailConfigRetentionMs {
+			unsupported = append(unsupported, name)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		c.logger.Warn("Ignoring Topic Config Entries Not Supported By Azure EventHubs", zap.String("Topic", topicName), zap.Strings("ConfigEntries", unsupported))
+	}
+}
+
 // Utility Function For Converting Millis To Days (Rounded Up To Larger Day Value)
 func convertMillisToDays(millis int64) int32 {
 	return int32(math.Ceil(float64(millis) / float64(constants.MillisPerDay)))
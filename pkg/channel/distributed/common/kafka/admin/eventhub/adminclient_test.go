@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"testing"
 
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
@@ -306,6 +307,167 @@ func TestDeleteTopic(t *testing.T) {
 	}
 }
 
+// Test The CreatePartitions() Functionality
+func TestCreatePartitions(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	logger := logtesting.TestLogger(t).Desugar()
+	topicName := "TestTopicName"
+	hub := createHubEntity(topicName, 4, 3)
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name           string
+		mockHubManager *MockHubManager
+		count          int32
+		expectedKError sarama.KError
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{
+			name:           "Success",
+			mockHubManager: NewMockHubManager(WithMockedGet(ctx, topicName, hub, false), WithMockedPut(ctx, topicName, false, 0)),
+			count:          8,
+			expectedKError: sarama.ErrNoError,
+		},
+		{
+			name:           "Already Enough Partitions",
+			mockHubManager: NewMockHubManager(WithMockedGet(ctx, topicName, hub, false)),
+			count:          4,
+			expectedKError: sarama.ErrNoError,
+		},
+		{
+			name:           "Nil HubManager",
+			count:          8,
+			expectedKError: sarama.ErrInvalidConfig,
+		},
+		{
+			name:           "EventHub Not Found",
+			mockHubManager: NewMockHubManager(WithMockedGet(ctx, topicName, nil, false)),
+			count:          8,
+			expectedKError: sarama.ErrUnknownTopicOrPartition,
+		},
+		{
+			name:           "Get Error",
+			mockHubManager: NewMockHubManager(WithMockedGet(ctx, topicName, nil, true)),
+			count:          8,
+			expectedKError: sarama.ErrUnknown,
+		},
+		{
+			name:           "Put Error",
+			mockHubManager: NewMockHubManager(WithMockedGet(ctx, topicName, hub, false), WithMockedPut(ctx, topicName, true, 999)),
+			count:          8,
+			expectedKError: sarama.ErrUnknown,
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A New EventHub AdminClient With Mock HubManager To Test
+			adminClient := &EventHubAdminClient{logger: logger}
+			if testCase.mockHubManager != nil {
+				adminClient.hubManager = testCase.mockHubManager
+			}
+
+			// Perform The Test
+			resultTopicError := adminClient.CreatePartitions(ctx, topicName, testCase.count)
+
+			// Verify The Results
+			assert.NotNil(t, resultTopicError)
+			assert.Equal(t, testCase.expectedKError, resultTopicError.Err)
+			if testCase.mockHubManager != nil {
+				testCase.mockHubManager.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+// Test The AlterTopicConfig() Functionality
+func TestAlterTopicConfig(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	logger := logtesting.TestLogger(t).Desugar()
+	topicName := "TestTopicName"
+	hub := createHubEntity(topicName, 4, 3)
+	currentRetentionMillis := strconv.FormatInt(int64(3*constants.MillisPerDay), 10)
+	newRetentionMillis := strconv.FormatInt(int64(5*constants.MillisPerDay), 10)
+	invalidRetentionMillis := "Invalid RetentionMillis"
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name           string
+		mockHubManager *MockHubManager
+		configEntries  map[string]*string
+		expectedKError sarama.KError
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{
+			name:           "Success",
+			mockHubManager: NewMockHubManager(WithMockedGet(ctx, topicName, hub, false), WithMockedPut(ctx, topicName, false, 0)),
+			configEntries:  map[string]*string{constants.TopicDetailConfigRetentionMs: &newRetentionMillis},
+			expectedKError: sarama.ErrNoError,
+		},
+		{
+			name:           "Unchanged Retention",
+			mockHubManager: NewMockHubManager(WithMockedGet(ctx, topicName, hub, false)),
+			configEntries:  map[string]*string{constants.TopicDetailConfigRetentionMs: &currentRetentionMillis},
+			expectedKError: sarama.ErrNoError,
+		},
+		{
+			name:           "Unsupported Config Entries Ignored",
+			mockHubManager: NewMockHubManager(WithMockedGet(ctx, topicName, hub, false), WithMockedPut(ctx, topicName, false, 0)),
+			configEntries:  map[string]*string{constants.TopicDetailConfigRetentionMs: &newRetentionMillis, "cleanup.policy": &invalidRetentionMillis},
+			expectedKError: sarama.ErrNoError,
+		},
+		{
+			name:           "No Supported Config Entries",
+			mockHubManager: NewMockHubManager(),
+			configEntries:  map[string]*string{"cleanup.policy": &invalidRetentionMillis},
+			expectedKError: sarama.ErrNoError,
+		},
+		{
+			name:           "Invalid Retention",
+			mockHubManager: NewMockHubManager(),
+			configEntries:  map[string]*string{constants.TopicDetailConfigRetentionMs: &invalidRetentionMillis},
+			expectedKError: sarama.ErrInvalidConfig,
+		},
+		{
+			name:           "Nil HubManager",
+			configEntries:  map[string]*string{constants.TopicDetailConfigRetentionMs: &newRetentionMillis},
+			expectedKError: sarama.ErrInvalidConfig,
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A New EventHub AdminClient With Mock HubManager To Test
+			adminClient := &EventHubAdminClient{logger: logger}
+			if testCase.mockHubManager != nil {
+				adminClient.hubManager = testCase.mockHubManager
+			}
+
+			// Perform The Test
+			resultTopicError := adminClient.AlterTopicConfig(ctx, topicName, testCase.configEntries)
+
+			// Verify The Results
+			assert.NotNil(t, resultTopicError)
+			assert.Equal(t, testCase.expectedKError, resultTopicError.Err)
+			if testCase.mockHubManager != nil {
+				testCase.mockHubManager.AssertExpectations(t)
+			}
+		})
+	}
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
@@ -333,3 +495,14 @@ func createTopicDetail(numPartitions int32, retentionMsString string) *sarama.To
 		ConfigEntries: map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMsString},
 	}
 }
+
+// Create An EventHub HubEntity For Testing
+func createHubEntity(name string, partitionCount int32, retentionDays int32) *eventhub.HubEntity {
+	return &eventhub.HubEntity{
+		Name: name,
+		HubDescription: &eventhub.HubDescription{
+			PartitionCount:         &partitionCount,
+			MessageRetentionInDays: &retentionDays,
+		},
+	}
+}
@@ -24,6 +24,8 @@ import (
 	"go.uber.org/zap"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/util"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+	"knative.dev/eventing-kafka/pkg/common/kafka/topic"
 	"knative.dev/pkg/logging"
 )
 
@@ -34,10 +36,12 @@ import (
 
 // Ensure The KafkaAdminClient Struct Implements The AdminClientInterface
 var _ types.AdminClientInterface = &KafkaAdminClient{}
+var _ types.ConsumerGroupLagInterface = &KafkaAdminClient{}
 
 // Kafka AdminClient Definition
 type KafkaAdminClient struct {
 	logger       *zap.Logger
+	client       sarama.Client
 	clusterAdmin sarama.ClusterAdmin
 }
 
@@ -47,16 +51,23 @@ func NewAdminClient(ctx context.Context, brokers []string, saramaConfig *sarama.
 	// Get The Logger From The Context
 	logger := logging.FromContext(ctx).Desugar()
 
-	// Create A New Sarama ClusterAdmin
-	clusterAdmin, err := NewClusterAdminFn(brokers, saramaConfig)
+	// Create A New Sarama Client & A ClusterAdmin From It (Closing The ClusterAdmin Also Closes The Client)
+	client, err := NewClientFn(brokers, saramaConfig)
+	if err != nil {
+		logger.Error("Failed To Create New Client", zap.Any("Config", saramaConfig), zap.Error(err))
+		return nil, err
+	}
+	clusterAdmin, err := NewClusterAdminFn(client)
 	if err != nil {
 		logger.Error("Failed To Create New ClusterAdmin", zap.Any("Config", saramaConfig), zap.Error(err))
+		_ = client.Close()
 		return nil, err
 	}
 
 	// Create The KafkaAdminClient
 	kafkaAdminClient := &KafkaAdminClient{
 		logger:       logger,
+		client:       client,
 		clusterAdmin: clusterAdmin,
 	}
 
@@ -65,9 +76,14 @@ func NewAdminClient(ctx context.Context, brokers []string, saramaConfig *sarama.
 	return kafkaAdminClient, nil
 }
 
-// Sarama NewClusterAdmin() Wrapper Function Variable To Facilitate Unit Testing
-var NewClusterAdminFn = func(brokers []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
-	return sarama.NewClusterAdmin(brokers, config)
+// Sarama NewClient() Wrapper Function Variable To Facilitate Unit Testing
+var NewClientFn = func(brokers []string, config *sarama.Config) (sarama.Client, error) {
+	return sarama.NewClient(brokers, config)
+}
+
+// Sarama NewClusterAdminFromClient() Wrapper Function Variable To Facilitate Unit Testing
+var NewClusterAdminFn = func(client sarama.Client) (sarama.ClusterAdmin, error) {
+	return sarama.NewClusterAdminFromClient(client)
 }
 
 // Sarama Pass-Through Function For Creating Topics
@@ -92,6 +108,69 @@ func (k KafkaAdminClient) DeleteTopic(_ context.Context, topicName string) *sara
 	}
 }
 
+// Sarama Function For Increasing The Number Of Partitions Of A Topic (No-Op If Topic Already Has Enough Partitions)
+func (k KafkaAdminClient) CreatePartitions(_ context.Context, topicName string, count int32) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Create Partitions Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return util.NewUnknownTopicError("unable to create partitions due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+
+	// Describe The Topic To Determine The Current Number Of Partitions
+	topicMetadata, err := k.clusterAdmin.DescribeTopics([]string{topicName})
+	if err != nil {
+		return util.PromoteErrorToTopicError(err)
+	}
+	for _, metadata := range topicMetadata {
+		if metadata.Name != topicName {
+			continue
+		}
+		if metadata.Err != sarama.ErrNoError {
+			return util.NewTopicError(metadata.Err, "failed to describe topic")
+		}
+		if int32(len(metadata.Partitions)) >= count {
+			return util.NewTopicError(sarama.ErrNoError, "topic already has the requested number of partitions")
+		}
+	}
+
+	// Increase The Number Of Partitions
+	err = k.clusterAdmin.CreatePartitions(topicName, count, nil, false)
+	return util.PromoteErrorToTopicError(err)
+}
+
+// Sarama Function For Altering Topic Config (Preserving Existing Topic-Level Overrides Which AlterConfig Would Reset)
+func (k KafkaAdminClient) AlterTopicConfig(_ context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	if k.clusterAdmin == nil {
+		k.logger.Error("Unable To Alter Topic Config Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return util.NewUnknownTopicError("unable to alter topic config due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+
+	// Describe The Current Topic Config
+	currentEntries, err := k.clusterAdmin.DescribeConfig(sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName})
+	if err != nil {
+		return util.PromoteErrorToTopicError(err)
+	}
+
+	// Merge The Requested Entries Into The Current Topic-Level Overrides
+	mergedEntries, changed := topic.MergeConfigEntries(currentEntries, configEntries)
+
+	// Nothing To Do If The Topic Config Already Matches
+	if len(changed) == 0 {
+		return util.NewTopicError(sarama.ErrNoError, "topic config already up to date")
+	}
+
+	err = k.clusterAdmin.AlterConfig(sarama.TopicResource, topicName, mergedEntries, false)
+	return util.PromoteErrorToTopicError(err)
+}
+
+// Sarama Function For Computing The Lag Of A ConsumerGroup On The Specified Topics
+func (k KafkaAdminClient) ConsumerGroupPartitionLags(topics []string, groupId string) ([]offset.PartitionLag, error) {
+	if k.client == nil || k.clusterAdmin == nil {
+		k.logger.Error("Unable To Compute ConsumerGroup Lag Due To Invalid ClusterAdmin - Check Kafka Authorization Secret")
+		return nil, fmt.Errorf("unable to compute consumer group lag due to invalid ClusterAdmin - check Kafka authorization secrets")
+	}
+	return offset.GetConsumerGroupPartitionLags(k.client, k.clusterAdmin, topics, groupId)
+}
+
 // Sarama Pass-Through Function For Closing ClusterAdmin
 func (k KafkaAdminClient) Close() error {
 	if k.clusterAdmin == nil {
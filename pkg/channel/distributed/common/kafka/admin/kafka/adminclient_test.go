@@ -28,6 +28,7 @@ import (
 	logtesting "knative.dev/pkg/logging/testing"
 
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
 )
 
 // Test The NewAdminClient() Functionality
@@ -40,17 +41,24 @@ func TestNewAdminClient(t *testing.T) {
 	// Create A Context With Test Logger
 	ctx := logging.WithLogger(context.TODO(), logtesting.TestLogger(t))
 
-	// Create A Mock Sarama ClusterAdmin To Test Against
+	// Create A Mock Sarama Client & ClusterAdmin To Test Against
+	mockClient := &controllertesting.MockClient{}
 	mockClusterAdmin := &MockClusterAdmin{}
 
-	// Mock The Sarama ClusterAdmin Creation For Testing
-	newClusterAdminFnPlaceholder := NewClusterAdminFn
-	NewClusterAdminFn = func(brokersArg []string, configArg *sarama.Config) (sarama.ClusterAdmin, error) {
+	// Mock The Sarama Client & ClusterAdmin Creation For Testing
+	newClientFnPlaceholder := NewClientFn
+	NewClientFn = func(brokersArg []string, configArg *sarama.Config) (sarama.Client, error) {
 		assert.Equal(t, brokers, brokersArg)
 		assert.Equal(t, config, configArg)
+		return mockClient, nil
+	}
+	newClusterAdminFnPlaceholder := NewClusterAdminFn
+	NewClusterAdminFn = func(clientArg sarama.Client) (sarama.ClusterAdmin, error) {
+		assert.Equal(t, mockClient, clientArg)
 		return mockClusterAdmin, nil
 	}
 	defer func() {
+		NewClientFn = newClientFnPlaceholder
 		NewClusterAdminFn = newClusterAdminFnPlaceholder
 	}()
 
@@ -206,6 +214,143 @@ func TestDeleteTopicInvalidAdminClient(t *testing.T) {
 	assert.Equal(t, errMsg, *resultTopicError.ErrMsg)
 }
 
+// Test The CreatePartitions() Functionality
+func TestCreatePartitions(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	topicMetadata := []*sarama.TopicMetadata{{
+		Name:       topicName,
+		Partitions: []*sarama.PartitionMetadata{{ID: 0}, {ID: 1}},
+	}}
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name                   string
+		count                  int32
+		expectCreatePartitions bool
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{name: "Fewer Partitions", count: 4, expectCreatePartitions: true},
+		{name: "Same Partitions", count: 2},
+		{name: "More Partitions", count: 1},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Sarama ClusterAdmin To Test Against
+			mockClusterAdmin := &MockClusterAdmin{}
+			mockClusterAdmin.On("DescribeTopics", []string{topicName}).Return(topicMetadata, nil)
+			if testCase.expectCreatePartitions {
+				mockClusterAdmin.On("CreatePartitions", topicName, testCase.count).Return(nil)
+			}
+
+			// Create A New Kafka AdminClient To Test
+			adminClient := &KafkaAdminClient{
+				logger:       logtesting.TestLogger(t).Desugar(),
+				clusterAdmin: mockClusterAdmin,
+			}
+
+			// Perform The Test
+			resultTopicError := adminClient.CreatePartitions(ctx, topicName, testCase.count)
+
+			// Verify The Results
+			if testCase.expectCreatePartitions {
+				assert.Nil(t, resultTopicError)
+			} else {
+				assert.NotNil(t, resultTopicError)
+				assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+			}
+			mockClusterAdmin.AssertExpectations(t)
+		})
+	}
+}
+
+// Test The CreatePartitions() Without ClusterAdmin Functionality
+func TestCreatePartitionsInvalidAdminClient(t *testing.T) {
+	adminClient := &KafkaAdminClient{logger: logtesting.TestLogger(t).Desugar()}
+	resultTopicError := adminClient.CreatePartitions(context.TODO(), "TestTopicName", 4)
+	assert.NotNil(t, resultTopicError)
+	assert.Equal(t, sarama.ErrUnknown, resultTopicError.Err)
+}
+
+// Test The AlterTopicConfig() Functionality
+func TestAlterTopicConfig(t *testing.T) {
+
+	// Test Data
+	ctx := context.TODO()
+	topicName := "TestTopicName"
+	configResource := sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName}
+	currentRetentionMillis := "86400000"
+	newRetentionMillis := "172800000"
+	cleanupPolicy := "compact"
+	currentEntries := []sarama.ConfigEntry{
+		{Name: constants.TopicDetailConfigRetentionMs, Value: currentRetentionMillis, Source: sarama.SourceTopic},
+		{Name: "cleanup.policy", Value: cleanupPolicy, Source: sarama.SourceTopic},
+		{Name: "segment.bytes", Value: "1073741824", Source: sarama.SourceDefault, Default: true},
+	}
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name               string
+		retentionMillis    string
+		expectAlterEntries map[string]*string
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{
+			name:            "Changed Retention",
+			retentionMillis: newRetentionMillis,
+			expectAlterEntries: map[string]*string{
+				constants.TopicDetailConfigRetentionMs: &newRetentionMillis,
+				"cleanup.policy":                       &cleanupPolicy,
+			},
+		},
+		{
+			name:            "Unchanged Retention",
+			retentionMillis: currentRetentionMillis,
+		},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			// Create A Mock Sarama ClusterAdmin To Test Against
+			mockClusterAdmin := &MockClusterAdmin{}
+			mockClusterAdmin.On("DescribeConfig", configResource).Return(currentEntries, nil)
+			if testCase.expectAlterEntries != nil {
+				mockClusterAdmin.On("AlterConfig", sarama.TopicResource, topicName, testCase.expectAlterEntries).Return(nil)
+			}
+
+			// Create A New Kafka AdminClient To Test
+			adminClient := &KafkaAdminClient{
+				logger:       logtesting.TestLogger(t).Desugar(),
+				clusterAdmin: mockClusterAdmin,
+			}
+
+			// Perform The Test
+			retentionMillis := testCase.retentionMillis
+			resultTopicError := adminClient.AlterTopicConfig(ctx, topicName, map[string]*string{constants.TopicDetailConfigRetentionMs: &retentionMillis})
+
+			// Verify The Results
+			if testCase.expectAlterEntries != nil {
+				assert.Nil(t, resultTopicError)
+			} else {
+				assert.NotNil(t, resultTopicError)
+				assert.Equal(t, sarama.ErrNoError, resultTopicError.Err)
+			}
+			mockClusterAdmin.AssertExpectations(t)
+		})
+	}
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
@@ -247,6 +392,24 @@ func TestCloseInvalidAdminClient(t *testing.T) {
 	assert.Equal(t, "unable to close invalid ClusterAdmin - check Kafka authorization secrets", err.Error())
 }
 
+// Test The ConsumerGroupPartitionLags() Without AdminClient Functionality
+func TestConsumerGroupPartitionLagsInvalidAdminClient(t *testing.T) {
+
+	// Test Logger
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Create A New Kafka AdminClient To Test
+	adminClient := &KafkaAdminClient{logger: logger}
+
+	// Perform The Test
+	lags, err := adminClient.ConsumerGroupPartitionLags([]string{"TestTopicName"}, "TestGroupId")
+
+	// Verify The Results
+	assert.Nil(t, lags)
+	assert.NotNil(t, err)
+	assert.Equal(t, "unable to compute consumer group lag due to invalid ClusterAdmin - check Kafka authorization secrets", err.Error())
+}
+
 //
 // Mock Sarama Kafka ClusterAdmin
 //
@@ -273,7 +436,8 @@ func (m *MockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
 }
 
 func (m *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
-	panic("implement me")
+	args := m.Called(topics)
+	return args.Get(0).([]*sarama.TopicMetadata), args.Error(1)
 }
 
 func (m *MockClusterAdmin) DeleteTopic(topic string) error {
@@ -282,7 +446,8 @@ func (m *MockClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (m *MockClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
-	panic("implement me")
+	args := m.Called(topic, count)
+	return args.Error(0)
 }
 
 func (m *MockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
@@ -298,11 +463,13 @@ func (m *MockClusterAdmin) DeleteRecords(topic string, partitionOffsets map[int3
 }
 
 func (m *MockClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
-	panic("implement me")
+	args := m.Called(resource)
+	return args.Get(0).([]sarama.ConfigEntry), args.Error(1)
 }
 
 func (m *MockClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
-	panic("implement me")
+	args := m.Called(resourceType, name, entries)
+	return args.Error(0)
 }
 
 func (m *MockClusterAdmin) CreateACL(resource sarama.Resource, acl sarama.Acl) error {
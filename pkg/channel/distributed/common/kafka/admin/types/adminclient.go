@@ -20,6 +20,8 @@ import (
 	"context"
 
 	"github.com/Shopify/sarama"
+
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
 )
 
 // AdminClient Type Enumeration
@@ -33,8 +35,19 @@ const (
 )
 
 // Sarama ClusterAdmin Wrapping Interface To Facilitate Other Implementations (e.g. Azure EventHubs)
+//
+// CreatePartitions() increases the number of partitions of an existing topic to the specified total count, and
+// is a no-op when the topic already has at least that many partitions.  AlterTopicConfig() updates the specified
+// config entries of an existing topic, leaving its other config entries unchanged.
 type AdminClientInterface interface {
 	CreateTopic(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
 	DeleteTopic(context.Context, string) *sarama.TopicError
+	CreatePartitions(context.Context, string, int32) *sarama.TopicError
+	AlterTopicConfig(context.Context, string, map[string]*string) *sarama.TopicError
 	Close() error
 }
+
+// Optional Interface Of The AdminClients Able To Read The ConsumerGroup Offsets From The Brokers (i.e. Kafka)
+type ConsumerGroupLagInterface interface {
+	ConsumerGroupPartitionLags(topics []string, groupId string) ([]offset.PartitionLag, error)
+}
@@ -21,7 +21,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer/wrapper"
 )
 
-// Create A Sarama SyncProducer (Via Wrapper)
-func CreateSyncProducer(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
-	return wrapper.NewSyncProducerFn(brokers, config)
+// Create A Sarama AsyncProducer (Via Wrapper)
+func CreateAsyncProducer(brokers []string, config *sarama.Config) (sarama.AsyncProducer, error) {
+	return wrapper.NewAsyncProducerFn(brokers, config)
 }
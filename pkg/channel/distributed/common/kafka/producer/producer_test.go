@@ -24,22 +24,22 @@ import (
 	producertesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer/testing"
 )
 
-// Test The CreateSyncProducer() Functionality
-func TestCreateSyncProducer(t *testing.T) {
+// Test The CreateAsyncProducer() Functionality
+func TestCreateAsyncProducer(t *testing.T) {
 
 	// Test Data
 	brokers := []string{"TestBrokers"}
 	config := sarama.NewConfig()
 
-	// Create A Mock SyncProducer, Stub NewSyncProducerWrapper() & Restore After Test
-	mockSyncProducer := producertesting.NewMockSyncProducer()
-	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
-	defer producertesting.RestoreNewSyncProducerFn()
+	// Create A Mock AsyncProducer, Stub NewAsyncProducerWrapper() & Restore After Test
+	mockAsyncProducer := producertesting.NewMockAsyncProducer()
+	producertesting.StubNewAsyncProducerFn(producertesting.ValidatingNewAsyncProducerFn(t, brokers, config, mockAsyncProducer))
+	defer producertesting.RestoreNewAsyncProducerFn()
 
 	// Perform The Test
-	producer, err := CreateSyncProducer(brokers, config)
+	producer, err := CreateAsyncProducer(brokers, config)
 
 	// Verify The Results
 	assert.Nil(t, err)
-	assert.Equal(t, mockSyncProducer, producer)
+	assert.Equal(t, mockAsyncProducer, producer)
 }
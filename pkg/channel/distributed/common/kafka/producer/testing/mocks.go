@@ -16,48 +16,86 @@ limitations under the License.
 
 package testing
 
-import "github.com/Shopify/sarama"
+import (
+	"sync"
+
+	"github.com/Shopify/sarama"
+)
 
 //
-// Mock Sarama SyncProducer Implementation
+// Mock Sarama AsyncProducer Implementation
 //
 
-var _ sarama.SyncProducer = &MockSyncProducer{}
+var _ sarama.AsyncProducer = &MockAsyncProducer{}
 
-type MockSyncProducer struct {
+type MockAsyncProducer struct {
+	input            chan *sarama.ProducerMessage
+	successes        chan *sarama.ProducerMessage
+	errors           chan *sarama.ProducerError
 	producerMessages chan sarama.ProducerMessage
-	offset           int64
-	closed           bool
+	closeOnce        sync.Once
+	closed           chan struct{}
 }
 
-func NewMockSyncProducer() *MockSyncProducer {
-	return &MockSyncProducer{
+// NewMockAsyncProducer returns a MockAsyncProducer acknowledging all the input messages, which are
+// assigned consecutive offsets of partition 1.
+func NewMockAsyncProducer() *MockAsyncProducer {
+	p := &MockAsyncProducer{
+		input:            make(chan *sarama.ProducerMessage),
+		successes:        make(chan *sarama.ProducerMessage, 1),
+		errors:           make(chan *sarama.ProducerError, 1),
 		producerMessages: make(chan sarama.ProducerMessage, 1),
-		closed:           false,
+		closed:           make(chan struct{}),
 	}
+	go func() {
+		var offset int64
+		for msg := range p.input {
+			p.producerMessages <- *msg
+			offset = offset + 1
+			msg.Partition = 1
+			msg.Offset = offset
+			p.successes <- msg
+		}
+		close(p.producerMessages)
+		close(p.successes)
+		close(p.errors)
+	}()
+	return p
 }
 
-func (p *MockSyncProducer) SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
-	p.producerMessages <- *msg
-	p.offset = p.offset + 1
-	return 1, p.offset, nil
+func (p *MockAsyncProducer) AsyncClose() {
+	p.closeOnce.Do(func() {
+		close(p.closed)
+		close(p.input)
+	})
 }
 
-func (p *MockSyncProducer) SendMessages(_ []*sarama.ProducerMessage) error {
-	// Not Currently In Use - No Need To Mock
+func (p *MockAsyncProducer) Close() error {
+	p.AsyncClose()
 	return nil
 }
 
-func (p *MockSyncProducer) GetMessage() sarama.ProducerMessage {
-	return <-p.producerMessages
+func (p *MockAsyncProducer) Input() chan<- *sarama.ProducerMessage {
+	return p.input
 }
 
-func (p *MockSyncProducer) Close() error {
-	p.closed = true
-	close(p.producerMessages)
-	return nil
+func (p *MockAsyncProducer) Successes() <-chan *sarama.ProducerMessage {
+	return p.successes
+}
+
+func (p *MockAsyncProducer) Errors() <-chan *sarama.ProducerError {
+	return p.errors
 }
 
-func (p *MockSyncProducer) Closed() bool {
-	return p.closed
+func (p *MockAsyncProducer) GetMessage() sarama.ProducerMessage {
+	return <-p.producerMessages
+}
+
+func (p *MockAsyncProducer) Closed() bool {
+	select {
+	case <-p.closed:
+		return true
+	default:
+		return false
+	}
 }
@@ -25,35 +25,35 @@ import (
 )
 
 //
-// Test Utilities For Stubbing The NewSyncProducerFn
+// Test Utilities For Stubbing The NewAsyncProducerFn
 //
 
-// Replace The NewSyncProducerFn With Specified Mock / Test Value
-func StubNewSyncProducerFn(stubNewSyncProducerFn wrapper.NewSyncProducerFnType) {
-	wrapper.NewSyncProducerFn = stubNewSyncProducerFn
+// Replace The NewAsyncProducerFn With Specified Mock / Test Value
+func StubNewAsyncProducerFn(stubNewAsyncProducerFn wrapper.NewAsyncProducerFnType) {
+	wrapper.NewAsyncProducerFn = stubNewAsyncProducerFn
 }
 
-// Restore The NewSyncProducerFn To Official Production Value
-func RestoreNewSyncProducerFn() {
-	wrapper.NewSyncProducerFn = wrapper.SaramaNewSyncProducerWrapper
+// Restore The NewAsyncProducerFn To Official Production Value
+func RestoreNewAsyncProducerFn() {
+	wrapper.NewAsyncProducerFn = wrapper.SaramaNewAsyncProducerWrapper
 }
 
-// Non-Validating NewSyncProducer Function
-func NonValidatingNewSyncProducerFn(mockSyncProducer sarama.SyncProducer) wrapper.NewSyncProducerFnType {
-	return func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
-		return mockSyncProducer, nil
+// Non-Validating NewAsyncProducer Function
+func NonValidatingNewAsyncProducerFn(mockAsyncProducer sarama.AsyncProducer) wrapper.NewAsyncProducerFnType {
+	return func(brokers []string, config *sarama.Config) (sarama.AsyncProducer, error) {
+		return mockAsyncProducer, nil
 	}
 }
 
-// Validating NewSyncProducer Function
-func ValidatingNewSyncProducerFn(t *testing.T,
+// Validating NewAsyncProducer Function
+func ValidatingNewAsyncProducerFn(t *testing.T,
 	expectedBrokers []string,
 	expectedConfig *sarama.Config,
-	mockSyncProducer sarama.SyncProducer) wrapper.NewSyncProducerFnType {
+	mockAsyncProducer sarama.AsyncProducer) wrapper.NewAsyncProducerFnType {
 
-	return func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
+	return func(brokers []string, config *sarama.Config) (sarama.AsyncProducer, error) {
 		assert.Equal(t, brokers, expectedBrokers)
 		assert.Equal(t, config, expectedConfig)
-		return mockSyncProducer, nil
+		return mockAsyncProducer, nil
 	}
 }
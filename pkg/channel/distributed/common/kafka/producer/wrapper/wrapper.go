@@ -19,12 +19,12 @@ package wrapper
 import "github.com/Shopify/sarama"
 
 // Define Function Type For Wrapper Variables (Typesafe Stubbing For Tests)
-type NewSyncProducerFnType = func(brokers []string, config *sarama.Config) (sarama.SyncProducer, error)
+type NewAsyncProducerFnType = func(brokers []string, config *sarama.Config) (sarama.AsyncProducer, error)
 
 // Function Variables To Facilitate Mocking Of Sarama Functionality In Unit Tests
-var NewSyncProducerFn = SaramaNewSyncProducerWrapper
+var NewAsyncProducerFn = SaramaNewAsyncProducerWrapper
 
-// The Production Sarama NewSyncProducer Wrapper Function
-func SaramaNewSyncProducerWrapper(brokers []string, config *sarama.Config) (sarama.SyncProducer, error) {
-	return sarama.NewSyncProducer(brokers, config)
+// The Production Sarama NewAsyncProducer Wrapper Function
+func SaramaNewAsyncProducerWrapper(brokers []string, config *sarama.Config) (sarama.AsyncProducer, error) {
+	return sarama.NewAsyncProducer(brokers, config)
 }
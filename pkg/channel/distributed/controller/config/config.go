@@ -21,6 +21,7 @@ import (
 
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
 )
 
 // ControllerConfigurationError is the type of error returned from VerifyConfiguration
@@ -44,6 +45,11 @@ func VerifyConfiguration(configuration *commonconfig.EventingKafkaConfig) error
 		return ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: " + configuration.Channel.AdminType)
 	}
 
+	// Verify The Dispatcher's Delivery Ordering
+	if _, err := commonconsumer.ParseDeliveryOrdering(configuration.Channel.Dispatcher.DeliveryOrdering); err != nil {
+		return ControllerConfigurationError("Invalid Dispatcher Delivery Ordering: " + err.Error())
+	}
+
 	// Verify mandatory configuration settings
 	switch {
 	case configuration.Channel.Dispatcher.Replicas < 1:
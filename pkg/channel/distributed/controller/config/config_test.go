@@ -53,6 +53,7 @@ type TestCase struct {
 	dispatcherMemoryLimit   resource.Quantity
 	dispatcherMemoryRequest resource.Quantity
 	dispatcherReplicas      int
+	dispatcherOrdering      string
 	receiverCpuLimit        resource.Quantity
 	receiverCpuRequest      resource.Quantity
 	receiverMemoryLimit     resource.Quantity
@@ -132,6 +133,15 @@ func TestVerifyConfiguration(t *testing.T) {
 	testCase.expectedError = ControllerConfigurationError("Distributed.Receiver.Replicas must be > 0")
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - Dispatcher.DeliveryOrdering")
+	testCase.dispatcherOrdering = "unordered"
+	testCases = append(testCases, testCase)
+
+	testCase = getValidTestCase("Invalid Config - Dispatcher.DeliveryOrdering")
+	testCase.dispatcherOrdering = "random"
+	testCase.expectedError = ControllerConfigurationError(`Invalid Dispatcher Delivery Ordering: invalid delivery ordering "random", must be one of "ordered", "key-ordered" or "unordered"`)
+	testCases = append(testCases, testCase)
+
 	testCase = getValidTestCase("Invalid Config - Kafka.Provider")
 	testCase.kafkaAdminType = "invalidadmintype"
 	testCase.expectedError = ControllerConfigurationError("Invalid / Unknown Kafka Admin Type: invalidadmintype")
@@ -146,6 +156,7 @@ func TestVerifyConfiguration(t *testing.T) {
 			testConfig.Channel.Dispatcher.MemoryLimit = testCase.dispatcherMemoryLimit
 			testConfig.Channel.Dispatcher.MemoryRequest = testCase.dispatcherMemoryRequest
 			testConfig.Channel.Dispatcher.Replicas = testCase.dispatcherReplicas
+			testConfig.Channel.Dispatcher.DeliveryOrdering = testCase.dispatcherOrdering
 			testConfig.Channel.Receiver.CpuLimit = testCase.receiverCpuLimit
 			testConfig.Channel.Receiver.CpuRequest = testCase.receiverCpuRequest
 			testConfig.Channel.Receiver.MemoryLimit = testCase.receiverMemoryLimit
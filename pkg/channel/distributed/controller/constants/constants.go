@@ -74,6 +74,9 @@ const (
 	KafkaChannelDispatcherLabel = "kafkachannel-dispatcher" // Dispatcher Label - Used To Mark Deployment As Dispatcher
 	KafkaTopicLabel             = "kafkaTopic"              // Topic Label - Indicates The Kafka Topic Of The KnativeChannel
 
+	// Annotations
+	KafkaClusterAnnotation = "kafka.eventing.knative.dev/kafkacluster" // KafkaCluster Annotation - Restarts The Dispatcher When The Cluster Changes
+
 	// Prometheus ServiceMonitor Selector Labels / Values
 	K8sAppChannelSelectorLabel    = "k8s-app"
 	K8sAppChannelSelectorValue    = "eventing-kafka-channels"
@@ -60,6 +60,9 @@ const (
 	// Kafka Secret Reconciliation
 	KafkaSecretReconciled
 	KafkaSecretFinalized
+
+	// KafkaCluster Resolution
+	KafkaClusterResolutionFailed
 )
 
 // CoreV1 EventType String Value
@@ -118,6 +121,8 @@ func (et CoreV1EventType) String() string {
 		eventTypeString = "KafkaSecretReconciled"
 	case KafkaSecretFinalized:
 		eventTypeString = "KafkaSecretFinalized"
+	case KafkaClusterResolutionFailed:
+		eventTypeString = "KafkaClusterResolutionFailed"
 	}
 
 	// Return The EventType String Value
@@ -47,6 +47,7 @@ func TestEventTypes(t *testing.T) {
 	performEventTypeStringTest(t, DispatcherServicePatchFailed, "DispatcherServicePatchFailed")
 	performEventTypeStringTest(t, KafkaSecretReconciled, "KafkaSecretReconciled")
 	performEventTypeStringTest(t, KafkaSecretFinalized, "KafkaSecretFinalized")
+	performEventTypeStringTest(t, KafkaClusterResolutionFailed, "KafkaClusterResolutionFailed")
 }
 
 // Perform A Single Instance Of The CoreV1 EventType String Test
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
+)
+
+// kafkaCluster Is The Kafka Cluster Of A KafkaChannel (Either The KafkaCluster It References Or The ConfigMap's One)
+type kafkaCluster struct {
+	brokers         []string
+	saramaConfig    *sarama.Config
+	adminClientType types.AdminClientType
+}
+
+// channelCluster Resolves The Kafka Cluster Of The Specified Channel
+func (r *Reconciler) channelCluster(ctx context.Context, channel *kafkav1beta1.KafkaChannel) (*kafkaCluster, error) {
+
+	// Use The Kafka Cluster Of The ConfigMap Unless The Channel References A KafkaCluster
+	if channel.Spec.ClusterRef == nil {
+		return &kafkaCluster{
+			brokers:         strings.Split(r.config.Kafka.Brokers, ","),
+			saramaConfig:    r.config.Sarama.Config,
+			adminClientType: r.adminClientType,
+		}, nil
+	}
+
+	// Reconcile The Channel Again When The KafkaCluster Changes
+	err := r.clusterTracker.TrackReference(kafkacluster.TrackerReference(channel.Spec.ClusterRef, channel.Namespace), channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to track the KafkaCluster: %w", err)
+	}
+
+	// Resolve The Brokers & Authentication Of The KafkaCluster (Always A Plain Kafka Cluster)
+	cluster, err := kafkacluster.GetForChannel(r.kafkaClusterLister, channel, r.environment.SystemNamespace)
+	if err != nil {
+		return nil, err
+	}
+	brokers, saramaConfig, err := kafkacluster.NewConfig(ctx, r.kubeClientset, cluster, r.config.Sarama.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaCluster{
+		brokers:         brokers,
+		saramaConfig:    saramaConfig,
+		adminClientType: types.Kafka,
+	}, nil
+}
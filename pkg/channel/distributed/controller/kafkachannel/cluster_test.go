@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/tracker"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	kafkaclusterlisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+)
+
+// Test The channelCluster() Functionality
+func TestChannelCluster(t *testing.T) {
+
+	// Create A Reconciler With A KafkaCluster In The Channel's Namespace
+	reconciler := newClusterTestReconciler(t)
+
+	// Test The ConfigMap's Kafka Cluster
+	cluster, err := reconciler.channelCluster(context.TODO(), controllertesting.NewKafkaChannel())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"config-broker:9092"}, cluster.brokers)
+	assert.Same(t, reconciler.config.Sarama.Config, cluster.saramaConfig)
+	assert.Equal(t, types.EventHub, cluster.adminClientType)
+
+	// Test The Referenced KafkaCluster
+	cluster, err = reconciler.channelCluster(context.TODO(), controllertesting.NewKafkaChannel(withClusterRef("")))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cluster-broker:9092"}, cluster.brokers)
+	assert.Equal(t, types.Kafka, cluster.adminClientType)
+
+	// Test A KafkaCluster Of Another Namespace
+	_, err = reconciler.channelCluster(context.TODO(), controllertesting.NewKafkaChannel(withClusterRef("other-namespace")))
+	assert.NotNil(t, err)
+}
+
+// Test The Dispatcher Deployment Of A Channel Referencing A KafkaCluster
+func TestNewDispatcherDeploymentWithKafkaCluster(t *testing.T) {
+
+	// Create A Reconciler With A KafkaCluster In The Channel's Namespace
+	reconciler := newClusterTestReconciler(t)
+	logger := logtesting.TestLogger(t).Desugar()
+
+	// Perform The Test
+	deployment, err := reconciler.newDispatcherDeployment(logger, controllertesting.NewKafkaChannel(withClusterRef("")))
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, "cluster-uid/1", deployment.Spec.Template.ObjectMeta.Annotations[constants.KafkaClusterAnnotation])
+	envVars := deployment.Spec.Template.Spec.Containers[0].Env
+	assert.Contains(t, envVars, corev1.EnvVar{Name: commonenv.KafkaClusterNameEnvVarKey, Value: "test-cluster"})
+	assert.Contains(t, envVars, corev1.EnvVar{Name: commonenv.KafkaClusterNamespaceEnvVarKey, Value: controllertesting.KafkaChannelNamespace})
+
+	// Verify The Channels Without A KafkaCluster Are Unchanged
+	deployment, err = reconciler.newDispatcherDeployment(logger, controllertesting.NewKafkaChannel())
+	assert.Nil(t, err)
+	assert.NotContains(t, deployment.Spec.Template.ObjectMeta.Annotations, constants.KafkaClusterAnnotation)
+}
+
+// newClusterTestReconciler Returns A Reconciler With A KafkaCluster In The Namespace Of The Test Channels
+func newClusterTestReconciler(t *testing.T) *Reconciler {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, namespace := range []string{controllertesting.KafkaChannelNamespace, "other-namespace"} {
+		assert.Nil(t, indexer.Add(&kafkav1alpha1.KafkaCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "test-cluster", UID: "cluster-uid", Generation: 1},
+			Spec:       kafkav1alpha1.KafkaClusterSpec{BootstrapServers: []string{"cluster-broker:9092"}},
+		}))
+	}
+	config := controllertesting.NewConfig()
+	config.Kafka.Brokers = "config-broker:9092"
+	config.Sarama.Config = sarama.NewConfig()
+	return &Reconciler{
+		kubeClientset:      fake.NewSimpleClientset(),
+		adminClientType:    types.EventHub,
+		environment:        controllertesting.NewEnvironment(),
+		config:             config,
+		kafkaClusterLister: kafkaclusterlisters.NewKafkaClusterLister(indexer),
+		clusterTracker:     tracker.New(func(k8stypes.NamespacedName) {}, time.Minute),
+	}
+}
+
+// withClusterRef Sets The KafkaCluster Reference Of The Channel
+func withClusterRef(namespace string) func(*kafkav1beta1.KafkaChannel) {
+	return func(channel *kafkav1beta1.KafkaChannel) {
+		channel.Spec.ClusterRef = &bindingsv1beta1.KafkaClusterReference{Name: "test-cluster", Namespace: namespace}
+	}
+}
@@ -25,11 +25,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	kafkachannelv1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/env"
 	kafkaclientsetinjection "knative.dev/eventing-kafka/pkg/client/injection/client"
+	"knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/messaging/v1beta1/kafkachannel"
 	kafkachannelreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
@@ -57,6 +59,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	kafkachannelInformer := kafkachannel.Get(ctx)
 	deploymentInformer := deployment.Get(ctx)
 	serviceInformer := service.Get(ctx)
+	kafkaClusterInformer := kafkacluster.Get(ctx)
 
 	// Load The Environment Variables
 	environment, err := env.FromContext(ctx)
@@ -109,6 +112,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		kafkaClientSet:       kafkaclientsetinjection.Get(ctx),
 		kafkachannelLister:   kafkachannelInformer.Lister(),
 		kafkachannelInformer: kafkachannelInformer.Informer(),
+		kafkaClusterLister:   kafkaClusterInformer.Lister(),
 		deploymentLister:     deploymentInformer.Lister(),
 		serviceLister:        serviceInformer.Lister(),
 		adminClientType:      kafkaAdminClientType,
@@ -119,6 +123,8 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 
 	// Create A New KafkaChannel Controller Impl With The Reconciler
 	controllerImpl := kafkachannelreconciler.NewImpl(ctx, rec)
+	rec.enqueueAfter = controllerImpl.EnqueueAfter
+	rec.clusterTracker = controllerImpl.Tracker
 
 	// Call GlobalResync on kafkachannels.
 	grCh := func(interface{}) {
@@ -162,6 +168,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		FilterFunc: FilterKafkaChannelOwnerByReferenceOrLabel(),
 		Handler:    controller.HandleAll(controllerImpl.EnqueueLabelOfNamespaceScopedResource(constants.KafkaChannelNamespaceLabel, constants.KafkaChannelNameLabel)),
 	})
+	kafkaClusterInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(controllerImpl.Tracker.OnChanged, kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster")),
+	))
 
 	// Return The KafkaChannel Controller Impl
 	return controllerImpl
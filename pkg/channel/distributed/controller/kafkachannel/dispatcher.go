@@ -43,6 +43,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 )
 
 //
@@ -572,6 +573,16 @@ func (r *Reconciler) newDispatcherDeployment(logger *zap.Logger, channel *kafkav
 		},
 	}
 
+	// Restart The Dispatcher When The KafkaCluster Of The Channel Changes (Only Resolved On Startup)
+	if channel.Spec.ClusterRef != nil {
+		cluster, err := kafkacluster.GetForChannel(r.kafkaClusterLister, channel, r.environment.SystemNamespace)
+		if err != nil {
+			logger.Error("Failed To Get The KafkaCluster Of The Channel", zap.Error(err))
+			return nil, err
+		}
+		deployment.Spec.Template.ObjectMeta.Annotations[constants.KafkaClusterAnnotation] = kafkacluster.Key(cluster)
+	}
+
 	// Update The Dispatcher Deployment's Annotations & Labels With Custom Config Values
 	deployment.ObjectMeta.Annotations = commonconfig.JoinStringMaps(deployment.ObjectMeta.Annotations, r.config.Channel.Dispatcher.DeploymentAnnotations)
 	deployment.ObjectMeta.Labels = commonconfig.JoinStringMaps(deployment.ObjectMeta.Labels, r.config.Channel.Dispatcher.DeploymentLabels)
@@ -662,6 +673,21 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 
 	}
 
+	// If The Channel References A KafkaCluster Then Append Its Name & Namespace (Dispatcher Connects To It Instead)
+	if channel.Spec.ClusterRef != nil {
+		clusterNamespace := channel.Spec.ClusterRef.Namespace
+		if clusterNamespace == "" {
+			clusterNamespace = channel.Namespace
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  commonenv.KafkaClusterNameEnvVarKey,
+			Value: channel.Spec.ClusterRef.Name,
+		}, corev1.EnvVar{
+			Name:  commonenv.KafkaClusterNamespaceEnvVarKey,
+			Value: clusterNamespace,
+		})
+	}
+
 	// Return The Dispatcher Deployment EnvVars Array
 	return envVars, nil
 }
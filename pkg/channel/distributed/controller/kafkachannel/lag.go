@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
+	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+)
+
+// ConsumerLagRefreshPeriod Is The Interval At Which The Lag Of The Subscribers Is Refreshed
+const ConsumerLagRefreshPeriod = 30 * time.Second
+
+// reconcileSubscribersLag Computes The Lag Of The ConsumerGroup Of Each Subscriber & Reports It In The
+// KafkaChannel Status And As Metrics.  The Lag Is Only Available With A Kafka AdminClient, since the
+// ConsumerGroup offsets are read directly from the Kafka brokers, and is computed with the reconciler's
+// AdminClient which must therefore be set.  Failures are logged but never fail the reconciliation, and the
+// last known lag of a subscriber is kept when its current lag cannot be retrieved.
+func (r *Reconciler) reconcileSubscribersLag(ctx context.Context, channel *kafkav1beta1.KafkaChannel) {
+
+	// Nothing To Do Without Subscribers Or Without Access To The Kafka Brokers
+	lagClient, ok := r.adminClient.(types.ConsumerGroupLagInterface)
+	if len(channel.Spec.Subscribers) == 0 || !ok {
+		channel.Status.SubscribersLag = nil
+		return
+	}
+
+	// Get The Channel-Specific Logger Provided Via The Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Refresh The Lag Periodically, Even When It Cannot Be Retrieved Right Now
+	if r.enqueueAfter != nil {
+		defer r.enqueueAfter(channel, ConsumerLagRefreshPeriod)
+	}
+
+	// Compute The Lag Of Each Subscriber's ConsumerGroup On The KafkaChannel's Topic
+	topicName := util.TopicName(channel)
+	subscribersLag := make([]kafkav1beta1.SubscriberLagStatus, 0, len(channel.Spec.Subscribers))
+	for _, subscriber := range channel.Spec.Subscribers {
+		groupId := commonkafkautil.GroupId(string(subscriber.UID))
+		lags, err := lagClient.ConsumerGroupPartitionLags([]string{topicName}, groupId)
+		if err != nil {
+			logger.Warn("Failed To Compute ConsumerGroup Lag", zap.String("GroupId", groupId), zap.Error(err))
+			for _, previous := range channel.Status.SubscribersLag {
+				if previous.UID == subscriber.UID {
+					subscribersLag = append(subscribersLag, previous)
+				}
+			}
+			continue
+		}
+
+		err = metrics.ReportConsumerGroupLag(ctx, "KafkaChannel", channel.Namespace, channel.Name, groupId, lags)
+		if err != nil {
+			logger.Warn("Failed To Report ConsumerGroup Lag", zap.String("GroupId", groupId), zap.Error(err))
+		}
+
+		status := kafkav1beta1.SubscriberLagStatus{UID: subscriber.UID, ConsumerGroup: groupId, Total: offset.TotalLag(lags)}
+		for _, lag := range lags {
+			status.Partitions = append(status.Partitions, kafkav1beta1.PartitionLagStatus{Partition: lag.Partition, Offset: lag.Offset, Lag: lag.Lag})
+		}
+		subscribersLag = append(subscribersLag, status)
+	}
+
+	// Update The KafkaChannel Status
+	if len(subscribersLag) > 0 {
+		channel.Status.SubscribersLag = subscribersLag
+	} else {
+		channel.Status.SubscribersLag = nil
+	}
+}
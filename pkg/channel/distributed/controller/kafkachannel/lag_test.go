@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	kafkaadmin "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/kafka"
+	admintesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/testing"
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin/types"
+)
+
+// Test The reconcileSubscribersLag() Functionality
+func TestReconcileSubscribersLag(t *testing.T) {
+
+	// Test Data
+	topic := "test-namespace.test-channel"
+	groupId := "kafka.test-uid"
+
+	// Create A Mock Kafka Broker With The Topic's Offsets & The Subscriber's Committed Offsets
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()).
+			SetLeader(topic, 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+			SetOffset(topic, 0, -1, 15).
+			SetOffset(topic, 1, -1, 8),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).SetError(sarama.ErrNoError).
+			SetOffset(groupId, topic, 0, 10, "", sarama.ErrNoError).
+			SetOffset(groupId, topic, 1, 8, "", sarama.ErrNoError),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, groupId, broker),
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+	})
+
+	// Create A Reconciler With A Kafka AdminClient To Test
+	ctx := logtesting.TestContextWithLogger(t)
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = sarama.MaxVersion
+	adminClient, err := kafkaadmin.NewAdminClient(ctx, []string{broker.Addr()}, saramaConfig)
+	assert.Nil(t, err)
+	defer func() { _ = adminClient.Close() }()
+	var enqueued time.Duration
+	reconciler := &Reconciler{
+		adminClientType: types.Kafka,
+		adminClient:     adminClient,
+		enqueueAfter:    func(obj interface{}, after time.Duration) { enqueued = after },
+	}
+
+	// Create A KafkaChannel With A Single Subscriber
+	channel := &kafkav1beta1.KafkaChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-channel"},
+	}
+	channel.Spec.Subscribers = []eventingduckv1.SubscriberSpec{{UID: "test-uid"}}
+
+	// Perform The Test
+	reconciler.reconcileSubscribersLag(ctx, channel)
+
+	// Verify The Results
+	assert.Equal(t, ConsumerLagRefreshPeriod, enqueued)
+	assert.Equal(t, []kafkav1beta1.SubscriberLagStatus{{
+		UID:           "test-uid",
+		ConsumerGroup: groupId,
+		Total:         5,
+		Partitions: []kafkav1beta1.PartitionLagStatus{
+			{Partition: 0, Offset: 10, Lag: 5},
+			{Partition: 1, Offset: 8, Lag: 0},
+		},
+	}}, channel.Status.SubscribersLag)
+
+	// Verify The Lag Is Not Available Without A Kafka AdminClient
+	reconciler.adminClientType = types.EventHub
+	reconciler.adminClient = admintesting.NewMockAdminClient()
+	reconciler.reconcileSubscribersLag(ctx, channel)
+	assert.Nil(t, channel.Status.SubscribersLag)
+}
@@ -19,8 +19,8 @@ package kafkachannel
 import (
 	"context"
 	"fmt"
-	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +31,7 @@ import (
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
 
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/admin"
@@ -41,6 +42,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/messaging/v1beta1/kafkachannel"
+	kafkaclusterlisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
@@ -56,10 +58,13 @@ type Reconciler struct {
 	config               *commonconfig.EventingKafkaConfig
 	kafkachannelLister   kafkalisters.KafkaChannelLister
 	kafkachannelInformer cache.SharedIndexInformer
+	kafkaClusterLister   kafkaclusterlisters.KafkaClusterLister
+	clusterTracker       tracker.Interface
 	deploymentLister     appsv1listers.DeploymentLister
 	serviceLister        corev1listers.ServiceLister
 	adminMutex           *sync.Mutex
 	kafkaConfigMapHash   string
+	enqueueAfter         func(obj interface{}, after time.Duration)
 }
 
 var (
@@ -80,11 +85,10 @@ var (
 // lightweight REST clients so recreating them isn't a big deal and it simplifies the code significantly to
 // not have to support both use cases.
 //
-func (r *Reconciler) SetKafkaAdminClient(ctx context.Context) error {
+func (r *Reconciler) SetKafkaAdminClient(ctx context.Context, cluster *kafkaCluster) error {
 	_ = r.ClearKafkaAdminClient(ctx) // Attempt to close any lingering connections, ignore errors and continue
 	var err error
-	brokers := strings.Split(r.config.Kafka.Brokers, ",")
-	r.adminClient, err = admin.CreateAdminClient(ctx, brokers, r.config.Sarama.Config, r.adminClientType)
+	r.adminClient, err = admin.CreateAdminClient(ctx, cluster.brokers, cluster.saramaConfig, cluster.adminClientType)
 	if err != nil {
 		logger := logging.FromContext(ctx)
 		logger.Error("Failed To Create Kafka AdminClient", zap.Error(err))
@@ -125,20 +129,28 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *kafkav1beta1.Ka
 	// Add A Channel-Specific Logger To The Context
 	ctx = logging.WithLogger(ctx, util.ChannelLogger(logger, channel).Sugar())
 
+	// Reset The Channel's Status Conditions To Unknown (Addressable, Topic, Service, Deployment, etc...)
+	channel.Status.InitializeConditions()
+
+	// Resolve The Kafka Cluster Of The Channel (The Referenced KafkaCluster Or The ConfigMap's One)
+	cluster, err := r.channelCluster(ctx, channel)
+	if err != nil {
+		logger.Error("Failed To Resolve The Kafka Cluster Of The KafkaChannel", zap.Error(err))
+		channel.Status.MarkConfigFailed(event.KafkaClusterResolutionFailed.String(), "Failed To Resolve The KafkaCluster: %v", err)
+		return err
+	}
+
 	// Don't let another goroutine clear out the admin client while we're using it in this one
 	r.adminMutex.Lock()
 	defer r.adminMutex.Unlock()
 
 	// Create A New Kafka AdminClient For Each Reconciliation Attempt
-	err := r.SetKafkaAdminClient(ctx)
+	err = r.SetKafkaAdminClient(ctx, cluster)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = r.ClearKafkaAdminClient(ctx) }() // Ignore errors as nothing else can be done
 
-	// Reset The Channel's Status Conditions To Unknown (Addressable, Topic, Service, Deployment, etc...)
-	channel.Status.InitializeConditions()
-
 	// Perform The KafkaChannel Reconciliation & Handle Error Response
 	logger.Info("Channel Owned By Controller - Reconciling", zap.Any("Channel.Spec", channel.Spec))
 	err = r.reconcile(ctx, channel)
@@ -147,6 +159,9 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *kafkav1beta1.Ka
 		return err
 	}
 
+	// Report The Lag Of The Subscribers (Never Fails The Reconciliation)
+	r.reconcileSubscribersLag(ctx, channel)
+
 	// Return Success
 	logger.Info("Successfully Reconciled KafkaChannel", zap.Any("Channel", channel))
 	channel.Status.ObservedGeneration = channel.Generation
@@ -165,29 +180,38 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, channel *kafkav1beta1.Kaf
 	// Add A Channel-Specific Logger To The Context
 	ctx = logging.WithLogger(ctx, util.ChannelLogger(logger, channel).Sugar())
 
+	// Resolve The Kafka Cluster Of The Channel (Its Topic Can't Be Deleted Without It, e.g. Deleted KafkaCluster)
+	cluster, clusterErr := r.channelCluster(ctx, channel)
+
 	// Don't let another goroutine clear out the admin client while we're using it in this one
 	r.adminMutex.Lock()
 	defer r.adminMutex.Unlock()
 
 	// Create A New Kafka AdminClient For Each Reconciliation Attempt
-	err := r.SetKafkaAdminClient(ctx)
-	if err != nil {
-		return err
+	if clusterErr == nil {
+		err := r.SetKafkaAdminClient(ctx, cluster)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = r.ClearKafkaAdminClient(ctx) }() // Ignore errors as nothing else can be done
 	}
-	defer func() { _ = r.ClearKafkaAdminClient(ctx) }() // Ignore errors as nothing else can be done
 
 	// Finalize The Dispatcher (Manual Finalization Due To Cross-Namespace Ownership)
-	err = r.finalizeDispatcher(ctx, channel)
+	err := r.finalizeDispatcher(ctx, channel)
 	if err != nil {
 		logger.Info("Failed To Finalize KafkaChannel", zap.Error(err))
 		return fmt.Errorf(constants.FinalizationFailedError)
 	}
 
-	// Finalize The Kafka Topic
-	err = r.finalizeKafkaTopic(ctx, channel)
-	if err != nil {
-		logger.Error("Failed To Finalize KafkaChannel", zap.Error(err))
-		return fmt.Errorf(constants.FinalizationFailedError)
+	// Finalize The Kafka Topic (Unless Its Kafka Cluster Is Gone)
+	if clusterErr != nil {
+		logger.Warn("Failed To Resolve The Kafka Cluster Of The KafkaChannel - Skipping Kafka Topic Finalization", zap.Error(clusterErr))
+	} else {
+		err = r.finalizeKafkaTopic(ctx, channel)
+		if err != nil {
+			logger.Error("Failed To Finalize KafkaChannel", zap.Error(err))
+			return fmt.Errorf(constants.FinalizationFailedError)
+		}
 	}
 
 	// Return Success
@@ -214,7 +238,9 @@ func (r *Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaC
 	// ConfigMap.  Therefore, we will instead check the Kafka Secret associated with the
 	// KafkaChannel here.
 	//
-	if len(r.config.Kafka.AuthSecretName) > 0 {
+	// (The Channels Referencing A KafkaCluster Use Its Authentication Instead)
+	//
+	if len(r.config.Kafka.AuthSecretName) > 0 || channel.Spec.ClusterRef != nil {
 		channel.Status.MarkConfigTrue()
 	} else {
 		channel.Status.MarkConfigFailed(event.KafkaSecretReconciled.String(), "No Kafka Secret For KafkaChannel")
@@ -79,7 +79,7 @@ func TestSetKafkaAdminClient(t *testing.T) {
 	reconciler := &Reconciler{config: &commonconfig.EventingKafkaConfig{}, adminClient: mockAdminClient1}
 
 	// Perform The Test
-	resultErr := reconciler.SetKafkaAdminClient(context.TODO())
+	resultErr := reconciler.SetKafkaAdminClient(context.TODO(), &kafkaCluster{})
 
 	// Verify Results
 	assert.Nil(t, resultErr)
@@ -105,7 +105,7 @@ func TestSetKafkaAdminClientError(t *testing.T) {
 	reconciler := &Reconciler{config: &commonconfig.EventingKafkaConfig{}, adminClient: mockAdminClient1}
 
 	// Perform The Test
-	resultErr := reconciler.SetKafkaAdminClient(context.TODO())
+	resultErr := reconciler.SetKafkaAdminClient(context.TODO(), &kafkaCluster{})
 
 	// Verify Results
 	assert.NotNil(t, resultErr)
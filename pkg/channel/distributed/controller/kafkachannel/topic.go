@@ -32,6 +32,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/event"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/controller/util"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
 )
 
 // reconcileKafkaTopic Reconciles The Kafka Topic Associated With The Specified Channel
@@ -55,7 +56,7 @@ func (r *Reconciler) reconcileKafkaTopic(ctx context.Context, channel *kafkav1be
 	retentionMillis := retentionDuration.Milliseconds()
 
 	// Create The Topic (Handles Case Where Already Exists)
-	err = r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis)
+	err = r.createTopic(ctx, topicName, numPartitions, replicationFactor, retentionMillis, channel.Spec.TopicConfig)
 
 	// Log Results & Return Status
 	if err != nil {
@@ -78,6 +79,23 @@ func (r *Reconciler) finalizeKafkaTopic(ctx context.Context, channel *kafkav1bet
 	// Get Channel Specific Logger (Provided Via Context) & Add Topic Name
 	logger := logging.FromContext(ctx).Desugar().With(zap.String("TopicName", topicName))
 
+	// Delete The Retry Topics Of The Subscribers (If Enabled, One Per Retry Of Their DeliverySpec)
+	if r.config.Channel.Dispatcher.RetryTopics {
+		for _, subscriber := range channel.Spec.Subscribers {
+			retryMax := 0
+			if subscriber.Delivery != nil && subscriber.Delivery.Retry != nil {
+				retryMax = int(*subscriber.Delivery.Retry)
+			}
+			for _, retryTopicName := range retry.TopicNames(topicName, subscriber.UID, retryMax) {
+				err := r.deleteTopic(ctx, retryTopicName)
+				if err != nil {
+					logger.Error("Failed To Finalize Kafka Retry Topic", zap.String("RetryTopicName", retryTopicName), zap.Error(err))
+					return err
+				}
+			}
+		}
+	}
+
 	// Delete The Kafka Topic & Handle Error Response
 	err := r.deleteTopic(ctx, topicName)
 	if err != nil {
@@ -90,20 +108,24 @@ func (r *Reconciler) finalizeKafkaTopic(ctx context.Context, channel *kafkav1bet
 }
 
 // createTopic Creates The Specified Kafka Topic
-func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitions int32, replicationFactor int16, retentionMillis int64) error {
+func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitions int32, replicationFactor int16, retentionMillis int64, topicConfig map[string]string) error {
 
 	// Get The Logger From The Context
 	logger := logging.FromContext(ctx)
 
-	// Create The TopicDefinition
+	// Create The TopicDefinition (Including The Validated Topic Config Overrides Of The Channel)
 	retentionMillisString := strconv.FormatInt(retentionMillis, 10)
+	configEntries := make(map[string]*string, len(topicConfig)+1)
+	for name, value := range topicConfig {
+		value := value
+		configEntries[name] = &value
+	}
+	configEntries[commonconstants.KafkaTopicConfigRetentionMs] = &retentionMillisString
 	topicDetail := &sarama.TopicDetail{
 		NumPartitions:     partitions,
 		ReplicationFactor: replicationFactor,
 		ReplicaAssignment: nil, // Currently Not Assigning Partitions To Replicas
-		ConfigEntries: map[string]*string{
-			commonconstants.KafkaTopicConfigRetentionMs: &retentionMillisString,
-		},
+		ConfigEntries:     configEntries,
 	}
 
 	// Attempt To Create The Topic & Process TopicError Results (Including Success ;)
@@ -116,7 +138,7 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 			return nil
 		case sarama.ErrTopicAlreadyExists:
 			logger.Info("Kafka Topic Already Exists - No Creation Required")
-			return nil
+			return r.updateTopic(ctx, topicName, partitions, topicDetail.ConfigEntries)
 		default:
 			logger.Error("Failed To Create Topic")
 			return err
@@ -127,6 +149,45 @@ func (r *Reconciler) createTopic(ctx context.Context, topicName string, partitio
 	}
 }
 
+// updateTopic Updates The Partitions & Config Of The Specified Existing Kafka Topic
+func (r *Reconciler) updateTopic(ctx context.Context, topicName string, partitions int32, configEntries map[string]*string) error {
+
+	// Get The Logger From The Context
+	logger := logging.FromContext(ctx)
+
+	// Increase The Number Of Partitions If Necessary (Decreasing Is Rejected By The Webhook)
+	err := r.adminClient.CreatePartitions(ctx, topicName, partitions)
+	if err != nil {
+		logger := logger.With(zap.Int16("KError", int16(err.Err)), zap.Int32("Partitions", partitions))
+		switch err.Err {
+		case sarama.ErrNoError:
+			logger.Debug("Successfully Reconciled Kafka Topic Partitions")
+		case sarama.ErrUnsupportedVersion:
+			logger.Warn("AdminClient Does Not Support Increasing Kafka Topic Partitions - Skipping")
+		default:
+			logger.Error("Failed To Create Topic Partitions")
+			return err
+		}
+	}
+
+	// Alter The Topic Config (Retention & Overrides) If It Has Changed Or Drifted
+	err = r.adminClient.AlterTopicConfig(ctx, topicName, configEntries)
+	if err != nil {
+		logger := logger.With(zap.Int16("KError", int16(err.Err)))
+		switch err.Err {
+		case sarama.ErrNoError:
+			logger.Debug("Successfully Reconciled Kafka Topic Config")
+		case sarama.ErrUnsupportedVersion:
+			logger.Warn("AdminClient Does Not Support Altering Kafka Topic Config - Skipping")
+		default:
+			logger.Error("Failed To Alter Topic Config")
+			return err
+		}
+	}
+
+	return nil
+}
+
 // deleteTopic Deletes The Specified Kafka Topic
 func (r *Reconciler) deleteTopic(ctx context.Context, topicName string) error {
 
@@ -22,14 +22,18 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/controller"
 
 	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
 )
 
 // Define The Topic TestCase Type
@@ -40,16 +44,17 @@ type TopicTestCase struct {
 	MockErrorCode   sarama.KError
 	WantError       string
 	WantCreate      bool
+	WantUpdate      bool
 	WantDelete      bool
+
+	MockUpdateErrorCode sarama.KError
 }
 
-//
 // Test The Kafka Topic Reconciliation
 //
 // Ideally the Knative Eventing test runner implementation would have provided a hook for additional
 // channel-type-specific (ie Kafka, NATS, etc.) validation, but unfortunately it is solely focused
 // on the K8S objects existing/not.  Therefore, we're left to test the actual Topic handling separately.
-//
 func TestReconcileTopic(t *testing.T) {
 
 	// Define & Initialize The TopicTestCases
@@ -107,6 +112,102 @@ func TestReconcileTopic(t *testing.T) {
 				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString},
 			},
 			MockErrorCode: sarama.ErrTopicAlreadyExists,
+			WantUpdate:    true,
+		},
+		{
+			Name: "Create New Topic With Topic Config",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+				controllertesting.WithTopicConfig,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries: map[string]*string{
+					commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString,
+					"cleanup.policy":   &controllertesting.CleanupPolicy,
+					"compression.type": &controllertesting.CompressionType,
+				},
+			},
+		},
+		{
+			Name: "Update Preexisting Topic Config",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+				controllertesting.WithTopicConfig,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries: map[string]*string{
+					commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString,
+					"cleanup.policy":   &controllertesting.CleanupPolicy,
+					"compression.type": &controllertesting.CompressionType,
+				},
+			},
+			MockErrorCode: sarama.ErrTopicAlreadyExists,
+			WantUpdate:    true,
+		},
+		{
+			Name: "Update Preexisting Topic Not Supported",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString},
+			},
+			MockErrorCode:       sarama.ErrTopicAlreadyExists,
+			WantUpdate:          true,
+			MockUpdateErrorCode: sarama.ErrUnsupportedVersion,
+		},
+		{
+			Name: "Error Updating Preexisting Topic",
+			Channel: controllertesting.NewKafkaChannel(
+				controllertesting.WithFinalizer,
+				controllertesting.WithAddress,
+				controllertesting.WithInitializedConditions,
+				controllertesting.WithKafkaChannelServiceReady,
+				controllertesting.WithReceiverServiceReady,
+				controllertesting.WithReceiverDeploymentReady,
+				controllertesting.WithDispatcherDeploymentReady,
+			),
+			WantCreate: true,
+			WantDelete: false,
+			WantTopicDetail: &sarama.TopicDetail{
+				NumPartitions:     controllertesting.NumPartitions,
+				ReplicationFactor: controllertesting.ReplicationFactor,
+				ConfigEntries:     map[string]*string{commonconstants.KafkaTopicConfigRetentionMs: &controllertesting.RetentionMillisString},
+			},
+			MockErrorCode:       sarama.ErrTopicAlreadyExists,
+			WantUpdate:          true,
+			MockUpdateErrorCode: sarama.ErrInvalidPartitions,
+			WantError:           sarama.ErrInvalidPartitions.Error() + " - " + controllertesting.ErrorString,
 		},
 		{
 			Name: "Error Creating Topic",
@@ -208,6 +309,9 @@ func topicTestCaseFactory(tc TopicTestCase) func(t *testing.T) {
 			if !mockAdminClient.CreateTopicsCalled() {
 				t.Errorf("expected CreateTopics() called to be %t", tc.WantCreate)
 			}
+			if mockAdminClient.CreatePartitionsCalled() != tc.WantUpdate {
+				t.Errorf("expected CreatePartitions() called to be %t", tc.WantUpdate)
+			}
 		}
 
 		// Perform The Test (Delete) - Called By Knative FinalizeKind() Directly
@@ -260,6 +364,39 @@ func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controlle
 			return topicError
 		},
 
+		// Mock CreatePartitions Behavior - Validate Parameters & Return MockUpdateError
+		MockCreatePartitionsFunc: func(ctx context.Context, topicName string, count int32) *sarama.TopicError {
+			if !tc.WantUpdate {
+				t.Error("Unexpected CreatePartitions() Call")
+			}
+			if topicName != controllertesting.TopicName {
+				t.Errorf("unexpected topic name '%s'", topicName)
+			}
+			if count != tc.WantTopicDetail.NumPartitions {
+				t.Errorf("unexpected partition count %d", count)
+			}
+			errMsg := controllertesting.SuccessString
+			if tc.MockUpdateErrorCode != sarama.ErrNoError {
+				errMsg = controllertesting.ErrorString
+			}
+			return &sarama.TopicError{Err: tc.MockUpdateErrorCode, ErrMsg: &errMsg}
+		},
+
+		// Mock AlterTopicConfig Behavior - Validate Parameters & Return MockUpdateError
+		MockAlterTopicConfigFunc: func(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+			if !tc.WantUpdate {
+				t.Error("Unexpected AlterTopicConfig() Call")
+			}
+			if diff := cmp.Diff(tc.WantTopicDetail.ConfigEntries, configEntries); diff != "" {
+				t.Errorf("expected ConfigEntries: %+v", diff)
+			}
+			errMsg := controllertesting.SuccessString
+			if tc.MockUpdateErrorCode != sarama.ErrNoError {
+				errMsg = controllertesting.ErrorString
+			}
+			return &sarama.TopicError{Err: tc.MockUpdateErrorCode, ErrMsg: &errMsg}
+		},
+
 		// Mock DeleteTopic Behavior - Validate Parameters & Return MockError
 		MockDeleteTopicFunc: func(ctx context.Context, topicName string) *sarama.TopicError {
 			if !tc.WantDelete {
@@ -283,3 +420,38 @@ func createMockAdminClientForTestCase(t *testing.T, tc TopicTestCase) *controlle
 		},
 	}
 }
+
+// Test The Finalization Of The Retry Topics Of The Subscribers
+func TestFinalizeKafkaTopicRetryTopics(t *testing.T) {
+
+	// Create A KafkaChannel With Two Subscribers (Retrying Twice & Not Retrying)
+	retryCount := int32(2)
+	channel := controllertesting.NewKafkaChannel()
+	channel.Spec.Subscribers = []eventingduck.SubscriberSpec{{UID: "uid-1", Delivery: &eventingduck.DeliverySpec{Retry: &retryCount}}, {UID: "uid-2"}}
+
+	// Track The Deleted Topics
+	var deletedTopics []string
+	mockAdminClient := &controllertesting.MockAdminClient{
+		MockDeleteTopicFunc: func(ctx context.Context, topicName string) *sarama.TopicError {
+			deletedTopics = append(deletedTopics, topicName)
+			return nil
+		},
+	}
+
+	// Initialize The Reconciler With The Retry Topics Enabled
+	r := &Reconciler{
+		adminClient: mockAdminClient,
+		config: controllertesting.NewConfig(func(kafkaConfig *commonconfig.EventingKafkaConfig) {
+			kafkaConfig.Channel.Dispatcher.RetryTopics = true
+		}),
+	}
+
+	// Perform The Test & Verify The Retry Topics Were Deleted Along With The Topic
+	err := r.finalizeKafkaTopic(context.TODO(), channel)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		retry.TopicName(controllertesting.TopicName, "uid-1", 1),
+		retry.TopicName(controllertesting.TopicName, "uid-1", 2),
+		controllertesting.TopicName,
+	}, deletedTopics)
+}
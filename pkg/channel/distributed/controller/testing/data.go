@@ -192,7 +192,11 @@ Producer:
 var (
 	RetentionDuration     = 3 * 24 * time.Hour // Match RetentionDurationISO8601 Value Above !
 	RetentionMillisString = strconv.FormatInt(RetentionDuration.Milliseconds(), 10)
-	DeletionTimestamp     = metav1.Now()
+
+	// Topic Config Overrides - Match WithTopicConfig() Option Below !
+	CleanupPolicy     = "compact"
+	CompressionType   = "zstd"
+	DeletionTimestamp = metav1.Now()
 
 	DispatcherDeploymentAnnotations = map[string]string{"DDAKey1": "DDAValue1", "DDAKey2": "DDAValue2", "DDAKey3": "DDAValue3"}
 	DispatcherDeploymentLabels      = map[string]string{"DDLKey1": "DDLValue1", "DDLKey2": "DDLValue2", "DDLKey3": "DDLValue3"}
@@ -597,6 +601,14 @@ func WithEmptySpec(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.Spec = kafkav1beta1.KafkaChannelSpec{}
 }
 
+// WithTopicConfig Sets The KafkaChannel's Topic Config Overrides
+func WithTopicConfig(kafkachannel *kafkav1beta1.KafkaChannel) {
+	kafkachannel.Spec.TopicConfig = map[string]string{
+		"cleanup.policy":   CleanupPolicy,
+		"compression.type": CompressionType,
+	}
+}
+
 // WithDeletionTimestamp Sets The KafkaChannel's DeletionTimestamp To Current Time
 func WithDeletionTimestamp(kafkachannel *kafkav1beta1.KafkaChannel) {
 	kafkachannel.ObjectMeta.SetDeletionTimestamp(&DeletionTimestamp)
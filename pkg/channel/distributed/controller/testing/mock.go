@@ -32,12 +32,16 @@ var _ types.AdminClientInterface = &MockAdminClient{}
 
 // Mock Kafka AdminClient Implementation
 type MockAdminClient struct {
-	closeCalled         bool
-	createTopicsCalled  bool
-	deleteTopicsCalled  bool
-	MockCreateTopicFunc func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
-	MockDeleteTopicFunc func(context.Context, string) *sarama.TopicError
-	MockCloseFunc       func() error
+	closeCalled              bool
+	createTopicsCalled       bool
+	deleteTopicsCalled       bool
+	createPartitionsCalled   bool
+	alterTopicConfigCalled   bool
+	MockCreateTopicFunc      func(context.Context, string, *sarama.TopicDetail) *sarama.TopicError
+	MockDeleteTopicFunc      func(context.Context, string) *sarama.TopicError
+	MockCreatePartitionsFunc func(context.Context, string, int32) *sarama.TopicError
+	MockAlterTopicConfigFunc func(context.Context, string, map[string]*string) *sarama.TopicError
+	MockCloseFunc            func() error
 }
 
 // Mock Kafka AdminClient CreateTopic() Function - Calls Custom CreateTopic() If Specified, Otherwise Returns Success
@@ -70,6 +74,36 @@ func (m *MockAdminClient) DeleteTopicsCalled() bool {
 	return m.deleteTopicsCalled
 }
 
+// Mock Kafka AdminClient CreatePartitions() Function - Calls Custom CreatePartitions() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) CreatePartitions(ctx context.Context, topicName string, count int32) *sarama.TopicError {
+	m.createPartitionsCalled = true
+	if m.MockCreatePartitionsFunc != nil {
+		return m.MockCreatePartitionsFunc(ctx, topicName, count)
+	}
+	errMsg := "mock CreatePartitions() success"
+	return &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Check On Calls To CreatePartitions()
+func (m *MockAdminClient) CreatePartitionsCalled() bool {
+	return m.createPartitionsCalled
+}
+
+// Mock Kafka AdminClient AlterTopicConfig() Function - Calls Custom AlterTopicConfig() If Specified, Otherwise Returns Success
+func (m *MockAdminClient) AlterTopicConfig(ctx context.Context, topicName string, configEntries map[string]*string) *sarama.TopicError {
+	m.alterTopicConfigCalled = true
+	if m.MockAlterTopicConfigFunc != nil {
+		return m.MockAlterTopicConfigFunc(ctx, topicName, configEntries)
+	}
+	errMsg := "mock AlterTopicConfig() success"
+	return &sarama.TopicError{Err: sarama.ErrNoError, ErrMsg: &errMsg}
+}
+
+// Check On Calls To AlterTopicConfig()
+func (m *MockAdminClient) AlterTopicConfigCalled() bool {
+	return m.alterTopicConfigCalled
+}
+
 // Mock Kafka AdminClient Close Function - NoOp
 func (m *MockAdminClient) Close() error {
 	m.closeCalled = true
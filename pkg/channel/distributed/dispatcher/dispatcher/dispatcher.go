@@ -35,6 +35,7 @@ import (
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
 	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
 	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 )
 
@@ -48,6 +49,9 @@ type DispatcherConfig struct {
 	StatsReporter   metrics.StatsReporter
 	MetricsRegistry gometrics.Registry
 	SaramaConfig    *sarama.Config
+	Ordering        commonconsumer.DeliveryOrdering
+	MaxInFlight     int
+	RetryTopics     bool
 }
 
 // SubscriberWrapper Defines A Knative Eventing SubscriberSpec Wrapper Enhanced With Sarama ConsumerGroup ID
@@ -77,6 +81,7 @@ type DispatcherImpl struct {
 	MetricsStopChan    chan struct{}
 	MetricsStoppedChan chan struct{}
 	consumerMgr        commonconsumer.KafkaConsumerGroupManager
+	retryProducer      *retryProducer
 }
 
 // Verify The DispatcherImpl Implements The Dispatcher Interface
@@ -97,6 +102,11 @@ func NewDispatcher(dispatcherConfig DispatcherConfig, controlServer controlproto
 		consumerMgr:        consumerGroupManager,
 	}
 
+	// Create The Producer Of The Retry Topics If Enabled (Connecting On First Retry)
+	if dispatcherConfig.RetryTopics {
+		dispatcher.retryProducer = newRetryProducer(dispatcherConfig.Logger, dispatcherConfig.Brokers, dispatcherConfig.SaramaConfig)
+	}
+
 	// Start Observing Metrics
 	dispatcher.ObserveMetrics(dispatcherconstants.MetricsInterval)
 
@@ -120,6 +130,11 @@ func (d *DispatcherImpl) Shutdown() {
 
 	// Close the Consumer Group Manager notification channels
 	d.consumerMgr.ClearNotifications()
+
+	// Close The Producer Of The Retry Topics
+	if d.retryProducer != nil {
+		d.retryProducer.close()
+	}
 }
 
 // UpdateSubscriptions manages the Dispatcher's Subscriptions to align with new state
@@ -149,9 +164,20 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 			// Create A ConsumerGroup Logger
 			logger := d.Logger.With(zap.String("GroupId", groupId))
 
-			// Create/Start A New ConsumerGroup With Custom Handler
+			// Create/Start A New ConsumerGroup With Custom Handler (Also Consuming The Retry Topics If Enabled)
 			handler := NewHandler(logger, groupId, &subscriberSpec)
-			err := d.consumerMgr.StartConsumerGroup(ctx, groupId, []string{d.Topic}, handler, channelRef)
+			topics := []string{d.Topic}
+			var err error
+			if d.retryProducer != nil {
+				retryTopics := retry.TopicNames(d.Topic, subscriberSpec.UID, handler.retryConfig.RetryMax)
+				handler.EnableRetryTopics(retryTopics, d.retryProducer)
+				topics = append(topics, retryTopics...)
+				err = d.createRetryTopics(retryTopics)
+			}
+			if err == nil {
+				err = d.consumerMgr.StartConsumerGroup(ctx, groupId, topics, handler, channelRef,
+					commonconsumer.WithDeliveryOrdering(d.Ordering, d.MaxInFlight))
+			}
 			if err != nil {
 
 				// Log & Return Failure
@@ -196,6 +222,11 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 		subscription, ok := subscriptions[subscriber.UID]
 		if !ok || subscription.Error != nil {
 			d.closeConsumerGroup(subscriber)
+
+			// Delete The Retry Topics Of Removed Subscriptions
+			if !ok && d.retryProducer != nil {
+				d.deleteRetryTopics(subscriber)
+			}
 		}
 	}
 
@@ -270,6 +301,12 @@ func (d *DispatcherImpl) SecretChanged(ctx context.Context, secret *corev1.Secre
 	// Replace The Dispatcher's ConsumerGroupFactory With Updated Version Using New Config
 	// Note:  This will close and recreate all managed ConsumerGroups
 	reconfigureErr := d.consumerMgr.Reconfigure(d.DispatcherConfig.Brokers, d.DispatcherConfig.SaramaConfig)
+
+	// Recreate The Producer Of The Retry Topics With The New Config (On Next Retry)
+	if d.retryProducer != nil {
+		d.retryProducer.reconfigure(d.DispatcherConfig.Brokers, d.DispatcherConfig.SaramaConfig)
+	}
+
 	if reconfigureErr != nil {
 
 		// Remove All Failed Subscribers From List To Allow Recreation Next Reconcile Loop (Expects Caller To Requeue KafkaChannel!)
@@ -39,6 +39,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/common/consumer"
 	consumertesting "knative.dev/eventing-kafka/pkg/common/consumer/testing"
 	controltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
 	kafkatesting "knative.dev/eventing-kafka/pkg/common/kafka/testing"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
@@ -421,6 +422,81 @@ func TestUpdateSubscriptions(t *testing.T) {
 	}
 }
 
+// Test The UpdateSubscriptions() Functionality With The Retry Topics Enabled
+func TestUpdateSubscriptionsRetryTopics(t *testing.T) {
+
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	// Test Data
+	topic := "test-namespace.test-channel"
+	retryMax := int32(2)
+	retryTopics123 := retry.TopicNames(topic, uid123, 3)
+	retryTopics456 := retry.TopicNames(topic, uid456, int(retryMax))
+	config, err := commonclient.NewConfigBuilder().WithDefaults().FromYaml(clienttesting.DefaultSaramaConfigYaml).Build(ctx)
+	assert.Nil(t, err)
+
+	// Mock The Sarama ClusterAdmin (And Restore Post-Test)
+	var createdTopics, deletedTopics []string
+	mockClusterAdmin := &commontesting.MockClusterAdmin{
+		MockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+			return []*sarama.TopicMetadata{{Name: topic, Partitions: []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{1}}}}}, nil
+		},
+		MockCreateTopicFunc: func(topic string, _ *sarama.TopicDetail, _ bool) error {
+			createdTopics = append(createdTopics, topic)
+			return nil
+		},
+		MockListTopicsFunc: func() (map[string]sarama.TopicDetail, error) {
+			topics := map[string]sarama.TopicDetail{topic: {}}
+			for _, retryTopic := range append(retryTopics123, retryTopics456...) {
+				topics[retryTopic] = sarama.TopicDetail{}
+			}
+			return topics, nil
+		},
+		MockDeleteTopicFunc: func(topic string) error {
+			deletedTopics = append(deletedTopics, topic)
+			return nil
+		},
+	}
+	newClusterAdminWrapperPlaceholder := newClusterAdminWrapper
+	newClusterAdminWrapper = func(brokers []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
+		return mockClusterAdmin, nil
+	}
+	defer func() { newClusterAdminWrapper = newClusterAdminWrapperPlaceholder }()
+
+	// Create A New DispatcherImpl To Test, Subscribed By 123
+	mockManager := consumertesting.NewMockConsumerGroupManager()
+	dispatcherConfig := DispatcherConfig{
+		Logger:       logger.Desugar(),
+		Brokers:      []string{configtesting.DefaultKafkaBroker},
+		Topic:        topic,
+		SaramaConfig: config,
+		RetryTopics:  true,
+	}
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: dispatcherConfig,
+		subscribers:      map[types.UID]*SubscriberWrapper{uid123: createSubscriberWrapper(uid123)},
+		consumerMgr:      mockManager,
+		retryProducer:    newRetryProducer(logger.Desugar(), dispatcherConfig.Brokers, config),
+	}
+
+	errorSource := make(chan error)
+	defer close(errorSource)
+	mockManager.On("StartConsumerGroup", mock.Anything, "kafka."+id456, append([]string{topic}, retryTopics456...), mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockManager.On("Errors", "kafka."+id456).Return((<-chan error)(errorSource)).Maybe() // Asynchronous
+	mockManager.On("IsManaged", "kafka."+id123).Return(true)
+	mockManager.On("CloseConsumerGroup", "kafka."+id123).Return(nil)
+
+	// Perform The Test (Replace Subscription 123 With 456)
+	result := dispatcher.UpdateSubscriptions(ctx, types.NamespacedName{}, []eventingduck.SubscriberSpec{{UID: uid456, Delivery: &eventingduck.DeliverySpec{Retry: &retryMax}}})
+
+	// Verify The Retry Topics Of 456 Were Created And Consumed, And The Ones Of 123 Deleted
+	assert.Equal(t, 0, result.FailedCount())
+	assert.Equal(t, retryTopics456, createdTopics)
+	assert.ElementsMatch(t, retryTopics123, deletedTopics)
+	mockManager.AssertExpectations(t)
+}
+
 // Test The Dispatcher's SecretChanged Functionality
 func TestSecretChanged(t *testing.T) {
 
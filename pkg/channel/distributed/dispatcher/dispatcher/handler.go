@@ -21,6 +21,7 @@ import (
 	"errors"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/Shopify/sarama"
 	kafkasaramaprotocol "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
@@ -32,12 +33,14 @@ import (
 	"knative.dev/eventing/pkg/kncloudevents"
 
 	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
-// Verify The Handler Implements The Common KafkaConsumerHandler
+// Verify The Handler Implements The Common KafkaConsumerHandler (Delayed For The Retry Topics)
 var _ commonconsumer.KafkaConsumerHandler = &Handler{}
+var _ commonconsumer.KafkaDelayedConsumerHandler = &Handler{}
 
 // Handler Struct implementing the KafkaConsumerHandler Interface
 type Handler struct {
@@ -49,6 +52,8 @@ type Handler struct {
 	replyURL          *url.URL
 	deadLetterURL     *url.URL
 	retryConfig       kncloudevents.RetryConfig
+	retryTopics       []string
+	retryProducer     retry.Producer
 }
 
 // NewHandler creates a new Handler instance.
@@ -98,6 +103,13 @@ func NewHandler(logger *zap.Logger, groupId string, subscriber *eventingduck.Sub
 	return handler
 }
 
+// EnableRetryTopics configures the Handler to retry the failed messages through the specified retry topics
+// (one per attempt, produced with the specified Producer and also consumed by the Handler) instead of in-line.
+func (h *Handler) EnableRetryTopics(retryTopics []string, producer retry.Producer) {
+	h.retryTopics = retryTopics
+	h.retryProducer = producer
+}
+
 // Wrapper Function To Facilitate Testing With A Mock Knative MessageDispatcher
 var newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
 	return channel.NewMessageDispatcher(logger)
@@ -125,7 +137,8 @@ func (h *Handler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMe
 	// Convert ConsumerMessage.Headers Into HTTP Header Struct For Dispatching (Passing-Through of "Additional Headers")
 	// Using Sarama RecordHeaders instead of CloudEvent Message.Headers to support multi-value HTTP Headers without
 	// serialization.  Also, filtering CloudEvent "ce" headers which are already taken from the Message.
-	httpHeader := tracing.ConvertRecordHeadersToHttpHeader(tracing.FilterCeRecordHeaders(consumerMessage.Headers))
+	// The Retry Headers Are Also Filtered, As They Are Only Meaningful To The Retry Topic.
+	httpHeader := tracing.ConvertRecordHeadersToHttpHeader(tracing.FilterCeRecordHeaders(retry.FilterRecordHeaders(consumerMessage.Headers)))
 
 	// Convert The Sarama ConsumerMessage Into A CloudEvents Message
 	message := kafkasaramaprotocol.NewMessageFromConsumerMessage(consumerMessage)
@@ -138,8 +151,14 @@ func (h *Handler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMe
 	ctx, span := tracing.StartTraceFromMessage(h.Logger.Sugar(), ctx, message, "kafkachannel-"+consumerMessage.Topic)
 	defer span.End()
 
-	// Dispatch The Message With Configured Retries, DLQ, etc
-	info, err := h.MessageDispatcher.DispatchMessageWithRetries(ctx, message, httpHeader, h.destinationURL, h.replyURL, h.deadLetterURL, &h.retryConfig)
+	// Dispatch The Message With Configured Retries (In-Line Or Through The Retry Topic), DLQ, etc
+	var info *channel.DispatchExecutionInfo
+	var err error
+	if h.retryProducer != nil {
+		info, err = h.retryDispatcher().Dispatch(ctx, consumerMessage, message, httpHeader)
+	} else {
+		info, err = h.MessageDispatcher.DispatchMessageWithRetries(ctx, message, httpHeader, h.destinationURL, h.replyURL, h.deadLetterURL, &h.retryConfig)
+	}
 	h.Logger.Debug("Received Response", zap.Any("ExecutionInfo", executionInfoWrapper{info}))
 
 	//
@@ -154,6 +173,10 @@ func (h *Handler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMe
 	// for which the message might not have been given a fair chance and needs
 	// to be attempted again upon subsequent restart.
 	//
+	// With the retry topics enabled, a failed message is marked once its retry
+	// has been produced to the retry topic of the attempt, which then owns its
+	// remaining attempts (or once it has been sent to the DLQ).
+	//
 	// This is different from the Consolidated KafkaChannel implementation
 	// which only returns true if message was delivered successfully.
 	//
@@ -175,6 +198,28 @@ func (h *Handler) Handle(ctx context.Context, consumerMessage *sarama.ConsumerMe
 	return markMessage, nil
 }
 
+// NotBefore returns the time before which a message of the retry topics must not be dispatched
+// (the zero time for the messages of the channel topic, or when the retry topics are disabled)
+func (h *Handler) NotBefore(consumerMessage *sarama.ConsumerMessage) time.Time {
+	if h.retryProducer == nil {
+		return time.Time{}
+	}
+	return h.retryDispatcher().NotBefore(consumerMessage)
+}
+
+// retryDispatcher returns the retry.Dispatcher of the Handler's subscriber and retry topics
+func (h *Handler) retryDispatcher() *retry.Dispatcher {
+	return &retry.Dispatcher{
+		MessageDispatcher: h.MessageDispatcher,
+		Producer:          h.retryProducer,
+		Topics:            h.retryTopics,
+		Destination:       h.destinationURL,
+		Reply:             h.replyURL,
+		DeadLetter:        h.deadLetterURL,
+		RetryConfig:       h.retryConfig,
+	}
+}
+
 // SetReady is used by the "Prober" implementation for tracking ConsumerGroup
 // status which we are not using at the moment, and is believed to be
 // undergoing refactor / replacement in favor of using the control-protocol
@@ -37,6 +37,7 @@ import (
 	logtesting "knative.dev/pkg/logging/testing"
 
 	dispatchertesting "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/testing"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
 )
 
 // Test Data
@@ -414,3 +415,88 @@ func Test_executionInfoWrapper(t *testing.T) {
 		})
 	}
 }
+
+// retryMessageDispatcher Fails The Dispatches To The Subscriber With A Retryable Response Code
+type retryMessageDispatcher struct {
+	destinations []*url.URL
+	headers      []http.Header
+}
+
+func (m *retryMessageDispatcher) DispatchMessage(ctx context.Context, message binding.Message, headers http.Header, destination *url.URL, reply *url.URL, deadLetter *url.URL) (*channel.DispatchExecutionInfo, error) {
+	panic("implement me")
+}
+
+func (m *retryMessageDispatcher) DispatchMessageWithRetries(_ context.Context, _ binding.Message, headers http.Header, destination *url.URL, _ *url.URL, _ *url.URL, _ *kncloudevents.RetryConfig, _ ...binding.Transformer) (*channel.DispatchExecutionInfo, error) {
+	m.destinations = append(m.destinations, destination)
+	m.headers = append(m.headers, headers)
+	if destination.String() == testSubscriberURIString {
+		return &channel.DispatchExecutionInfo{ResponseCode: http.StatusServiceUnavailable}, fmt.Errorf("subscriber unavailable")
+	}
+	return &channel.DispatchExecutionInfo{ResponseCode: http.StatusAccepted}, nil
+}
+
+// retryProducerMock Records The Produced Retries
+type retryProducerMock struct {
+	messages []*sarama.ProducerMessage
+}
+
+func (p *retryProducerMock) SendMessage(_ context.Context, message *sarama.ProducerMessage) (int32, int64, error) {
+	p.messages = append(p.messages, message)
+	return 0, int64(len(p.messages)), nil
+}
+
+// Test The Handler's Handle() Functionality With The Retry Topics Enabled
+func TestHandleRetryTopics(t *testing.T) {
+
+	retryTopics := retry.TopicNames(testTopic, testSubscriberUID, int(testRetryCount))
+	deliverySpec := createDeliverySpec(testDeadLetterURI, true)
+
+	// Mock The newMessageDispatcherWrapper Function (And Restore Post-Test)
+	mockMessageDispatcher := &retryMessageDispatcher{}
+	newMessageDispatcherWrapperPlaceholder := newMessageDispatcherWrapper
+	newMessageDispatcherWrapper = func(logger *zap.Logger) channel.MessageDispatcher {
+		return mockMessageDispatcher
+	}
+	defer func() { newMessageDispatcherWrapper = newMessageDispatcherWrapperPlaceholder }()
+
+	// Create The Handler With The Retry Topics
+	producer := &retryProducerMock{}
+	handler := createTestHandler(t, testSubscriberURI, nil, &deliverySpec)
+	handler.EnableRetryTopics(retryTopics, producer)
+
+	// A Failed Message Of The Channel Topic Is Produced To The First Retry Topic And Marked
+	result, err := handler.Handle(context.TODO(), createConsumerMessage(t))
+	assert.Nil(t, err)
+	assert.True(t, result)
+	assert.Equal(t, []*url.URL{testSubscriberURI.URL()}, mockMessageDispatcher.destinations)
+	assert.Len(t, producer.messages, 1)
+	retryMessage := producer.messages[0]
+	assert.Equal(t, retryTopics[0], retryMessage.Topic)
+
+	// The Retry Is Due After The Backoff And Only Dispatched By The Retry Topics
+	retryConsumerMessage := createConsumerMessage(t)
+	retryConsumerMessage.Topic = retryTopics[0]
+	retryConsumerMessage.Headers = make([]*sarama.RecordHeader, 0, len(retryMessage.Headers))
+	for i := range retryMessage.Headers {
+		retryConsumerMessage.Headers = append(retryConsumerMessage.Headers, &retryMessage.Headers[i])
+	}
+	assert.True(t, handler.NotBefore(retryConsumerMessage).After(time.Now()))
+	assert.True(t, handler.NotBefore(createConsumerMessage(t)).IsZero())
+
+	// The Last Retry Is Sent To The DeadLetterSink, Without The Retry Headers
+	retryConsumerMessage.Topic = retryTopics[testRetryCount-1]
+	for i, header := range retryConsumerMessage.Headers {
+		if string(header.Key) == retry.AttemptHeader {
+			retryConsumerMessage.Headers[i] = &sarama.RecordHeader{Key: header.Key, Value: []byte(fmt.Sprint(testRetryCount))}
+		}
+	}
+	result, err = handler.Handle(context.TODO(), retryConsumerMessage)
+	assert.Nil(t, err)
+	assert.True(t, result)
+	assert.Len(t, producer.messages, 1)
+	assert.Equal(t, []*url.URL{testSubscriberURI.URL(), testSubscriberURI.URL(), testDeadLetterURI.URL()}, mockMessageDispatcher.destinations)
+	for _, headers := range mockMessageDispatcher.headers {
+		assert.Empty(t, headers.Get(retry.AttemptHeader))
+		assert.Empty(t, headers.Get(retry.DueHeader))
+	}
+}
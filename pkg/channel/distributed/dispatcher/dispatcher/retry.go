@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer"
+	commonproducer "knative.dev/eventing-kafka/pkg/common/kafka/producer"
+	"knative.dev/eventing-kafka/pkg/common/kafka/retry"
+)
+
+// Verify The retryProducer Implements The retry.Producer Interface
+var _ retry.Producer = &retryProducer{}
+
+// retryProducer produces the retries of all the subscriptions of the Dispatcher.  The Kafka producer is
+// created on first use, and recreated with the new Sarama config when the Kafka Secret changes.
+type retryProducer struct {
+	logger        *zap.Logger
+	lock          sync.RWMutex
+	brokers       []string
+	config        *sarama.Config
+	kafkaProducer *commonproducer.AsyncProducer
+}
+
+// newRetryProducer Is The retryProducer Constructor
+func newRetryProducer(logger *zap.Logger, brokers []string, config *sarama.Config) *retryProducer {
+	return &retryProducer{logger: logger, brokers: brokers, config: producerConfig(config)}
+}
+
+// SendMessage sends the retry message with the current Kafka producer (creating it if necessary)
+func (p *retryProducer) SendMessage(ctx context.Context, message *sarama.ProducerMessage) (int32, int64, error) {
+	for {
+		// Hold The Read Lock While Sending So The Kafka Producer Isn't Closed Under The Message
+		p.lock.RLock()
+		if p.kafkaProducer != nil {
+			defer p.lock.RUnlock()
+			return p.kafkaProducer.SendMessage(ctx, message)
+		}
+		p.lock.RUnlock()
+
+		if err := p.createKafkaProducer(); err != nil {
+			return -1, -1, err
+		}
+	}
+}
+
+// createKafkaProducer creates the Kafka producer, unless another retry already did
+func (p *retryProducer) createKafkaProducer() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.kafkaProducer == nil {
+		saramaProducer, err := producer.CreateAsyncProducer(p.brokers, p.config)
+		if err != nil {
+			p.logger.Error("Failed To Create Retry Kafka AsyncProducer", zap.Error(err))
+			return err
+		}
+		p.kafkaProducer = commonproducer.NewAsyncProducer(saramaProducer)
+	}
+	return nil
+}
+
+// reconfigure closes the current Kafka producer, so that the next retry creates one with the new config
+func (p *retryProducer) reconfigure(brokers []string, config *sarama.Config) {
+	p.lock.Lock()
+	kafkaProducer := p.kafkaProducer
+	p.kafkaProducer = nil
+	p.brokers = brokers
+	p.config = producerConfig(config)
+	p.lock.Unlock()
+
+	if kafkaProducer != nil {
+		if err := kafkaProducer.Close(); err != nil {
+			p.logger.Error("Failed To Close Retry Kafka AsyncProducer", zap.Error(err))
+		}
+	}
+}
+
+// close closes the current Kafka producer, if any
+func (p *retryProducer) close() {
+	p.lock.Lock()
+	kafkaProducer := p.kafkaProducer
+	p.kafkaProducer = nil
+	p.lock.Unlock()
+
+	if kafkaProducer != nil {
+		if err := kafkaProducer.Close(); err != nil {
+			p.logger.Error("Failed To Close Retry Kafka AsyncProducer", zap.Error(err))
+		}
+	}
+}
+
+// producerConfig returns a copy of the Dispatcher's Sarama config returning the results of the messages produced
+func producerConfig(config *sarama.Config) *sarama.Config {
+	configCopy := *config
+	commonproducer.ConfigureBatching(&configCopy, 0, 0)
+	return &configCopy
+}
+
+// Wrapper Function To Facilitate Testing With A Mock Sarama ClusterAdmin
+var newClusterAdminWrapper = func(brokers []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
+	return sarama.NewClusterAdmin(brokers, config)
+}
+
+// createRetryTopics creates the retry topics of a subscriber that don't exist yet
+func (d *DispatcherImpl) createRetryTopics(retryTopics []string) error {
+	if len(retryTopics) == 0 {
+		return nil
+	}
+	admin, err := newClusterAdminWrapper(d.Brokers, d.SaramaConfig)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = admin.Close() }()
+	return retry.CreateTopics(admin, d.Topic, retryTopics)
+}
+
+// deleteRetryTopics deletes the retry topics of a removed subscriber (failures are only logged, as the topics
+// don't affect the other subscribers and are also deleted along with the KafkaChannel)
+func (d *DispatcherImpl) deleteRetryTopics(subscriber *SubscriberWrapper) {
+	logger := d.Logger.With(zap.String("SubscriberUID", string(subscriber.UID)))
+
+	admin, err := newClusterAdminWrapper(d.Brokers, d.SaramaConfig)
+	if err != nil {
+		logger.Error("Failed To Create ClusterAdmin For Deleting Retry Topics", zap.Error(err))
+		return
+	}
+	defer func() { _ = admin.Close() }()
+
+	if err = retry.DeleteTopics(admin, d.Topic, subscriber.UID); err != nil {
+		logger.Error("Failed To Delete Retry Topics", zap.Error(err))
+	} else {
+		logger.Info("Successfully Deleted Retry Topics")
+	}
+}
@@ -53,6 +53,10 @@ type Environment struct {
 	// Kafka Authorization
 	KafkaSecretName      string // Required
 	KafkaSecretNamespace string // Required
+
+	// KafkaCluster Of The Channel (Instead Of The ConfigMap's Kafka Cluster)
+	KafkaClusterName      string // Optional
+	KafkaClusterNamespace string // Optional
 }
 
 // Get The Environment
@@ -141,6 +145,10 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 	}
 	environment.ResyncPeriod = time.Duration(resyncMinutes) * time.Minute
 
+	// Get The Optional KafkaCluster Config Values
+	environment.KafkaClusterName = env.GetOptionalConfigValue(logger, env.KafkaClusterNameEnvVarKey, "")
+	environment.KafkaClusterNamespace = env.GetOptionalConfigValue(logger, env.KafkaClusterNamespaceEnvVarKey, "")
+
 	// Log The Dispatcher Configuration Loaded From Environment Variables
 	logger.Info("Environment Variables", zap.Any("Environment", environment))
 
@@ -45,6 +45,8 @@ const (
 	kafkaSecretNamespace = "TestKafkaPassword"
 	podName              = "TestPod"
 	containerName        = "TestContainer"
+	kafkaClusterName     = "TestKafkaCluster"
+	kafkaClusterNs       = "TestKafkaClusterNamespace"
 )
 
 // Define The TestCase Struct
@@ -62,6 +64,8 @@ type TestCase struct {
 	kafkaSecretNamespace string
 	podName              string
 	containerName        string
+	kafkaClusterName     string
+	kafkaClusterNs       string
 	expectedError        error
 	expectedResyncPeriod string
 }
@@ -152,6 +156,11 @@ func TestGetEnvironment(t *testing.T) {
 	testCase.expectedResyncPeriod = "600" // 10 hours - default value
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - KafkaCluster")
+	testCase.kafkaClusterName = kafkaClusterName
+	testCase.kafkaClusterNs = kafkaClusterNs
+	testCases = append(testCases, testCase)
+
 	// Loop Over All The TestCases
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -170,6 +179,8 @@ func TestGetEnvironment(t *testing.T) {
 			assertSetenv(t, commonenv.KafkaSecretNamespaceEnvVarKey, testCase.kafkaSecretNamespace)
 			assertSetenv(t, commonenv.PodNameEnvVarKey, testCase.podName)
 			assertSetenv(t, commonenv.ContainerNameEnvVarKey, testCase.containerName)
+			assertSetenvNonempty(t, commonenv.KafkaClusterNameEnvVarKey, testCase.kafkaClusterName)
+			assertSetenvNonempty(t, commonenv.KafkaClusterNamespaceEnvVarKey, testCase.kafkaClusterNs)
 
 			// Perform The Test
 			environment, err := GetEnvironment(logger)
@@ -190,6 +201,8 @@ func TestGetEnvironment(t *testing.T) {
 				assert.Equal(t, testCase.podName, environment.PodName)
 				assert.Equal(t, testCase.containerName, environment.ContainerName)
 				assert.Equal(t, testCase.expectedResyncPeriod, strconv.Itoa(int(environment.ResyncPeriod/time.Minute)))
+				assert.Equal(t, testCase.kafkaClusterName, environment.KafkaClusterName)
+				assert.Equal(t, testCase.kafkaClusterNs, environment.KafkaClusterNamespace)
 
 			} else {
 				assert.Equal(t, testCase.expectedError, err)
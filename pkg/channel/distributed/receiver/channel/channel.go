@@ -27,22 +27,26 @@ import (
 	eventingChannel "knative.dev/eventing/pkg/channel"
 	"knative.dev/pkg/logging"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	messaging "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	distributedmessaging "knative.dev/eventing-kafka/pkg/channel/distributed/apis/messaging"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	kafkainformers "knative.dev/eventing-kafka/pkg/client/informers/externalversions"
+	kafkaclusterlisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 )
 
 // Package Variables
 var (
 	logger             *zap.Logger
 	kafkaChannelLister kafkalisters.KafkaChannelLister
+	kafkaClusterLister kafkaclusterlisters.KafkaClusterLister
 	stopChan           chan struct{}
 )
 
-// InitializeKafkaChannelLister initializes the KafkaChannel Lister singleton.
+// InitializeKafkaChannelLister initializes the KafkaChannel (and KafkaCluster) Lister singletons.
 func InitializeKafkaChannelLister(ctx context.Context, client kafkaclientset.Interface, healthServer *health.Server, resyncDuration time.Duration) error {
 	// Get The Logger From The Provided Context
 	logger = logging.FromContext(ctx).Desugar()
@@ -57,10 +61,15 @@ func InitializeKafkaChannelLister(ctx context.Context, client kafkaclientset.Int
 	// Get A KafkaChannel Informer From The SharedInformerFactory - Start The Informer & Wait For It
 	kafkaChannelInformer := sharedInformerFactory.Messaging().V1beta1().KafkaChannels()
 	go kafkaChannelInformer.Informer().Run(stopChan)
+
+	// Get A KafkaCluster Informer From The SharedInformerFactory (For The KafkaChannels Referencing One) - Start The Informer & Wait For Both
+	kafkaClusterInformer := sharedInformerFactory.Kafka().V1alpha1().KafkaClusters()
+	go kafkaClusterInformer.Informer().Run(stopChan)
 	sharedInformerFactory.WaitForCacheSync(stopChan)
 
-	// Get A KafkaChannel Lister From The Informer
+	// Get The KafkaChannel & KafkaCluster Listers From The Informers
 	kafkaChannelLister = kafkaChannelInformer.Lister()
+	kafkaClusterLister = kafkaClusterInformer.Lister()
 
 	// Return Success
 	logger.Info("Successfully Initialized KafkaChannel Lister")
@@ -111,6 +120,18 @@ func ValidateKafkaChannel(channelReference eventingChannel.ChannelReference) err
 	return nil
 }
 
+// GetKafkaCluster returns the KafkaCluster referenced by the specified KafkaChannel, or nil if it uses the default
+// Kafka cluster.  The referenced KafkaCluster must be in the namespace of the channel or in the system namespace.
+func GetKafkaCluster(channelReference eventingChannel.ChannelReference, systemNamespace string) (*kafkav1alpha1.KafkaCluster, error) {
+	kafkaChannel, err := kafkaChannelLister.KafkaChannels(channelReference.Namespace).Get(channelReference.Name)
+	if err != nil {
+		return nil, err
+	} else if kafkaChannel.Spec.ClusterRef == nil {
+		return nil, nil
+	}
+	return kafkacluster.GetForChannel(kafkaClusterLister, kafkaChannel, systemNamespace)
+}
+
 // Close The Channel Lister (Stop Processing)
 func Close() {
 	if stopChan != nil {
@@ -23,9 +23,16 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	receivertesting "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/testing"
 	fakeclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	kafkaclusterlisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/messaging/v1beta1"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -44,6 +51,7 @@ func TestInitializeKafkaChannelLister(t *testing.T) {
 	// Verify The Results
 	assert.Nil(t, err)
 	assert.NotNil(t, kafkaChannelLister)
+	assert.NotNil(t, kafkaClusterLister)
 	assert.Equal(t, true, healthServer.ChannelReady())
 }
 
@@ -82,6 +90,43 @@ func performValidateKafkaChannelTest(t *testing.T, channelName string, channelNa
 	assert.Equal(t, err, validationError != nil)
 }
 
+// Test The GetKafkaCluster() Functionality
+func TestGetKafkaCluster(t *testing.T) {
+
+	// Test Data
+	systemNamespace := "knative-eventing"
+	channelIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	clusterIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, channel := range []*kafkav1beta1.KafkaChannel{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "local"}, Spec: kafkav1beta1.KafkaChannelSpec{ClusterRef: &bindingsv1beta1.KafkaClusterReference{Name: "cluster"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "system"}, Spec: kafkav1beta1.KafkaChannelSpec{ClusterRef: &bindingsv1beta1.KafkaClusterReference{Name: "cluster", Namespace: systemNamespace}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other"}, Spec: kafkav1beta1.KafkaChannelSpec{ClusterRef: &bindingsv1beta1.KafkaClusterReference{Name: "cluster", Namespace: "other"}}},
+	} {
+		assert.Nil(t, channelIndexer.Add(channel))
+	}
+	for _, namespace := range []string{"ns", systemNamespace, "other"} {
+		assert.Nil(t, clusterIndexer.Add(&kafkav1alpha1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cluster"}}))
+	}
+	kafkaChannelLister = kafkalisters.NewKafkaChannelLister(channelIndexer)
+	kafkaClusterLister = kafkaclusterlisters.NewKafkaClusterLister(clusterIndexer)
+
+	// Perform The Tests & Verify The Results
+	cluster, err := GetKafkaCluster(receivertesting.CreateChannelReference("default", "ns"), systemNamespace)
+	assert.Nil(t, err)
+	assert.Nil(t, cluster)
+	cluster, err = GetKafkaCluster(receivertesting.CreateChannelReference("local", "ns"), systemNamespace)
+	assert.Nil(t, err)
+	assert.Equal(t, "ns", cluster.Namespace)
+	cluster, err = GetKafkaCluster(receivertesting.CreateChannelReference("system", "ns"), systemNamespace)
+	assert.Nil(t, err)
+	assert.Equal(t, systemNamespace, cluster.Namespace)
+	_, err = GetKafkaCluster(receivertesting.CreateChannelReference("other", "ns"), systemNamespace)
+	assert.NotNil(t, err)
+	_, err = GetKafkaCluster(receivertesting.CreateChannelReference("missing", "ns"), systemNamespace)
+	assert.NotNil(t, err)
+}
+
 // Test The Close() Functionality
 func TestClose(t *testing.T) {
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/Shopify/sarama"
@@ -29,15 +30,20 @@ import (
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	eventingChannel "knative.dev/eventing/pkg/channel"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/util"
 	"knative.dev/eventing-kafka/pkg/common/client"
 	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	commonproducer "knative.dev/eventing-kafka/pkg/common/kafka/producer"
 	kafkasarama "knative.dev/eventing-kafka/pkg/common/kafka/sarama"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
@@ -45,7 +51,7 @@ import (
 // Producer Struct
 type Producer struct {
 	logger             *zap.Logger
-	kafkaProducer      sarama.SyncProducer
+	kafkaProducer      *commonproducer.AsyncProducer
 	healthServer       *health.Server
 	statsReporter      metrics.StatsReporter
 	metricsRegistry    gometrics.Registry
@@ -53,6 +59,21 @@ type Producer struct {
 	metricsStoppedChan chan struct{}
 	configuration      *sarama.Config
 	brokers            []string
+	clusterResolver    ClusterResolver
+	kubeClient         kubernetes.Interface
+	clusterLock        sync.Mutex
+	clusterProducers   map[types.UID]*clusterProducer
+}
+
+// ClusterResolver returns the KafkaCluster referenced by the KafkaChannel (nil for the default Kafka cluster)
+type ClusterResolver func(channelReference eventingChannel.ChannelReference) (*kafkav1alpha1.KafkaCluster, error)
+
+// clusterProducer is the producer of the KafkaChannels referencing a KafkaCluster, for the current spec of the cluster
+type clusterProducer struct {
+	key           string
+	lock          sync.RWMutex
+	kafkaProducer *commonproducer.AsyncProducer
+	closed        bool
 }
 
 // NewProducer returns a new Producer instance with specified configuration.
@@ -63,19 +84,19 @@ func NewProducer(logger *zap.Logger,
 	healthServer *health.Server) (*Producer, error) {
 
 	// Create The Kafka Producer Using The Specified Kafka Authentication
-	logger.Info("Creating Kafka SyncProducer")
-	kafkaProducer, err := producer.CreateSyncProducer(brokers, config)
+	logger.Info("Creating Kafka AsyncProducer")
+	kafkaProducer, err := producer.CreateAsyncProducer(brokers, config)
 	if err != nil {
-		logger.Error("Failed To Create Kafka SyncProducer - Exiting", zap.Error(err), zap.Any("Brokers", brokers))
+		logger.Error("Failed To Create Kafka AsyncProducer - Exiting", zap.Error(err), zap.Any("Brokers", brokers))
 		return nil, err
 	} else {
-		logger.Info("Successfully Created Kafka SyncProducer")
+		logger.Info("Successfully Created Kafka AsyncProducer")
 	}
 
-	// Create A New Producer
+	// Create A New Producer (Batching The Messages Of Concurrent Requests Through The AsyncProducer)
 	newProducer := &Producer{
 		logger:             logger,
-		kafkaProducer:      kafkaProducer,
+		kafkaProducer:      commonproducer.NewAsyncProducer(kafkaProducer),
 		healthServer:       healthServer,
 		statsReporter:      statsReporter,
 		metricsRegistry:    config.MetricRegistry,
@@ -83,6 +104,7 @@ func NewProducer(logger *zap.Logger,
 		metricsStoppedChan: make(chan struct{}),
 		configuration:      config,
 		brokers:            brokers,
+		clusterProducers:   make(map[types.UID]*clusterProducer),
 	}
 
 	// Start Observing Metrics
@@ -97,6 +119,7 @@ func NewProducer(logger *zap.Logger,
 }
 
 // ProduceKafkaMessage creates and sends a Sarama ProducerMessage to the specified Topic and waits for the delivery confirmation.
+// The messages of concurrent requests are batched together, and the produce latency is reported per KafkaChannel.
 func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eventingChannel.ChannelReference, message binding.Message, httpHeader http.Header, transformers ...binding.Transformer) error {
 
 	// Validate The Kafka Producer (Must Be Pre-Initialized)
@@ -134,16 +157,109 @@ func (p *Producer) ProduceKafkaMessage(ctx context.Context, channelReference eve
 			zap.Any("Headers", kafkasarama.StringifyHeaders(producerMessage.Headers)), // Log human-readable strings, not base64
 			zap.ByteString("Message", msgBytes))
 	}
-	partition, offset, err := p.kafkaProducer.SendMessage(producerMessage)
+	start := time.Now()
+	partition, offset, err := p.sendMessage(ctx, channelReference, producerMessage)
 	if err != nil {
 		logger.Error("Failed To Send Message To Kafka", zap.Error(err))
 		return err
 	} else {
 		logger.Debug("Successfully Sent Message To Kafka", zap.Int32("Partition", partition), zap.Int64("Offset", offset))
+		if err = metrics.ReportProduceLatency(ctx, "KafkaChannel", channelReference.Namespace, channelReference.Name, time.Since(start)); err != nil {
+			logger.Warn("Failed To Report Produce Latency", zap.Error(err))
+		}
 		return nil
 	}
 }
 
+// SetClusterResolver enables the KafkaChannels referencing a KafkaCluster, whose messages are produced to their own
+// cluster instead of the default one.  The Secrets of the clusters are read with the specified Kubernetes client.
+func (p *Producer) SetClusterResolver(resolver ClusterResolver, kubeClient kubernetes.Interface) {
+	p.clusterResolver = resolver
+	p.kubeClient = kubeClient
+}
+
+// sendMessage sends the message through the producer of the Kafka cluster of the KafkaChannel.
+func (p *Producer) sendMessage(ctx context.Context, channelReference eventingChannel.ChannelReference, producerMessage *sarama.ProducerMessage) (int32, int64, error) {
+	clusterProducer, err := p.getClusterProducer(ctx, channelReference)
+	if err != nil {
+		return -1, -1, err
+	} else if clusterProducer == nil {
+		return p.kafkaProducer.SendMessage(ctx, producerMessage)
+	}
+
+	// Prevent The Producer From Being Closed While Sending (The KafkaCluster Might Have Just Changed)
+	clusterProducer.lock.RLock()
+	defer clusterProducer.lock.RUnlock()
+	if clusterProducer.closed {
+		return -1, -1, errors.New("the kafka producer of the KafkaCluster was closed - unable to produce message")
+	}
+	return clusterProducer.kafkaProducer.SendMessage(ctx, producerMessage)
+}
+
+// getClusterProducer returns the producer of the KafkaCluster referenced by the KafkaChannel (nil for the default
+// Kafka cluster), creating it on first use or when the spec of the cluster changed.
+func (p *Producer) getClusterProducer(ctx context.Context, channelReference eventingChannel.ChannelReference) (*clusterProducer, error) {
+	if p.clusterResolver == nil {
+		return nil, nil
+	}
+	cluster, err := p.clusterResolver(channelReference)
+	if err != nil {
+		p.logger.Error("Failed To Resolve The KafkaCluster Of The Channel", zap.Any("ChannelReference", channelReference), zap.Error(err))
+		return nil, err
+	} else if cluster == nil {
+		return nil, nil
+	}
+
+	p.clusterLock.Lock()
+	defer p.clusterLock.Unlock()
+
+	// Reuse The Producer Of The Current Spec Of The KafkaCluster
+	key := kafkacluster.Key(cluster)
+	existing := p.clusterProducers[cluster.UID]
+	if existing != nil && existing.key == key {
+		return existing, nil
+	}
+
+	// Create A Producer Sharing The Producer Settings (And Metrics Registry) Of The Default Cluster
+	logger := p.logger.With(zap.String("KafkaCluster", cluster.Namespace+"/"+cluster.Name))
+	logger.Info("Creating Kafka AsyncProducer Of KafkaCluster")
+	brokers, config, err := kafkacluster.NewConfig(ctx, p.kubeClient, cluster, p.configuration)
+	if err != nil {
+		logger.Error("Failed To Configure The KafkaCluster", zap.Error(err))
+		return nil, err
+	}
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+	kafkaProducer, err := producer.CreateAsyncProducer(brokers, config)
+	if err != nil {
+		logger.Error("Failed To Create Kafka AsyncProducer Of KafkaCluster", zap.Error(err), zap.Any("Brokers", brokers))
+		return nil, err
+	}
+	newProducer := &clusterProducer{key: key, kafkaProducer: commonproducer.NewAsyncProducer(kafkaProducer)}
+	p.clusterProducers[cluster.UID] = newProducer
+
+	// Close The Producer Of The Previous Spec Once Its In-Flight Messages Are Sent
+	if existing != nil {
+		go func() {
+			if err := existing.close(); err != nil {
+				logger.Error("Failed To Close Previous Kafka AsyncProducer Of KafkaCluster", zap.Error(err))
+			}
+		}()
+	}
+	return newProducer, nil
+}
+
+// close waits for the in-flight messages of the cluster producer and closes it.
+func (c *clusterProducer) close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.kafkaProducer.Close()
+}
+
 // ObserveMetrics is an async process for observing Kafka metrics.
 func (p *Producer) ObserveMetrics(interval time.Duration) {
 
@@ -213,13 +329,20 @@ func (p *Producer) SecretChanged(ctx context.Context, secret *corev1.Secret) *Pr
 	// Create A New Producer With The New Configuration (Reusing All Other Existing Config)
 	p.logger.Info("Changes Detected In New Secret - Closing & Recreating Producer")
 
-	// Shut down the current producer and recreate it with new settings
+	// Shut down the current producer and recreate it with new settings (the producers of the KafkaClusters don't
+	// use the Secret, so they are handed over to the new producer)
+	p.clusterLock.Lock()
+	clusterProducers := p.clusterProducers
+	p.clusterProducers = make(map[types.UID]*clusterProducer)
+	p.clusterLock.Unlock()
 	p.Close()
 	reconfiguredKafkaProducer, err := NewProducer(p.logger, newConfig, p.brokers, p.statsReporter, p.healthServer)
 	if err != nil {
 		p.logger.Fatal("Failed To Create Kafka Producer With New Configuration", zap.Error(err))
 		return nil
 	}
+	reconfiguredKafkaProducer.SetClusterResolver(p.clusterResolver, p.kubeClient)
+	reconfiguredKafkaProducer.clusterProducers = clusterProducers
 
 	// Successfully Created New Producer - Close Old One And Return New One
 	p.logger.Info("Successfully Created New Producer")
@@ -236,8 +359,17 @@ func (p *Producer) Close() {
 	close(p.metricsStopChan)
 	<-p.metricsStoppedChan
 
-	// Close The Kafka Producer & Log Results
+	// Close The Kafka Producer (Flushing The Buffered Messages) & Log Results
 	err := p.kafkaProducer.Close()
+	p.clusterLock.Lock()
+	for uid, clusterProducer := range p.clusterProducers {
+		if clusterErr := clusterProducer.close(); clusterErr != nil {
+			p.logger.Error("Failed To Close Kafka AsyncProducer Of KafkaCluster", zap.Error(clusterErr), zap.String("UID", string(uid)))
+			err = clusterErr
+		}
+	}
+	p.clusterProducers = make(map[types.UID]*clusterProducer)
+	p.clusterLock.Unlock()
 	if err != nil {
 		p.logger.Error("Failed To Close Kafka Producer", zap.Error(err))
 	} else {
@@ -19,14 +19,19 @@ package producer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	eventingChannel "knative.dev/eventing/pkg/channel"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	producertesting "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/producer/testing"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/receiver/constants"
 	channelhealth "knative.dev/eventing-kafka/pkg/channel/distributed/receiver/health"
@@ -45,18 +50,18 @@ func TestNewProducer(t *testing.T) {
 	brokers := []string{configtesting.DefaultKafkaBroker}
 	config := sarama.NewConfig()
 
-	// Create A Mock Kafka SyncProducer
-	mockSyncProducer := producertesting.NewMockSyncProducer()
+	// Create A Mock Kafka AsyncProducer
+	mockAsyncProducer := producertesting.NewMockAsyncProducer()
 
-	// Stub NewSyncProducerWrapper() For Testing And Restore After Test
-	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
-	defer producertesting.RestoreNewSyncProducerFn()
+	// Stub NewAsyncProducerWrapper() For Testing And Restore After Test
+	producertesting.StubNewAsyncProducerFn(producertesting.ValidatingNewAsyncProducerFn(t, brokers, config, mockAsyncProducer))
+	defer producertesting.RestoreNewAsyncProducerFn()
 
 	// Create And Validate A Test Producer
-	createTestProducer(t, brokers, config, mockSyncProducer)
+	createTestProducer(t, brokers, config)
 
-	// Verify The Mock SyncProducer State
-	assert.False(t, mockSyncProducer.Closed())
+	// Verify The Mock AsyncProducer State
+	assert.False(t, mockAsyncProducer.Closed())
 }
 
 // Test The ProduceKafkaMessage() Functionality For Event With PartitionKey
@@ -73,22 +78,22 @@ func TestProduceKafkaMessage(t *testing.T) {
 		"x-foo":        {"TestFoo"},
 	}
 
-	// Create A Mock Kafka SyncProducer
-	mockSyncProducer := producertesting.NewMockSyncProducer()
+	// Create A Mock Kafka AsyncProducer
+	mockAsyncProducer := producertesting.NewMockAsyncProducer()
 
-	// Stub NewSyncProducerWrapper() For Testing And Restore After Test
-	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
-	defer producertesting.RestoreNewSyncProducerFn()
+	// Stub NewAsyncProducerWrapper() For Testing And Restore After Test
+	producertesting.StubNewAsyncProducerFn(producertesting.ValidatingNewAsyncProducerFn(t, brokers, config, mockAsyncProducer))
+	defer producertesting.RestoreNewAsyncProducerFn()
 
 	// Create Producer To Test
-	producer := createTestProducer(t, brokers, config, mockSyncProducer)
+	producer := createTestProducer(t, brokers, config)
 
 	// Perform The Test & Verify Results
 	err := producer.ProduceKafkaMessage(context.Background(), channelReference, bindingMessage, httpHeader)
 	assert.Nil(t, err)
 
 	// Verify Message Was Produced Correctly
-	producerMessage := mockSyncProducer.GetMessage()
+	producerMessage := mockAsyncProducer.GetMessage()
 	assert.NotNil(t, producerMessage)
 	assert.Equal(t, receivertesting.TopicName, producerMessage.Topic)
 	value, err := producerMessage.Value.Encode()
@@ -112,6 +117,60 @@ func TestProduceKafkaMessage(t *testing.T) {
 	}
 }
 
+// Test The ProduceKafkaMessage() Functionality For A Channel Referencing A KafkaCluster
+func TestProduceKafkaMessageWithKafkaCluster(t *testing.T) {
+
+	// Test Data
+	brokers := []string{configtesting.DefaultKafkaBroker}
+	clusterBrokers := []string{"cluster-broker:9092"}
+	config := sarama.NewConfig()
+	channelReference := receivertesting.CreateChannelReference(receivertesting.ChannelName, receivertesting.ChannelNamespace)
+	cluster := &kafkav1alpha1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: receivertesting.ChannelNamespace, Name: "test-cluster", UID: "cluster-uid", Generation: 1},
+		Spec:       kafkav1alpha1.KafkaClusterSpec{BootstrapServers: clusterBrokers},
+	}
+
+	// Create A Producer For The Default Cluster
+	mockAsyncProducer := producertesting.NewMockAsyncProducer()
+	producertesting.StubNewAsyncProducerFn(producertesting.ValidatingNewAsyncProducerFn(t, brokers, config, mockAsyncProducer))
+	defer producertesting.RestoreNewAsyncProducerFn()
+	producer := createTestProducer(t, brokers, config)
+	producer.SetClusterResolver(func(ref eventingChannel.ChannelReference) (*kafkav1alpha1.KafkaCluster, error) {
+		assert.Equal(t, channelReference, ref)
+		return cluster, nil
+	}, fake.NewSimpleClientset())
+
+	// Create A Mock Kafka AsyncProducer For Each Spec Of The KafkaCluster
+	clusterAsyncProducers := []*producertesting.MockAsyncProducer{producertesting.NewMockAsyncProducer(), producertesting.NewMockAsyncProducer()}
+	created := 0
+	producertesting.StubNewAsyncProducerFn(func(actualBrokers []string, actualConfig *sarama.Config) (sarama.AsyncProducer, error) {
+		assert.Equal(t, clusterBrokers, actualBrokers)
+		assert.True(t, actualConfig.Producer.Return.Successes)
+		created++
+		return clusterAsyncProducers[created-1], nil
+	})
+
+	// Verify The Messages Are Produced To The KafkaCluster (Reusing Its Producer)
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1), nil))
+		assert.Equal(t, receivertesting.TopicName, clusterAsyncProducers[0].GetMessage().Topic)
+	}
+	assert.Equal(t, 1, created)
+
+	// Verify A Change Of The KafkaCluster Replaces Its Producer
+	cluster = cluster.DeepCopy()
+	cluster.Generation = 2
+	assert.Nil(t, producer.ProduceKafkaMessage(context.Background(), channelReference, receivertesting.CreateBindingMessage(cloudevents.VersionV1), nil))
+	assert.Equal(t, receivertesting.TopicName, clusterAsyncProducers[1].GetMessage().Topic)
+	assert.Equal(t, 2, created)
+	assert.Eventually(t, clusterAsyncProducers[0].Closed, time.Second, 10*time.Millisecond)
+
+	// Verify Closing The Producer Closes The Producers Of The KafkaClusters
+	producer.Close()
+	assert.True(t, mockAsyncProducer.Closed())
+	assert.True(t, clusterAsyncProducers[1].Closed())
+}
+
 // Test The Producer's SecretChanged Functionality
 func TestSecretChanged(t *testing.T) {
 
@@ -179,28 +238,28 @@ func TestSecretChanged(t *testing.T) {
 		},
 	}
 
-	// Make Sure To Restore The NewSyncProducer Wrapper After The Test
-	defer producertesting.RestoreNewSyncProducerFn()
+	// Make Sure To Restore The NewAsyncProducer Wrapper After The Test
+	defer producertesting.RestoreNewAsyncProducerFn()
 
 	// Run The Filtered TestCases
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 
-			// Mock The SyncProducer & Stub The NewSyncProducerWrapper()
-			mockSyncProducer := producertesting.NewMockSyncProducer()
-			producertesting.StubNewSyncProducerFn(producertesting.NonValidatingNewSyncProducerFn(mockSyncProducer))
+			// Mock The AsyncProducer & Stub The NewAsyncProducerWrapper()
+			mockAsyncProducer := producertesting.NewMockAsyncProducer()
+			producertesting.StubNewAsyncProducerFn(producertesting.NonValidatingNewAsyncProducerFn(mockAsyncProducer))
 
 			// Create A Test Producer To Perform Tests Against
 			baseSaramaConfig, err := commonclient.NewConfigBuilder().WithDefaults().FromYaml(clienttesting.DefaultSaramaConfigYaml).WithAuth(auth).Build(ctx)
 			assert.Nil(t, err)
-			producer := createTestProducer(t, brokers, baseSaramaConfig, mockSyncProducer)
+			producer := createTestProducer(t, brokers, baseSaramaConfig)
 
 			// Perform The Test
 			newProducer := producer.SecretChanged(ctx, testCase.newSecret)
 
 			// Verify Expected State
 			assert.Equal(t, testCase.expectNewProducer, newProducer != nil)
-			assert.Equal(t, testCase.expectNewProducer, mockSyncProducer.Closed())
+			assert.Equal(t, testCase.expectNewProducer, mockAsyncProducer.Closed())
 			if newProducer != nil {
 				if testCase.expectEmptyAuth {
 					// An empty username in the secret will force no-authorization even if it was enabled before
@@ -223,26 +282,26 @@ func TestClose(t *testing.T) {
 	brokers := []string{configtesting.DefaultKafkaBroker}
 	config := sarama.NewConfig()
 
-	// Create A Mock Kafka SyncProducer
-	mockSyncProducer := producertesting.NewMockSyncProducer()
+	// Create A Mock Kafka AsyncProducer
+	mockAsyncProducer := producertesting.NewMockAsyncProducer()
 
-	// Stub NewSyncProducerWrapper() For Testing And Restore After Test
-	producertesting.StubNewSyncProducerFn(producertesting.ValidatingNewSyncProducerFn(t, brokers, config, mockSyncProducer))
-	defer producertesting.RestoreNewSyncProducerFn()
+	// Stub NewAsyncProducerWrapper() For Testing And Restore After Test
+	producertesting.StubNewAsyncProducerFn(producertesting.ValidatingNewAsyncProducerFn(t, brokers, config, mockAsyncProducer))
+	defer producertesting.RestoreNewAsyncProducerFn()
 
 	// Create A Test Producer
-	producer := createTestProducer(t, brokers, config, mockSyncProducer)
+	producer := createTestProducer(t, brokers, config)
 
 	// Perform The Test
 	producer.Close()
 
 	// Verify The Results
 	assert.False(t, producer.healthServer.ProducerReady())
-	assert.True(t, mockSyncProducer.Closed())
+	assert.True(t, mockAsyncProducer.Closed())
 }
 
 // Utility Function For Creating A Producer With Specified Configuration
-func createTestProducer(t *testing.T, brokers []string, config *sarama.Config, syncProducer sarama.SyncProducer) *Producer {
+func createTestProducer(t *testing.T, brokers []string, config *sarama.Config) *Producer {
 
 	// Create A Test Logger
 	logger := logtesting.TestLogger(t).Desugar()
@@ -260,7 +319,7 @@ func createTestProducer(t *testing.T, brokers []string, config *sarama.Config, s
 	assert.Equal(t, logger, producer.logger)
 	assert.Equal(t, config, producer.configuration)
 	assert.Equal(t, brokers, producer.brokers)
-	assert.Equal(t, syncProducer, producer.kafkaProducer)
+	assert.NotNil(t, producer.kafkaProducer)
 	assert.Equal(t, healthServer, producer.healthServer)
 	assert.Equal(t, statsReporter, producer.statsReporter)
 	assert.Equal(t, config.MetricRegistry, producer.metricsRegistry)
@@ -35,6 +35,8 @@ import (
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// For group messaging.knative.dev
 	messagingv1beta1.SchemeGroupVersion.WithKind("KafkaChannel"): &messagingv1beta1.KafkaChannel{},
+	// For group kafka.eventing.knative.dev (the KafkaClusters referenced by the channels)
+	kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster"): &kafkav1alpha1.KafkaCluster{},
 }
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{}
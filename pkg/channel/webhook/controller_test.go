@@ -25,10 +25,13 @@ import (
 )
 
 func TestDefaultTypeMap(t *testing.T) {
-	assert.Len(t, types, 1)
+	assert.Len(t, types, 2)
 	typeEntry := types[messagingv1beta1.SchemeGroupVersion.WithKind("KafkaChannel")]
 	assert.NotNil(t, typeEntry)
 	assert.IsType(t, &messagingv1beta1.KafkaChannel{}, typeEntry)
+	clusterTypeEntry := types[kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster")]
+	assert.NotNil(t, clusterTypeEntry)
+	assert.IsType(t, &kafkav1alpha1.KafkaCluster{}, clusterTypeEntry)
 }
 
 func TestDefaultCallbacksMap(t *testing.T) {
@@ -37,12 +40,16 @@ func TestDefaultCallbacksMap(t *testing.T) {
 
 func TestIncludeResetOffset(t *testing.T) {
 	IncludeResetOffset()
-	assert.Len(t, types, 2)
+	assert.Len(t, types, 3)
 
 	kcTypeEntry := types[messagingv1beta1.SchemeGroupVersion.WithKind("KafkaChannel")]
 	assert.NotNil(t, kcTypeEntry)
 	assert.IsType(t, &messagingv1beta1.KafkaChannel{}, kcTypeEntry)
 
+	clusterTypeEntry := types[kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster")]
+	assert.NotNil(t, clusterTypeEntry)
+	assert.IsType(t, &kafkav1alpha1.KafkaCluster{}, clusterTypeEntry)
+
 	roTypeEntry := types[kafkav1alpha1.SchemeGroupVersion.WithKind("ResetOffset")]
 	assert.NotNil(t, roTypeEntry)
 	assert.IsType(t, &kafkav1alpha1.ResetOffset{}, roTypeEntry)
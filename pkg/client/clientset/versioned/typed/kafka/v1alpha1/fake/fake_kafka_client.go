@@ -28,6 +28,10 @@ type FakeKafkaV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKafkaV1alpha1) KafkaClusters(namespace string) v1alpha1.KafkaClusterInterface {
+	return &FakeKafkaClusters{c, namespace}
+}
+
 func (c *FakeKafkaV1alpha1) ResetOffsets(namespace string) v1alpha1.ResetOffsetInterface {
 	return &FakeResetOffsets{c, namespace}
 }
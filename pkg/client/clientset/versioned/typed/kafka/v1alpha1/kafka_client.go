@@ -28,6 +28,7 @@ import (
 
 type KafkaV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	KafkaClustersGetter
 	ResetOffsetsGetter
 }
 
@@ -36,6 +37,10 @@ type KafkaV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KafkaV1alpha1Client) KafkaClusters(namespace string) KafkaClusterInterface {
+	return newKafkaClusters(c, namespace)
+}
+
 func (c *KafkaV1alpha1Client) ResetOffsets(namespace string) ResetOffsetInterface {
 	return newResetOffsets(c, namespace)
 }
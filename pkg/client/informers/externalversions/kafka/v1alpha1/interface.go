@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// KafkaClusters returns a KafkaClusterInformer.
+	KafkaClusters() KafkaClusterInformer
 	// ResetOffsets returns a ResetOffsetInformer.
 	ResetOffsets() ResetOffsetInformer
 }
@@ -39,6 +41,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// KafkaClusters returns a KafkaClusterInformer.
+func (v *version) KafkaClusters() KafkaClusterInformer {
+	return &kafkaClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ResetOffsets returns a ResetOffsetInformer.
 func (v *version) ResetOffsets() ResetOffsetInformer {
 	return &resetOffsetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -264,6 +264,137 @@ func (w *wrapKafkaV1alpha1) RESTClient() rest.Interface {
 	panic("RESTClient called on dynamic client!")
 }
 
+func (w *wrapKafkaV1alpha1) KafkaClusters(namespace string) typedkafkav1alpha1.KafkaClusterInterface {
+	return &wrapKafkaV1alpha1KafkaClusterImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kafka.eventing.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "kafkaclusters",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapKafkaV1alpha1KafkaClusterImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedkafkav1alpha1.KafkaClusterInterface = (*wrapKafkaV1alpha1KafkaClusterImpl)(nil)
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) Create(ctx context.Context, in *v1alpha1.KafkaCluster, opts v1.CreateOptions) (*v1alpha1.KafkaCluster, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "KafkaCluster",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaCluster{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.KafkaCluster, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaCluster{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.KafkaClusterList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaClusterList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KafkaCluster, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaCluster{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) Update(ctx context.Context, in *v1alpha1.KafkaCluster, opts v1.UpdateOptions) (*v1alpha1.KafkaCluster, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "KafkaCluster",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaCluster{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) UpdateStatus(ctx context.Context, in *v1alpha1.KafkaCluster, opts v1.UpdateOptions) (*v1alpha1.KafkaCluster, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "KafkaCluster",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.KafkaCluster{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1KafkaClusterImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapKafkaV1alpha1) ResetOffsets(namespace string) typedkafkav1alpha1.ResetOffsetInterface {
 	return &wrapKafkaV1alpha1ResetOffsetImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// KafkaClusterListerExpansion allows custom methods to be added to
+// KafkaClusterLister.
+type KafkaClusterListerExpansion interface{}
+
+// KafkaClusterNamespaceListerExpansion allows custom methods to be added to
+// KafkaClusterNamespaceLister.
+type KafkaClusterNamespaceListerExpansion interface{}
+
 // ResetOffsetListerExpansion allows custom methods to be added to
 // ResetOffsetLister.
 type ResetOffsetListerExpansion interface{}
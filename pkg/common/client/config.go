@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"time"
@@ -48,13 +49,50 @@ type KafkaSaslConfig struct {
 	User     string
 	Password string
 	SaslType string
+
+	// OAUTHBEARER Settings (User & Password Are The Client ID & Secret)
+	TokenURL string
+	Scopes   []string
+
+	// GSSAPI Settings (User Is The Principal, Password Is Only Used Without Keytab)
+	KerberosServiceName string
+	KerberosRealm       string
+	KerberosKeytab      string // Base64 Encoded
+	KerberosConfig      string // krb5.conf Content
 }
 
 // HasSameSettings returns true if all of the SASL settings in the provided config are the same as in this struct
 func (c *KafkaSaslConfig) HasSameSettings(saramaConfig *sarama.Config) bool {
-	return saramaConfig.Net.SASL.User == c.User &&
-		saramaConfig.Net.SASL.Password == c.Password &&
-		string(saramaConfig.Net.SASL.Mechanism) == c.SaslType
+	if saramaConfig.Net.SASL.User != c.User ||
+		saramaConfig.Net.SASL.Password != c.Password ||
+		string(saramaConfig.Net.SASL.Mechanism) != c.SaslType {
+		return false
+	}
+
+	switch c.SaslType {
+	case sarama.SASLTypeOAuth:
+		tokenProvider, ok := saramaConfig.Net.SASL.TokenProvider.(*OAuthTokenProvider)
+		return ok && tokenProvider.tokenURL == c.TokenURL &&
+			cmp.Equal(tokenProvider.scopes, c.Scopes, cmpopts.EquateEmpty())
+	case sarama.SASLTypeGSSAPI:
+		return saramaConfig.Net.SASL.GSSAPI.ServiceName == c.KerberosServiceName &&
+			saramaConfig.Net.SASL.GSSAPI.Realm == c.KerberosRealm &&
+			saramaConfig.Net.SASL.GSSAPI.KerberosConfigPath == kerberosFilePath("krb5", ".conf", []byte(c.KerberosConfig)) &&
+			saramaConfig.Net.SASL.GSSAPI.KeyTabPath == c.keytabPath()
+	}
+	return true
+}
+
+// keytabPath returns the path of the file in which the Kerberos keytab is written (empty if there is none)
+func (c *KafkaSaslConfig) keytabPath() string {
+	if c.KerberosKeytab == "" {
+		return ""
+	}
+	keytab, err := base64.StdEncoding.DecodeString(c.KerberosKeytab)
+	if err != nil {
+		return "" // An Invalid Keytab Fails To Build A Config, So There Is Nothing To Compare To
+	}
+	return kerberosFilePath("principal", ".keytab", keytab)
 }
 
 // HasSameBrokers returns true if all of the brokers in the slice are present and in the same order as
@@ -263,6 +301,23 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 				config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &XDGSCRAMClient{HashGeneratorFcn: SHA512} }
 				config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
 			}
+
+			if b.auth.SASL.SaslType == sarama.SASLTypeOAuth {
+				if b.auth.SASL.TokenURL == "" {
+					return nil, fmt.Errorf("the token URL is required by the %s SASL type", sarama.SASLTypeOAuth)
+				}
+				config.Net.SASL.TokenProvider = NewOAuthTokenProvider(b.auth.SASL.User, b.auth.SASL.Password, b.auth.SASL.TokenURL, b.auth.SASL.Scopes)
+				config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+			}
+
+			if b.auth.SASL.SaslType == sarama.SASLTypeGSSAPI {
+				gssapiConfig, err := newGSSAPIConfig(b.auth.SASL)
+				if err != nil {
+					return nil, fmt.Errorf("Error creating GSSAPI config: %w", err)
+				}
+				config.Net.SASL.GSSAPI = gssapiConfig
+				config.Net.SASL.Mechanism = sarama.SASLTypeGSSAPI
+			}
 			config.Net.SASL.User = b.auth.SASL.User
 		}
 	}
@@ -281,6 +336,12 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 			config.Consumer.Offsets.Initial = sarama.OffsetOldest
 		case v1beta1.OffsetLatest:
 			config.Consumer.Offsets.Initial = sarama.OffsetNewest
+		default:
+			// The offsets at a timestamp are committed by InitOffsets, the partitions created since
+			// then only contain records produced after the timestamp.
+			if _, err := b.initialOffset.ParseSaramaOffsetTime(); err == nil {
+				config.Consumer.Offsets.Initial = sarama.OffsetOldest
+			}
 		}
 	}
 
@@ -289,6 +350,9 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 
 	if b.auth != nil && b.auth.SASL != nil {
 		config.Net.SASL.Password = b.auth.SASL.Password
+		if b.auth.SASL.SaslType == sarama.SASLTypeGSSAPI {
+			config.Net.SASL.GSSAPI.Password = b.auth.SASL.Password
+		}
 	}
 
 	return config, nil
@@ -24,6 +24,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/pem"
 	"math/big"
 	"regexp"
@@ -317,6 +318,14 @@ func TestBuildSaramaConfig(t *testing.T) {
 	assert.Equal(t, "newClientId", config.ClientID)
 	assert.Equal(t, sarama.OffsetOldest, config.Consumer.Offsets.Initial)
 	assert.Equal(t, sarama.V2_0_0_0, config.Version)
+
+	// Verify that a timestamp initial offset consumes the new partitions from the oldest offset
+	config, err = NewConfigBuilder().
+		WithDefaults().
+		WithInitialOffset("2021-06-01T00:00:00Z").
+		Build(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, sarama.OffsetOldest, config.Consumer.Offsets.Initial)
 }
 
 func extractSaramaConfig(t *testing.T, saramaConfigField string) string {
@@ -465,6 +474,59 @@ Metadata:
 	assert.False(t, config.Net.TLS.Enable)
 }
 
+func TestBuildSaramaConfigWithOAuthAndGSSAPI(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.TODO(), logger)
+	defer restoreKerberosDir(KerberosDir)
+	KerberosDir = t.TempDir()
+
+	// OAUTHBEARER uses a token provider for the client credentials
+	config, err := NewConfigBuilder().
+		WithDefaults().
+		WithAuth(&KafkaAuthConfig{SASL: &KafkaSaslConfig{
+			User:     "my-client",
+			Password: "my-secret",
+			SaslType: sarama.SASLTypeOAuth,
+			TokenURL: "https://oauth.example.com/token",
+		}}).
+		Build(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), config.Net.SASL.Mechanism)
+	assert.IsType(t, &OAuthTokenProvider{}, config.Net.SASL.TokenProvider)
+	assert.Nil(t, config.Validate())
+
+	// OAUTHBEARER requires a token URL
+	_, err = NewConfigBuilder().
+		WithAuth(&KafkaAuthConfig{SASL: &KafkaSaslConfig{User: "my-client", SaslType: sarama.SASLTypeOAuth}}).
+		Build(ctx)
+	assert.NotNil(t, err)
+
+	// GSSAPI writes the Kerberos config and sets the password after the config has been logged
+	config, err = NewConfigBuilder().
+		WithDefaults().
+		WithAuth(&KafkaAuthConfig{SASL: &KafkaSaslConfig{
+			User:                "my-principal",
+			Password:            "my-password",
+			SaslType:            sarama.SASLTypeGSSAPI,
+			KerberosServiceName: "kafka",
+			KerberosRealm:       "EXAMPLE.COM",
+			KerberosConfig:      "[libdefaults]\n",
+		}}).
+		Build(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeGSSAPI), config.Net.SASL.Mechanism)
+	assert.Equal(t, sarama.KRB5_USER_AUTH, config.Net.SASL.GSSAPI.AuthType)
+	assert.Equal(t, "my-password", config.Net.SASL.GSSAPI.Password)
+	assert.NotEmpty(t, config.Net.SASL.GSSAPI.KerberosConfigPath)
+	assert.Nil(t, config.Validate())
+
+	// GSSAPI requires the Kerberos config
+	_, err = NewConfigBuilder().
+		WithAuth(&KafkaAuthConfig{SASL: &KafkaSaslConfig{User: "my-principal", SaslType: sarama.SASLTypeGSSAPI}}).
+		Build(ctx)
+	assert.NotNil(t, err)
+}
+
 // Verify that comparisons of sarama config structs function as expected
 func TestSaramaConfigEqual(t *testing.T) {
 	logger := logtesting.TestLogger(t)
@@ -799,9 +861,74 @@ func TestHasSameSettings(t *testing.T) {
 	saramaConfig.Net.SASL.Password = "password1"
 	assert.False(t, authConfig.SASL.HasSameSettings(saramaConfig))
 	saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+	assert.False(t, authConfig.SASL.HasSameSettings(saramaConfig))
+	saramaConfig.Net.SASL.TokenProvider = NewOAuthTokenProvider("user1", "password1", "", nil)
 	assert.True(t, authConfig.SASL.HasSameSettings(saramaConfig))
 }
 
+func TestHasSameSettingsOAuthAndGSSAPI(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.TODO(), logger)
+	defer restoreKerberosDir(KerberosDir)
+	KerberosDir = t.TempDir()
+
+	oauthConfig := KafkaSaslConfig{
+		User:     "my-client",
+		Password: "my-secret",
+		SaslType: sarama.SASLTypeOAuth,
+		TokenURL: "https://oauth.example.com/token",
+		Scopes:   []string{"kafka"},
+	}
+	gssapiConfig := KafkaSaslConfig{
+		User:                "my-principal",
+		Password:            "my-password",
+		SaslType:            sarama.SASLTypeGSSAPI,
+		KerberosServiceName: "kafka",
+		KerberosRealm:       "EXAMPLE.COM",
+		KerberosKeytab:      base64.StdEncoding.EncodeToString([]byte("keytab")),
+		KerberosConfig:      "[libdefaults]\n",
+	}
+
+	// Define The TestCase Struct
+	type TestCase struct {
+		name    string
+		current KafkaSaslConfig
+		update  func(sasl *KafkaSaslConfig)
+		same    bool
+	}
+
+	// Create The TestCases
+	testCases := []TestCase{
+		{name: "OAuth Unchanged", current: oauthConfig, update: func(sasl *KafkaSaslConfig) {}, same: true},
+		{name: "OAuth Changed Token URL", current: oauthConfig, update: func(sasl *KafkaSaslConfig) { sasl.TokenURL = "https://other.example.com/token" }},
+		{name: "OAuth Changed Scopes", current: oauthConfig, update: func(sasl *KafkaSaslConfig) { sasl.Scopes = []string{"kafka", "admin"} }},
+		{name: "OAuth Removed Scopes", current: oauthConfig, update: func(sasl *KafkaSaslConfig) { sasl.Scopes = nil }},
+		{name: "GSSAPI Unchanged", current: gssapiConfig, update: func(sasl *KafkaSaslConfig) {}, same: true},
+		{name: "GSSAPI Changed Service Name", current: gssapiConfig, update: func(sasl *KafkaSaslConfig) { sasl.KerberosServiceName = "other" }},
+		{name: "GSSAPI Changed Realm", current: gssapiConfig, update: func(sasl *KafkaSaslConfig) { sasl.KerberosRealm = "OTHER.COM" }},
+		{name: "GSSAPI Changed Config", current: gssapiConfig, update: func(sasl *KafkaSaslConfig) { sasl.KerberosConfig = "[realms]\n" }},
+		{name: "GSSAPI Changed Keytab", current: gssapiConfig, update: func(sasl *KafkaSaslConfig) {
+			sasl.KerberosKeytab = base64.StdEncoding.EncodeToString([]byte("other"))
+		}},
+		{name: "GSSAPI Removed Keytab", current: gssapiConfig, update: func(sasl *KafkaSaslConfig) { sasl.KerberosKeytab = "" }},
+	}
+
+	// Run The TestCases
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			current := testCase.current
+			saramaConfig, err := NewConfigBuilder().WithDefaults().WithAuth(&KafkaAuthConfig{SASL: &current}).Build(ctx)
+			assert.Nil(t, err)
+			assert.True(t, current.HasSameSettings(saramaConfig))
+
+			updated := testCase.current
+			updated.Scopes = append([]string(nil), testCase.current.Scopes...)
+			testCase.update(&updated)
+			assert.Equal(t, testCase.same, updated.HasSameSettings(saramaConfig))
+		})
+	}
+}
+
 func TestHasSameBrokers(t *testing.T) {
 
 	// Define The TestCase Struct
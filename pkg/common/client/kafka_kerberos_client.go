@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Shopify/sarama"
+)
+
+// KerberosDir is the directory in which the krb5.conf and keytab files required by Sarama are written
+var KerberosDir = filepath.Join(os.TempDir(), "kafka-kerberos")
+
+// newGSSAPIConfig returns the Sarama GSSAPI config for the specified SASL settings, writing the
+// Kerberos configuration and (base64 encoded) keytab to files as Sarama only accepts their paths.
+// The password is intentionally not set so that it isn't logged along with the Sarama config.
+func newGSSAPIConfig(sasl *KafkaSaslConfig) (sarama.GSSAPIConfig, error) {
+	gssapiConfig := sarama.GSSAPIConfig{
+		AuthType:    sarama.KRB5_USER_AUTH,
+		ServiceName: sasl.KerberosServiceName,
+		Username:    sasl.User,
+		Realm:       sasl.KerberosRealm,
+	}
+
+	if sasl.KerberosConfig == "" {
+		return gssapiConfig, fmt.Errorf("the Kerberos configuration is required by the %s SASL type", sarama.SASLTypeGSSAPI)
+	}
+	configPath, err := writeKerberosFile("krb5", ".conf", []byte(sasl.KerberosConfig))
+	if err != nil {
+		return gssapiConfig, err
+	}
+	gssapiConfig.KerberosConfigPath = configPath
+
+	if sasl.KerberosKeytab != "" {
+		keytab, err := base64.StdEncoding.DecodeString(sasl.KerberosKeytab)
+		if err != nil {
+			return gssapiConfig, fmt.Errorf("failed to decode the base64 Kerberos keytab: %w", err)
+		}
+		keytabPath, err := writeKerberosFile("principal", ".keytab", keytab)
+		if err != nil {
+			return gssapiConfig, err
+		}
+		gssapiConfig.AuthType = sarama.KRB5_KEYTAB_AUTH
+		gssapiConfig.KeyTabPath = keytabPath
+	}
+
+	return gssapiConfig, nil
+}
+
+// writeKerberosFile writes the data to a file of the KerberosDir named after its content,
+// so that building the same config repeatedly does not create additional files.
+func writeKerberosFile(prefix string, extension string, data []byte) (string, error) {
+	if err := os.MkdirAll(KerberosDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create the Kerberos directory: %w", err)
+	}
+	path := kerberosFilePath(prefix, extension, data)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write the Kerberos file %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// kerberosFilePath returns the path of the KerberosDir file in which writeKerberosFile writes the data
+func kerberosFilePath(prefix string, extension string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return filepath.Join(KerberosDir, prefix+"-"+hex.EncodeToString(sum[:8])+extension)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGSSAPIConfig(t *testing.T) {
+	krb5Config := "[libdefaults]\n  default_realm = EXAMPLE.COM\n"
+	keytab := []byte{0x05, 0x02, 0x00, 0x00, 0x00, 0x42}
+
+	testCases := map[string]struct {
+		sasl             *KafkaSaslConfig
+		expectedAuthType int
+		expectKeytab     bool
+		expectErr        bool
+	}{
+		"Keytab Auth": {
+			sasl: &KafkaSaslConfig{
+				User:                "kafka-client",
+				KerberosServiceName: "kafka",
+				KerberosRealm:       "EXAMPLE.COM",
+				KerberosKeytab:      base64.StdEncoding.EncodeToString(keytab),
+				KerberosConfig:      krb5Config,
+			},
+			expectedAuthType: sarama.KRB5_KEYTAB_AUTH,
+			expectKeytab:     true,
+		},
+		"User Auth": {
+			sasl: &KafkaSaslConfig{
+				User:                "kafka-client",
+				Password:            "super-secret",
+				KerberosServiceName: "kafka",
+				KerberosRealm:       "EXAMPLE.COM",
+				KerberosConfig:      krb5Config,
+			},
+			expectedAuthType: sarama.KRB5_USER_AUTH,
+		},
+		"Missing Config": {
+			sasl: &KafkaSaslConfig{
+				User:           "kafka-client",
+				KerberosKeytab: base64.StdEncoding.EncodeToString(keytab),
+			},
+			expectErr: true,
+		},
+		"Invalid Keytab Encoding": {
+			sasl: &KafkaSaslConfig{
+				User:           "kafka-client",
+				KerberosKeytab: "not base64!",
+				KerberosConfig: krb5Config,
+			},
+			expectErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer restoreKerberosDir(KerberosDir)
+			KerberosDir = t.TempDir()
+
+			gssapiConfig, err := newGSSAPIConfig(tc.sasl)
+			if tc.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedAuthType, gssapiConfig.AuthType)
+			assert.Equal(t, tc.sasl.User, gssapiConfig.Username)
+			assert.Equal(t, tc.sasl.KerberosServiceName, gssapiConfig.ServiceName)
+			assert.Equal(t, tc.sasl.KerberosRealm, gssapiConfig.Realm)
+			assert.Empty(t, gssapiConfig.Password)
+
+			configData, err := ioutil.ReadFile(gssapiConfig.KerberosConfigPath)
+			assert.Nil(t, err)
+			assert.Equal(t, krb5Config, string(configData))
+
+			if tc.expectKeytab {
+				keytabData, err := ioutil.ReadFile(gssapiConfig.KeyTabPath)
+				assert.Nil(t, err)
+				assert.Equal(t, keytab, keytabData)
+			} else {
+				assert.Empty(t, gssapiConfig.KeyTabPath)
+			}
+
+			// Building The Same Config Again Reuses The Same Files
+			sameConfig, err := newGSSAPIConfig(tc.sasl)
+			assert.Nil(t, err)
+			assert.Equal(t, gssapiConfig, sameConfig)
+		})
+	}
+}
+
+func restoreKerberosDir(dir string) {
+	KerberosDir = dir
+}
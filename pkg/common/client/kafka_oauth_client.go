@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// OAuthTokenProvider is a sarama.AccessTokenProvider obtaining the OAUTHBEARER tokens
+// with the OAuth client credentials flow.  Tokens are cached and refreshed once expired.
+type OAuthTokenProvider struct {
+	tokenURL    string
+	scopes      []string
+	tokenSource oauth2.TokenSource
+}
+
+var _ sarama.AccessTokenProvider = (*OAuthTokenProvider)(nil)
+
+// NewOAuthTokenProvider returns an OAuthTokenProvider requesting tokens with the
+// specified client credentials and scopes from the tokenURL endpoint.
+func NewOAuthTokenProvider(clientID, clientSecret, tokenURL string, scopes []string) *OAuthTokenProvider {
+	config := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       scopes,
+	}
+	return &OAuthTokenProvider{
+		tokenURL:    tokenURL,
+		scopes:      scopes,
+		tokenSource: config.TokenSource(context.Background()),
+	}
+}
+
+// Token returns the current access token, requesting a new one if it has expired
+func (p *OAuthTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain OAuth token: %w", err)
+	}
+	return &sarama.AccessToken{Token: token.AccessToken}, nil
+}
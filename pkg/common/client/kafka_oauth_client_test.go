@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuthTokenProvider(t *testing.T) {
+	testCases := map[string]struct {
+		expiresIn        int
+		expectedRequests int
+	}{
+		"Token Cached Until Expiry": {
+			expiresIn:        3600,
+			expectedRequests: 1,
+		},
+		"Expired Token Refreshed": {
+			expiresIn:        1, // Within The oauth2 Expiry Delta So Always Considered Expired
+			expectedRequests: 2,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.Nil(t, r.ParseForm())
+				assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+				assert.Equal(t, "scope-a scope-b", r.PostForm.Get("scope"))
+				clientId, clientSecret, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "my-client", clientId)
+				assert.Equal(t, "my-secret", clientSecret)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, requests, tc.expiresIn)
+			}))
+			defer server.Close()
+
+			provider := NewOAuthTokenProvider("my-client", "my-secret", server.URL, []string{"scope-a", "scope-b"})
+
+			token, err := provider.Token()
+			assert.Nil(t, err)
+			assert.Equal(t, "token-1", token.Token)
+
+			token, err = provider.Token()
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprintf("token-%d", tc.expectedRequests), token.Token)
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
+
+func TestOAuthTokenProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := NewOAuthTokenProvider("my-client", "bad-secret", server.URL, nil)
+	token, err := provider.Token()
+	assert.NotNil(t, err)
+	assert.Nil(t, token)
+}
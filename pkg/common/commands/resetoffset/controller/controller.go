@@ -22,6 +22,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	"knative.dev/pkg/configmap"
@@ -30,6 +31,7 @@ import (
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/system"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/resetoffset"
 	resetoffsetreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/resetoffset"
 	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
@@ -81,13 +83,28 @@ func NewControllerFactory(
 
 		// Configure The Informers' EventHandlers
 		logger.Info("Setting Up EventHandlers")
-		resetoffsetInformer.Informer().AddEventHandler(controller.HandleAll(controllerImpl.Enqueue))
+		resetoffsetInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: filterSupportedResetOffsets(refMapper),
+			Handler:    controller.HandleAll(controllerImpl.Enqueue),
+		})
 
 		// Return The ResetOffset Controller
 		return controllerImpl
 	}
 }
 
+// filterSupportedResetOffsets returns a FilterFunc accepting only the ResetOffsets whose Ref is supported by
+// the RefMapper, so that the ResetOffsets of other Controllers (KafkaChannel, KafkaSource, etc) are ignored.
+func filterSupportedResetOffsets(refMapper refmappers.ResetOffsetRefMapper) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		resetOffset, ok := obj.(*kafkav1alpha1.ResetOffset)
+		return ok && refMapper.IsSupported(resetOffset)
+	}
+}
+
 // Shutdown performs clean tear-down of resources.
 func Shutdown() {
 	// Currently nothing to do
@@ -22,6 +22,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/client/injection/kube/client/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake" // Knative Fake Informer Injection
 	"knative.dev/pkg/injection"
@@ -31,6 +33,7 @@ import (
 
 	fakekafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
 	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/resetoffset/fake" // Force Fake Informer Injection
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
 	refmapperstesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers/testing"
 	configtesting "knative.dev/eventing-kafka/pkg/common/config/testing"
 	"knative.dev/eventing-kafka/pkg/common/configmaploader"
@@ -90,6 +93,29 @@ func TestNewControllerFactory(t *testing.T) {
 	mockResetOffsetRefMapper.AssertExpectations(t)
 }
 
+// Test The filterSupportedResetOffsets() Functionality
+func TestFilterSupportedResetOffsets(t *testing.T) {
+
+	// Create Test ResetOffsets
+	supportedResetOffset := controllertesting.NewResetOffset()
+	unsupportedResetOffset := controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{Kind: "Foo"}))
+
+	// Create A Mock ResetOffset Ref Mapper Supporting Only The Supported ResetOffset
+	mockResetOffsetRefMapper := &refmapperstesting.MockResetOffsetRefMapper{}
+	mockResetOffsetRefMapper.On("IsSupported", supportedResetOffset).Return(true)
+	mockResetOffsetRefMapper.On("IsSupported", unsupportedResetOffset).Return(false)
+
+	// Perform The Test
+	filterFunc := filterSupportedResetOffsets(mockResetOffsetRefMapper)
+
+	// Verify The Results
+	assert.True(t, filterFunc(supportedResetOffset))
+	assert.True(t, filterFunc(cache.DeletedFinalStateUnknown{Obj: supportedResetOffset}))
+	assert.False(t, filterFunc(unsupportedResetOffset))
+	assert.False(t, filterFunc("not-a-reset-offset"))
+	mockResetOffsetRefMapper.AssertExpectations(t)
+}
+
 // Test The Shutdown() Functionality
 func TestShutdown(t *testing.T) {
 	Shutdown() // Currently nothing to test
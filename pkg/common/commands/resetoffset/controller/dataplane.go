@@ -75,31 +75,59 @@ func (r *Reconciler) reconcileDataPlaneServices(ctx context.Context, resetOffset
 	}
 	logger.Debug("Detected DataPlane Services", zap.Any("Pod IPs", podIPs))
 
-	// Define Service Callback Functions To Manage The Reconciler AsyncCommandNotificationStore
+	// Define The Service Callback Function To Manage The Reconciler AsyncCommandNotificationStore
 	resetOffsetNamespacedName := types.NamespacedName{
 		Namespace: resetOffset.GetNamespace(),
 		Name:      resetOffset.GetName(),
 	}
-	newServiceCallbackFn := func(newHost string, service ctrl.Service) {
-		logger.Debug("New Control-Protocol Service Callback", zap.String("Host", newHost))
-		service.MessageHandler(r.asyncCommandNotificationStore.MessageHandler(resetOffsetNamespacedName, newHost))
-	}
 	oldServiceCallbackFn := func(oldHost string) {
 		logger.Debug("Old Control-Protocol Service Callback", zap.String("Host", oldHost))
 		r.asyncCommandNotificationStore.CleanPodNotification(resetOffsetNamespacedName, oldHost)
 	}
 
 	// Reconcile The Services/Connections For Specified Key / Pods
-	services, err := r.connectionPool.ReconcileConnections(ctx, refInfo.ConnectionPoolKey, podIPs, newServiceCallbackFn, oldServiceCallbackFn)
+	services, err := r.connectionPool.ReconcileConnections(ctx, refInfo.ConnectionPoolKey, podIPs, nil, oldServiceCallbackFn)
 	if err != nil {
 		logger.Error("Failed to reconcile connections", zap.Error(err))
 		return nil, err
 	}
 
+	// Set The MessageHandler Of All The Services, Which May Have Been Dialed By Another Controller Sharing The ConnectionPool
+	for host, service := range services {
+		service.MessageHandler(r.asyncCommandResultHandler(host))
+	}
+
 	// Return Success
 	return services, nil
 }
 
+// asyncCommandResultHandler returns the MessageHandler of the control-protocol connection to a DataPlane pod, which
+// stores the AsyncCommandResults for the ResetOffset that sent the command.  The connections to a DataPlane are shared
+// by all of its ResetOffsets (and possibly by other controllers), so the MessageHandler doesn't depend on the ResetOffset.
+func (r *Reconciler) asyncCommandResultHandler(host string) ctrl.MessageHandler {
+	return ctrl.MessageHandlerFunc(func(ctx context.Context, message ctrl.ServiceMessage) {
+		logger := logging.FromContext(ctx).Desugar().With(zap.String("Host", host))
+
+		// Parse The AsyncCommandResult To Determine The ResetOffset Of Its Command
+		asyncCommandResult := &ctrlmessage.AsyncCommandResult{}
+		err := asyncCommandResult.UnmarshalBinary(message.Payload())
+		if err != nil {
+			logger.Error("Failed to parse AsyncCommandResult", zap.Error(err))
+			message.AckWithError(err)
+			return
+		}
+		resetOffsetNamespacedName, ok := r.pendingAsyncCommands.Load(string(asyncCommandResult.CommandId))
+		if !ok {
+			logger.Debug("Ignoring AsyncCommandResult of unknown command", zap.Binary("CommandID", asyncCommandResult.CommandId))
+			message.Ack()
+			return
+		}
+
+		// Store The AsyncCommandResult For The ResetOffset
+		r.asyncCommandNotificationStore.MessageHandler(resetOffsetNamespacedName.(types.NamespacedName), host).HandleServiceMessage(ctx, message)
+	})
+}
+
 // startConsumerGroups sends Start messages to the specified DataPlane services for a Topic / ConsumerGroup and
 // waits for the async responses.  A multi-error is returned if any ConsumerGroup was not started successfully.
 func (r *Reconciler) startConsumerGroups(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset, services map[string]ctrl.Service, refInfo *refmappers.RefInfo) error {
@@ -182,8 +210,15 @@ func (r *Reconciler) sendConsumerGroupAsyncCommand(ctx context.Context,
 		return fmt.Errorf("received invalid ConsumerGroupAsyncCommand OpCode: %d", uint8(opCode))
 	}
 
-	// Create The ConsumerGroupAsyncCommand With CommandLock
-	consumerGroupAsyncCommand := commands.NewConsumerGroupAsyncCommand(commandId, refInfo.TopicName, refInfo.GroupId, commandLock)
+	// Create The ConsumerGroupAsyncCommand With CommandLock (The DataPlane Manages ConsumerGroups By GroupId, All Topics Included)
+	consumerGroupAsyncCommand := commands.NewConsumerGroupAsyncCommand(commandId, strings.Join(refInfo.TopicNames, ","), refInfo.GroupId, commandLock)
+
+	// Track The Command Until Its Result Is Received, For The Result To Be Routed To The ResetOffset
+	r.pendingAsyncCommands.Store(string(consumerGroupAsyncCommand.SerializedId()), types.NamespacedName{
+		Namespace: resetOffset.GetNamespace(),
+		Name:      resetOffset.GetName(),
+	})
+	defer r.pendingAsyncCommands.Delete(string(consumerGroupAsyncCommand.SerializedId()))
 
 	// Send The ConsumerGroupAsyncCommand & Wait For Acknowledgement
 	err = service.SendAndWaitForAck(opCode, consumerGroupAsyncCommand)
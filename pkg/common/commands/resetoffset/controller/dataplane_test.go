@@ -18,7 +18,9 @@ package controller
 
 import (
 	"context"
+	"encoding"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -30,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "knative.dev/control-protocol/pkg"
 	ctrlmessage "knative.dev/control-protocol/pkg/message"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 
@@ -118,6 +121,9 @@ func TestReconciler_ReconcileDataPlaneServices(t *testing.T) {
 					mock.AnythingOfType("func(string)")).
 					Return(test.expectedServices, test.connectionPoolErr)
 			}
+			for _, service := range test.expectedServices {
+				service.(*controlprotocoltesting.MockService).On("MessageHandler", mock.Anything).Return().Once()
+			}
 
 			// Create A Mock Control-Protocol AsyncCommandNotificationStore
 			mockAsyncCommandNotificationStore := &controlprotocoltesting.MockAsyncCommandNotificationStore{}
@@ -138,10 +144,63 @@ func TestReconciler_ReconcileDataPlaneServices(t *testing.T) {
 			mockPodLister.AssertExpectations(t)
 			mockAsyncCommandNotificationStore.AssertExpectations(t)
 			mockConnectionPool.AssertExpectations(t)
+			mockDataPlaneService1.AssertExpectations(t)
+			mockDataPlaneService2.AssertExpectations(t)
 		})
 	}
 }
 
+func TestReconciler_AsyncCommandResultsOfMultipleResetOffsets(t *testing.T) {
+
+	// Create A Context With Test Logger
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	// Create A Reconciler With A Real AsyncCommandNotificationStore
+	reconciler := &Reconciler{
+		uid:                           types.UID(uuid.NewString()),
+		asyncCommandNotificationStore: ctrlreconciler.NewAsyncCommandNotificationStore(func(types.NamespacedName) {}),
+	}
+
+	// Create A Single DataPlane Service Whose Connection Handler Is Set Once (As By The ConnectionPool)
+	podIp := "1.2.3.4"
+	service := &asyncCommandResultService{ctx: ctx}
+	service.MessageHandler(reconciler.asyncCommandResultHandler(podIp))
+	services := map[string]ctrl.Service{podIp: service}
+
+	// Stop The ConsumerGroups Of Two ResetOffsets Sharing The DataPlane, Both Receiving Their AsyncCommandResult
+	refInfo := refmapperstesting.NewRefInfo()
+	for _, name := range []string{"reset-offset-1", "reset-offset-2"} {
+		resetOffset := controllertesting.NewResetOffset()
+		resetOffset.Name = name
+		resetOffset.UID = types.UID(uuid.NewString())
+		assert.Nil(t, reconciler.stopConsumerGroups(ctx, resetOffset, services, refInfo))
+	}
+}
+
+// asyncCommandResultService is a control-protocol Service answering each AsyncCommand with a successful result
+type asyncCommandResultService struct {
+	ctx     context.Context
+	handler ctrl.MessageHandler
+}
+
+func (s *asyncCommandResultService) SendAndWaitForAck(opcode ctrl.OpCode, payload encoding.BinaryMarshaler) error {
+	result := ctrlmessage.AsyncCommandResult{CommandId: payload.(ctrlmessage.AsyncCommand).SerializedId()}
+	data, err := result.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	message := ctrl.NewMessage(uuid.New(), uint8(opcode), data)
+	go s.handler.HandleServiceMessage(s.ctx, ctrl.NewServiceMessage(&message, func(error) {}))
+	return nil
+}
+
+func (s *asyncCommandResultService) MessageHandler(handler ctrl.MessageHandler) {
+	s.handler = handler
+}
+
+func (s *asyncCommandResultService) ErrorHandler(_ ctrl.ErrorHandler) {}
+
 func TestReconciler_StartConsumerGroups(t *testing.T) {
 	performStartStopConsumerGroupAsyncCommandsTest(t, commands.StartConsumerGroupOpCode)
 }
@@ -213,8 +272,8 @@ func performStartStopConsumerGroupAsyncCommandsTest(t *testing.T, opCode ctrl.Op
 			}
 
 			// Create Test ConsumerGroupAsyncCommands For Each Pod
-			consumerGroupAsyncCommand1 := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: commandId1, TopicName: refInfo.TopicName, GroupId: refInfo.GroupId, Lock: commandLock}
-			consumerGroupAsyncCommand2 := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: commandId2, TopicName: refInfo.TopicName, GroupId: refInfo.GroupId, Lock: commandLock}
+			consumerGroupAsyncCommand1 := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: commandId1, TopicName: strings.Join(refInfo.TopicNames, ","), GroupId: refInfo.GroupId, Lock: commandLock}
+			consumerGroupAsyncCommand2 := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: commandId2, TopicName: strings.Join(refInfo.TopicNames, ","), GroupId: refInfo.GroupId, Lock: commandLock}
 
 			// Create A Mock Control-Protocol AsyncCommandNotificationStore & Assign To Reconciler
 			mockAsyncCommandNotificationStore := &controlprotocoltesting.MockAsyncCommandNotificationStore{}
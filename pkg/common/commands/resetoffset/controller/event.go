@@ -24,6 +24,7 @@ const (
 	ResetOffsetReconciled CoreV1EventType = iota
 	ResetOffsetFinalized
 	ResetOffsetSkipped
+	ResetOffsetDryRun
 )
 
 // CoreV1 EventType String Value
@@ -40,6 +41,8 @@ func (et CoreV1EventType) String() string {
 		eventTypeString = "ResetOffsetFinalized"
 	case ResetOffsetSkipped:
 		eventTypeString = "ResetOffsetSkipped"
+	case ResetOffsetDryRun:
+		eventTypeString = "ResetOffsetDryRun"
 	}
 
 	// Return The EventType String Value
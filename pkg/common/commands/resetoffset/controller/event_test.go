@@ -33,6 +33,7 @@ func TestEventTypes(t *testing.T) {
 		{name: "ResetOffsetReconciled", eventType: ResetOffsetReconciled, expect: "ResetOffsetReconciled"},
 		{name: "ResetOffsetFinalized", eventType: ResetOffsetFinalized, expect: "ResetOffsetFinalized"},
 		{name: "ResetOffsetSkipped", eventType: ResetOffsetSkipped, expect: "ResetOffsetSkipped"},
+		{name: "ResetOffsetDryRun", eventType: ResetOffsetDryRun, expect: "ResetOffsetDryRun"},
 	}
 
 	for _, test := range tests {
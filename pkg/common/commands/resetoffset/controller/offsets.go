@@ -33,6 +33,9 @@ import (
 // PartitionOffsetManagers is a map of Partition -> Sarama PartitionOffsetManager
 type PartitionOffsetManagers map[int32]sarama.PartitionOffsetManager
 
+// TopicPartitionOffsetManagers is a map of Topic -> PartitionOffsetManagers
+type TopicPartitionOffsetManagers map[string]PartitionOffsetManagers
+
 // SaramaNewClientFnType defines the Sarama NewClient() function signature.
 type SaramaNewClientFnType func([]string, *sarama.Config) (sarama.Client, error)
 
@@ -47,18 +50,28 @@ type SaramaNewOffsetManagerFromClientFnType func(group string, client sarama.Cli
 // function used when reconciling offsets which facilitates stubbing in unit tests.
 var SaramaNewOffsetManagerFromClientFn SaramaNewOffsetManagerFromClientFnType = sarama.NewOffsetManagerFromClient
 
-// reconcileOffsets updates the Offsets of all Partitions for the specified
-// Topic / ConsumerGroup to the Offset value corresponding to the specified
-// offsetTime (millis since epoch) and return OffsetMappings of the old/new
-// state.  An error will be returned and the Offsets will not be committed
-// if any problems occur.
-func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.RefInfo, offsetTime int64) ([]kafkav1alpha1.OffsetMapping, error) {
+// reconcileOffsets updates the Offsets of the Partitions for the specified
+// Topics / ConsumerGroup to the Offset value corresponding to the specified
+// offsetTime (millis since epoch), or to their explicit Offset, and return
+// OffsetMappings of the old/new state.  All Partitions are updated unless
+// a subset is specified via partitionOffsets.  An error will be returned
+// and the Offsets will not be committed if any problems occur.  When dryRun
+// is enabled the OffsetMappings are computed without moving or committing
+// any Offsets.
+func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.RefInfo, offsetTime int64, partitionOffsets []kafkav1alpha1.PartitionOffset, dryRun bool) ([]kafkav1alpha1.OffsetMapping, error) {
 
 	// Get The Logger From The Context & Enhance The With Parameters
 	logger := logging.FromContext(ctx).Desugar().With(
-		zap.String("Topic", refInfo.TopicName),
+		zap.Strings("Topics", refInfo.TopicNames),
 		zap.String("Group", refInfo.GroupId),
-		zap.Int64("Time", offsetTime))
+		zap.Int64("Time", offsetTime),
+		zap.Bool("DryRun", dryRun))
+
+	// Use The Kafka Connection Of The RefInfo If Specified (e.g. KafkaSource)
+	brokers, saramaConfig := r.kafkaBrokers, r.saramaConfig
+	if len(refInfo.Brokers) > 0 && refInfo.SaramaConfig != nil {
+		brokers, saramaConfig = refInfo.Brokers, refInfo.SaramaConfig
+	}
 
 	// Initialize A New Sarama Client
 	//
@@ -67,20 +80,31 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 	// after periods of inactivity to deal with...
 	//   https://github.com/Shopify/sarama/issues/1162
 	//   https://github.com/Shopify/sarama/issues/866
-	saramaClient, err := SaramaNewClientFn(r.kafkaBrokers, r.saramaConfig)
+	saramaClient, err := SaramaNewClientFn(brokers, saramaConfig)
 	defer safeCloseSaramaClient(logger, saramaClient)
 	if saramaClient == nil || err != nil {
 		logger.Error("Failed to create a new Sarama Client", zap.Error(err))
 		return nil, err
 	}
 
-	// Get The Partitions Of The Specified Kafka Topic
-	partitions, err := saramaClient.Partitions(refInfo.TopicName)
+	// Get The Partitions Of The Specified Kafka Topics
+	topicPartitions := make(map[string][]int32, len(refInfo.TopicNames))
+	for _, topicName := range refInfo.TopicNames {
+		partitions, err := saramaClient.Partitions(topicName)
+		if err != nil {
+			logger.Error("Failed to determine Partitions for Topic", zap.String("Topic", topicName), zap.Error(err))
+			return nil, err
+		}
+		logger.Debug("Found Topic Partitions", zap.String("Topic", topicName), zap.Any("Partitions", partitions))
+		topicPartitions[topicName] = partitions
+	}
+
+	// Select The Partitions To Update & Their Explicit Offsets (If Any)
+	topicPartitions, explicitOffsets, err := selectPartitions(topicPartitions, partitionOffsets)
 	if err != nil {
-		logger.Error("Failed to determine Partitions for Topic", zap.Error(err))
+		logger.Error("Failed to select the Partitions to update", zap.Error(err))
 		return nil, err
 	}
-	logger.Debug("Found Topic Partitions", zap.Any("Partitions", partitions))
 
 	// Create An OffsetManager For The Specified ConsumerGroup
 	offsetManager, err := SaramaNewOffsetManagerFromClientFn(refInfo.GroupId, saramaClient)
@@ -89,24 +113,41 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 		return nil, err
 	}
 
-	// Create The Required PartitionOffsetManagers For The Specified Topic / Partitions
-	partitionOffsetManagers, err := createPartitionOffsetManagers(offsetManager, refInfo.TopicName, partitions)
-	if err != nil {
-		logger.Error("Failed to create PartitionOffsetManagers for Topic Partitions", zap.Error(err))
-		_ = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
-		return nil, err
+	// Update The Partitions Of Every Topic
+	offsetMappings := make([]kafkav1alpha1.OffsetMapping, 0)
+	topicPartitionOffsetManagers := make(TopicPartitionOffsetManagers, len(refInfo.TopicNames))
+	for _, topicName := range refInfo.TopicNames {
+
+		// Create The Required PartitionOffsetManagers For The Topic / Partitions
+		partitions := topicPartitions[topicName]
+		partitionOffsetManagers, err := createPartitionOffsetManagers(offsetManager, topicName, partitions, dryRun)
+		topicPartitionOffsetManagers[topicName] = partitionOffsetManagers
+		if err != nil {
+			logger.Error("Failed to create PartitionOffsetManagers for Topic Partitions", zap.String("Topic", topicName), zap.Error(err))
+			_ = closeManagersAndDrainErrors(logger, offsetManager, topicPartitionOffsetManagers)
+			return nil, err
+		}
+
+		// Update The Topic Partitions To The Specified Offset Time Or Explicit Offsets
+		topicOffsetMappings, err := updateOffsets(logger, saramaClient, partitionOffsetManagers, topicName, partitions, offsetTime, explicitOffsets[topicName])
+		if err != nil {
+			logger.Error("Failed to update Offsets for Topic Partitions", zap.String("Topic", topicName), zap.Error(err))
+			_ = closeManagersAndDrainErrors(logger, offsetManager, topicPartitionOffsetManagers)
+			return nil, err
+		}
+		offsetMappings = append(offsetMappings, topicOffsetMappings...)
 	}
 
-	// Update All Topic Partitions To The Specified Offset Time
-	offsetMappings, err := updateOffsets(logger, saramaClient, offsetManager, partitionOffsetManagers, refInfo.TopicName, partitions, offsetTime)
-	if err != nil {
-		logger.Error("Failed to update Offsets for Topic Partitions", zap.Error(err))
-		_ = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
-		return nil, err
+	// All Partitions Of All Topics Updated Successfully - Commit The New Offsets (Unless Dry Run)!
+	if dryRun {
+		logger.Info("All Offsets computed successfully - skipping Commit for dry run")
+	} else {
+		logger.Info("All Offsets updated successfully - performing Commit")
+		offsetManager.Commit() // No Errors Returned - Will be in PartitionOffsetManager.Errors() Channel Post-Close!
 	}
 
 	// Close The Sarama Managers And Get Any Accumulated Errors
-	err = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
+	err = closeManagersAndDrainErrors(logger, offsetManager, topicPartitionOffsetManagers)
 	if err != nil {
 		logger.Error("PartitionOffsetManager Errors encountered", zap.Error(err))
 		return nil, err
@@ -117,18 +158,18 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 }
 
 // updateOffsets attempts to update all of the specified Topic's Partitions
-// and performs the final Commit() if all were successfully updated.  The
-// old/new Offset values are returned if successful.  Per the Sarama library
+// without committing them, so that the Offsets of all Topics can be committed
+// atomically.  The old/new Offset values are returned if successful.  Per the Sarama library
 // implementation, Errors directly related to Offset management are available
 // on the respective PartitionOffsetManager's Error channel.  Such errors are
 // not returned here as they should be drained after closing the Managers.
 func updateOffsets(logger *zap.Logger,
 	saramaClient sarama.Client,
-	offsetManager sarama.OffsetManager,
 	partitionOffsetManagers PartitionOffsetManagers,
 	topicName string,
 	partitions []int32,
-	offsetTime int64) ([]kafkav1alpha1.OffsetMapping, error) {
+	offsetTime int64,
+	explicitOffsets map[int32]int64) ([]kafkav1alpha1.OffsetMapping, error) {
 
 	// The OffsetMappings To Be Returned For ResetOffset Status
 	offsetMappings := make([]kafkav1alpha1.OffsetMapping, len(partitions))
@@ -146,8 +187,14 @@ func updateOffsets(logger *zap.Logger,
 			return nil, fmt.Errorf("missing PartitionOffsetManager - unable to update Offset")
 		}
 
-		// Update The Individual Offset To Specified Time
-		offsetMapping, updateErr := updateOffset(logger, saramaClient, partitionOffsetManager, topicName, partition, offsetTime)
+		// Update The Individual Offset To Explicit Offset Or Specified Time
+		var offsetMapping *kafkav1alpha1.OffsetMapping
+		var updateErr error
+		if explicitOffset, ok := explicitOffsets[partition]; ok {
+			offsetMapping = updateExplicitOffset(partitionOffsetManager, topicName, partition, explicitOffset)
+		} else {
+			offsetMapping, updateErr = updateOffset(logger, saramaClient, partitionOffsetManager, topicName, partition, offsetTime)
+		}
 		if updateErr != nil {
 			logger.Error("Failed to update Offset - skipping Commit", zap.Error(updateErr))
 			return nil, updateErr
@@ -155,10 +202,6 @@ func updateOffsets(logger *zap.Logger,
 		offsetMappings[index] = *offsetMapping
 	}
 
-	// All Partitions Updated Successfully - Commit The New Offsets!
-	logger.Info("All Offsets updated successfully - performing Commit")
-	offsetManager.Commit() // No Errors Returned - Will be in PartitionOffsetManager.Errors() Channel Post-Close!
-
 	// Return Success!
 	return offsetMappings, nil
 }
@@ -180,26 +223,120 @@ func updateOffset(logger *zap.Logger,
 		return nil, err
 	}
 
+	// Move The Partition's Offset To The New Offset
+	return moveOffset(partitionOffsetManager, topic, partition, newOffset, formatOffsetMetaData(offsetTime)), nil
+}
+
+// updateExplicitOffset performs an update of a single Partition's Offset to the
+// specified explicit Offset and returns an OffsetMapping representing the old/new
+// state.  No Offset changes are committed to allow for atomic commit/fail decision
+// for all Offsets.
+func updateExplicitOffset(partitionOffsetManager sarama.PartitionOffsetManager,
+	topic string,
+	partition int32,
+	offset int64) *kafkav1alpha1.OffsetMapping {
+	return moveOffset(partitionOffsetManager, topic, partition, offset, formatExplicitOffsetMetaData(offset))
+}
+
+// moveOffset moves the Partition's Offset forward/back to the new Offset and returns
+// an OffsetMapping representing the old/new state.
+func moveOffset(partitionOffsetManager sarama.PartitionOffsetManager,
+	topic string,
+	partition int32,
+	newOffset int64,
+	offsetMetaData string) *kafkav1alpha1.OffsetMapping {
+
 	// Get The Current Offset Of Partition (Accuracy Depends On ConsumerGroup Having Been Stopped)
 	currentOffset, _ := partitionOffsetManager.NextOffset()
 
 	// Update The Partition's Offset Forward/Back As Needed
-	offsetMetaData := formatOffsetMetaData(offsetTime)
 	if newOffset > currentOffset {
 		partitionOffsetManager.MarkOffset(newOffset, offsetMetaData) // No Errors Returned - On PartitionOffsetManager.Errors() Channel Instead
 	} else if newOffset < currentOffset {
 		partitionOffsetManager.ResetOffset(newOffset, offsetMetaData) // No Errors Returned - On PartitionOffsetManager.Errors() Channel Instead
 	}
 
-	// Create An OffsetMapping For The Partition
-	offsetMapping := &kafkav1alpha1.OffsetMapping{
-		Partition: partition,
-		OldOffset: currentOffset,
-		NewOffset: newOffset,
+	// Return An OffsetMapping For The Partition
+	return &kafkav1alpha1.OffsetMapping{
+		Topic:           topic,
+		Partition:       partition,
+		OldOffset:       currentOffset,
+		NewOffset:       newOffset,
+		EstimatedReplay: estimateReplay(currentOffset, newOffset),
 	}
+}
 
-	// Return The OffsetMapping Success
-	return offsetMapping, nil
+// estimateReplay returns the number of messages re-delivered (positive) or skipped (negative) by moving
+// from the currentOffset to the newOffset.  No estimate is possible if the ConsumerGroup has not committed
+// an Offset yet (Sarama then returns the negative initial OffsetNewest / OffsetOldest value).
+func estimateReplay(currentOffset int64, newOffset int64) int64 {
+	if currentOffset < 0 || newOffset < 0 {
+		return 0
+	}
+	return currentOffset - newOffset
+}
+
+// selectPartitions returns the subset of the specified Topic Partitions to be updated along with the explicit
+// Offsets of those Partitions (Topic -> Partition -> Offset).  All Partitions are selected if no partitionOffsets
+// are specified, and an error is returned if a specified Topic / Partition does not exist.
+func selectPartitions(topicPartitions map[string][]int32, partitionOffsets []kafkav1alpha1.PartitionOffset) (map[string][]int32, map[string]map[int32]int64, error) {
+
+	// Select All Partitions If No Subset Is Specified
+	explicitOffsets := make(map[string]map[int32]int64, len(topicPartitions))
+	if len(partitionOffsets) == 0 {
+		return topicPartitions, explicitOffsets, nil
+	}
+
+	// Determine The Requested Partitions Of Each Topic
+	requested := make(map[string]map[int32]bool, len(topicPartitions))
+	for _, partitionOffset := range partitionOffsets {
+		found := false
+		for topicName, partitions := range topicPartitions {
+			if partitionOffset.Topic != "" && partitionOffset.Topic != topicName {
+				continue
+			}
+			if !containsPartition(partitions, partitionOffset.Partition) {
+				continue
+			}
+			found = true
+			if requested[topicName] == nil {
+				requested[topicName] = make(map[int32]bool)
+			}
+			requested[topicName][partitionOffset.Partition] = true
+			if partitionOffset.Offset != nil {
+				if explicitOffsets[topicName] == nil {
+					explicitOffsets[topicName] = make(map[int32]int64)
+				}
+				explicitOffsets[topicName][partitionOffset.Partition] = *partitionOffset.Offset
+			}
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("partition %d of topic '%s' does not exist", partitionOffset.Partition, partitionOffset.Topic)
+		}
+	}
+
+	// Filter The Topic Partitions Preserving Their Order
+	selectedTopicPartitions := make(map[string][]int32, len(requested))
+	for topicName, partitions := range topicPartitions {
+		selected := make([]int32, 0, len(requested[topicName]))
+		for _, partition := range partitions {
+			if requested[topicName][partition] {
+				selected = append(selected, partition)
+			}
+		}
+		selectedTopicPartitions[topicName] = selected
+	}
+	return selectedTopicPartitions, explicitOffsets, nil
+}
+
+// containsPartition returns true if the specified partition is in the list of partitions.
+func containsPartition(partitions []int32, partition int32) bool {
+	for _, p := range partitions {
+		if p == partition {
+			return true
+		}
+	}
+	return false
 }
 
 // formatOffsetMetaData returns a "metadata" string, suitable for use with MarkOffset/ResetOffset, for the specified time.
@@ -207,6 +344,11 @@ func formatOffsetMetaData(time int64) string {
 	return fmt.Sprintf("resetoffset.%d", time)
 }
 
+// formatExplicitOffsetMetaData returns a "metadata" string, suitable for use with MarkOffset/ResetOffset, for the specified explicit offset.
+func formatExplicitOffsetMetaData(offset int64) string {
+	return fmt.Sprintf("resetoffset.offset.%d", offset)
+}
+
 // safeCloseSaramaClient will attempt to close the specified Sarama Client
 func safeCloseSaramaClient(logger *zap.Logger, client sarama.Client) {
 	if client != nil && !client.Closed() {
@@ -217,11 +359,15 @@ func safeCloseSaramaClient(logger *zap.Logger, client sarama.Client) {
 	}
 }
 
-// createPartitionOffsetManagers initializes the PartitionOffsetManagers for the specified topic / partitions.
-func createPartitionOffsetManagers(offsetManager sarama.OffsetManager, topicName string, partitions []int32) (PartitionOffsetManagers, error) {
+// createPartitionOffsetManagers initializes the PartitionOffsetManagers for the specified topic / partitions,
+// which will not move any Offsets if dryRun is enabled.
+func createPartitionOffsetManagers(offsetManager sarama.OffsetManager, topicName string, partitions []int32, dryRun bool) (PartitionOffsetManagers, error) {
 	partitionOffsetManagers := make(PartitionOffsetManagers, len(partitions))
 	for _, partition := range partitions {
 		partitionOffsetManager, err := offsetManager.ManagePartition(topicName, partition)
+		if dryRun && partitionOffsetManager != nil {
+			partitionOffsetManager = &dryRunPartitionOffsetManager{PartitionOffsetManager: partitionOffsetManager}
+		}
 		partitionOffsetManagers[partition] = partitionOffsetManager
 		if err != nil {
 			return partitionOffsetManagers, err
@@ -230,11 +376,22 @@ func createPartitionOffsetManagers(offsetManager sarama.OffsetManager, topicName
 	return partitionOffsetManagers, nil
 }
 
-// closePartitionOffsetManagers performs an AsyncClose on the PartitionOffsetManagers
-func closePartitionOffsetManagers(partitionOffsetManagers PartitionOffsetManagers) {
-	for _, partitionOffsetManager := range partitionOffsetManagers {
-		if partitionOffsetManager != nil {
-			partitionOffsetManager.AsyncClose() // Fast - No Errors Returned - Works Without AutoCommit ; )
+// dryRunPartitionOffsetManager is a PartitionOffsetManager which ignores any attempt to move the Offset,
+// allowing the new Offsets to be computed without altering the state of the ConsumerGroup.
+type dryRunPartitionOffsetManager struct {
+	sarama.PartitionOffsetManager
+}
+
+func (m *dryRunPartitionOffsetManager) MarkOffset(int64, string)  {}
+func (m *dryRunPartitionOffsetManager) ResetOffset(int64, string) {}
+
+// closePartitionOffsetManagers performs an AsyncClose on the PartitionOffsetManagers of all Topics
+func closePartitionOffsetManagers(topicPartitionOffsetManagers TopicPartitionOffsetManagers) {
+	for _, partitionOffsetManagers := range topicPartitionOffsetManagers {
+		for _, partitionOffsetManager := range partitionOffsetManagers {
+			if partitionOffsetManager != nil {
+				partitionOffsetManager.AsyncClose() // Fast - No Errors Returned - Works Without AutoCommit ; )
+			}
 		}
 	}
 }
@@ -245,10 +402,10 @@ func closePartitionOffsetManagers(partitionOffsetManagers PartitionOffsetManager
 // has been performed, and the errors could be related to prior MarkOffset / ResetOffset
 // / Commit operations.  These Sarama "managers" are intertwined and Sarama is very
 // proscriptive about the order in which they should be closed and drained.
-func closeManagersAndDrainErrors(logger *zap.Logger, offsetManager sarama.OffsetManager, partitionOffsetManagers PartitionOffsetManagers) error {
+func closeManagersAndDrainErrors(logger *zap.Logger, offsetManager sarama.OffsetManager, topicPartitionOffsetManagers TopicPartitionOffsetManagers) error {
 
 	// Close The PartitionOffsetManagers (Must Be Called Before Closing OffsetManager)
-	closePartitionOffsetManagers(partitionOffsetManagers)
+	closePartitionOffsetManagers(topicPartitionOffsetManagers)
 
 	// Close The OffsetManager (Must Be Called After Closing PartitionOffsetManagers)
 	if offsetManager != nil {
@@ -259,7 +416,10 @@ func closeManagersAndDrainErrors(logger *zap.Logger, offsetManager sarama.Offset
 	}
 
 	// Drain The PartitionOffsetManagers Error Channels (Must Be Called After Close)
-	pomErr := drainPartitionOffsetManagerErrors(partitionOffsetManagers)
+	var pomErr error
+	for _, partitionOffsetManagers := range topicPartitionOffsetManagers {
+		multierr.AppendInto(&pomErr, drainPartitionOffsetManagerErrors(partitionOffsetManagers))
+	}
 	if pomErr != nil {
 		logger.Error("Errors encountered during Offset update", zap.Errors("Sarama PartitionOffsetManager Errors", multierr.Errors(pomErr)))
 	}
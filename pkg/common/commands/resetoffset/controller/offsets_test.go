@@ -62,9 +62,14 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 	offsetTime := int64(123456789)
 	metadata := formatOffsetMetaData(offsetTime)
 
+	explicitOffset2 := int64(175)
+	explicitMetadata := formatExplicitOffsetMetaData(explicitOffset2)
+
 	// Define The Test Cases
 	tests := []struct {
 		name                    string
+		partitionOffsets        []kafkav1alpha1.PartitionOffset
+		dryRun                  bool
 		client                  *controllertesting.MockClient
 		offsetManager           *controllertesting.MockOffsetManager
 		partitionOffsetManagers map[int32]*controllertesting.MockPartitionOffsetManager
@@ -99,8 +104,8 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
 			},
 			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{
-				{Partition: partition1, OldOffset: oldOffset1, NewOffset: newFutureOffset1},
-				{Partition: partition2, OldOffset: oldOffset2, NewOffset: newFutureOffset2},
+				{Topic: topicName, Partition: partition1, OldOffset: oldOffset1, NewOffset: newFutureOffset1, EstimatedReplay: oldOffset1 - newFutureOffset1},
+				{Topic: topicName, Partition: partition2, OldOffset: oldOffset2, NewOffset: newFutureOffset2, EstimatedReplay: oldOffset2 - newFutureOffset2},
 			},
 			expectedErr: nil,
 		},
@@ -128,12 +133,121 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
 			},
 			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{
-				{Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1},
-				{Partition: partition2, OldOffset: oldOffset2, NewOffset: newPastOffset2},
+				{Topic: topicName, Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1, EstimatedReplay: oldOffset1 - newPastOffset1},
+				{Topic: topicName, Partition: partition2, OldOffset: oldOffset2, NewOffset: newPastOffset2, EstimatedReplay: oldOffset2 - newPastOffset2},
+			},
+			expectedErr: nil,
+		},
+
+		{
+			name:             "Successful Partition Subset",
+			partitionOffsets: []kafkav1alpha1.PartitionOffset{{Partition: partition2}},
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2}, nil),
+				controllertesting.WithClientMockGetOffset(topicName, partition2, offsetTime, newPastOffset2, nil),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			offsetManager: controllertesting.NewMockOffsetManager(
+				controllertesting.WithOffsetManagerMockCommit(),
+				controllertesting.WithOffsetManagerMockClose(nil)),
+			partitionOffsetManagers: map[int32]*controllertesting.MockPartitionOffsetManager{
+				partition2: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset2, ""),
+					controllertesting.WithPartitionOffsetManagerMockResetOffset(newPastOffset2, metadata),
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+			},
+			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{
+				{Topic: topicName, Partition: partition2, OldOffset: oldOffset2, NewOffset: newPastOffset2, EstimatedReplay: oldOffset2 - newPastOffset2},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Successful Explicit Offsets",
+			partitionOffsets: []kafkav1alpha1.PartitionOffset{
+				{Topic: topicName, Partition: partition1},
+				{Topic: topicName, Partition: partition2, Offset: &explicitOffset2},
+			},
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2}, nil),
+				controllertesting.WithClientMockGetOffset(topicName, partition1, offsetTime, newFutureOffset1, nil),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			offsetManager: controllertesting.NewMockOffsetManager(
+				controllertesting.WithOffsetManagerMockCommit(),
+				controllertesting.WithOffsetManagerMockClose(nil)),
+			partitionOffsetManagers: map[int32]*controllertesting.MockPartitionOffsetManager{
+				partition1: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset1, ""),
+					controllertesting.WithPartitionOffsetManagerMockMarkOffset(newFutureOffset1, metadata),
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+				partition2: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset2, ""),
+					controllertesting.WithPartitionOffsetManagerMockResetOffset(explicitOffset2, explicitMetadata),
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+			},
+			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{
+				{Topic: topicName, Partition: partition1, OldOffset: oldOffset1, NewOffset: newFutureOffset1, EstimatedReplay: oldOffset1 - newFutureOffset1},
+				{Topic: topicName, Partition: partition2, OldOffset: oldOffset2, NewOffset: explicitOffset2, EstimatedReplay: oldOffset2 - explicitOffset2},
+			},
+			expectedErr: nil,
+		},
+
+		{
+			name:   "Successful Dry Run",
+			dryRun: true,
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2}, nil),
+				controllertesting.WithClientMockGetOffset(topicName, partition1, offsetTime, newPastOffset1, nil),
+				controllertesting.WithClientMockGetOffset(topicName, partition2, offsetTime, newFutureOffset2, nil),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			offsetManager: controllertesting.NewMockOffsetManager(
+				controllertesting.WithOffsetManagerMockClose(nil)), // No Commit
+			partitionOffsetManagers: map[int32]*controllertesting.MockPartitionOffsetManager{
+				partition1: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset1, ""), // No ResetOffset
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+				partition2: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset2, ""), // No MarkOffset
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+			},
+			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{
+				{Topic: topicName, Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1, EstimatedReplay: oldOffset1 - newPastOffset1},
+				{Topic: topicName, Partition: partition2, OldOffset: oldOffset2, NewOffset: newFutureOffset2, EstimatedReplay: oldOffset2 - newFutureOffset2},
 			},
 			expectedErr: nil,
 		},
 
+		//
+		// Partition Selection Error Tests
+		//
+
+		{
+			name:             "Unknown Partition",
+			partitionOffsets: []kafkav1alpha1.PartitionOffset{{Partition: 7}},
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2}, nil),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			expectedOffsetMappings: nil,
+			expectedErr:            fmt.Errorf("partition 7 of topic '' does not exist"),
+		},
+		{
+			name:             "Unknown Topic",
+			partitionOffsets: []kafkav1alpha1.PartitionOffset{{Topic: "UnknownTopic", Partition: partition1}},
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2}, nil),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			expectedOffsetMappings: nil,
+			expectedErr:            fmt.Errorf("partition 0 of topic 'UnknownTopic' does not exist"),
+		},
+
 		//
 		// Sarama Error Tests
 		//
@@ -201,7 +315,7 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 					controllertesting.WithPartitionOffsetManagerMockErrors(),
 					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
 			},
-			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{{Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1}},
+			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{{Topic: topicName, Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1, EstimatedReplay: oldOffset1 - newPastOffset1}},
 			expectedErr:            nil,
 		},
 
@@ -239,7 +353,7 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 					controllertesting.WithPartitionOffsetManagerMockErrors(),
 					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
 			},
-			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{{Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1}},
+			expectedOffsetMappings: []kafkav1alpha1.OffsetMapping{{Topic: topicName, Partition: partition1, OldOffset: oldOffset1, NewOffset: newPastOffset1, EstimatedReplay: oldOffset1 - newPastOffset1}},
 			expectedErr:            nil,
 		},
 
@@ -321,12 +435,12 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 
 			// Create The RefInfo
 			refInfo := &refmappers.RefInfo{
-				TopicName: topicName,
-				GroupId:   groupId,
+				TopicNames: []string{topicName},
+				GroupId:    groupId,
 			}
 
 			// Perform The Test
-			offsetMappings, err := reconciler.reconcileOffsets(ctx, refInfo, offsetTime)
+			offsetMappings, err := reconciler.reconcileOffsets(ctx, refInfo, offsetTime, test.partitionOffsets, test.dryRun)
 
 			// Verify The Results
 			assert.Equal(t, test.expectedErr, err)
@@ -346,6 +460,86 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 	}
 }
 
+// Test The reconcileOffsets() Functionality With Multiple Topics & RefInfo Kafka Connection (e.g. KafkaSource)
+func TestReconciler_ReconcileOffsetsMultipleTopics(t *testing.T) {
+
+	// Test Data
+	refBrokers := []string{"TestRefBrokers"}
+	refSaramaConfig := sarama.NewConfig()
+	topicName1 := "TestTopicName1"
+	topicName2 := "TestTopicName2"
+	groupId := controllertesting.GroupId
+	partition := int32(0)
+	oldOffset1 := int64(100)
+	newOffset1 := int64(50)
+	oldOffset2 := int64(200)
+	newOffset2 := int64(250)
+	offsetTime := int64(123456789)
+	metadata := formatOffsetMetaData(offsetTime)
+
+	// Create The Mock Sarama Client / OffsetManager / PartitionOffsetManagers
+	client := controllertesting.NewMockClient(
+		controllertesting.WithClientMockPartitions(topicName1, []int32{partition}, nil),
+		controllertesting.WithClientMockPartitions(topicName2, []int32{partition}, nil),
+		controllertesting.WithClientMockGetOffset(topicName1, partition, offsetTime, newOffset1, nil),
+		controllertesting.WithClientMockGetOffset(topicName2, partition, offsetTime, newOffset2, nil),
+		controllertesting.WithClientMockClosed(false),
+		controllertesting.WithClientMockClose(nil))
+	partitionOffsetManager1 := controllertesting.NewMockPartitionOffsetManager(
+		controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset1, ""),
+		controllertesting.WithPartitionOffsetManagerMockResetOffset(newOffset1, metadata),
+		controllertesting.WithPartitionOffsetManagerMockErrors(),
+		controllertesting.WithPartitionOffsetManagerMockAsyncClose())
+	partitionOffsetManager2 := controllertesting.NewMockPartitionOffsetManager(
+		controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset2, ""),
+		controllertesting.WithPartitionOffsetManagerMockMarkOffset(newOffset2, metadata),
+		controllertesting.WithPartitionOffsetManagerMockErrors(),
+		controllertesting.WithPartitionOffsetManagerMockAsyncClose())
+	offsetManager := controllertesting.NewMockOffsetManager(
+		controllertesting.WithOffsetManagerMockManagePartition(topicName1, partition, partitionOffsetManager1, nil),
+		controllertesting.WithOffsetManagerMockManagePartition(topicName2, partition, partitionOffsetManager2, nil),
+		controllertesting.WithOffsetManagerMockCommit(),
+		controllertesting.WithOffsetManagerMockClose(nil))
+
+	// Stub The Sarama Functions - The RefInfo Kafka Connection Should Be Used
+	stubSaramaNewClientFn(t, refBrokers, refSaramaConfig, client, nil)
+	defer restoreSaramaNewClientFn()
+	stubSaramaNewOffsetManagerFromClientFn(t, groupId, client, offsetManager, nil)
+	defer restoreSaramaNewOffsetManagerFromClientFn()
+
+	// Create A Reconciler & RefInfo To Test
+	reconciler := &Reconciler{kafkaBrokers: []string{controllertesting.Brokers}, saramaConfig: sarama.NewConfig()}
+	refInfo := &refmappers.RefInfo{
+		TopicNames:   []string{topicName1, topicName2},
+		GroupId:      groupId,
+		Brokers:      refBrokers,
+		SaramaConfig: refSaramaConfig,
+	}
+
+	// Perform The Test
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+	offsetMappings, err := reconciler.reconcileOffsets(ctx, refInfo, offsetTime, nil, false)
+
+	// Verify The Results
+	assert.Nil(t, err)
+	assert.Equal(t, []kafkav1alpha1.OffsetMapping{
+		{Topic: topicName1, Partition: partition, OldOffset: oldOffset1, NewOffset: newOffset1, EstimatedReplay: oldOffset1 - newOffset1},
+		{Topic: topicName2, Partition: partition, OldOffset: oldOffset2, NewOffset: newOffset2, EstimatedReplay: oldOffset2 - newOffset2},
+	}, offsetMappings)
+	client.AssertExpectations(t)
+	offsetManager.AssertExpectations(t)
+	partitionOffsetManager1.AssertExpectations(t)
+	partitionOffsetManager2.AssertExpectations(t)
+}
+
+// Test The estimateReplay() Functionality
+func TestEstimateReplay(t *testing.T) {
+	assert.Equal(t, int64(50), estimateReplay(100, 50))
+	assert.Equal(t, int64(-50), estimateReplay(100, 150))
+	assert.Equal(t, int64(0), estimateReplay(sarama.OffsetNewest, 150))
+	assert.Equal(t, int64(0), estimateReplay(100, sarama.OffsetOldest))
+}
+
 //
 // Stubbing Utilities
 //
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -56,6 +57,7 @@ type Reconciler struct {
 	refMapper                     refmappers.ResetOffsetRefMapper
 	connectionPool                ctrlreconciler.ControlPlaneConnectionPool
 	asyncCommandNotificationStore ctrlreconciler.AsyncCommandNotificationStore
+	pendingAsyncCommands          sync.Map // Serialized AsyncCommand ID -> ResetOffset NamespacedName
 }
 
 // ReconcileKind implements the Reconciler Interface and is responsible for performing Offset repositioning.
@@ -89,10 +91,15 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 		return fmt.Errorf("failed to map 'ref' to Kafka Topic and Group: %v", err)
 	}
 	logger.Info("Successfully mapped ResetOffset.Spec.Ref", zap.Any("RefInfo", refInfo))
-	resetOffset.Status.SetTopic(refInfo.TopicName)
+	resetOffset.Status.SetTopic(strings.Join(refInfo.TopicNames, ","))
 	resetOffset.Status.SetGroup(refInfo.GroupId)
 	resetOffset.Status.MarkRefMappedTrue()
 
+	// Only Compute The New Offsets, Without Stopping The ConsumerGroups, When Performing A Dry Run
+	if resetOffset.Spec.DryRun {
+		return r.reconcileDryRun(ctx, resetOffset, refInfo)
+	}
+
 	// Reconcile The DataPlane "Services" From The ConnectionPool For Specified Key
 	dataPlaneServices, err := r.reconcileDataPlaneServices(ctx, resetOffset, refInfo)
 	if err != nil {
@@ -107,12 +114,10 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 	if !resetOffset.Status.IsOffsetsUpdated() {
 
 		// Parse The Sarama Offset Time From ResetOffset Spec
-		offsetTime, err := resetOffset.Spec.ParseSaramaOffsetTime()
+		offsetTime, err := parseOffsetTime(logger, resetOffset)
 		if err != nil {
-			logger.Error("Failed to parse Sarama Offset Time from ResetOffset Spec", zap.Error(err))
 			return err // Should never happen assuming Validation is in place
 		}
-		logger.Info("Successfully parsed Sarama Offset Time from ResetOffset Spec", zap.Int64("Time (millis)", offsetTime))
 
 		// Stop The ConsumerGroup In Associated Dispatchers
 		err = r.stopConsumerGroups(ctx, resetOffset, dataPlaneServices, refInfo)
@@ -125,7 +130,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 		resetOffset.Status.MarkConsumerGroupsStoppedTrue()
 
 		// Update The Sarama Offsets & Update ResetOffset CRD With OffsetMappings (Single Atomic Operation For All Offsets)
-		offsetMappings, err := r.reconcileOffsets(ctx, refInfo, offsetTime)
+		offsetMappings, err := r.reconcileOffsets(ctx, refInfo, offsetTime, resetOffset.Spec.Offset.Partitions, false)
 		if err != nil {
 			logger.Error("Failed to update Offsets of ConsumerGroup Partitions", zap.Error(err))
 			resetOffset.Status.MarkOffsetsUpdatedFailed("FailedToUpdateOffsets", "Failed to update Offsets of ConsumerGroup Partitions: %v", err)
@@ -152,6 +157,50 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 	return reconciler.NewEvent(corev1.EventTypeNormal, ResetOffsetReconciled.String(), "Reconciled successfully")
 }
 
+// reconcileDryRun computes the new Offsets of the ResetOffset and populates the Status.Partitions
+// without stopping the ConsumerGroups or committing the Offsets.  The OffsetsUpdated condition is
+// left Unknown so that the ResetOffset will be executed once the DryRun is disabled.
+func (r *Reconciler) reconcileDryRun(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset, refInfo *refmappers.RefInfo) reconciler.Event {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Parse The Sarama Offset Time From ResetOffset Spec
+	offsetTime, err := parseOffsetTime(logger, resetOffset)
+	if err != nil {
+		return err // Should never happen assuming Validation is in place
+	}
+
+	// Compute The Sarama Offsets Without Moving Or Committing Them
+	offsetMappings, err := r.reconcileOffsets(ctx, refInfo, offsetTime, resetOffset.Spec.Offset.Partitions, true)
+	if err != nil {
+		logger.Error("Failed to compute Offsets of ConsumerGroup Partitions", zap.Error(err))
+		resetOffset.Status.MarkOffsetsUpdatedFailed("FailedToComputeOffsets", "Failed to compute Offsets of ConsumerGroup Partitions: %v", err)
+		return fmt.Errorf("failed to compute Offsets of ConsumerGroup Partitions: %v", err)
+	}
+	resetOffset.Status.SetPartitions(offsetMappings)
+	logger.Info("Successfully computed Offsets of all partitions (dry run)")
+	resetOffset.Status.MarkOffsetsUpdatedDryRun()
+
+	// Return Dry Run Success Event
+	return reconciler.NewEvent(corev1.EventTypeNormal, ResetOffsetDryRun.String(), "Computed offsets without updating them (dry run)")
+}
+
+// parseOffsetTime returns the Sarama Offset Time of the ResetOffset Spec, which is optional (zero) when all the
+// Partitions have explicit Offsets.
+func parseOffsetTime(logger *zap.Logger, resetOffset *kafkav1alpha1.ResetOffset) (int64, error) {
+	if !resetOffset.Spec.HasOffsetTime() {
+		return 0, nil
+	}
+	offsetTime, err := resetOffset.Spec.ParseSaramaOffsetTime()
+	if err != nil {
+		logger.Error("Failed to parse Sarama Offset Time from ResetOffset Spec", zap.Error(err))
+		return 0, err
+	}
+	logger.Info("Successfully parsed Sarama Offset Time from ResetOffset Spec", zap.Int64("Time (millis)", offsetTime))
+	return offsetTime, nil
+}
+
 // FinalizeKind implements the Finalizer Interface and is responsible for performing any necessary cleanup.
 func (r *Reconciler) FinalizeKind(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset) reconciler.Event {
 
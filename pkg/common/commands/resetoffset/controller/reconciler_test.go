@@ -75,7 +75,7 @@ func TestReconcile(t *testing.T) {
 	metadata := formatOffsetMetaData(offsetTime)
 
 	offsetMappings := []kafkav1alpha1.OffsetMapping{
-		{Partition: 0, OldOffset: oldOffset, NewOffset: newOffset},
+		{Topic: topicName, Partition: 0, OldOffset: oldOffset, NewOffset: newOffset, EstimatedReplay: oldOffset - newOffset},
 	}
 
 	podIp := "1.2.3.4"
@@ -129,6 +129,28 @@ func TestReconcile(t *testing.T) {
 			},
 		},
 
+		{
+			Name:    "Dry Run Success",
+			Key:     controllertesting.ResetOffsetKey,
+			Objects: []runtime.Object{controllertesting.NewResetOffset(controllertesting.WithFinalizer, controllertesting.WithSpecDryRun)},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewResetOffset(
+						controllertesting.WithFinalizer,
+						controllertesting.WithSpecDryRun,
+						controllertesting.WithStatusInitialized,
+						controllertesting.WithStatusTopic(topicName),
+						controllertesting.WithStatusGroup(groupId),
+						controllertesting.WithStatusPartitions(offsetMappings),
+						controllertesting.WithStatusRefMapped(true),
+						controllertesting.WithStatusOffsetsUpdatedDryRun),
+				},
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, ResetOffsetDryRun.String(), "Computed offsets without updating them (dry run)"),
+			},
+		},
+
 		//
 		// "Skipping" Tests
 		//
@@ -238,6 +260,28 @@ func TestReconcile(t *testing.T) {
 				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("failed to update Offsets of ConsumerGroup Partitions: %v", testErr.Error())),
 			},
 		},
+		{
+			Name:          "Dry Run Compute Offsets Error",
+			Key:           controllertesting.ResetOffsetKey,
+			Objects:       []runtime.Object{controllertesting.NewResetOffset(controllertesting.WithFinalizer, controllertesting.WithSpecDryRun)},
+			OtherTestData: map[string]interface{}{"SaramaNewClientFnErr": testErr},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewResetOffset(
+						controllertesting.WithFinalizer,
+						controllertesting.WithSpecDryRun,
+						controllertesting.WithStatusInitialized,
+						controllertesting.WithStatusTopic(topicName),
+						controllertesting.WithStatusGroup(groupId),
+						controllertesting.WithStatusRefMapped(true),
+						controllertesting.WithStatusOffsetsUpdated(false, "FailedToComputeOffsets", "Failed to compute Offsets of ConsumerGroup Partitions: test-error")),
+				},
+			},
+			WantErr: true,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("failed to compute Offsets of ConsumerGroup Partitions: %v", testErr.Error())),
+			},
+		},
 		{
 			Name:          "Start ConsumerGroups Error",
 			Key:           controllertesting.ResetOffsetKey,
@@ -362,13 +406,14 @@ func TestReconcile(t *testing.T) {
 		assert.Nil(t, err)
 		startCommandId, err := GenerateCommandId(controllertesting.NewResetOffset(), podIp, commands.StartConsumerGroupOpCode)
 		assert.Nil(t, err)
-		stopConsumerGroupAsyncCommand := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: stopCommandId, TopicName: refInfo.TopicName, GroupId: refInfo.GroupId, Lock: stopCommandLock}
-		startConsumerGroupAsyncCommand := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: startCommandId, TopicName: refInfo.TopicName, GroupId: refInfo.GroupId, Lock: startCommandLock}
+		stopConsumerGroupAsyncCommand := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: stopCommandId, TopicName: strings.Join(refInfo.TopicNames, ","), GroupId: refInfo.GroupId, Lock: stopCommandLock}
+		startConsumerGroupAsyncCommand := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: startCommandId, TopicName: strings.Join(refInfo.TopicNames, ","), GroupId: refInfo.GroupId, Lock: startCommandLock}
 
 		// Create The Mock Service To Test Against
 		mockDataPlaneService := &controlprotocoltesting.MockService{}
 		mockDataPlaneService.On("SendAndWaitForAck", commands.StopConsumerGroupOpCode, stopConsumerGroupAsyncCommand).Return(stopConsumerGroupsErr)
 		mockDataPlaneService.On("SendAndWaitForAck", commands.StartConsumerGroupOpCode, startConsumerGroupAsyncCommand).Return(startConsumerGroupsErr)
+		mockDataPlaneService.On("MessageHandler", mock.Anything).Return().Maybe()
 		services := map[string]ctrl.Service{podIp: mockDataPlaneService}
 
 		// Create A Mock Control-Protocol ConnectionPool
@@ -97,6 +97,10 @@ func WithSpecRef(ref *duckv1.KReference) ResetOffsetOption {
 	}
 }
 
+func WithSpecDryRun(resetOffset *kafkav1alpha1.ResetOffset) {
+	resetOffset.Spec.DryRun = true
+}
+
 func WithDeletionTimestamp(resetOffset *kafkav1alpha1.ResetOffset) {
 	resetOffset.ObjectMeta.SetDeletionTimestamp(&DeletionTimestamp)
 }
@@ -167,6 +171,10 @@ func WithStatusOffsetsUpdated(state bool, failed ...string) ResetOffsetOption {
 	}
 }
 
+func WithStatusOffsetsUpdatedDryRun(resetOffset *kafkav1alpha1.ResetOffset) {
+	resetOffset.Status.MarkOffsetsUpdatedDryRun()
+}
+
 func WithStatusConsumerGroupsStarted(state bool, failed ...string) ResetOffsetOption {
 	return func(resetOffset *kafkav1alpha1.ResetOffset) {
 		if state {
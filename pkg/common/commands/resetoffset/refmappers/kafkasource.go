@@ -0,0 +1,229 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refmappers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/logging"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/apis/sources"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkaclusterinformers "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
+	kafkasourceinformers "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+	sourceslisters "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+//
+// KafkaSourceRefMapperFactory
+//
+
+// Verify The KafkaSource ResetOffsetRefMapperFactory Implements The Interface
+var _ ResetOffsetRefMapperFactory = &KafkaSourceRefMapperFactory{}
+
+// KafkaSourceRefMapperFactory implements the ResetOffsetRefMapperFactory for KafkaSources
+type KafkaSourceRefMapperFactory struct {
+	ConnectionPoolKeyMapper  KafkaSourceConnectionPoolKeyMapper
+	DataPlaneNamespaceMapper KafkaSourceDataPlaneNamespaceMapper
+	DataPlaneLabelsMapper    KafkaSourceDataPlaneLabelsMapper
+}
+
+// NewKafkaSourceRefMapperFactory returns an initialized KafkaSourceRefMapperFactory
+func NewKafkaSourceRefMapperFactory(connectionPoolKeyMapper KafkaSourceConnectionPoolKeyMapper,
+	dataPlaneNamespaceMapper KafkaSourceDataPlaneNamespaceMapper,
+	dataPlaneLabelsMapper KafkaSourceDataPlaneLabelsMapper) *KafkaSourceRefMapperFactory {
+
+	return &KafkaSourceRefMapperFactory{
+		ConnectionPoolKeyMapper:  connectionPoolKeyMapper,
+		DataPlaneNamespaceMapper: dataPlaneNamespaceMapper,
+		DataPlaneLabelsMapper:    dataPlaneLabelsMapper,
+	}
+}
+
+// Create implements the ResetOffsetRefMapperFactory interface for KafkaSource references.  It will return
+// a new KafkaSourceRefMapper instance using the specific data-plane mappers.  It also relies on the
+// Context having injected informers (KafkaSourceInformer & KafkaClusterInformer) and Kubernetes client.
+func (f *KafkaSourceRefMapperFactory) Create(ctx context.Context) ResetOffsetRefMapper {
+	return NewKafkaSourceRefMapper(ctx,
+		f.ConnectionPoolKeyMapper,
+		f.DataPlaneNamespaceMapper,
+		f.DataPlaneLabelsMapper)
+}
+
+//
+// KafkaSourceRefMapper
+//
+
+// KafkaSourceConnectionPoolKeyMapper defines a function signature for mapping a KafkaSource to a control-protocol ControlPlaneConnectionPool Key.
+type KafkaSourceConnectionPoolKeyMapper func(*sourcesv1beta1.KafkaSource) (string, error)
+
+// KafkaSourceDataPlaneNamespaceMapper defines a function signature for mapping a KafkaSource to the Kubernetes namespace of the DataPlane components.
+type KafkaSourceDataPlaneNamespaceMapper func(*sourcesv1beta1.KafkaSource) (string, error)
+
+// KafkaSourceDataPlaneLabelsMapper defines a function signature for mapping a KafkaSource to the Kubernetes labels of the DataPlane Pods.
+type KafkaSourceDataPlaneLabelsMapper func(*sourcesv1beta1.KafkaSource) (map[string]string, error)
+
+// Verify The KafkaSource ResetOffsetRefMapper Implements The Interface
+var _ ResetOffsetRefMapper = &KafkaSourceRefMapper{}
+
+// KafkaSourceRefMapper implements the ResetOffsetRefMapper for KafkaSources
+type KafkaSourceRefMapper struct {
+	logger                   *zap.Logger
+	kubeClient               kubernetes.Interface
+	kafkaSourceLister        sourceslisters.KafkaSourceLister
+	kafkaClusterLister       kafkalisters.KafkaClusterLister
+	connectionPoolKeyMapper  KafkaSourceConnectionPoolKeyMapper
+	dataPlaneNamespaceMapper KafkaSourceDataPlaneNamespaceMapper
+	dataPlaneLabelsMapper    KafkaSourceDataPlaneLabelsMapper
+}
+
+// NewKafkaSourceRefMapper returns an initialized KafkaSourceRefMapper
+func NewKafkaSourceRefMapper(ctx context.Context,
+	connectionPoolKeyMapper KafkaSourceConnectionPoolKeyMapper,
+	dataPlaneNamespaceMapper KafkaSourceDataPlaneNamespaceMapper,
+	dataPlaneLabelsMapper KafkaSourceDataPlaneLabelsMapper) *KafkaSourceRefMapper {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Get The KafkaSource Informer From Context (Context Must Have Injected Informers From SharedMain())
+	kafkaSourceInformer := kafkasourceinformers.Get(ctx)
+
+	// Return An Initialized KafkaSourceRefMapper
+	return &KafkaSourceRefMapper{
+		logger:                   logger,
+		kubeClient:               kubeclient.Get(ctx),
+		kafkaSourceLister:        kafkaSourceInformer.Lister(),
+		kafkaClusterLister:       kafkaclusterinformers.Get(ctx).Lister(),
+		connectionPoolKeyMapper:  connectionPoolKeyMapper,
+		dataPlaneNamespaceMapper: dataPlaneNamespaceMapper,
+		dataPlaneLabelsMapper:    dataPlaneLabelsMapper,
+	}
+}
+
+// IsSupported implements the ResetOffsetRefMapper interface and returns true for KafkaSource references.
+func (m *KafkaSourceRefMapper) IsSupported(resetOffset *kafkav1alpha1.ResetOffset) bool {
+	return resetOffset != nil && isKafkaSourceRef(resetOffset.Spec.Ref)
+}
+
+// MapRef implements the ResetOffsetRefMapper interface for KafkaSource references. It will return an
+// error in all cases other than successfully mapping the ResetOffset.Spec.Ref to Kafka Topics / Group.
+func (m *KafkaSourceRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (*RefInfo, error) {
+
+	// Validate The ResetOffset
+	if resetOffset == nil {
+		m.logger.Warn("Received nil ResetOffset argument")
+		return nil, fmt.Errorf("unable to map nil ResetOffset")
+	}
+
+	// Get The ResetOffset Ref From Spec & Enhance Logger
+	ref := resetOffset.Spec.Ref
+	logger := m.logger.With(zap.Any("Ref", ref))
+
+	// Validate The Reference
+	if !isKafkaSourceRef(ref) {
+		logger.Warn("Received ResetOffset with non KafkaSource reference")
+		return nil, fmt.Errorf("received ResetOffset with non KafkaSource reference: %v", ref)
+	}
+	if ref.Name == "" {
+		logger.Warn("Received ResetOffset with unnamed KafkaSource reference")
+		return nil, fmt.Errorf("received ResetOffset with unnamed KafkaSource reference: %v", ref)
+	}
+
+	// Default Optional Ref.Namespace If Not Provided
+	refNamespace := ref.Namespace
+	if refNamespace == "" {
+		refNamespace = resetOffset.Namespace
+	}
+
+	// Attempt To Get The Specified KafkaSource
+	kafkaSource, err := m.kafkaSourceLister.KafkaSources(refNamespace).Get(ref.Name)
+	if err != nil {
+		logger.Error("Failed to get KafkaSource referenced by ResetOffset", zap.Error(err))
+		return nil, fmt.Errorf("failed to get KafkaSource referenced by ResetOffset.Spec.Ref '%v': %v", ref, err)
+	}
+	if kafkaSource == nil {
+		logger.Info("No KafkaSource found for ResetOffset reference")
+		return nil, fmt.Errorf("no KafkaSource found for ResetOffset.Spec.Ref %v", ref)
+	}
+
+	// Validate The KafkaSource Topics (Or Topics Matching Its Pattern) & ConsumerGroup (Defaulted By The Webhook)
+	if len(kafkaSource.ConsumedTopics()) == 0 || kafkaSource.Spec.ConsumerGroup == "" {
+		logger.Warn("KafkaSource referenced by ResetOffset has no Topics or ConsumerGroup")
+		return nil, fmt.Errorf("KafkaSource referenced by ResetOffset.Spec.Ref '%v' has no topics or consumerGroup", ref)
+	}
+
+	// Get The Kafka Brokers & Sarama Config (Including Auth) Of The KafkaSource
+	brokers, saramaConfig, err := client.NewConfigFromSpec(context.Background(), m.kubeClient, m.kafkaClusterLister, kafkaSource)
+	if err != nil {
+		logger.Error("Failed to create Sarama Config for KafkaSource", zap.Error(err))
+		return nil, fmt.Errorf("failed to create Sarama Config for KafkaSource '%v': %v", ref, err)
+	}
+
+	// Force Enable Consumer Error Handling & Disable Manual Commits (Same As The ResetOffset Controller's Config)
+	saramaConfig.Consumer.Return.Errors = true
+	saramaConfig.Consumer.Offsets.AutoCommit.Enable = false
+
+	// Map The KafkaSource To The control-protocol ControlPlaneConnectionPool Key Via Custom KafkaSourceConnectionPoolKeyMapper
+	connectionPoolKey, err := m.connectionPoolKeyMapper(kafkaSource)
+	if err != nil {
+		logger.Error("Failed to map KafkaSource to ControlPlaneConnectionPool Key", zap.Error(err))
+		return nil, fmt.Errorf("failed to map KafkaSource '%v' to ConnectionPool Key: %v", ref, err)
+	}
+
+	// Map The KafkaSource To The Kubernetes Namespace of the DataPlane Pods.
+	dataPlaneNamespace, err := m.dataPlaneNamespaceMapper(kafkaSource)
+	if err != nil {
+		logger.Error("Failed to map KafkaSource to DataPlane Namespace", zap.Error(err))
+		return nil, fmt.Errorf("failed to map KafkaSource '%v' to DataPlane Namespace: %v", ref, err)
+	}
+
+	// Map The KafkaSource To The Kubernetes Labels of the DataPlane Pods.
+	dataPlaneLabels, err := m.dataPlaneLabelsMapper(kafkaSource)
+	if err != nil {
+		logger.Error("Failed to map KafkaSource to DataPlane Pod Labels", zap.Error(err))
+		return nil, fmt.Errorf("failed to map KafkaSource '%v' to DataPlane Pod Labels: %v", ref, err)
+	}
+
+	// Create The RefInfo Struct
+	refInfo := &RefInfo{
+		TopicNames:         kafkaSource.ConsumedTopics(),
+		GroupId:            kafkaSource.Spec.ConsumerGroup,
+		ConnectionPoolKey:  connectionPoolKey,
+		DataPlaneNamespace: dataPlaneNamespace,
+		DataPlaneLabels:    dataPlaneLabels,
+		Brokers:            brokers,
+		SaramaConfig:       saramaConfig,
+	}
+
+	// Successfully Mapped The Ref - Return Results
+	return refInfo, nil
+}
+
+// isKafkaSourceRef returns true if the specified KReference is a KafkaSource reference.
+func isKafkaSourceRef(ref duckv1.KReference) bool {
+	return strings.HasPrefix(ref.APIVersion, sources.GroupName) && ref.Kind == "KafkaSource"
+}
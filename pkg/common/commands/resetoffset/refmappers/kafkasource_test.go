@@ -0,0 +1,299 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refmappers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	_ "knative.dev/pkg/client/injection/kube/client/fake" // Knative Fake Client Injection
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster/fake" // Knative Fake Informer Injection
+	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource/fake" // Knative Fake Informer Injection
+	sourceslisters "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+)
+
+const (
+	KafkaSourceNamespace = "kafkasource-namespace"
+	KafkaSourceName      = "kafkasource-name"
+	KafkaSourceBroker    = "kafkasource-broker:9092"
+	KafkaSourceTopic1    = "TestKafkaSourceTopic1"
+	KafkaSourceTopic2    = "TestKafkaSourceTopic2"
+)
+
+func TestNewKafkaSourceRefMapperFactory(t *testing.T) {
+
+	// Create A Context With Test Logger
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	// Register Fake Informers (See Injection "_" Imports Above!)
+	ctx, fakeInformers := injection.Fake.SetupInformers(ctx, &rest.Config{})
+	assert.NotNil(t, fakeInformers)
+
+	// Create Test Mappers
+	connectionPoolKeyMapper := newMockKafkaSourceConnectionPoolKeyMapper(t, nil, ConnectionPoolKey, nil)
+	dataPlaneNamespaceMapper := newMockKafkaSourceDataPlaneNamespaceMapper(t, nil, DataPlaneNamespace, nil)
+	dataPlaneLabelsMapper := newMockKafkaSourceDataPlaneLabelsMapper(t, nil, DataPlaneLabels, nil)
+
+	// Perform The Test - Create New KafkaSource RefMapper Factory
+	factory := NewKafkaSourceRefMapperFactory(connectionPoolKeyMapper, dataPlaneNamespaceMapper, dataPlaneLabelsMapper)
+	assert.NotNil(t, factory)
+
+	// Test The Factory Create()
+	refMapper := factory.Create(ctx)
+	assert.NotNil(t, refMapper)
+}
+
+func TestNewKafkaSourceRefMapper(t *testing.T) {
+
+	// Create A Context With Test Logger
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	// Register Fake Informers (See Injection "_" Imports Above!)
+	ctx, fakeInformers := injection.Fake.SetupInformers(ctx, &rest.Config{})
+	assert.NotNil(t, fakeInformers)
+
+	// Perform The Test - Create A New KafkaSourceRefMapper
+	kafkaSourceRefMapper := NewKafkaSourceRefMapper(ctx,
+		newMockKafkaSourceConnectionPoolKeyMapper(t, nil, ConnectionPoolKey, nil),
+		newMockKafkaSourceDataPlaneNamespaceMapper(t, nil, DataPlaneNamespace, nil),
+		newMockKafkaSourceDataPlaneLabelsMapper(t, nil, DataPlaneLabels, nil))
+
+	// Verify The Results
+	assert.NotNil(t, kafkaSourceRefMapper)
+	assert.Equal(t, logger.Desugar(), kafkaSourceRefMapper.logger)
+	assert.NotNil(t, kafkaSourceRefMapper.kubeClient)
+	assert.NotNil(t, kafkaSourceRefMapper.kafkaSourceLister)
+	assert.NotNil(t, kafkaSourceRefMapper.connectionPoolKeyMapper) // Testify / DeepEqual Cannot Compare func Types
+	assert.NotNil(t, kafkaSourceRefMapper.dataPlaneNamespaceMapper)
+	assert.NotNil(t, kafkaSourceRefMapper.dataPlaneLabelsMapper)
+}
+
+func TestKafkaSourceRefMapper_IsSupported(t *testing.T) {
+	kafkaSourceRefMapper := &KafkaSourceRefMapper{logger: logtesting.TestLogger(t).Desugar()}
+	assert.False(t, kafkaSourceRefMapper.IsSupported(nil))
+	assert.False(t, kafkaSourceRefMapper.IsSupported(controllertesting.NewResetOffset()))
+	assert.True(t, kafkaSourceRefMapper.IsSupported(controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+		Kind:       "KafkaSource",
+		APIVersion: sourcesv1beta1.SchemeGroupVersion.String(),
+		Name:       KafkaSourceName,
+	}))))
+}
+
+func TestKafkaSourceRefMapper_MapRef(t *testing.T) {
+
+	// Test Data
+	logger := logtesting.TestLogger(t).Desugar()
+	testErr := fmt.Errorf("test-error")
+
+	// Create A Test KafkaSource
+	kafkaSource := &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: KafkaSourceNamespace,
+			Name:      KafkaSourceName,
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{KafkaSourceBroker},
+			},
+			Topics:        []string{KafkaSourceTopic1, KafkaSourceTopic2},
+			ConsumerGroup: GroupId,
+		},
+	}
+
+	// Create A KafkaSource Without ConsumerGroup
+	kafkaSourceWithoutGroup := kafkaSource.DeepCopy()
+	kafkaSourceWithoutGroup.Name = "kafkasource-without-group"
+	kafkaSourceWithoutGroup.Spec.ConsumerGroup = ""
+
+	// Create The KafkaSource References
+	kafkaSourceRef := &duckv1.KReference{
+		Kind:       "KafkaSource",
+		APIVersion: sourcesv1beta1.SchemeGroupVersion.String(),
+		Namespace:  KafkaSourceNamespace,
+		Name:       KafkaSourceName,
+	}
+
+	// Define The Test Cases
+	tests := []struct {
+		name                     string
+		resetOffset              *kafkav1alpha1.ResetOffset
+		connectionPoolKeyMapper  KafkaSourceConnectionPoolKeyMapper
+		dataPlaneNamespaceMapper KafkaSourceDataPlaneNamespaceMapper
+		dataPlaneLabelsMapper    KafkaSourceDataPlaneLabelsMapper
+		wantRefInfo              bool
+		wantErr                  bool
+	}{
+		{
+			name:                     "Success",
+			resetOffset:              controllertesting.NewResetOffset(controllertesting.WithSpecRef(kafkaSourceRef)),
+			connectionPoolKeyMapper:  newMockKafkaSourceConnectionPoolKeyMapper(t, kafkaSource, ConnectionPoolKey, nil),
+			dataPlaneNamespaceMapper: newMockKafkaSourceDataPlaneNamespaceMapper(t, kafkaSource, DataPlaneNamespace, nil),
+			dataPlaneLabelsMapper:    newMockKafkaSourceDataPlaneLabelsMapper(t, kafkaSource, DataPlaneLabels, nil),
+			wantRefInfo:              true,
+		},
+		{
+			name:        "Nil ResetOffset",
+			resetOffset: nil,
+			wantErr:     true,
+		},
+		{
+			name: "Invalid ResetOffset.Spec.Ref",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+				Kind:       "Subscription",
+				APIVersion: "messaging.knative.dev/v1",
+				Name:       KafkaSourceName,
+			})),
+			wantErr: true,
+		},
+		{
+			name: "ResetOffset.Spec.Ref Without Name",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+				Kind:       "KafkaSource",
+				APIVersion: sourcesv1beta1.SchemeGroupVersion.String(),
+				Namespace:  KafkaSourceNamespace,
+			})),
+			wantErr: true,
+		},
+		{
+			name: "KafkaSource Not Found",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+				Kind:       "KafkaSource",
+				APIVersion: sourcesv1beta1.SchemeGroupVersion.String(),
+				Namespace:  KafkaSourceNamespace,
+				Name:       "unknown-kafkasource",
+			})),
+			wantErr: true,
+		},
+		{
+			name: "KafkaSource Without ConsumerGroup",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+				Kind:       "KafkaSource",
+				APIVersion: sourcesv1beta1.SchemeGroupVersion.String(),
+				Namespace:  KafkaSourceNamespace,
+				Name:       kafkaSourceWithoutGroup.Name,
+			})),
+			wantErr: true,
+		},
+		{
+			name:                     "ConnectionPoolKey Mapper Error",
+			resetOffset:              controllertesting.NewResetOffset(controllertesting.WithSpecRef(kafkaSourceRef)),
+			connectionPoolKeyMapper:  newMockKafkaSourceConnectionPoolKeyMapper(t, kafkaSource, ConnectionPoolKey, testErr),
+			dataPlaneNamespaceMapper: newMockKafkaSourceDataPlaneNamespaceMapper(t, kafkaSource, DataPlaneNamespace, nil),
+			dataPlaneLabelsMapper:    newMockKafkaSourceDataPlaneLabelsMapper(t, kafkaSource, DataPlaneLabels, nil),
+			wantErr:                  true,
+		},
+		{
+			name:                     "DataPlaneNamespace Mapper Error",
+			resetOffset:              controllertesting.NewResetOffset(controllertesting.WithSpecRef(kafkaSourceRef)),
+			connectionPoolKeyMapper:  newMockKafkaSourceConnectionPoolKeyMapper(t, kafkaSource, ConnectionPoolKey, nil),
+			dataPlaneNamespaceMapper: newMockKafkaSourceDataPlaneNamespaceMapper(t, kafkaSource, DataPlaneNamespace, testErr),
+			dataPlaneLabelsMapper:    newMockKafkaSourceDataPlaneLabelsMapper(t, kafkaSource, DataPlaneLabels, nil),
+			wantErr:                  true,
+		},
+		{
+			name:                     "DataPlaneLabels Mapper Error",
+			resetOffset:              controllertesting.NewResetOffset(controllertesting.WithSpecRef(kafkaSourceRef)),
+			connectionPoolKeyMapper:  newMockKafkaSourceConnectionPoolKeyMapper(t, kafkaSource, ConnectionPoolKey, nil),
+			dataPlaneNamespaceMapper: newMockKafkaSourceDataPlaneNamespaceMapper(t, kafkaSource, DataPlaneNamespace, nil),
+			dataPlaneLabelsMapper:    newMockKafkaSourceDataPlaneLabelsMapper(t, kafkaSource, DataPlaneLabels, testErr),
+			wantErr:                  true,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A KafkaSourceLister Populated With The Test KafkaSources
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.Nil(t, indexer.Add(kafkaSource))
+			assert.Nil(t, indexer.Add(kafkaSourceWithoutGroup))
+
+			// Create A New KafkaSourceRefMapper To Test
+			kafkaSourceRefMapper := &KafkaSourceRefMapper{
+				logger:                   logger,
+				kubeClient:               fake.NewSimpleClientset(),
+				kafkaSourceLister:        sourceslisters.NewKafkaSourceLister(indexer),
+				connectionPoolKeyMapper:  test.connectionPoolKeyMapper,
+				dataPlaneNamespaceMapper: test.dataPlaneNamespaceMapper,
+				dataPlaneLabelsMapper:    test.dataPlaneLabelsMapper,
+			}
+
+			// Perform The Test - Map A KafkaSource To Kafka Topic Names & ConsumerGroup ID
+			refInfo, err := kafkaSourceRefMapper.MapRef(test.resetOffset)
+
+			// Validate The Results
+			assert.Equal(t, test.wantErr, err != nil)
+			if test.wantRefInfo {
+				assert.NotNil(t, refInfo)
+				assert.Equal(t, []string{KafkaSourceTopic1, KafkaSourceTopic2}, refInfo.TopicNames)
+				assert.Equal(t, GroupId, refInfo.GroupId)
+				assert.Equal(t, ConnectionPoolKey, refInfo.ConnectionPoolKey)
+				assert.Equal(t, DataPlaneNamespace, refInfo.DataPlaneNamespace)
+				assert.Equal(t, DataPlaneLabels, refInfo.DataPlaneLabels)
+				assert.Equal(t, []string{KafkaSourceBroker}, refInfo.Brokers)
+				assert.NotNil(t, refInfo.SaramaConfig)
+				assert.True(t, refInfo.SaramaConfig.Consumer.Return.Errors)
+				assert.False(t, refInfo.SaramaConfig.Consumer.Offsets.AutoCommit.Enable)
+			} else {
+				assert.Nil(t, refInfo)
+			}
+		})
+	}
+}
+
+//
+// Mock Mappers
+//
+
+func newMockKafkaSourceConnectionPoolKeyMapper(t *testing.T, expectedKafkaSource *sourcesv1beta1.KafkaSource, connectionPoolKey string, err error) KafkaSourceConnectionPoolKeyMapper {
+	return func(kafkaSource *sourcesv1beta1.KafkaSource) (string, error) {
+		assert.Equal(t, expectedKafkaSource, kafkaSource)
+		return connectionPoolKey, err
+	}
+}
+
+func newMockKafkaSourceDataPlaneNamespaceMapper(t *testing.T, expectedKafkaSource *sourcesv1beta1.KafkaSource, dataPlaneNamespace string, err error) KafkaSourceDataPlaneNamespaceMapper {
+	return func(kafkaSource *sourcesv1beta1.KafkaSource) (string, error) {
+		assert.Equal(t, expectedKafkaSource, kafkaSource)
+		return dataPlaneNamespace, err
+	}
+}
+
+func newMockKafkaSourceDataPlaneLabelsMapper(t *testing.T, expectedKafkaSource *sourcesv1beta1.KafkaSource, dataPlaneLabels map[string]string, err error) KafkaSourceDataPlaneLabelsMapper {
+	return func(kafkaSource *sourcesv1beta1.KafkaSource) (map[string]string, error) {
+		assert.Equal(t, expectedKafkaSource, kafkaSource)
+		return dataPlaneLabels, err
+	}
+}
@@ -26,6 +26,7 @@ import (
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	subscriptioninformers "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription"
 	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
 
 	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
@@ -134,6 +135,11 @@ func NewSubscriptionRefMapper(ctx context.Context,
 	}
 }
 
+// IsSupported implements the ResetOffsetRefMapper interface and returns true for Subscription references.
+func (m *SubscriptionRefMapper) IsSupported(resetOffset *kafkav1alpha1.ResetOffset) bool {
+	return resetOffset != nil && isSubscriptionRef(resetOffset.Spec.Ref)
+}
+
 // MapRef implements the ResetOffsetRefMapper interface for Subscription references. It will return an
 // error in all cases other than successfully mapping the ResetOffset.Spec.Ref to a Kafka Topic / Group.
 func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (*RefInfo, error) {
@@ -149,7 +155,7 @@ func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (
 	logger := m.logger.With(zap.Any("Ref", ref))
 
 	// Validate The Reference
-	if !strings.HasPrefix(ref.APIVersion, messaging.GroupName) || ref.Kind != "Subscription" {
+	if !isSubscriptionRef(ref) {
 		m.logger.Warn("Received ResetOffset with non Subscription reference")
 		return nil, fmt.Errorf("received ResetOffset with non Subscription reference: %v", ref)
 	}
@@ -212,7 +218,7 @@ func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (
 
 	// Create The RefInfo Struct
 	refInfo := &RefInfo{
-		TopicName:          topicName,
+		TopicNames:         []string{topicName},
 		GroupId:            groupId,
 		ConnectionPoolKey:  connectionPoolKey,
 		DataPlaneNamespace: dataPlaneNamespace,
@@ -222,3 +228,8 @@ func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (
 	// Successfully Mapped The Ref - Return Results
 	return refInfo, nil
 }
+
+// isSubscriptionRef returns true if the specified KReference is a Knative Subscription reference.
+func isSubscriptionRef(ref duckv1.KReference) bool {
+	return strings.HasPrefix(ref.APIVersion, messaging.GroupName) && ref.Kind == "Subscription"
+}
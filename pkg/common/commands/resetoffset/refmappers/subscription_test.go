@@ -161,7 +161,7 @@ func TestResetOffsetSubscriptionRefMapper_MapRef(t *testing.T) {
 			dataPlaneNamespaceMapper: newMockSubscriptionDataPlaneNamespaceMapper(t, subscription, DataPlaneNamespace, nil),
 			dataPlaneLabelsMapper:    newMockSubscriptionDataPlaneLabelsMapper(t, subscription, DataPlaneLabels, nil),
 			wantRefInfo: &RefInfo{
-				TopicName:          TopicName,
+				TopicNames:         []string{TopicName},
 				GroupId:            GroupId,
 				ConnectionPoolKey:  ConnectionPoolKey,
 				DataPlaneNamespace: DataPlaneNamespace,
@@ -197,7 +197,7 @@ func TestResetOffsetSubscriptionRefMapper_MapRef(t *testing.T) {
 			dataPlaneNamespaceMapper: newMockSubscriptionDataPlaneNamespaceMapper(t, subscription, DataPlaneNamespace, nil),
 			dataPlaneLabelsMapper:    newMockSubscriptionDataPlaneLabelsMapper(t, subscription, DataPlaneLabels, nil),
 			wantRefInfo: &RefInfo{
-				TopicName:          TopicName,
+				TopicNames:         []string{TopicName},
 				GroupId:            GroupId,
 				ConnectionPoolKey:  ConnectionPoolKey,
 				DataPlaneNamespace: DataPlaneNamespace,
@@ -311,6 +311,17 @@ func TestResetOffsetSubscriptionRefMapper_MapRef(t *testing.T) {
 	}
 }
 
+func TestResetOffsetSubscriptionRefMapper_IsSupported(t *testing.T) {
+	subscriptionRefMapper := &SubscriptionRefMapper{logger: logtesting.TestLogger(t).Desugar()}
+	assert.False(t, subscriptionRefMapper.IsSupported(nil))
+	assert.False(t, subscriptionRefMapper.IsSupported(controllertesting.NewResetOffset()))
+	assert.True(t, subscriptionRefMapper.IsSupported(controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+		Kind:       "Subscription",
+		APIVersion: messagingv1.SchemeGroupVersion.String(),
+		Name:       SubscriptionName,
+	}))))
+}
+
 //
 // Mock SubscriptionLister
 //
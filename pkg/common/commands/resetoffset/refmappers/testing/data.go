@@ -42,7 +42,7 @@ func NewRefInfo(options ...RefInfoOption) *refmappers.RefInfo {
 
 	// Create The Default Test RefInfo
 	refInfo := &refmappers.RefInfo{
-		TopicName:          controllertesting.TopicName,
+		TopicNames:         []string{controllertesting.TopicName},
 		GroupId:            controllertesting.GroupId,
 		ConnectionPoolKey:  ConnectionPoolKey,
 		DataPlaneNamespace: DataPlaneNamespace,
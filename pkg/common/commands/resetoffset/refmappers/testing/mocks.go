@@ -50,6 +50,11 @@ type MockResetOffsetRefMapper struct {
 	mock.Mock
 }
 
+func (m *MockResetOffsetRefMapper) IsSupported(resetOffset *kafkav1alpha1.ResetOffset) bool {
+	args := m.Called(resetOffset)
+	return args.Bool(0)
+}
+
 func (m *MockResetOffsetRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (*refmappers.RefInfo, error) {
 	args := m.Called(resetOffset)
 	return args.Get(0).(*refmappers.RefInfo), args.Error(1)
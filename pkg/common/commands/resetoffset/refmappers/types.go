@@ -19,6 +19,8 @@ package refmappers
 import (
 	"context"
 
+	"github.com/Shopify/sarama"
+
 	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 )
 
@@ -34,6 +36,10 @@ type ResetOffsetRefMapperFactory interface {
 // extensibility to support additional KRef types, such as Triggers, instead of just
 // Subscriptions.
 type ResetOffsetRefMapper interface {
+	// IsSupported returns true if the ResetOffset.Spec.Ref is of a type handled by the
+	// mapper.  Unsupported ResetOffsets are ignored by the ResetOffset Controller so that
+	// several Controllers (KafkaChannel, KafkaSource, etc) can share the ResetOffset CRD.
+	IsSupported(*kafkav1alpha1.ResetOffset) bool
 	MapRef(*kafkav1alpha1.ResetOffset) (*RefInfo, error)
 }
 
@@ -41,10 +47,16 @@ type ResetOffsetRefMapper interface {
 // to a particular use-case, as provided by a customized ResetOffsetRefMapper implementation.
 // This allows implementations of Kafka Channels/Brokers/etc to differ from one another
 // and still make use of the shared ResetOffset Controller.
+//
+// The optional Brokers / SaramaConfig override the Kafka connection of the ResetOffset
+// Controller (config-kafka ConfigMap) for resources which specify their own Kafka cluster
+// (e.g. KafkaSource bootstrapServers / net).
 type RefInfo struct {
-	TopicName          string
+	TopicNames         []string
 	GroupId            string
 	ConnectionPoolKey  string
 	DataPlaneNamespace string
 	DataPlaneLabels    map[string]string
+	Brokers            []string
+	SaramaConfig       *sarama.Config
 }
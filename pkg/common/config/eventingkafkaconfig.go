@@ -47,9 +47,21 @@ type EKReceiverConfig struct {
 	EKKubernetesConfig
 }
 
-// EKDispatcherConfig has the base Kubernetes fields (Cpu, Memory, Replicas) only
+// EKDispatcherConfig has the base Kubernetes fields (Cpu, Memory, Replicas), the delivery ordering
+// of the messages of a partition ("ordered", "key-ordered" or "unordered") with its in-flight window,
+// and whether the subscriptions retry their failed events through retry topics instead of in-line
 type EKDispatcherConfig struct {
 	EKKubernetesConfig
+	DeliveryOrdering string `json:"deliveryOrdering,omitempty"`
+	MaxInFlight      int    `json:"maxInFlight,omitempty"`
+	RetryTopics      bool   `json:"retryTopics,omitempty"`
+}
+
+// EKProducerConfig configures the batching of the events produced by the channel receivers: the maximum
+// number of events of a batch, and the maximum time (in milliseconds) an event waits for its batch to fill
+type EKProducerConfig struct {
+	BatchSize int `json:"batchSize,omitempty"`
+	LingerMs  int `json:"lingerMs,omitempty"`
 }
 
 // EKCloudEventConfig contains the values send to the Knative cloudevents' ConfigureConnectionArgs function
@@ -75,6 +87,7 @@ type EKSourceConfig struct {
 type EKChannelConfig struct {
 	Dispatcher EKDispatcherConfig `json:"dispatcher,omitempty"` // Consolidated and Distributed channels
 	Receiver   EKReceiverConfig   `json:"receiver,omitempty"`   // Distributed channel only
+	Producer   EKProducerConfig   `json:"producer,omitempty"`   // Consolidated and Distributed channels
 	AdminType  string             `json:"adminType,omitempty"`  // Distributed channel only
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"strconv"
+	"strings"
 
 	"github.com/Shopify/sarama"
 	corev1 "k8s.io/api/core/v1"
@@ -98,14 +99,31 @@ func GetAuthConfigFromSecret(secret *corev1.Secret) *client.KafkaAuthConfig {
 	}
 
 	authConfig.SASL = &client.KafkaSaslConfig{
-		User:     username,
-		Password: string(secret.Data[constants.KafkaSecretKeyPassword]),
-		SaslType: saslType,
+		User:                username,
+		Password:            string(secret.Data[constants.KafkaSecretKeyPassword]),
+		SaslType:            saslType,
+		TokenURL:            string(secret.Data[constants.KafkaSecretKeyTokenURL]),
+		Scopes:              SplitScopes(string(secret.Data[constants.KafkaSecretKeyScopes])),
+		KerberosServiceName: string(secret.Data[constants.KafkaSecretKeyKerberosServiceName]),
+		KerberosRealm:       string(secret.Data[constants.KafkaSecretKeyKerberosRealm]),
+		KerberosKeytab:      string(secret.Data[constants.KafkaSecretKeyKerberosKeytab]),
+		KerberosConfig:      string(secret.Data[constants.KafkaSecretKeyKerberosConfig]),
 	}
 
 	return &authConfig
 }
 
+// SplitScopes returns the individual OAuth scopes of the specified comma separated list.
+func SplitScopes(scopes string) []string {
+	var result []string
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
+
 // JoinStringMaps returns a new map containing the contents of both argument maps, preferring the contents of map1 on conflict.
 func JoinStringMaps(map1 map[string]string, map2 map[string]string) map[string]string {
 	resultMap := make(map[string]string, len(map1))
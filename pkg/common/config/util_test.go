@@ -2,8 +2,10 @@ package config
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -133,6 +135,27 @@ func TestGetAuthConfigFromSecret(t *testing.T) {
 				constants.KafkaSecretKeySaslType: []byte(commontesting.OldAuthSaslType),
 			},
 		},
+		{
+			name: "Valid secret, OAUTHBEARER",
+			data: map[string][]byte{
+				constants.KafkaSecretKeyUsername: []byte("client-id"),
+				constants.KafkaSecretKeyPassword: []byte("client-secret"),
+				constants.KafkaSecretKeySaslType: []byte(sarama.SASLTypeOAuth),
+				constants.KafkaSecretKeyTokenURL: []byte("https://oauth.example.com/token"),
+				constants.KafkaSecretKeyScopes:   []byte("scope-a, scope-b"),
+			},
+		},
+		{
+			name: "Valid secret, GSSAPI",
+			data: map[string][]byte{
+				constants.KafkaSecretKeyUsername:            []byte("principal"),
+				constants.KafkaSecretKeySaslType:            []byte(sarama.SASLTypeGSSAPI),
+				constants.KafkaSecretKeyKerberosServiceName: []byte("kafka"),
+				constants.KafkaSecretKeyKerberosRealm:       []byte("EXAMPLE.COM"),
+				constants.KafkaSecretKeyKerberosKeytab:      []byte("a2V5dGFi"),
+				constants.KafkaSecretKeyKerberosConfig:      []byte("[libdefaults]"),
+			},
+		},
 		{
 			name: "Valid secret, backwards-compatibility, TLS and SASL",
 			data: map[string][]byte{
@@ -192,6 +215,12 @@ func TestGetAuthConfigFromSecret(t *testing.T) {
 				assertKey(t, testCase.data, constants.KafkaSecretKeySaslType, kafkaAuth.SASL.SaslType)
 				assertKey(t, testCase.data, SaslUser, kafkaAuth.SASL.User)
 				assertKey(t, testCase.data, SaslPassword, kafkaAuth.SASL.Password)
+				assertKey(t, testCase.data, constants.KafkaSecretKeyTokenURL, kafkaAuth.SASL.TokenURL)
+				assertKey(t, testCase.data, constants.KafkaSecretKeyScopes, strings.Join(kafkaAuth.SASL.Scopes, ", "))
+				assertKey(t, testCase.data, constants.KafkaSecretKeyKerberosServiceName, kafkaAuth.SASL.KerberosServiceName)
+				assertKey(t, testCase.data, constants.KafkaSecretKeyKerberosRealm, kafkaAuth.SASL.KerberosRealm)
+				assertKey(t, testCase.data, constants.KafkaSecretKeyKerberosKeytab, kafkaAuth.SASL.KerberosKeytab)
+				assertKey(t, testCase.data, constants.KafkaSecretKeyKerberosConfig, kafkaAuth.SASL.KerberosConfig)
 				if kafkaAuth.TLS != nil {
 					assertKey(t, testCase.data, TlsCacert, kafkaAuth.TLS.Cacert)
 					assertKey(t, testCase.data, TlsUsercert, kafkaAuth.TLS.Usercert)
@@ -208,6 +237,13 @@ func getSaramaTestSecret(t *testing.T, name string,
 	return commontesting.GetTestSaramaSecret(name, username, password, namespace, saslType)
 }
 
+// Test The SplitScopes() Functionality
+func TestSplitScopes(t *testing.T) {
+	assert.Nil(t, SplitScopes(""))
+	assert.Equal(t, []string{"scope-a"}, SplitScopes("scope-a"))
+	assert.Equal(t, []string{"scope-a", "scope-b"}, SplitScopes(" scope-a, ,scope-b "))
+}
+
 // Test The JoinStringMaps() Functionality
 func TestJoinStringMaps(t *testing.T) {
 	map1 := map[string]string{"key1": "value1a", "key2": "value2", "key3": "value3"}
@@ -67,6 +67,18 @@ const (
 	KafkaSecretKeyPassword = "password"
 	// KafkaSecretKeySaslType is the SASL type key in the Kafka Auth Config Secret
 	KafkaSecretKeySaslType = "sasltype"
+	// KafkaSecretKeyTokenURL is the OAUTHBEARER token endpoint key in the Kafka Auth Config Secret
+	KafkaSecretKeyTokenURL = "tokenurl"
+	// KafkaSecretKeyScopes is the OAUTHBEARER comma separated scopes key in the Kafka Auth Config Secret
+	KafkaSecretKeyScopes = "scopes"
+	// KafkaSecretKeyKerberosServiceName is the GSSAPI service name key in the Kafka Auth Config Secret
+	KafkaSecretKeyKerberosServiceName = "kerberosservicename"
+	// KafkaSecretKeyKerberosRealm is the GSSAPI realm key in the Kafka Auth Config Secret
+	KafkaSecretKeyKerberosRealm = "kerberosrealm"
+	// KafkaSecretKeyKerberosKeytab is the GSSAPI base64 encoded keytab key in the Kafka Auth Config Secret
+	KafkaSecretKeyKerberosKeytab = "kerberoskeytab"
+	// KafkaSecretKeyKerberosConfig is the GSSAPI krb5.conf key in the Kafka Auth Config Secret
+	KafkaSecretKeyKerberosConfig = "kerberosconfig"
 
 	// KnativeLoggingConfigMapNameEnvVarKey Is The Environment Variable Used For Knative Logging Configuration
 	KnativeLoggingConfigMapNameEnvVarKey = "CONFIG_LOGGING_NAME" // Note - Matches value of configMapNameEnv constant in Knative.dev/pkg/logging !
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// DefaultBatchMaxWait is the default maximum time a message waits for its batch to be full
+const DefaultBatchMaxWait = time.Second
+
+// KafkaBatchConsumerHandler is a KafkaConsumerHandler able to handle the messages of a partition in batches
+type KafkaBatchConsumerHandler interface {
+	KafkaConsumerHandler
+
+	// HandleBatch handles the messages of a partition, in offset order. When this function returns true,
+	// the consumer group offsets of all the messages are marked as consumed.
+	// The returned error is enqueued in errors channel.
+	HandleBatch(context context.Context, messages []*sarama.ConsumerMessage) (bool, error)
+}
+
+// WithBatching configures the messages of a partition to be dispatched in batches of up to maxSize
+// messages, a batch being dispatched when full or maxWait (DefaultBatchMaxWait if <= 0) after its
+// first message.  The batches of a partition are dispatched one at a time, whatever the delivery
+// ordering.  Batching is only enabled with a maxSize > 0 and a KafkaBatchConsumerHandler.
+func WithBatching(maxSize int, maxWait time.Duration) SaramaConsumerHandlerOption {
+	return func(handler *SaramaConsumerHandler) {
+		handler.batchMaxSize = maxSize
+		if maxWait <= 0 {
+			maxWait = DefaultBatchMaxWait
+		}
+		handler.batchMaxWait = maxWait
+	}
+}
+
+// consumeBatches dispatches the messages of the claim in batches, one batch at a time
+func (consumer *SaramaConsumerHandler) consumeBatches(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, handler KafkaBatchConsumerHandler) {
+	batch := make([]*sarama.ConsumerMessage, 0, consumer.batchMaxSize)
+	var batchTimeout <-chan time.Time
+	messages := claim.Messages()
+
+	flush := func() {
+		dispatched := batch
+		batch = make([]*sarama.ConsumerMessage, 0, consumer.batchMaxSize)
+		batchTimeout = nil
+
+		mustMark := consumer.dispatch(session, func(ctx context.Context) bool {
+			return consumer.handleBatch(ctx, claim, handler, dispatched)
+		})
+		if mustMark {
+			// Marking the last message marks the offsets of all the messages of the batch
+			consumer.markMessage(session, dispatched[len(dispatched)-1])
+		}
+	}
+
+	for {
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				// The messages of the last batch are dispatched again by the next session if it's closed
+				if len(batch) > 0 && session.Context().Err() == nil {
+					flush()
+				}
+				return
+			}
+
+			consumer.logMessage(message)
+
+			// Preemptively interrupt processing messages if the session is closed (see consumeOrdered)
+			if session.Context().Err() != nil {
+				consumer.logger.Infof("Session closed for %s/%d. Exiting ConsumeClaim ", claim.Topic(), claim.Partition())
+				return
+			}
+
+			batch = append(batch, message)
+			if len(batch) == 1 {
+				batchTimeout = time.After(consumer.batchMaxWait)
+			}
+			if len(batch) >= consumer.batchMaxSize {
+				flush()
+			}
+
+		case <-batchTimeout:
+			flush()
+
+		case <-session.Context().Done():
+			consumer.logger.Infof("Session closed for %s/%d. Exiting ConsumeClaim ", claim.Topic(), claim.Partition())
+			return
+		}
+	}
+}
+
+// handleBatch calls the KafkaBatchConsumerHandler with the specified messages, reporting any error, and
+// returns whether the messages must be marked
+func (consumer *SaramaConsumerHandler) handleBatch(ctx context.Context, claim sarama.ConsumerGroupClaim, handler KafkaBatchConsumerHandler, messages []*sarama.ConsumerMessage) bool {
+	mustMark, err := handler.HandleBatch(ctx, messages)
+
+	if err != nil {
+		consumer.logger.Infow("Failure while handling a batch of messages",
+			zap.String("topic", claim.Topic()),
+			zap.Int32("partition", claim.Partition()),
+			zap.Int64("firstOffset", messages[0].Offset),
+			zap.Int64("lastOffset", messages[len(messages)-1].Offset),
+			zap.Error(err))
+		consumer.errors <- err
+		consumer.handler.SetReady(claim.Partition(), false)
+	}
+
+	return mustMark
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// batchHandler records the sizes of the batches it handles and fails the batches containing failOffset
+type batchHandler struct {
+	mockMessageHandler
+	mutex      sync.Mutex
+	sizes      []int
+	failOffset int64
+}
+
+func (h *batchHandler) HandleBatch(_ context.Context, messages []*sarama.ConsumerMessage) (bool, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.sizes = append(h.sizes, len(messages))
+	for _, message := range messages {
+		if message.Offset == h.failOffset {
+			return false, errors.New("batch failed")
+		}
+	}
+	return true, nil
+}
+
+func (h *batchHandler) batchSizes() []int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]int(nil), h.sizes...)
+}
+
+type cancelableSession struct {
+	offsetRecordingSession
+	ctx context.Context
+}
+
+func (s *cancelableSession) Context() context.Context {
+	return s.ctx
+}
+
+type channelClaim struct {
+	mockConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c channelClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+func makeMessages(count int) []*sarama.ConsumerMessage {
+	messages := make([]*sarama.ConsumerMessage, count)
+	for i := range messages {
+		messages[i] = &sarama.ConsumerMessage{Offset: int64(i)}
+	}
+	return messages
+}
+
+func TestConsumeClaimBatches(t *testing.T) {
+	tests := map[string]struct {
+		failOffset   int64
+		expectSizes  []int
+		expectOffset int64
+		expectErrors int
+	}{
+		"all batches succeed": {
+			failOffset:   -1,
+			expectSizes:  []int{4, 4, 2},
+			expectOffset: 9,
+		},
+		"second batch fails": {
+			failOffset:   5,
+			expectSizes:  []int{4, 4, 2},
+			expectOffset: 9,
+			expectErrors: 1,
+		},
+		"last batch fails": {
+			failOffset:   8,
+			expectSizes:  []int{4, 4, 2},
+			expectOffset: 7,
+			expectErrors: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := makeMessages(10)
+			handler := &batchHandler{failOffset: test.failOffset}
+			errorCh := make(chan error, len(messages))
+			cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, errorCh, WithBatching(4, time.Minute))
+
+			session := &offsetRecordingSession{offset: -1}
+			claim := multiMessageClaim{messages: messages}
+
+			_ = cgh.ConsumeClaim(session, claim)
+
+			assert.Equal(t, test.expectSizes, handler.batchSizes())
+			assert.Equal(t, test.expectOffset, session.offset)
+			assert.Len(t, errorCh, test.expectErrors)
+		})
+	}
+}
+
+func TestConsumeClaimBatchMaxWait(t *testing.T) {
+	handler := &batchHandler{failOffset: -1}
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, make(chan error, 1), WithBatching(10, 10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &cancelableSession{offsetRecordingSession: offsetRecordingSession{offset: -1}, ctx: ctx}
+	claim := channelClaim{messages: make(chan *sarama.ConsumerMessage)}
+
+	done := make(chan struct{})
+	go func() {
+		_ = cgh.ConsumeClaim(session, claim)
+		close(done)
+	}()
+
+	for _, message := range makeMessages(3) {
+		claim.messages <- message
+	}
+
+	// The partial batch is dispatched once the maximum wait is elapsed
+	assert.Eventually(t, func() bool {
+		return len(handler.batchSizes()) == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int{3}, handler.batchSizes())
+
+	cancel()
+	<-done
+
+	assert.Equal(t, int64(2), session.offset)
+}
+
+func TestConsumeClaimBatchingRequiresBatchHandler(t *testing.T) {
+	messages := makeMessages(5)
+	handler := &concurrencyHandler{keyInFlight: make(map[string]int)}
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, make(chan error, 1), WithBatching(2, 0))
+
+	session := &offsetRecordingSession{}
+	_ = cgh.ConsumeClaim(session, multiMessageClaim{messages: messages})
+
+	// Handlers unable to handle batches are dispatched one message at a time
+	assert.Equal(t, 1, handler.maxInFlight)
+	assert.Equal(t, int64(4), session.offset)
+}
@@ -81,6 +81,16 @@ func (c kafkaConsumerGroupFactoryImpl) StartConsumerGroup(ctx context.Context, g
 	return c.startExistingConsumerGroup(groupID, consumerGroup, consumerGroup.Consume, topics, logger, handler, channelRef, options...), nil
 }
 
+// forCluster returns a copy of the factory creating the consumer groups of the given Kafka cluster,
+// with the sarama config of the factory if config is nil.
+func (c kafkaConsumerGroupFactoryImpl) forCluster(brokers []string, config *sarama.Config) kafkaConsumerGroupFactoryImpl {
+	c.addrs = brokers
+	if config != nil {
+		c.config = config
+	}
+	return c
+}
+
 // createConsumerGroup creates a Sarama ConsumerGroup using the newConsumerGroup wrapper, with the
 // factory's internal brokers and sarama config.
 func (c kafkaConsumerGroupFactoryImpl) createConsumerGroup(groupID string) (sarama.ConsumerGroup, error) {
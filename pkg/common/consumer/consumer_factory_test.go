@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -216,3 +217,22 @@ func TestErrorWhileNewConsumerGroup(t *testing.T) {
 		t.Errorf("Should contain an error with message consume error. Got %v", err)
 	}
 }
+
+func TestFactoryForCluster(t *testing.T) {
+	factory := kafkaConsumerGroupFactoryImpl{
+		config:         sarama.NewConfig(),
+		addrs:          []string{"b1", "b2"},
+		offsetsChecker: &mockConsumerGroupOffsetsChecker{},
+	}
+	clusterConfig := sarama.NewConfig()
+
+	cluster := factory.forCluster([]string{"c1"}, clusterConfig)
+	assert.Equal(t, []string{"c1"}, cluster.addrs)
+	assert.Same(t, clusterConfig, cluster.config)
+	assert.Same(t, factory.offsetsChecker, cluster.offsetsChecker)
+	assert.Equal(t, []string{"b1", "b2"}, factory.addrs)
+
+	brokersOnly := factory.forCluster([]string{"c1"}, nil)
+	assert.Equal(t, []string{"c1"}, brokersOnly.addrs)
+	assert.Same(t, factory.config, brokersOnly.config)
+}
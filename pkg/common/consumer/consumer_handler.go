@@ -19,6 +19,7 @@ package consumer
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/Shopify/sarama"
@@ -63,6 +64,20 @@ func WithTimeout(timeout time.Duration) SaramaConsumerHandlerOption {
 	}
 }
 
+// WithDeliveryOrdering configures how the messages of a partition are dispatched to the handler.
+// The maxInFlight argument bounds the number of messages of a partition being dispatched at the
+// same time with the KeyOrdered and Unordered delivery orderings (DefaultMaxInFlight if < 1).
+// Default is Ordered.
+func WithDeliveryOrdering(ordering DeliveryOrdering, maxInFlight int) SaramaConsumerHandlerOption {
+	return func(handler *SaramaConsumerHandler) {
+		handler.ordering = ordering
+		if maxInFlight < 1 {
+			maxInFlight = DefaultMaxInFlight
+		}
+		handler.maxInFlight = maxInFlight
+	}
+}
+
 // ConsumerHandler implements sarama.ConsumerGroupHandler and provides some glue code to simplify message handling
 // You must implement KafkaConsumerHandler and create a new SaramaConsumerHandler with it
 type SaramaConsumerHandler struct {
@@ -72,6 +87,16 @@ type SaramaConsumerHandler struct {
 	// Request to sink timeout
 	timeout time.Duration
 
+	// How the messages of a partition are dispatched to the handler
+	ordering DeliveryOrdering
+
+	// Maximum number of messages of a partition being dispatched at the same time (if not Ordered)
+	maxInFlight int
+
+	// Maximum number of messages and waiting time of the batches of messages (if batching)
+	batchMaxSize int
+	batchMaxWait time.Duration
+
 	lifecycleListener SaramaConsumerLifecycleListener
 
 	logger *zap.SugaredLogger
@@ -87,6 +112,8 @@ func NewConsumerHandler(logger *zap.SugaredLogger, handler KafkaConsumerHandler,
 		handler:           handler,
 		lifecycleListener: noopSaramaConsumerLifecycleListener{},
 		timeout:           60 * time.Second, // default rebalance timeout
+		ordering:          Ordered,
+		maxInFlight:       DefaultMaxInFlight,
 		logger:            logger,
 		errors:            errorsCh,
 	}
@@ -121,27 +148,30 @@ func (consumer *SaramaConsumerHandler) Cleanup(session sarama.ConsumerGroupSessi
 
 // ConsumeClaim must start a consumer loop of ConsumerGroupClaim's Messages().
 func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	consumer.logger.Infow(fmt.Sprintf("Starting partition consumer, topic: %s, partition: %d, initialOffset: %d", claim.Topic(), claim.Partition(), claim.InitialOffset()), zap.String("ConsumeGroup", consumer.handler.GetConsumerGroup()))
+	consumer.logger.Infow(fmt.Sprintf("Starting partition consumer, topic: %s, partition: %d, initialOffset: %d", claim.Topic(), claim.Partition(), claim.InitialOffset()), zap.String("ConsumeGroup", consumer.handler.GetConsumerGroup()), zap.String("ordering", string(consumer.ordering)))
 	consumer.handler.SetReady(claim.Partition(), true)
-	c := make(chan bool)
 
 	// NOTE:
 	// Do not move the code below to a goroutine.
 	// The `ConsumeClaim` itself is called within a goroutine, see:
 	// https://github.com/Shopify/sarama/blob/master/consumer_group.go#L27-L29
+	if batchHandler, ok := consumer.handler.(KafkaBatchConsumerHandler); ok && consumer.batchMaxSize > 0 {
+		consumer.consumeBatches(session, claim, batchHandler)
+	} else if consumer.ordering == KeyOrdered || consumer.ordering == Unordered {
+		consumer.consumeConcurrently(session, claim)
+	} else {
+		consumer.consumeOrdered(session, claim)
+	}
+
+	consumer.logger.Infof("Stopping partition consumer, topic: %s, partition: %d", claim.Topic(), claim.Partition())
+	return nil
+}
+
+// consumeOrdered dispatches the messages of the claim one at a time
+func (consumer *SaramaConsumerHandler) consumeOrdered(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) {
 	for message := range claim.Messages() {
 
-		// Debug Log Kafka ConsumerMessage
-		if consumer.logger.Desugar().Core().Enabled(zap.DebugLevel) {
-			// Checked Logging Level First To Avoid Calling StringifyHeaderPtrs In Production
-			consumer.logger.Debugw("Consuming Kafka Message",
-				zap.Any("Headers", kafkasarama.StringifyHeaderPtrs(message.Headers)), // Log human-readable strings, not base64
-				zap.ByteString("Key", message.Key),
-				zap.ByteString("Value", message.Value),
-				zap.String("Topic", message.Topic),
-				zap.Int32("Partition", message.Partition),
-				zap.Int64("Offset", message.Offset))
-		}
+		consumer.logMessage(message)
 
 		// Preemptively interrupt processing messages if the session is closed.
 		// Processing all messages from the buffered channel can take a long time,
@@ -151,51 +181,181 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 			break
 		}
 
-		// We need to control when to cancel Handle calls so give it a downstream context
-		hctx, cancel := context.WithCancel(context.Background())
+		// Wait for the message to be due, if delayed
+		if !consumer.delay(session, message) {
+			consumer.logger.Infof("Session closed for %s/%d. Exiting ConsumeClaim ", claim.Topic(), claim.Partition())
+			break
+		}
 
-		// Start Handle goroutine
-		go func() {
-			mustMark, err := consumer.handler.Handle(hctx, message)
+		mustMark := consumer.dispatch(session, func(ctx context.Context) bool {
+			return consumer.handle(ctx, claim, message)
+		})
 
-			if err != nil {
-				consumer.logger.Infow("Failure while handling a message", zap.String("topic", message.Topic), zap.Int32("partition", message.Partition), zap.Int64("offset", message.Offset), zap.Error(err))
-				consumer.errors <- err
-				consumer.handler.SetReady(claim.Partition(), false)
-			}
+		if mustMark {
+			consumer.markMessage(session, message)
+		}
+	}
+}
 
-			c <- mustMark
-		}()
+// dispatch calls the handle function in a goroutine and returns its result.  When the session is
+// canceled, the call is canceled if it doesn't return before the timeout.
+func (consumer *SaramaConsumerHandler) dispatch(session sarama.ConsumerGroupSession, handle func(ctx context.Context) bool) bool {
+	c := make(chan bool)
 
-		var mustMark bool
+	// We need to control when to cancel Handle calls so give it a downstream context
+	hctx, cancel := context.WithCancel(context.Background())
+
+	// Start Handle goroutine
+	go func() {
+		c <- handle(hctx)
+	}()
+
+	var mustMark bool
+	select {
+	case mustMark = <-c:
+		// Handle returned gracefully, call cancel to free the context resources.
+		cancel()
+	case <-session.Context().Done():
+		// Consumer session canceled, wait for in-flight request to finish before we hit a rebalance timeout
 		select {
+		case <-time.After(consumer.timeout):
+			// Handle still didn't return, cancel the in-flight request
+			cancel()
+			// Unblock the Handle goroutine
+			mustMark = <-c
 		case mustMark = <-c:
 			// Handle returned gracefully, call cancel to free the context resources.
 			cancel()
+		}
+	}
+	return mustMark
+}
+
+// consumeConcurrently dispatches up to maxInFlight messages of the claim at the same time,
+// serializing the messages sharing the same key with the KeyOrdered delivery ordering.
+// Only the contiguous completed offsets are marked.
+func (consumer *SaramaConsumerHandler) consumeConcurrently(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) {
+
+	// We need to control when to cancel Handle calls so give them a downstream context
+	hctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inFlight := make(chan struct{}, consumer.maxInFlight)
+	tracker := newOffsetTracker(func(message *sarama.ConsumerMessage) {
+		consumer.markMessage(session, message)
+	})
+	sequencer := newKeySequencer()
+	wg := sync.WaitGroup{}
+
+consumeLoop:
+	for message := range claim.Messages() {
+
+		consumer.logMessage(message)
+
+		// Preemptively interrupt processing messages if the session is closed (see consumeOrdered)
+		if session.Context().Err() != nil {
+			consumer.logger.Infof("Session closed for %s/%d. Exiting ConsumeClaim ", claim.Topic(), claim.Partition())
+			break
+		}
+
+		// Wait for the message to be due, if delayed (which also holds the later messages of the claim)
+		if !consumer.delay(session, message) {
+			consumer.logger.Infof("Session closed for %s/%d. Exiting ConsumeClaim ", claim.Topic(), claim.Partition())
+			break
+		}
+
+		// Wait for an in-flight slot to be available
+		select {
+		case inFlight <- struct{}{}:
 		case <-session.Context().Done():
-			// Consumer session canceled, wait for in-flight request to finish before we hit a rebalance timeout
-			select {
-			case <-time.After(consumer.timeout):
-				// Handle still didn't return, cancel the in-flight request
-				cancel()
-				// Unblock the Handle goroutine
-				mustMark = <-c
-			case mustMark = <-c:
-				// Handle returned gracefully, call cancel to free the context resources.
-				cancel()
-			}
+			consumer.logger.Infof("Session closed for %s/%d. Exiting ConsumeClaim ", claim.Topic(), claim.Partition())
+			break consumeLoop
 		}
 
-		if mustMark {
-			session.MarkMessage(message, "") // Mark kafka message as processed
-			if consumer.logger.Desugar().Core().Enabled(zap.DebugLevel) {
-				consumer.logger.Debugw("Message marked", zap.String("topic", message.Topic), zap.Binary("value", message.Value))
+		tracked := tracker.track(message)
+
+		var previous <-chan struct{}
+		release := func() {}
+		if consumer.ordering == KeyOrdered && len(message.Key) > 0 {
+			previous, release = sequencer.next(string(message.Key))
+		}
+
+		wg.Add(1)
+		go func(message *sarama.ConsumerMessage) {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+			defer release()
+
+			// Wait for the previous message with the same key, if any
+			if previous != nil {
+				select {
+				case <-previous:
+				case <-hctx.Done():
+					tracker.complete(tracked, false)
+					return
+				}
 			}
+
+			tracker.complete(tracked, consumer.handle(hctx, claim, message))
+		}(message)
+	}
+
+	// Wait for the in-flight messages before returning
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-session.Context().Done():
+		// Consumer session canceled, wait for in-flight requests to finish before we hit a rebalance timeout
+		select {
+		case <-done:
+		case <-time.After(consumer.timeout):
+			// Handle still didn't return, cancel the in-flight requests
+			cancel()
+			<-done
 		}
 	}
+}
 
-	consumer.logger.Infof("Stopping partition consumer, topic: %s, partition: %d", claim.Topic(), claim.Partition())
-	return nil
+// handle calls the KafkaConsumerHandler with the specified message, reporting any error, and
+// returns whether the message must be marked
+func (consumer *SaramaConsumerHandler) handle(ctx context.Context, claim sarama.ConsumerGroupClaim, message *sarama.ConsumerMessage) bool {
+	mustMark, err := consumer.handler.Handle(ctx, message)
+
+	if err != nil {
+		consumer.logger.Infow("Failure while handling a message", zap.String("topic", message.Topic), zap.Int32("partition", message.Partition), zap.Int64("offset", message.Offset), zap.Error(err))
+		consumer.errors <- err
+		consumer.handler.SetReady(claim.Partition(), false)
+	}
+
+	return mustMark
+}
+
+// markMessage marks the specified message as processed
+func (consumer *SaramaConsumerHandler) markMessage(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) {
+	session.MarkMessage(message, "") // Mark kafka message as processed
+	if consumer.logger.Desugar().Core().Enabled(zap.DebugLevel) {
+		consumer.logger.Debugw("Message marked", zap.String("topic", message.Topic), zap.Binary("value", message.Value))
+	}
+}
+
+// logMessage logs the specified message at debug level
+func (consumer *SaramaConsumerHandler) logMessage(message *sarama.ConsumerMessage) {
+	// Debug Log Kafka ConsumerMessage
+	if consumer.logger.Desugar().Core().Enabled(zap.DebugLevel) {
+		// Checked Logging Level First To Avoid Calling StringifyHeaderPtrs In Production
+		consumer.logger.Debugw("Consuming Kafka Message",
+			zap.Any("Headers", kafkasarama.StringifyHeaderPtrs(message.Headers)), // Log human-readable strings, not base64
+			zap.ByteString("Key", message.Key),
+			zap.ByteString("Value", message.Value),
+			zap.String("Topic", message.Topic),
+			zap.Int32("Partition", message.Partition),
+			zap.Int64("Offset", message.Offset))
+	}
 }
 
 var _ sarama.ConsumerGroupHandler = (*SaramaConsumerHandler)(nil)
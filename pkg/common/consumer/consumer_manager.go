@@ -95,6 +95,7 @@ type ManagerEvent struct {
 type KafkaConsumerGroupManager interface {
 	Reconfigure(brokers []string, config *sarama.Config) *ReconfigureError
 	StartConsumerGroup(ctx context.Context, groupId string, topics []string, handler KafkaConsumerHandler, ref types.NamespacedName, options ...SaramaConsumerHandlerOption) error
+	StartClusterConsumerGroup(ctx context.Context, brokers []string, config *sarama.Config, groupId string, topics []string, handler KafkaConsumerHandler, ref types.NamespacedName, options ...SaramaConsumerHandlerOption) error
 	CloseConsumerGroup(groupId string) error
 	Errors(groupId string) <-chan error
 	IsManaged(groupId string) bool
@@ -106,6 +107,12 @@ type KafkaConsumerGroupManager interface {
 // groupMap is a mapping of GroupIDs to managed Consumer Group interfaces
 type groupMap map[string]managedGroup
 
+// groupCluster is the Kafka cluster of a consumer group started with StartClusterConsumerGroup
+type groupCluster struct {
+	brokers []string
+	config  *sarama.Config
+}
+
 // kafkaConsumerGroupManagerImpl is the primary implementation of a KafkaConsumerGroupManager, which
 // handles control protocol messages and stopping/starting ("pausing/resuming") of ConsumerGroups.
 type kafkaConsumerGroupManagerImpl struct {
@@ -113,7 +120,8 @@ type kafkaConsumerGroupManagerImpl struct {
 	server         controlprotocol.ServerHandler
 	factory        *kafkaConsumerGroupFactoryImpl
 	groups         groupMap
-	groupLock      sync.RWMutex // Synchronizes write access to the groupMap
+	groupClusters  map[string]groupCluster // The Kafka clusters of the groups not using the manager's one
+	groupLock      sync.RWMutex            // Synchronizes write access to the groupMap and groupClusters
 	notifyChannels []chan ManagerEvent
 	eventLock      sync.Mutex
 	offsetsChecker ConsumerGroupOffsetsChecker
@@ -147,6 +155,7 @@ func NewConsumerGroupManager(logger *zap.Logger, serverHandler controlprotocol.S
 		logger:         logger,
 		server:         serverHandler,
 		groups:         make(groupMap),
+		groupClusters:  make(map[string]groupCluster),
 		factory:        &kafkaConsumerGroupFactoryImpl{addrs: brokers, config: config, offsetsChecker: offsetsChecker, enqueue: enqueue},
 		groupLock:      sync.RWMutex{},
 		eventLock:      sync.Mutex{},
@@ -239,7 +248,7 @@ func (m *kafkaConsumerGroupManagerImpl) Reconfigure(brokers []string, config *sa
 		}
 	}
 
-	m.factory = &kafkaConsumerGroupFactoryImpl{addrs: brokers, config: config, offsetsChecker: m.offsetsChecker}
+	m.factory = &kafkaConsumerGroupFactoryImpl{addrs: brokers, config: config, offsetsChecker: m.offsetsChecker, enqueue: m.factory.enqueue}
 
 	// Restart any groups this function stopped
 	m.logger.Info("Reconfigure Consumer Group Manager - Starting All Managed Consumer Groups")
@@ -265,11 +274,25 @@ func (m *kafkaConsumerGroupManagerImpl) Reconfigure(brokers []string, config *sa
 // StartConsumerGroup uses the consumer factory to create a new ConsumerGroup, add it to the list
 // of managed groups (for start/stop functionality) and start the Consume loop.
 func (m *kafkaConsumerGroupManagerImpl) StartConsumerGroup(ctx context.Context, groupId string, topics []string, handler KafkaConsumerHandler, ref types.NamespacedName, options ...SaramaConsumerHandlerOption) error {
+	return m.startManagedGroup(ctx, nil, groupId, topics, handler, ref, options...)
+}
+
+// StartClusterConsumerGroup is StartConsumerGroup for a group consuming from the given Kafka cluster
+// instead of the manager's one (with the manager's sarama config if config is nil).  The group keeps
+// consuming from that cluster when the manager is reconfigured.
+func (m *kafkaConsumerGroupManagerImpl) StartClusterConsumerGroup(ctx context.Context, brokers []string, config *sarama.Config, groupId string, topics []string, handler KafkaConsumerHandler, ref types.NamespacedName, options ...SaramaConsumerHandlerOption) error {
+	return m.startManagedGroup(ctx, &groupCluster{brokers: brokers, config: config}, groupId, topics, handler, ref, options...)
+}
+
+// startManagedGroup creates and starts a managed group consuming from the given Kafka cluster, or
+// from the manager's one if nil.
+func (m *kafkaConsumerGroupManagerImpl) startManagedGroup(ctx context.Context, cluster *groupCluster, groupId string, topics []string, handler KafkaConsumerHandler, ref types.NamespacedName, options ...SaramaConsumerHandlerOption) error {
 	logger := logging.FromContext(ctx)
 
 	groupLogger := m.logger.With(zap.String("GroupId", groupId))
 	groupLogger.Info("Creating New Managed ConsumerGroup")
-	group, err := m.factory.createConsumerGroup(groupId)
+	factory := m.clusterFactory(cluster)
+	group, err := factory.createConsumerGroup(groupId)
 	if err != nil {
 		groupLogger.Error("Failed To Create New Managed ConsumerGroup")
 		return err
@@ -287,12 +310,12 @@ func (m *kafkaConsumerGroupManagerImpl) StartConsumerGroup(ctx context.Context,
 
 	// The only things we really want from the factory are the cancel function for the customConsumerGroup
 	// and the error channel (if the error channel isn't read it will fill up and block between consume calls)
-	customGroup := m.factory.startExistingConsumerGroup(groupId, group, consume, topics, logger, handler, ref, options...)
+	customGroup := factory.startExistingConsumerGroup(groupId, group, consume, topics, logger, handler, ref, options...)
 	managedGrp := createManagedGroup(ctx, m.logger, group, customGroup.Errors(), cancel, customGroup.cancel)
 
 	// Add the Sarama ConsumerGroup we obtained from the factory to the managed group map,
 	// so that it can be stopped and started via control-protocol messages.
-	m.setGroup(groupId, managedGrp)
+	m.setGroup(groupId, managedGrp, cluster)
 	m.notify(ManagerEvent{Event: GroupCreated, GroupId: groupId})
 	return nil
 }
@@ -407,8 +430,10 @@ func (m *kafkaConsumerGroupManagerImpl) startConsumerGroup(lock *commands.Comman
 		return fmt.Errorf("start requested for consumer group not in managed list: %s", groupId)
 	}
 
+	// The group keeps consuming from the Kafka cluster it was started with (if not the manager's one)
+	factory := m.clusterFactory(m.getGroupCluster(groupId))
 	createGroup := func() (sarama.ConsumerGroup, error) {
-		return m.factory.createConsumerGroup(groupId)
+		return factory.createConsumerGroup(groupId)
 	}
 
 	// Instruct the managed group to use this new ConsumerGroup
@@ -434,11 +459,35 @@ func (m *kafkaConsumerGroupManagerImpl) getGroup(groupId string) managedGroup {
 	return m.groups[groupId]
 }
 
-// setGroup associates a group with a groupId in the groups map using the groupLock mutex
-func (m *kafkaConsumerGroupManagerImpl) setGroup(groupId string, group managedGroup) {
+// getGroupCluster returns the Kafka cluster of a group, nil for the manager's one, using the groupLock mutex
+func (m *kafkaConsumerGroupManagerImpl) getGroupCluster(groupId string) *groupCluster {
+	m.groupLock.RLock()
+	defer m.groupLock.RUnlock()
+	if cluster, ok := m.groupClusters[groupId]; ok {
+		return &cluster
+	}
+	return nil
+}
+
+// clusterFactory returns the consumer factory of the given Kafka cluster, the manager's one if nil
+func (m *kafkaConsumerGroupManagerImpl) clusterFactory(cluster *groupCluster) kafkaConsumerGroupFactoryImpl {
+	if cluster == nil {
+		return *m.factory
+	}
+	return m.factory.forCluster(cluster.brokers, cluster.config)
+}
+
+// setGroup associates a group and its Kafka cluster (nil for the manager's one) with a groupId in the
+// groups map using the groupLock mutex
+func (m *kafkaConsumerGroupManagerImpl) setGroup(groupId string, group managedGroup, cluster *groupCluster) {
 	m.groupLock.Lock()
 	defer m.groupLock.Unlock()
 	m.groups[groupId] = group
+	if cluster != nil {
+		m.groupClusters[groupId] = *cluster
+	} else {
+		delete(m.groupClusters, groupId)
+	}
 }
 
 // getGroup removes a group from the groups map by groupId, using the groupLock mutex
@@ -446,6 +495,7 @@ func (m *kafkaConsumerGroupManagerImpl) removeGroup(groupId string) {
 	m.groupLock.Lock()
 	defer m.groupLock.Unlock()
 	delete(m.groups, groupId)
+	delete(m.groupClusters, groupId)
 }
 
 // lockBefore will lock the managedGroup corresponding to the groupId, if lock.LockBefore is true
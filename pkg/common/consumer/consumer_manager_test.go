@@ -178,6 +178,39 @@ func TestStartConsumerGroup(t *testing.T) {
 	}
 }
 
+func TestStartClusterConsumerGroup(t *testing.T) {
+	defer restoreNewConsumerGroup(newConsumerGroup)
+
+	ctx := context.TODO()
+	managerConfig := &sarama.Config{}
+	clusterConfig := &sarama.Config{}
+	manager := NewConsumerGroupManager(logtesting.TestLogger(t).Desugar(), getMockServerHandler(), []string{"b1"}, managerConfig, &NoopConsumerGroupOffsetsChecker{}, func(ref types.NamespacedName) {})
+	mockGroup := kafkatesting.NewMockConsumerGroup()
+	mockGroup.On("Errors").Return(mockGroup.ErrorChan)
+	var createdAddrs []string
+	var createdConfig *sarama.Config
+	newConsumerGroup = func(addrs []string, groupID string, config *sarama.Config) (sarama.ConsumerGroup, error) {
+		createdAddrs = addrs
+		createdConfig = config
+		return mockGroup, nil
+	}
+
+	err := manager.StartClusterConsumerGroup(ctx, []string{"c1"}, clusterConfig, "testid", []string{}, nil, types.NamespacedName{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"c1"}, createdAddrs)
+	assert.Same(t, clusterConfig, createdConfig)
+
+	// The group is restarted on its own cluster, even after the manager is reconfigured
+	impl := manager.(*kafkaConsumerGroupManagerImpl)
+	impl.factory = &kafkaConsumerGroupFactoryImpl{addrs: []string{"b2"}, config: managerConfig}
+	factory := impl.clusterFactory(impl.getGroupCluster("testid"))
+	assert.Equal(t, []string{"c1"}, factory.addrs)
+	assert.Same(t, clusterConfig, factory.config)
+
+	impl.removeGroup("testid")
+	assert.Nil(t, impl.getGroupCluster("testid"))
+}
+
 func TestCloseConsumerGroup(t *testing.T) {
 	defer restoreNewConsumerGroup(newConsumerGroup) // must use if calling getManagerWithMockGroup in the test
 
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// KafkaDelayedConsumerHandler is a KafkaConsumerHandler whose messages may have to wait before being
+// handled (e.g. the retries of a retry topic, which are only due once their backoff has elapsed).  As
+// the later messages of a partition wait along with the delayed one, the messages of a partition should
+// be due in order (e.g. a retry topic per backoff), otherwise they are handled late.
+type KafkaDelayedConsumerHandler interface {
+	KafkaConsumerHandler
+
+	// NotBefore returns the time before which the message must not be handled (the zero time if the
+	// message can be handled immediately)
+	NotBefore(message *sarama.ConsumerMessage) time.Time
+}
+
+// delay waits until the message can be handled, and returns false if the session is closed before.
+// Only the claim of the message waits, the other partitions of the session keep being consumed.
+func (consumer *SaramaConsumerHandler) delay(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) bool {
+	delayedHandler, ok := consumer.handler.(KafkaDelayedConsumerHandler)
+	if !ok {
+		return true
+	}
+
+	wait := time.Until(delayedHandler.NotBefore(message))
+	if wait <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-session.Context().Done():
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type delayedHandler struct {
+	mockMessageHandler
+	notBefore time.Time
+	mutex     sync.Mutex
+	handled   []time.Time
+}
+
+func (h *delayedHandler) Handle(_ context.Context, _ *sarama.ConsumerMessage) (bool, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.handled = append(h.handled, time.Now())
+	return true, nil
+}
+
+func (h *delayedHandler) NotBefore(_ *sarama.ConsumerMessage) time.Time {
+	return h.notBefore
+}
+
+func TestConsumeClaimDelayed(t *testing.T) {
+	for _, ordering := range []DeliveryOrdering{Ordered, Unordered} {
+		t.Run(string(ordering), func(t *testing.T) {
+			messages := []*sarama.ConsumerMessage{{Offset: 1}, {Offset: 2}}
+			notBefore := time.Now().Add(100 * time.Millisecond)
+			handler := &delayedHandler{notBefore: notBefore}
+			cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, make(chan error, len(messages)), WithDeliveryOrdering(ordering, 2))
+
+			session := &cancelableSession{ctx: context.Background()}
+			_ = cgh.ConsumeClaim(session, multiMessageClaim{messages: messages})
+
+			assert.Len(t, handler.handled, len(messages))
+			for _, handled := range handler.handled {
+				assert.False(t, handled.Before(notBefore))
+			}
+			assert.Equal(t, int64(2), session.offset)
+		})
+	}
+}
+
+func TestConsumeClaimDelayedSessionClosed(t *testing.T) {
+	for _, ordering := range []DeliveryOrdering{Ordered, Unordered} {
+		t.Run(string(ordering), func(t *testing.T) {
+			messages := []*sarama.ConsumerMessage{{Offset: 1}}
+			handler := &delayedHandler{notBefore: time.Now().Add(time.Hour)}
+			cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, make(chan error, len(messages)), WithDeliveryOrdering(ordering, 2))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			session := &cancelableSession{ctx: ctx}
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			done := make(chan struct{})
+			go func() {
+				_ = cgh.ConsumeClaim(session, multiMessageClaim{messages: messages})
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("ConsumeClaim didn't return after the session was closed")
+			}
+			assert.Empty(t, handler.handled)
+			assert.Equal(t, int64(0), session.offset)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Shopify/sarama"
+)
+
+// DeliveryOrdering defines how the messages of a partition are dispatched to the KafkaConsumerHandler
+type DeliveryOrdering string
+
+const (
+	// Ordered dispatches the messages of a partition one at a time, in offset order.
+	Ordered DeliveryOrdering = "ordered"
+
+	// KeyOrdered dispatches the messages of a partition concurrently, except for messages
+	// sharing the same key which are dispatched one at a time, in offset order.
+	// Messages without a key are dispatched as if they were unordered.
+	KeyOrdered DeliveryOrdering = "key-ordered"
+
+	// Unordered dispatches the messages of a partition concurrently.
+	Unordered DeliveryOrdering = "unordered"
+
+	// DefaultMaxInFlight is the default maximum number of messages of a partition being
+	// dispatched at the same time with the KeyOrdered and Unordered delivery orderings.
+	DefaultMaxInFlight = 100
+)
+
+// ParseDeliveryOrdering returns the DeliveryOrdering matching the specified (case-insensitive)
+// value, defaulting to Ordered when the value is empty.
+func ParseDeliveryOrdering(value string) (DeliveryOrdering, error) {
+	switch ordering := DeliveryOrdering(strings.ToLower(value)); ordering {
+	case "":
+		return Ordered, nil
+	case Ordered, KeyOrdered, Unordered:
+		return ordering, nil
+	default:
+		return Ordered, fmt.Errorf("invalid delivery ordering %q, must be one of %q, %q or %q", value, Ordered, KeyOrdered, Unordered)
+	}
+}
+
+// trackedMessage is a message being dispatched, tracked by an offsetTracker
+type trackedMessage struct {
+	message  *sarama.ConsumerMessage
+	done     bool
+	mustMark bool
+}
+
+// offsetTracker tracks the messages of a single partition being dispatched concurrently
+// so that only contiguous completed offsets are marked.  Messages must be tracked in
+// offset order, which is the order they are received from the ConsumerGroupClaim.
+type offsetTracker struct {
+	mutex   sync.Mutex
+	pending []*trackedMessage
+	mark    func(message *sarama.ConsumerMessage)
+}
+
+// newOffsetTracker returns an offsetTracker calling the specified mark function for
+// the highest message of each contiguous range of completed messages.
+func newOffsetTracker(mark func(message *sarama.ConsumerMessage)) *offsetTracker {
+	return &offsetTracker{mark: mark}
+}
+
+// track starts tracking the specified message
+func (t *offsetTracker) track(message *sarama.ConsumerMessage) *trackedMessage {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tracked := &trackedMessage{message: message}
+	t.pending = append(t.pending, tracked)
+	return tracked
+}
+
+// complete records that the handling of the specified message is over, and marks the
+// highest message which must be marked among the contiguous completed messages, if any.
+// Messages which must not be marked do not prevent later messages from being marked,
+// which is consistent with the Ordered delivery.
+func (t *offsetTracker) complete(tracked *trackedMessage, mustMark bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tracked.done = true
+	tracked.mustMark = mustMark
+
+	var toMark *sarama.ConsumerMessage
+	completed := 0
+	for ; completed < len(t.pending) && t.pending[completed].done; completed++ {
+		if t.pending[completed].mustMark {
+			toMark = t.pending[completed].message
+		}
+	}
+	t.pending = t.pending[completed:]
+
+	if toMark != nil {
+		t.mark(toMark)
+	}
+}
+
+// keySequencer serializes the dispatching of messages sharing the same key
+type keySequencer struct {
+	mutex sync.Mutex
+	tails map[string]chan struct{}
+}
+
+func newKeySequencer() *keySequencer {
+	return &keySequencer{tails: make(map[string]chan struct{})}
+}
+
+// next enqueues a message with the specified key, and returns a channel which is closed when
+// the previous message with the same key is done (nil if there is none) as well as a function
+// which must be called when the message is done.
+func (s *keySequencer) next(key string) (<-chan struct{}, func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous := s.tails[key]
+	current := make(chan struct{})
+	s.tails[key] = current
+
+	return previous, func() {
+		s.mutex.Lock()
+		if s.tails[key] == current {
+			delete(s.tails, key)
+		}
+		s.mutex.Unlock()
+		close(current)
+	}
+}
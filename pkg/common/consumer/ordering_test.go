@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestParseDeliveryOrdering(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected DeliveryOrdering
+		err      bool
+	}{
+		{value: "", expected: Ordered},
+		{value: "ordered", expected: Ordered},
+		{value: "Key-Ordered", expected: KeyOrdered},
+		{value: "unordered", expected: Unordered},
+		{value: "random", expected: Ordered, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			ordering, err := ParseDeliveryOrdering(test.value)
+			assert.Equal(t, test.expected, ordering)
+			assert.Equal(t, test.err, err != nil)
+		})
+	}
+}
+
+func TestOffsetTracker(t *testing.T) {
+	var marked []int64
+	tracker := newOffsetTracker(func(message *sarama.ConsumerMessage) {
+		marked = append(marked, message.Offset)
+	})
+
+	tracked := make([]*trackedMessage, 5)
+	for i := range tracked {
+		tracked[i] = tracker.track(&sarama.ConsumerMessage{Offset: int64(i)})
+	}
+
+	tracker.complete(tracked[1], true)
+	assert.Empty(t, marked) // offset 0 still in flight
+
+	tracker.complete(tracked[0], true)
+	assert.Equal(t, []int64{1}, marked)
+
+	tracker.complete(tracked[3], true)
+	tracker.complete(tracked[4], false)
+	assert.Equal(t, []int64{1}, marked) // offset 2 still in flight
+
+	tracker.complete(tracked[2], false)
+	assert.Equal(t, []int64{1, 3}, marked) // offset 4 must not be marked
+	assert.Empty(t, tracker.pending)
+}
+
+func TestKeySequencer(t *testing.T) {
+	sequencer := newKeySequencer()
+
+	previous, releaseFirst := sequencer.next("key")
+	assert.Nil(t, previous)
+
+	previous, releaseSecond := sequencer.next("key")
+	assert.NotNil(t, previous)
+
+	other, releaseOther := sequencer.next("other")
+	assert.Nil(t, other)
+	releaseOther()
+
+	select {
+	case <-previous:
+		t.Fatal("previous message with the same key should not be done")
+	default:
+	}
+
+	releaseFirst()
+	<-previous
+	releaseSecond()
+	assert.Empty(t, sequencer.tails)
+}
+
+// concurrencyHandler records the maximum number of concurrent Handle calls, per key and overall
+type concurrencyHandler struct {
+	mutex          sync.Mutex
+	inFlight       int
+	maxInFlight    int
+	keyInFlight    map[string]int
+	maxKeyInFlight int
+}
+
+func (h *concurrencyHandler) Handle(_ context.Context, message *sarama.ConsumerMessage) (bool, error) {
+	h.mutex.Lock()
+	h.inFlight++
+	h.keyInFlight[string(message.Key)]++
+	if h.inFlight > h.maxInFlight {
+		h.maxInFlight = h.inFlight
+	}
+	if h.keyInFlight[string(message.Key)] > h.maxKeyInFlight {
+		h.maxKeyInFlight = h.keyInFlight[string(message.Key)]
+	}
+	h.mutex.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	h.mutex.Lock()
+	h.inFlight--
+	h.keyInFlight[string(message.Key)]--
+	h.mutex.Unlock()
+	return true, nil
+}
+
+func (h *concurrencyHandler) SetReady(int32, bool) {}
+
+func (h *concurrencyHandler) GetConsumerGroup() string {
+	return "consumer group"
+}
+
+type offsetRecordingSession struct {
+	mockConsumerGroupSession
+	mutex  sync.Mutex
+	offset int64
+}
+
+func (s *offsetRecordingSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if msg.Offset > s.offset {
+		s.offset = msg.Offset
+	}
+}
+
+type multiMessageClaim struct {
+	mockConsumerGroupClaim
+	messages []*sarama.ConsumerMessage
+}
+
+func (c multiMessageClaim) Messages() <-chan *sarama.ConsumerMessage {
+	ch := make(chan *sarama.ConsumerMessage, len(c.messages))
+	for _, message := range c.messages {
+		ch <- message
+	}
+	close(ch)
+	return ch
+}
+
+func TestConsumeClaimDeliveryOrdering(t *testing.T) {
+	tests := []struct {
+		ordering          DeliveryOrdering
+		maxInFlight       int
+		expectMaxInFlight func(t *testing.T, actual int)
+		expectMaxPerKey   int
+	}{
+		{
+			ordering:          Ordered,
+			expectMaxInFlight: func(t *testing.T, actual int) { assert.Equal(t, 1, actual) },
+			expectMaxPerKey:   1,
+		},
+		{
+			ordering:          KeyOrdered,
+			maxInFlight:       4,
+			expectMaxInFlight: func(t *testing.T, actual int) { assert.LessOrEqual(t, actual, 4) },
+			expectMaxPerKey:   1,
+		},
+		{
+			ordering:          Unordered,
+			maxInFlight:       3,
+			expectMaxInFlight: func(t *testing.T, actual int) { assert.Equal(t, 3, actual) },
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.ordering), func(t *testing.T) {
+			messages := make([]*sarama.ConsumerMessage, 20)
+			for i := range messages {
+				messages[i] = &sarama.ConsumerMessage{
+					Key:    []byte(fmt.Sprintf("key-%d", i%2)),
+					Offset: int64(i),
+				}
+			}
+
+			handler := &concurrencyHandler{keyInFlight: make(map[string]int)}
+			errorCh := make(chan error, len(messages))
+			cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, errorCh, WithDeliveryOrdering(test.ordering, test.maxInFlight))
+
+			session := &offsetRecordingSession{}
+			claim := multiMessageClaim{messages: messages}
+
+			_ = cgh.ConsumeClaim(session, claim)
+
+			test.expectMaxInFlight(t, handler.maxInFlight)
+			if test.expectMaxPerKey > 0 {
+				assert.Equal(t, test.expectMaxPerKey, handler.maxKeyInFlight)
+			}
+			assert.Equal(t, int64(len(messages)-1), session.offset)
+		})
+	}
+}
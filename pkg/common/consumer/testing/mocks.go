@@ -67,6 +67,11 @@ func (m *MockConsumerGroupManager) StartConsumerGroup(ctx context.Context, group
 	return m.Called(ctx, groupId, topics, handler, channelRef, options).Error(0)
 }
 
+func (m *MockConsumerGroupManager) StartClusterConsumerGroup(ctx context.Context, brokers []string, config *sarama.Config, groupId string, topics []string,
+	handler consumer.KafkaConsumerHandler, channelRef types.NamespacedName, options ...consumer.SaramaConsumerHandlerOption) error {
+	return m.Called(ctx, brokers, config, groupId, topics, handler, channelRef, options).Error(0)
+}
+
 func (m *MockConsumerGroupManager) CloseConsumerGroup(groupId string) error {
 	if group, ok := m.Groups[groupId]; ok {
 		_ = group.Close()
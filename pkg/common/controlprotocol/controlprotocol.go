@@ -18,6 +18,7 @@ package controlprotocol
 
 import (
 	"context"
+	"encoding"
 	"sync"
 	"time"
 
@@ -46,6 +47,7 @@ type ServerHandler interface {
 	AddAsyncHandler(opcode ctrl.OpCode, resultOpcode ctrl.OpCode, payloadType message.AsyncCommand, handler AsyncHandlerFunc)
 	AddSyncHandler(opcode ctrl.OpCode, handler ctrl.MessageHandlerFunc)
 	RemoveHandler(opcode ctrl.OpCode)
+	SendAndWaitForAck(opcode ctrl.OpCode, payload encoding.BinaryMarshaler) error
 }
 
 // serverHandlerImpl is the primary implementation of a ServerHandler
@@ -116,6 +118,11 @@ func (s *serverHandlerImpl) RemoveHandler(opcode ctrl.OpCode) {
 	s.setHandler()
 }
 
+// SendAndWaitForAck sends a message to the control-protocol client and waits for its acknowledgement
+func (s *serverHandlerImpl) SendAndWaitForAck(opcode ctrl.OpCode, payload encoding.BinaryMarshaler) error {
+	return s.server.SendAndWaitForAck(opcode, payload)
+}
+
 // setHandler re-sets the MessageHandler on the internal control-protocol service to a copy of the router
 func (s *serverHandlerImpl) setHandler() {
 	// Invoke the MessageHandler on the control-protocol service with a copy of our router map, to avoid it being
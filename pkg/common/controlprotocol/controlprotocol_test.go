@@ -99,3 +99,22 @@ func TestHandlers(t *testing.T) {
 
 	handler.Shutdown(time.Millisecond)
 }
+
+func TestSendAndWaitForAck(t *testing.T) {
+	saveStartServer := startServerWrapper
+	defer func() { startServerWrapper = saveStartServer }()
+
+	payload := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: 1}
+	mockService := &ctrltesting.MockService{}
+	mockService.On("MessageHandler", mock.Anything).Return()
+	mockService.On("SendAndWaitForAck", ctrl.OpCode(1), payload).Return(nil)
+
+	startServerWrapper = func(_ context.Context, _ ...network.ControlServerOption) (*network.ControlServer, error) {
+		return &network.ControlServer{Service: mockService}, nil
+	}
+	handler, err := NewServerHandler(context.Background(), 12345)
+	assert.Nil(t, err)
+
+	assert.Nil(t, handler.SendAndWaitForAck(ctrl.OpCode(1), payload))
+	mockService.AssertExpectations(t)
+}
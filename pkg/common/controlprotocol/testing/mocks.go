@@ -157,6 +157,11 @@ func (s *MockServerHandler) RemoveHandler(opcode ctrl.OpCode) {
 	_ = s.Called(opcode)
 }
 
+func (s *MockServerHandler) SendAndWaitForAck(opcode ctrl.OpCode, payload encoding.BinaryMarshaler) error {
+	args := s.Called(opcode, payload)
+	return args.Error(0)
+}
+
 func GetMockServerHandler() *MockServerHandler {
 	return &MockServerHandler{
 		Router:  make(ctrlservice.MessageRouter),
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offset
+
+import (
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// NewClientsFunc creates the Kafka client and cluster admin of a Kafka cluster.
+type NewClientsFunc func(brokers []string, config *sarama.Config) (sarama.Client, sarama.ClusterAdmin, error)
+
+// ClientsCache keeps the Kafka client and cluster admin of each resource (e.g. to compute the lag of its
+// consumer groups periodically) until the config of the resource changes, instead of connecting to the
+// Kafka cluster every time.  It is safe for concurrent use.
+type ClientsCache struct {
+	newClients NewClientsFunc
+
+	mu      sync.Mutex
+	clients map[string]*cachedClients
+}
+
+type cachedClients struct {
+	configKey string
+	client    sarama.Client
+	admin     sarama.ClusterAdmin
+}
+
+// NewClientsCache returns a cache creating the clients with newClients, or with NewClients if nil.
+func NewClientsCache(newClients NewClientsFunc) *ClientsCache {
+	if newClients == nil {
+		newClients = NewClients
+	}
+	return &ClientsCache{newClients: newClients, clients: make(map[string]*cachedClients)}
+}
+
+// NewClients creates a Kafka client and a cluster admin sharing its connections.
+func NewClients(brokers []string, config *sarama.Config) (sarama.Client, sarama.ClusterAdmin, error) {
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	return client, admin, nil
+}
+
+// Get returns the client and cluster admin of the resource.  They are created with the brokers and config
+// returned by newConfig when the resource has none yet, or when its config key changed since then.
+func (c *ClientsCache) Get(resource string, configKey string, newConfig func() ([]string, *sarama.Config, error)) (sarama.Client, sarama.ClusterAdmin, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.clients[resource]; ok {
+		if cached.configKey == configKey {
+			return cached.client, cached.admin, nil
+		}
+		delete(c.clients, resource)
+		cached.close()
+	}
+
+	brokers, config, err := newConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, admin, err := c.newClients(brokers, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.clients[resource] = &cachedClients{configKey: configKey, client: client, admin: admin}
+	return client, admin, nil
+}
+
+// Invalidate closes the client and cluster admin of the resource, so that they are created again by the
+// next Get, e.g. after they failed because the credentials of the Kafka cluster changed.
+func (c *ClientsCache) Invalidate(resource string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.clients[resource]; ok {
+		delete(c.clients, resource)
+		cached.close()
+	}
+}
+
+// Retain closes the clients and cluster admins of the resources other than the given ones.
+func (c *ClientsCache) Retain(resources sets.String) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for resource, cached := range c.clients {
+		if !resources.Has(resource) {
+			delete(c.clients, resource)
+			cached.close()
+		}
+	}
+}
+
+// Close closes the clients and cluster admins of all the resources.
+func (c *ClientsCache) Close() {
+	c.Retain(sets.NewString())
+}
+
+func (c *cachedClients) close() {
+	// The cluster admin closes the client it was created from, the client is closed again in case it wasn't
+	_ = c.admin.Close()
+	_ = c.client.Close()
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type closedClient struct {
+	sarama.Client
+	brokers []string
+	closed  bool
+}
+
+func (c *closedClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+type closedAdmin struct {
+	sarama.ClusterAdmin
+	closed bool
+}
+
+func (a *closedAdmin) Close() error {
+	a.closed = true
+	return nil
+}
+
+func TestClientsCache(t *testing.T) {
+	created := 0
+	cache := NewClientsCache(func(brokers []string, _ *sarama.Config) (sarama.Client, sarama.ClusterAdmin, error) {
+		created++
+		return &closedClient{brokers: brokers}, &closedAdmin{}, nil
+	})
+	config := func(brokers ...string) func() ([]string, *sarama.Config, error) {
+		return func() ([]string, *sarama.Config, error) {
+			return brokers, sarama.NewConfig(), nil
+		}
+	}
+
+	client, admin, err := cache.Get("ns/a", "1", config("broker-1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"broker-1"}, client.(*closedClient).brokers)
+
+	// The clients are kept while the config key is unchanged
+	sameClient, sameAdmin, err := cache.Get("ns/a", "1", config("ignored"))
+	require.NoError(t, err)
+	assert.Same(t, client, sameClient)
+	assert.Same(t, admin, sameAdmin)
+	assert.Equal(t, 1, created)
+
+	// The clients are created again when the config key changes
+	newClient, _, err := cache.Get("ns/a", "2", config("broker-2"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"broker-2"}, newClient.(*closedClient).brokers)
+	assert.True(t, client.(*closedClient).closed)
+	assert.True(t, admin.(*closedAdmin).closed)
+
+	// A failing config doesn't create the clients
+	_, _, err = cache.Get("ns/b", "1", func() ([]string, *sarama.Config, error) {
+		return nil, nil, errors.New("invalid config")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 2, created)
+
+	// The invalidated clients are created again
+	cache.Invalidate("ns/a")
+	assert.True(t, newClient.(*closedClient).closed)
+	newClient, _, err = cache.Get("ns/a", "2", config("broker-2"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, created)
+
+	otherClient, _, err := cache.Get("ns/b", "1", config("broker-3"))
+	require.NoError(t, err)
+	cache.Retain(sets.NewString("ns/b"))
+	assert.True(t, newClient.(*closedClient).closed)
+	assert.False(t, otherClient.(*closedClient).closed)
+
+	cache.Close()
+	assert.True(t, otherClient.(*closedClient).closed)
+}
@@ -19,6 +19,9 @@ package offset
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -32,7 +35,10 @@ import (
 // is closed before at least one message is consumed from ALL partitions.
 // Without InitOffsets, an event sent to a partition with an uninitialized offset
 // will not be forwarded when the session is closed (or a rebalancing is in progress).
-func InitOffsets(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, consumerGroup string) (int32, error) {
+// The uninitialized offsets are set at the initial offset time, which is either sarama.OffsetNewest,
+// sarama.OffsetOldest or a timestamp (millis since epoch). For a timestamp, the offset of each partition
+// is the one of its first record at or after the timestamp, or the newest offset when there is none.
+func InitOffsets(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, consumerGroup string, initialOffset int64) (int32, error) {
 	offsetManager, err := sarama.NewOffsetManagerFromClient(consumerGroup, kafkaClient)
 	if err != nil {
 		return -1, err
@@ -44,8 +50,12 @@ func InitOffsets(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClien
 		return -1, err
 	}
 
-	// Fetch topic offsets
-	topicOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, sarama.OffsetNewest)
+	// Fetch topic offsets (the offsets at a timestamp are resolved for the uninitialized partitions only)
+	offsetTime := initialOffset
+	if offsetTime >= 0 {
+		offsetTime = sarama.OffsetNewest
+	}
+	topicOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, offsetTime)
 	if err != nil {
 		return -1, fmt.Errorf("failed to get the topic offsets: %w", err)
 	}
@@ -72,6 +82,16 @@ func InitOffsets(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClien
 					continue
 				}
 
+				if initialOffset >= 0 {
+					timestampOffset, err := kafkaClient.GetOffset(topic, partitionID, initialOffset)
+					if err != nil {
+						return -1, fmt.Errorf("failed to get the offset of topic %s and partition %d at time %d: %w", topic, partitionID, initialOffset, err)
+					}
+					if timestampOffset >= 0 {
+						offset = timestampOffset
+					}
+				}
+
 				logging.FromContext(ctx).Infow("initializing offset", zap.String("topic", topic), zap.Int32("partition", partitionID), zap.Int64("offset", offset))
 
 				pm, err := offsetManager.ManagePartition(topic, partitionID)
@@ -118,6 +138,99 @@ func CheckIfAllOffsetsInitialized(kafkaClient sarama.Client, kafkaAdminClient sa
 	return true, nil
 }
 
+// MatchTopics refreshes the metadata of the cluster and returns the names of the topics matching the
+// pattern, sorted. The internal topics (e.g. __consumer_offsets) never match.
+func MatchTopics(kafkaClient sarama.Client, pattern *regexp.Regexp) ([]string, error) {
+	if err := kafkaClient.RefreshMetadata(); err != nil {
+		return nil, fmt.Errorf("failed to refresh the metadata: %w", err)
+	}
+	topics, err := kafkaClient.Topics()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		if !strings.HasPrefix(topic, "__") && pattern.MatchString(topic) {
+			matched = append(matched, topic)
+		}
+	}
+	sort.Strings(matched)
+	return matched, nil
+}
+
+// PartitionLag is the lag of a consumer group on a partition
+type PartitionLag struct {
+	Topic     string
+	Partition int32
+	// Offset is the committed offset of the consumer group, or -1 if it isn't committed yet
+	Offset int64
+	// LogEndOffset is the offset of the next record produced to the partition
+	LogEndOffset int64
+	Lag          int64
+}
+
+// GetConsumerGroupLag returns the total lag of the consumer group on the topics, i.e. the number of
+// records between its committed offsets and the newest offsets, along with the number of partitions.
+// The partitions whose offset isn't committed yet don't count toward the lag.
+func GetConsumerGroupLag(kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, consumerGroup string) (int64, int32, error) {
+	lags, err := GetConsumerGroupPartitionLags(kafkaClient, kafkaAdminClient, topics, consumerGroup)
+	if err != nil {
+		return -1, -1, err
+	}
+	return TotalLag(lags), int32(len(lags)), nil
+}
+
+// GetConsumerGroupPartitionLags returns the lag of the consumer group on each partition of the topics,
+// sorted by topic and partition.
+func GetConsumerGroupPartitionLags(kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, consumerGroup string) ([]PartitionLag, error) {
+	_, topicPartitions, err := retrieveAllPartitions(topics, kafkaClient)
+	if err != nil {
+		return nil, err
+	}
+
+	topicOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, sarama.OffsetNewest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the topic offsets: %w", err)
+	}
+
+	offsets, err := kafkaAdminClient.ListConsumerGroupOffsets(consumerGroup, topicPartitions)
+	if err != nil {
+		return nil, err
+	}
+
+	lags := make([]PartitionLag, 0)
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			lag := PartitionLag{Topic: topic, Partition: partition, Offset: -1, LogEndOffset: topicOffsets[topic][partition]}
+			if block := offsets.GetBlock(topic, partition); block != nil {
+				lag.Offset = block.Offset
+			}
+			// The partitions whose offset isn't initialized yet have no lag
+			if lag.Offset != -1 && lag.LogEndOffset > lag.Offset {
+				lag.Lag = lag.LogEndOffset - lag.Offset
+			}
+			lags = append(lags, lag)
+		}
+	}
+	sort.Slice(lags, func(i, j int) bool {
+		if lags[i].Topic != lags[j].Topic {
+			return lags[i].Topic < lags[j].Topic
+		}
+		return lags[i].Partition < lags[j].Partition
+	})
+	return lags, nil
+}
+
+// TotalLag returns the sum of the lags of the partitions
+func TotalLag(lags []PartitionLag) int64 {
+	var total int64
+	for _, lag := range lags {
+		total += lag.Lag
+	}
+	return total
+}
+
 func retrieveAllPartitions(topics []string, kafkaClient sarama.Client) (int, map[string][]int32, error) {
 	totalPartitions := 0
 
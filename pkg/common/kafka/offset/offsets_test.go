@@ -17,10 +17,12 @@ limitations under the License.
 package offset
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -30,6 +32,8 @@ var testCases = map[string]struct {
 	topicOffsets map[string]map[int32]int64
 	cgOffsets    map[string]map[int32]int64
 	initialized  bool
+	lag          int64
+	partitions   int32
 }{
 	"one topic, one partition, initialized": {
 		topics: []string{"my-topic"},
@@ -44,6 +48,8 @@ var testCases = map[string]struct {
 			},
 		},
 		initialized: true,
+		lag:         3,
+		partitions:  1,
 	},
 	"one topic, one partition, uninitialized": {
 		topics: []string{"my-topic"},
@@ -58,6 +64,8 @@ var testCases = map[string]struct {
 			},
 		},
 		initialized: false,
+		lag:         0,
+		partitions:  1,
 	},
 	"several topics, several partitions, not all initialized": {
 		topics: []string{"my-topic", "my-topic-2", "my-topic-3"},
@@ -72,6 +80,22 @@ var testCases = map[string]struct {
 			"my-topic-3": {0: 5, 1: 7, 2: -1, 3: 10},
 		},
 		initialized: false,
+		lag:         0,
+		partitions:  9,
+	},
+	"several topics, several partitions, lagging": {
+		topics: []string{"my-topic", "my-topic-2"},
+		topicOffsets: map[string]map[int32]int64{
+			"my-topic":   {0: 5, 1: 7},
+			"my-topic-2": {0: 5, 1: 7, 2: 9},
+		},
+		cgOffsets: map[string]map[int32]int64{
+			"my-topic":   {0: 1, 1: 7},
+			"my-topic-2": {0: 5, 1: -1, 2: 4},
+		},
+		initialized: false,
+		lag:         9,
+		partitions:  5,
 	},
 }
 
@@ -102,7 +126,7 @@ func TestInitOffsets(t *testing.T) {
 
 			// test InitOffsets
 			ctx := logtesting.TestContextWithLogger(t)
-			partitionCt, err := InitOffsets(ctx, sc, kac, tc.topics, group)
+			partitionCt, err := InitOffsets(ctx, sc, kac, tc.topics, group, sarama.OffsetNewest)
 			total := 0
 			for _, partitions := range tc.topicOffsets {
 				total += len(partitions)
@@ -115,6 +139,87 @@ func TestInitOffsets(t *testing.T) {
 	}
 }
 
+func TestInitOffsetsInitialOffset(t *testing.T) {
+	topic := "my-topic"
+	timestamp := int64(1622505600000)
+
+	testCases := map[string]struct {
+		initialOffset int64
+		want          map[int32]int64
+	}{
+		"latest": {
+			initialOffset: sarama.OffsetNewest,
+			want:          map[int32]int64{0: 10, 1: 20},
+		},
+		"earliest": {
+			initialOffset: sarama.OffsetOldest,
+			want:          map[int32]int64{0: 0, 1: 5},
+		},
+		"timestamp": {
+			initialOffset: timestamp,
+			// No record at or after the timestamp in partition 1
+			want: map[int32]int64{0: 4, 1: 20},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			broker := sarama.NewMockBroker(t, 1)
+			defer broker.Close()
+
+			group := "my-group"
+
+			broker.SetHandlerByMap(map[string]sarama.MockResponse{
+				"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+					SetOffset(topic, 0, sarama.OffsetNewest, 10).
+					SetOffset(topic, 1, sarama.OffsetNewest, 20).
+					SetOffset(topic, 0, sarama.OffsetOldest, 0).
+					SetOffset(topic, 1, sarama.OffsetOldest, 5).
+					SetOffset(topic, 0, timestamp, 4).
+					SetOffset(topic, 1, timestamp, -1),
+				"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).SetError(sarama.ErrNoError).
+					SetOffset(group, topic, 0, -1, "", sarama.ErrNoError).
+					SetOffset(group, topic, 1, -1, "", sarama.ErrNoError),
+				"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t),
+				"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+					SetCoordinator(sarama.CoordinatorGroup, group, broker),
+				"MetadataRequest": sarama.NewMockMetadataResponse(t).
+					SetController(broker.BrokerID()).
+					SetBroker(broker.Addr(), broker.BrokerID()).
+					SetLeader(topic, 0, broker.BrokerID()).
+					SetLeader(topic, 1, broker.BrokerID()),
+				"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+			})
+
+			config := sarama.NewConfig()
+			config.Version = sarama.MaxVersion
+
+			sc, err := sarama.NewClient([]string{broker.Addr()}, config)
+			require.NoError(t, err)
+			defer sc.Close()
+
+			kac, err := sarama.NewClusterAdminFromClient(sc)
+			require.NoError(t, err)
+			defer kac.Close()
+
+			_, err = InitOffsets(logtesting.TestContextWithLogger(t), sc, kac, []string{topic}, group, tc.initialOffset)
+			require.NoError(t, err)
+
+			committed := make(map[int32]int64)
+			for _, rr := range broker.History() {
+				if request, ok := rr.Request.(*sarama.OffsetCommitRequest); ok {
+					for partition := range tc.want {
+						if offset, _, err := request.Offset(topic, partition); err == nil {
+							committed[partition] = offset
+						}
+					}
+				}
+			}
+			assert.Equal(t, tc.want, committed)
+		})
+	}
+}
+
 func TestCheckIfAllOffsetsInitialized(t *testing.T) {
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -150,6 +255,116 @@ func TestCheckIfAllOffsetsInitialized(t *testing.T) {
 	}
 }
 
+func TestGetConsumerGroupLag(t *testing.T) {
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			broker := sarama.NewMockBroker(t, 1)
+			defer broker.Close()
+
+			group := "my-group"
+
+			configureMockBroker(t, group, tc.topicOffsets, tc.cgOffsets, tc.initialized, broker)
+
+			config := sarama.NewConfig()
+			config.Version = sarama.MaxVersion
+
+			sc, err := sarama.NewClient([]string{broker.Addr()}, config)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			defer sc.Close()
+
+			kac, err := sarama.NewClusterAdminFromClient(sc)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			defer kac.Close()
+
+			lag, partitions, err := GetConsumerGroupLag(sc, kac, tc.topics, group)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			assert.Equal(t, tc.lag, lag)
+			assert.Equal(t, tc.partitions, partitions)
+		})
+	}
+}
+
+func TestGetConsumerGroupPartitionLags(t *testing.T) {
+	tc := testCases["several topics, several partitions, lagging"]
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "my-group"
+
+	configureMockBroker(t, group, tc.topicOffsets, tc.cgOffsets, tc.initialized, broker)
+
+	config := sarama.NewConfig()
+	config.Version = sarama.MaxVersion
+
+	sc, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	defer sc.Close()
+
+	kac, err := sarama.NewClusterAdminFromClient(sc)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	defer kac.Close()
+
+	lags, err := GetConsumerGroupPartitionLags(sc, kac, tc.topics, group)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	assert.Equal(t, []PartitionLag{
+		{Topic: "my-topic", Partition: 0, Offset: 1, LogEndOffset: 5, Lag: 4},
+		{Topic: "my-topic", Partition: 1, Offset: 7, LogEndOffset: 7, Lag: 0},
+		{Topic: "my-topic-2", Partition: 0, Offset: 5, LogEndOffset: 5, Lag: 0},
+		{Topic: "my-topic-2", Partition: 1, Offset: -1, LogEndOffset: 7, Lag: 0},
+		{Topic: "my-topic-2", Partition: 2, Offset: 4, LogEndOffset: 9, Lag: 5},
+	}, lags)
+	assert.Equal(t, int64(9), TotalLag(lags))
+}
+
+func TestMatchTopics(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	metadataResponse := sarama.NewMockMetadataResponse(t).
+		SetController(broker.BrokerID()).
+		SetBroker(broker.Addr(), broker.BrokerID())
+	for _, topic := range []string{"orders-2", "orders-1", "invoices", "old-orders-1", "__consumer_offsets"} {
+		metadataResponse = metadataResponse.SetLeader(topic, 0, broker.BrokerID())
+	}
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest":    metadataResponse,
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.MaxVersion
+	sc, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sc.Close()
+
+	topics, err := MatchTopics(sc, regexp.MustCompile("^(?:orders-.*|__.*)$"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	assert.Equal(t, []string{"orders-1", "orders-2"}, topics)
+
+	topics, err = MatchTopics(sc, regexp.MustCompile("^(?:payments)$"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	assert.Empty(t, topics)
+}
+
 func configureMockBroker(t *testing.T, group string, topicOffsets map[string]map[int32]int64, cgOffsets map[string]map[int32]int64, initialized bool, broker *sarama.MockBroker) {
 	offsetResponse := sarama.NewMockOffsetResponse(t).SetVersion(1)
 	for topic, partitions := range topicOffsets {
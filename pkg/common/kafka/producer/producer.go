@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// AsyncProducer sends the messages of concurrent callers through a single sarama.AsyncProducer, so that
+// they are batched together, while each caller still waits for the confirmation of its own message.
+type AsyncProducer struct {
+	producer sarama.AsyncProducer
+	done     sync.WaitGroup
+
+	// closeLock is read locked by the senders while they enqueue their message, so that Close can't
+	// close the input of the producer under them, and the messages sent once closing are rejected
+	closeLock sync.RWMutex
+	closing   bool
+
+	// closeErrors are the errors of the messages failing once Close is called, returned by Close
+	errorsLock  sync.Mutex
+	flushing    bool
+	closeErrors sarama.ProducerErrors
+}
+
+// delivery is attached as the Metadata of each message sent, to notify its sender of the result
+type delivery struct {
+	result chan error
+}
+
+// NewAsyncProducer returns an AsyncProducer wrapping the given sarama.AsyncProducer, which must have been
+// created with both Producer.Return.Successes and Producer.Return.Errors enabled.
+func NewAsyncProducer(producer sarama.AsyncProducer) *AsyncProducer {
+	p := &AsyncProducer{producer: producer}
+	p.done.Add(2)
+	go func() {
+		defer p.done.Done()
+		for message := range producer.Successes() {
+			notify(message, nil)
+		}
+	}()
+	go func() {
+		defer p.done.Done()
+		for producerError := range producer.Errors() {
+			p.errorsLock.Lock()
+			if p.flushing {
+				p.closeErrors = append(p.closeErrors, producerError)
+			}
+			p.errorsLock.Unlock()
+			notify(producerError.Msg, producerError.Err)
+		}
+	}()
+	return p
+}
+
+// SendMessage sends the message and waits until it is acknowledged by the brokers, or until the context
+// is done. The partition and offset of the message are returned on success, and sarama.ErrShuttingDown
+// once the AsyncProducer is closing.
+func (p *AsyncProducer) SendMessage(ctx context.Context, message *sarama.ProducerMessage) (int32, int64, error) {
+	// Don't produce the messages of the senders which already gave up
+	if err := ctx.Err(); err != nil {
+		return -1, -1, err
+	}
+
+	d := &delivery{result: make(chan error, 1)}
+	message.Metadata = d
+
+	if err := p.enqueue(ctx, message); err != nil {
+		return -1, -1, err
+	}
+
+	select {
+	case err := <-d.result:
+		if err != nil {
+			return -1, -1, err
+		}
+		return message.Partition, message.Offset, nil
+	case <-ctx.Done():
+		return -1, -1, ctx.Err()
+	}
+}
+
+// enqueue passes the message to the producer, unless it is closing or the context is done first
+func (p *AsyncProducer) enqueue(ctx context.Context, message *sarama.ProducerMessage) error {
+	p.closeLock.RLock()
+	defer p.closeLock.RUnlock()
+	if p.closing {
+		return sarama.ErrShuttingDown
+	}
+
+	select {
+	case p.producer.Input() <- message:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes the buffered messages, notifies their senders and shuts down the producer. As with
+// sarama.AsyncProducer, the errors of the messages which failed to be flushed are returned. The messages
+// sent once Close is called are rejected, and the later calls of Close only wait for the shutdown.
+func (p *AsyncProducer) Close() error {
+	p.closeLock.Lock()
+	closing := p.closing
+	p.closing = true
+	p.closeLock.Unlock()
+	if closing {
+		p.done.Wait()
+		return nil
+	}
+
+	p.errorsLock.Lock()
+	p.flushing = true
+	p.errorsLock.Unlock()
+
+	p.producer.AsyncClose()
+	p.done.Wait()
+	if len(p.closeErrors) > 0 {
+		return p.closeErrors
+	}
+	return nil
+}
+
+// ConfigureBatching sets the maximum number of messages of a batch and the maximum time a message waits
+// for its batch to be filled before being sent (without it, the messages buffered while a request is in
+// flight are sent as soon as possible). The Sarama defaults are kept for unset (zero) values.
+func ConfigureBatching(config *sarama.Config, batchSize int, linger time.Duration) {
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+	if linger > 0 {
+		config.Producer.Flush.Frequency = linger
+	}
+	if batchSize > 0 {
+		config.Producer.Flush.MaxMessages = batchSize
+		// A full batch is sent without waiting for the linger to elapse
+		if config.Producer.Flush.Frequency > 0 {
+			config.Producer.Flush.Messages = batchSize
+		}
+	}
+}
+
+// notify sends the result of the delivery of the message to its sender
+func notify(message *sarama.ProducerMessage, err error) {
+	if message == nil {
+		return
+	}
+	if d, ok := message.Metadata.(*delivery); ok {
+		d.result <- err
+	}
+}
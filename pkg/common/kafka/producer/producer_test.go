@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncProducerSendMessage(t *testing.T) {
+	config := sarama.NewConfig()
+	ConfigureBatching(config, 0, 0)
+	mockProducer := mocks.NewAsyncProducer(t, config)
+	mockProducer.ExpectInputAndSucceed()
+	mockProducer.ExpectInputAndFail(errors.New("produce failed"))
+	mockProducer.ExpectInputAndSucceed()
+
+	producer := NewAsyncProducer(mockProducer)
+
+	// Each sender is notified of the result of its own message
+	results := make([]error, 3)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		message := &sarama.ProducerMessage{Topic: "test-topic", Value: sarama.StringEncoder("test")}
+		go func(i int) {
+			defer wg.Done()
+			_, _, results[i] = producer.SendMessage(context.Background(), message)
+		}(i)
+		// The mock expectations are matched in order
+		time.Sleep(10 * time.Millisecond)
+	}
+	wg.Wait()
+	assert.Nil(t, producer.Close())
+
+	assert.Nil(t, results[0])
+	assert.EqualError(t, results[1], "produce failed")
+	assert.Nil(t, results[2])
+}
+
+func TestAsyncProducerSendMessageCanceled(t *testing.T) {
+	config := sarama.NewConfig()
+	ConfigureBatching(config, 0, 0)
+	mockProducer := mocks.NewAsyncProducer(t, config)
+	producer := NewAsyncProducer(mockProducer)
+	defer producer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := producer.SendMessage(ctx, &sarama.ProducerMessage{Topic: "test-topic"})
+	assert.Equal(t, context.Canceled, err)
+}
+
+// flushFailingProducer fails to flush its buffered message when closed
+type flushFailingProducer struct {
+	sarama.AsyncProducer
+	buffered  *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func (p *flushFailingProducer) Successes() <-chan *sarama.ProducerMessage {
+	return p.successes
+}
+
+func (p *flushFailingProducer) Errors() <-chan *sarama.ProducerError {
+	return p.errors
+}
+
+func (p *flushFailingProducer) AsyncClose() {
+	go func() {
+		p.errors <- &sarama.ProducerError{Msg: p.buffered, Err: errors.New("flush failed")}
+		close(p.errors)
+		close(p.successes)
+	}()
+}
+
+func TestAsyncProducerCloseError(t *testing.T) {
+	mockProducer := &flushFailingProducer{
+		buffered:  &sarama.ProducerMessage{Topic: "test-topic"},
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+	producer := NewAsyncProducer(mockProducer)
+
+	err := producer.Close()
+	var producerErrors sarama.ProducerErrors
+	assert.ErrorAs(t, err, &producerErrors)
+	assert.Len(t, producerErrors, 1)
+	assert.Equal(t, "flush failed", producerErrors[0].Err.Error())
+}
+
+// echoProducer acknowledges the messages of its input until it is closed, like a sarama.AsyncProducer
+type echoProducer struct {
+	sarama.AsyncProducer
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func newEchoProducer() *echoProducer {
+	p := &echoProducer{
+		input:     make(chan *sarama.ProducerMessage),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+	go func() {
+		for message := range p.input {
+			p.successes <- message
+		}
+		close(p.successes)
+		close(p.errors)
+	}()
+	return p
+}
+
+func (p *echoProducer) Input() chan<- *sarama.ProducerMessage {
+	return p.input
+}
+
+func (p *echoProducer) Successes() <-chan *sarama.ProducerMessage {
+	return p.successes
+}
+
+func (p *echoProducer) Errors() <-chan *sarama.ProducerError {
+	return p.errors
+}
+
+func (p *echoProducer) AsyncClose() {
+	close(p.input)
+}
+
+func TestAsyncProducerSendMessageClosing(t *testing.T) {
+	producer := NewAsyncProducer(newEchoProducer())
+
+	// The messages sent concurrently with Close are either produced or rejected, without panicking
+	results := make(chan error, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := producer.SendMessage(context.Background(), &sarama.ProducerMessage{Topic: "test-topic"})
+			results <- err
+		}()
+	}
+	assert.Nil(t, producer.Close())
+	wg.Wait()
+	close(results)
+	for err := range results {
+		if err != nil {
+			assert.Equal(t, sarama.ErrShuttingDown, err)
+		}
+	}
+
+	// The messages sent once closed are rejected
+	_, _, err := producer.SendMessage(context.Background(), &sarama.ProducerMessage{Topic: "test-topic"})
+	assert.Equal(t, sarama.ErrShuttingDown, err)
+	assert.Nil(t, producer.Close())
+}
+
+func TestConfigureBatching(t *testing.T) {
+	tests := map[string]struct {
+		batchSize       int
+		linger          time.Duration
+		expectMessages  int
+		expectMax       int
+		expectFrequency time.Duration
+	}{
+		"defaults": {},
+		"batch size only": {
+			batchSize: 100,
+			expectMax: 100,
+		},
+		"linger only": {
+			linger:          5 * time.Millisecond,
+			expectFrequency: 5 * time.Millisecond,
+		},
+		"batch size and linger": {
+			batchSize:       100,
+			linger:          5 * time.Millisecond,
+			expectMessages:  100,
+			expectMax:       100,
+			expectFrequency: 5 * time.Millisecond,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := sarama.NewConfig()
+			ConfigureBatching(config, test.batchSize, test.linger)
+			assert.True(t, config.Producer.Return.Successes)
+			assert.True(t, config.Producer.Return.Errors)
+			assert.Equal(t, test.expectMessages, config.Producer.Flush.Messages)
+			assert.Equal(t, test.expectMax, config.Producer.Flush.MaxMessages)
+			assert.Equal(t, test.expectFrequency, config.Producer.Flush.Frequency)
+			assert.Nil(t, config.Validate())
+		})
+	}
+}
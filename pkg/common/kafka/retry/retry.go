@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retry implements the non-blocking retries of the KafkaChannel subscriptions through retry topics:
+// the failed events of a subscription are produced to the retry topic of their attempt with the time at which
+// they are due, consumed again once their backoff has elapsed, and finally sent to the dead letter sink of the
+// subscription.  Each attempt has its own retry topic, and so a single backoff, so that the messages of a retry
+// partition are due in the order in which they were produced, and waiting for the first one to be due doesn't
+// delay the others.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/channel/attributes"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const (
+	// AttemptHeader is the header of the messages of a retry topic with the number of the retry (starting at 1)
+	AttemptHeader = "kafkachannel-retry-attempt"
+
+	// DueHeader is the header of the messages of a retry topic with the time (in Unix milliseconds) before
+	// which the retry must not be dispatched
+	DueHeader = "kafkachannel-retry-due"
+)
+
+// Producer produces the messages of the retry topics
+type Producer interface {
+	SendMessage(ctx context.Context, message *sarama.ProducerMessage) (int32, int64, error)
+}
+
+// TopicName returns the name of the retry topic of the specified attempt (starting at 1) of the subscriber of
+// the specified channel topic
+func TopicName(topic string, subscriberUID types.UID, attempt int) string {
+	return topicPrefix(topic, subscriberUID) + strconv.Itoa(attempt)
+}
+
+// TopicNames returns the names of the retry topics of the subscriber of the specified channel topic, one per
+// retry, in the order of their attempts
+func TopicNames(topic string, subscriberUID types.UID, retryMax int) []string {
+	topics := make([]string, 0, retryMax)
+	for attempt := 1; attempt <= retryMax; attempt++ {
+		topics = append(topics, TopicName(topic, subscriberUID, attempt))
+	}
+	return topics
+}
+
+// topicPrefix returns the prefix of the names of the retry topics of the subscriber
+func topicPrefix(topic string, subscriberUID types.UID) string {
+	return fmt.Sprintf("%s.retry.%s.", topic, subscriberUID)
+}
+
+// FilterRecordHeaders returns the headers without the retry headers, which must not be dispatched
+func FilterRecordHeaders(headers []*sarama.RecordHeader) []*sarama.RecordHeader {
+	filtered := make([]*sarama.RecordHeader, 0, len(headers))
+	for _, header := range headers {
+		if header == nil || isRetryHeader(header.Key) {
+			continue
+		}
+		filtered = append(filtered, header)
+	}
+	return filtered
+}
+
+// Dispatcher dispatches the messages of a subscription once, scheduling the retries of the failed ones in the
+// retry topics of the subscription instead of retrying them in-line, so that the long backoffs don't block the
+// partition of the message.  The Topics are the retry topics of the attempts (see TopicNames), and the retries
+// are limited to their number.  Once the retries are exhausted, the messages are sent to the dead letter sink.
+type Dispatcher struct {
+	MessageDispatcher channel.MessageDispatcher
+	Producer          Producer
+	Topics            []string
+	Destination       *url.URL
+	Reply             *url.URL
+	DeadLetter        *url.URL
+	RetryConfig       kncloudevents.RetryConfig
+}
+
+// NotBefore returns the time before which the message must not be dispatched, which is only set for the
+// messages of the retry topics (see consumer.KafkaDelayedConsumerHandler)
+func (d *Dispatcher) NotBefore(consumerMessage *sarama.ConsumerMessage) time.Time {
+	if !d.isRetryTopic(consumerMessage.Topic) {
+		return time.Time{}
+	}
+	millis, err := strconv.ParseInt(headerValue(consumerMessage.Headers, DueHeader), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(millis)
+}
+
+// Dispatch dispatches the message (built from the consumerMessage) to the destination and the reply of the
+// subscription.  A failed dispatch is scheduled for a retry, or sent to the dead letter sink once the retries
+// are exhausted (or when the failure can't be retried).  An error is only returned when the message could be
+// neither delivered, nor retried, nor dead lettered.
+func (d *Dispatcher) Dispatch(ctx context.Context, consumerMessage *sarama.ConsumerMessage, message binding.Message, headers http.Header, transformers ...binding.Transformer) (*channel.DispatchExecutionInfo, error) {
+
+	// A single attempt per dispatch, the retries go through the retry topics
+	retryConfig := d.RetryConfig
+	retryConfig.RetryMax = 0
+	info, err := d.MessageDispatcher.DispatchMessageWithRetries(ctx, message, headers, d.Destination, d.Reply, nil, &retryConfig, transformers...)
+	if err == nil || ctx.Err() != nil {
+		return info, err
+	}
+
+	attempt := d.attempt(consumerMessage)
+	if attempt < d.RetryConfig.RetryMax && attempt < len(d.Topics) && d.retryable(ctx, info) {
+		if retryErr := d.scheduleRetry(ctx, consumerMessage, attempt+1); retryErr != nil {
+			return info, fmt.Errorf("%v (failed to schedule retry %d: %v)", err, attempt+1, retryErr)
+		}
+		return info, nil
+	}
+
+	if d.DeadLetter == nil {
+		return info, err
+	}
+
+	deadLetterInfo, deadLetterErr := d.MessageDispatcher.DispatchMessageWithRetries(ctx, message, headers, d.DeadLetter, nil, nil, &d.RetryConfig, append(transformers, errorTransformers(d.Destination, info)...)...)
+	if deadLetterErr != nil {
+		return deadLetterInfo, fmt.Errorf("unable to complete request to either %s (%v) or %s (%v)", d.Destination, err, d.DeadLetter, deadLetterErr)
+	}
+	return deadLetterInfo, nil
+}
+
+// attempt returns the number of the retries of the message already dispatched
+func (d *Dispatcher) attempt(consumerMessage *sarama.ConsumerMessage) int {
+	if !d.isRetryTopic(consumerMessage.Topic) {
+		return 0
+	}
+	attempt, err := strconv.Atoi(headerValue(consumerMessage.Headers, AttemptHeader))
+	if err != nil || attempt < 0 {
+		return 0
+	}
+	return attempt
+}
+
+// isRetryTopic returns whether the topic is one of the retry topics of the subscription
+func (d *Dispatcher) isRetryTopic(topic string) bool {
+	for _, retryTopic := range d.Topics {
+		if topic == retryTopic {
+			return true
+		}
+	}
+	return false
+}
+
+// retryable returns whether the failure of the dispatch can be retried, according to the retry configuration
+func (d *Dispatcher) retryable(ctx context.Context, info *channel.DispatchExecutionInfo) bool {
+	if d.RetryConfig.CheckRetry == nil || info == nil {
+		return true
+	}
+	retry, _ := d.RetryConfig.CheckRetry(ctx, &http.Response{StatusCode: info.ResponseCode}, nil)
+	return retry
+}
+
+// scheduleRetry produces the message to the retry topic of the attempt, due after the backoff of the attempt
+func (d *Dispatcher) scheduleRetry(ctx context.Context, consumerMessage *sarama.ConsumerMessage, attempt int) error {
+	var backoff time.Duration
+	if d.RetryConfig.Backoff != nil {
+		// The in-line retries number their backoffs from zero
+		backoff = d.RetryConfig.Backoff(attempt-1, nil)
+	}
+	due := time.Now().Add(backoff)
+
+	headers := make([]sarama.RecordHeader, 0, len(consumerMessage.Headers)+2)
+	for _, header := range FilterRecordHeaders(consumerMessage.Headers) {
+		headers = append(headers, *header)
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(AttemptHeader), Value: []byte(strconv.Itoa(attempt))},
+		sarama.RecordHeader{Key: []byte(DueHeader), Value: []byte(strconv.FormatInt(due.UnixMilli(), 10))},
+	)
+
+	_, _, err := d.Producer.SendMessage(ctx, &sarama.ProducerMessage{
+		Topic:   d.Topics[attempt-1],
+		Key:     saramaEncoder(consumerMessage.Key),
+		Value:   saramaEncoder(consumerMessage.Value),
+		Headers: headers,
+	})
+	return err
+}
+
+// errorTransformers returns the transformers adding the Knative error extensions of the failed dispatch
+func errorTransformers(destination *url.URL, info *channel.DispatchExecutionInfo) binding.Transformers {
+	if destination == nil {
+		destination = &url.URL{}
+	}
+	if info == nil {
+		info = &channel.DispatchExecutionInfo{}
+	}
+	return attributes.KnativeErrorTransformers(*destination, info.ResponseCode, sanitizeBody(info.ResponseBody))
+}
+
+// sanitizeBody removes the control characters of the response body, which are not allowed in header values
+func sanitizeBody(body []byte) string {
+	sanitized := make([]rune, 0, len(body))
+	for _, r := range string(body) {
+		if r >= 32 && r != 127 {
+			sanitized = append(sanitized, r)
+		}
+	}
+	return string(sanitized)
+}
+
+// saramaEncoder returns the Encoder of the bytes, or nil for nil bytes (e.g. messages without a key)
+func saramaEncoder(bytes []byte) sarama.Encoder {
+	if bytes == nil {
+		return nil
+	}
+	return sarama.ByteEncoder(bytes)
+}
+
+// headerValue returns the value of the first header with the specified key
+func headerValue(headers []*sarama.RecordHeader, key string) string {
+	for _, header := range headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// isRetryHeader returns whether the header key is one of the retry headers
+func isRetryHeader(key []byte) bool {
+	return string(key) == AttemptHeader || string(key) == DueHeader
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cloudevents "github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const testTopic = "test-namespace.test-channel"
+
+var testRetryTopics = []string{"test-namespace.test-channel.retry.test-uid.1", "test-namespace.test-channel.retry.test-uid.2"}
+
+// dispatch records a call of the fakeMessageDispatcher
+type dispatch struct {
+	destination  *url.URL
+	deadLetter   *url.URL
+	retryMax     int
+	transformers int
+}
+
+// fakeMessageDispatcher fails the dispatches to the failing destinations with the specified response code
+type fakeMessageDispatcher struct {
+	failing    []*url.URL
+	code       int
+	dispatches []dispatch
+}
+
+func (f *fakeMessageDispatcher) DispatchMessage(ctx context.Context, message binding.Message, headers http.Header, destination *url.URL, reply *url.URL, deadLetter *url.URL) (*channel.DispatchExecutionInfo, error) {
+	return f.DispatchMessageWithRetries(ctx, message, headers, destination, reply, deadLetter, nil)
+}
+
+func (f *fakeMessageDispatcher) DispatchMessageWithRetries(_ context.Context, _ binding.Message, _ http.Header, destination *url.URL, _ *url.URL, deadLetter *url.URL, config *kncloudevents.RetryConfig, transformers ...binding.Transformer) (*channel.DispatchExecutionInfo, error) {
+	f.dispatches = append(f.dispatches, dispatch{destination: destination, deadLetter: deadLetter, retryMax: config.RetryMax, transformers: len(transformers)})
+	for _, failing := range f.failing {
+		if destination == failing {
+			return &channel.DispatchExecutionInfo{ResponseCode: f.code, ResponseBody: []byte("failed\n")}, errors.New("dispatch failed")
+		}
+	}
+	return &channel.DispatchExecutionInfo{ResponseCode: http.StatusAccepted}, nil
+}
+
+// fakeProducer records the messages produced
+type fakeProducer struct {
+	err      error
+	messages []*sarama.ProducerMessage
+}
+
+func (f *fakeProducer) SendMessage(_ context.Context, message *sarama.ProducerMessage) (int32, int64, error) {
+	f.messages = append(f.messages, message)
+	return 0, int64(len(f.messages)), f.err
+}
+
+func TestTopicName(t *testing.T) {
+	assert.Equal(t, testRetryTopics[1], TopicName(testTopic, "test-uid", 2))
+}
+
+func TestTopicNames(t *testing.T) {
+	assert.Equal(t, testRetryTopics, TopicNames(testTopic, "test-uid", 2))
+	assert.Empty(t, TopicNames(testTopic, "test-uid", 0))
+}
+
+func TestFilterRecordHeaders(t *testing.T) {
+	headers := []*sarama.RecordHeader{
+		{Key: []byte("traceparent"), Value: []byte("00-trace")},
+		{Key: []byte(AttemptHeader), Value: []byte("2")},
+		nil,
+		{Key: []byte(DueHeader), Value: []byte("1234")},
+	}
+	assert.Equal(t, []*sarama.RecordHeader{headers[0]}, FilterRecordHeaders(headers))
+}
+
+func TestNotBefore(t *testing.T) {
+	due := time.UnixMilli(time.Now().Add(time.Minute).UnixMilli())
+	dueHeaders := []*sarama.RecordHeader{{Key: []byte(DueHeader), Value: []byte(strconv.FormatInt(due.UnixMilli(), 10))}}
+	d := &Dispatcher{Topics: testRetryTopics}
+
+	assert.Equal(t, due, d.NotBefore(&sarama.ConsumerMessage{Topic: testRetryTopics[0], Headers: dueHeaders}))
+	assert.Equal(t, due, d.NotBefore(&sarama.ConsumerMessage{Topic: testRetryTopics[1], Headers: dueHeaders}))
+	assert.True(t, d.NotBefore(&sarama.ConsumerMessage{Topic: testTopic, Headers: dueHeaders}).IsZero())
+	assert.True(t, d.NotBefore(&sarama.ConsumerMessage{Topic: testRetryTopics[0]}).IsZero())
+}
+
+func TestDispatch(t *testing.T) {
+	destination, _ := url.Parse("http://subscriber.test")
+	deadLetter, _ := url.Parse("http://dls.test")
+
+	retryConfig := kncloudevents.NoRetries()
+	retryConfig.RetryMax = 2
+	retryConfig.CheckRetry = kncloudevents.SelectiveRetry
+	retryConfig.Backoff = func(attemptNum int, _ *http.Response) time.Duration {
+		return time.Duration(attemptNum+1) * time.Minute
+	}
+
+	attemptHeaders := func(attempt int) []*sarama.RecordHeader {
+		return []*sarama.RecordHeader{
+			{Key: []byte("traceparent"), Value: []byte("00-trace")},
+			{Key: []byte(AttemptHeader), Value: []byte(strconv.Itoa(attempt))},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		message          *sarama.ConsumerMessage
+		topics           []string
+		failing          []*url.URL
+		code             int
+		deadLetter       *url.URL
+		producerErr      error
+		expectErr        bool
+		expectRetry      int
+		expectBackoff    time.Duration
+		expectDispatches int
+		expectDLS        bool
+	}{
+		{
+			name:             "Delivered",
+			message:          &sarama.ConsumerMessage{Topic: testTopic},
+			deadLetter:       deadLetter,
+			expectDispatches: 1,
+		},
+		{
+			name:             "First Retry",
+			message:          &sarama.ConsumerMessage{Topic: testTopic, Headers: attemptHeaders(5)},
+			failing:          []*url.URL{destination},
+			code:             http.StatusServiceUnavailable,
+			deadLetter:       deadLetter,
+			expectRetry:      1,
+			expectBackoff:    time.Minute,
+			expectDispatches: 1,
+		},
+		{
+			name:             "Second Retry",
+			message:          &sarama.ConsumerMessage{Topic: testRetryTopics[0], Headers: attemptHeaders(1)},
+			failing:          []*url.URL{destination},
+			code:             http.StatusServiceUnavailable,
+			expectRetry:      2,
+			expectBackoff:    2 * time.Minute,
+			expectDispatches: 1,
+		},
+		{
+			name:             "Retries Exhausted",
+			message:          &sarama.ConsumerMessage{Topic: testRetryTopics[1], Headers: attemptHeaders(2)},
+			failing:          []*url.URL{destination},
+			code:             http.StatusServiceUnavailable,
+			deadLetter:       deadLetter,
+			expectDispatches: 2,
+			expectDLS:        true,
+		},
+		{
+			name:             "Not Retryable",
+			message:          &sarama.ConsumerMessage{Topic: testTopic},
+			failing:          []*url.URL{destination},
+			code:             http.StatusBadRequest,
+			deadLetter:       deadLetter,
+			expectDispatches: 2,
+			expectDLS:        true,
+		},
+		{
+			name:             "Retries Limited To The Retry Topics",
+			message:          &sarama.ConsumerMessage{Topic: testRetryTopics[0], Headers: attemptHeaders(1)},
+			topics:           testRetryTopics[:1],
+			failing:          []*url.URL{destination},
+			code:             http.StatusServiceUnavailable,
+			deadLetter:       deadLetter,
+			expectDispatches: 2,
+			expectDLS:        true,
+		},
+		{
+			name:             "Retries Exhausted Without Dead Letter",
+			message:          &sarama.ConsumerMessage{Topic: testRetryTopics[1], Headers: attemptHeaders(2)},
+			failing:          []*url.URL{destination},
+			code:             http.StatusServiceUnavailable,
+			expectErr:        true,
+			expectDispatches: 1,
+		},
+		{
+			name:             "Dead Letter Failed",
+			message:          &sarama.ConsumerMessage{Topic: testTopic},
+			failing:          []*url.URL{destination, deadLetter},
+			code:             http.StatusBadRequest,
+			deadLetter:       deadLetter,
+			expectErr:        true,
+			expectDispatches: 2,
+			expectDLS:        true,
+		},
+		{
+			name:             "Retry Not Scheduled",
+			message:          &sarama.ConsumerMessage{Topic: testTopic, Headers: attemptHeaders(0)},
+			failing:          []*url.URL{destination},
+			code:             http.StatusServiceUnavailable,
+			producerErr:      errors.New("produce failed"),
+			expectErr:        true,
+			expectRetry:      1,
+			expectBackoff:    time.Minute,
+			expectDispatches: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			messageDispatcher := &fakeMessageDispatcher{failing: test.failing, code: test.code}
+			producer := &fakeProducer{err: test.producerErr}
+			topics := test.topics
+			if topics == nil {
+				topics = testRetryTopics
+			}
+			d := &Dispatcher{
+				MessageDispatcher: messageDispatcher,
+				Producer:          producer,
+				Topics:            topics,
+				Destination:       destination,
+				DeadLetter:        test.deadLetter,
+				RetryConfig:       retryConfig,
+			}
+
+			event := cloudevents.New()
+			event.SetID("id")
+			event.SetSource("source")
+			event.SetType("type")
+			before := time.Now()
+			_, err := d.Dispatch(context.Background(), test.message, binding.ToMessage(&event), http.Header{})
+
+			assert.Equal(t, test.expectErr, err != nil)
+			require.Len(t, messageDispatcher.dispatches, test.expectDispatches)
+			assert.Equal(t, destination, messageDispatcher.dispatches[0].destination)
+			assert.Nil(t, messageDispatcher.dispatches[0].deadLetter)
+			assert.Equal(t, 0, messageDispatcher.dispatches[0].retryMax)
+			if test.expectDLS {
+				assert.Equal(t, deadLetter, messageDispatcher.dispatches[1].destination)
+				assert.Equal(t, retryConfig.RetryMax, messageDispatcher.dispatches[1].retryMax)
+				assert.Equal(t, 3, messageDispatcher.dispatches[1].transformers)
+			}
+
+			if test.expectRetry == 0 {
+				assert.Empty(t, producer.messages)
+				return
+			}
+			require.Len(t, producer.messages, 1)
+			retry := producer.messages[0]
+			assert.Equal(t, testRetryTopics[test.expectRetry-1], retry.Topic)
+			require.Len(t, retry.Headers, 3)
+			assert.Equal(t, "traceparent", string(retry.Headers[0].Key))
+			assert.Equal(t, strconv.Itoa(test.expectRetry), string(retry.Headers[1].Value))
+			due, _ := strconv.ParseInt(string(retry.Headers[2].Value), 10, 64)
+			assert.False(t, time.UnixMilli(due).Before(before.Add(test.expectBackoff).Truncate(time.Millisecond)))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CreateTopics creates the retry topics, unless they already exist, with the number of partitions and the
+// replication factor of the channel topic whose failed events they retry
+func CreateTopics(admin sarama.ClusterAdmin, topic string, retryTopics []string) error {
+	metadata, err := admin.DescribeTopics([]string{topic})
+	if err != nil {
+		return fmt.Errorf("failed to describe topic %s: %w", topic, err)
+	}
+	if len(metadata) != 1 {
+		return fmt.Errorf("failed to describe topic %s: unexpected metadata of %d topics", topic, len(metadata))
+	}
+	if metadata[0].Err != sarama.ErrNoError {
+		return fmt.Errorf("failed to describe topic %s: %w", topic, metadata[0].Err)
+	}
+
+	detail := &sarama.TopicDetail{
+		NumPartitions:     int32(len(metadata[0].Partitions)),
+		ReplicationFactor: 1,
+	}
+	if len(metadata[0].Partitions) > 0 && len(metadata[0].Partitions[0].Replicas) > 0 {
+		detail.ReplicationFactor = int16(len(metadata[0].Partitions[0].Replicas))
+	}
+
+	for _, retryTopic := range retryTopics {
+		err = admin.CreateTopic(retryTopic, detail, false)
+		if topicErr, ok := err.(*sarama.TopicError); ok && topicErr.Err == sarama.ErrTopicAlreadyExists {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTopics deletes the existing retry topics of the subscriber of the channel topic.  The topics are listed
+// rather than named from the retry configuration, which may have changed since they were created.
+func DeleteTopics(admin sarama.ClusterAdmin, topic string, subscriberUID types.UID) error {
+	topics, err := admin.ListTopics()
+	if err != nil {
+		return fmt.Errorf("failed to list topics: %w", err)
+	}
+	prefix := topicPrefix(topic, subscriberUID)
+	for name := range topics {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(name, prefix)); err != nil {
+			continue
+		}
+		err = admin.DeleteTopic(name)
+		if err != nil && err != sarama.ErrUnknownTopicOrPartition {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+func TestCreateTopics(t *testing.T) {
+	tests := []struct {
+		name      string
+		metadata  []*sarama.TopicMetadata
+		createErr error
+		expectErr bool
+	}{
+		{
+			name: "Created",
+			metadata: []*sarama.TopicMetadata{{
+				Name:       testTopic,
+				Partitions: []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{1, 2}}, {ID: 1, Replicas: []int32{2, 3}}},
+			}},
+		},
+		{
+			name: "Already Exists",
+			metadata: []*sarama.TopicMetadata{{
+				Name:       testTopic,
+				Partitions: []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{1, 2}}, {ID: 1, Replicas: []int32{2, 3}}},
+			}},
+			createErr: &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists},
+		},
+		{
+			name:      "Channel Topic Not Found",
+			metadata:  []*sarama.TopicMetadata{{Name: testTopic, Err: sarama.ErrUnknownTopicOrPartition}},
+			expectErr: true,
+		},
+		{
+			name: "Creation Failed",
+			metadata: []*sarama.TopicMetadata{{
+				Name:       testTopic,
+				Partitions: []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{1, 2}}, {ID: 1, Replicas: []int32{2, 3}}},
+			}},
+			createErr: errors.New("creation failed"),
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var created []string
+			var createdDetail *sarama.TopicDetail
+			admin := &commontesting.MockClusterAdmin{
+				MockDescribeTopicsFunc: func(topics []string) ([]*sarama.TopicMetadata, error) {
+					assert.Equal(t, []string{testTopic}, topics)
+					return test.metadata, nil
+				},
+				MockCreateTopicFunc: func(topic string, detail *sarama.TopicDetail, _ bool) error {
+					created = append(created, topic)
+					createdDetail = detail
+					return test.createErr
+				},
+			}
+
+			err := CreateTopics(admin, testTopic, testRetryTopics)
+			assert.Equal(t, test.expectErr, err != nil)
+			if !test.expectErr {
+				assert.Equal(t, testRetryTopics, created)
+				assert.Equal(t, &sarama.TopicDetail{NumPartitions: 2, ReplicationFactor: 2}, createdDetail)
+			}
+		})
+	}
+}
+
+func TestDeleteTopics(t *testing.T) {
+	topics := map[string]sarama.TopicDetail{
+		testTopic:          {},
+		testRetryTopics[0]: {},
+		testRetryTopics[1]: {},
+		"test-namespace.test-channel.retry.test-uid":     {},
+		"test-namespace.test-channel.retry.test-uid.dlq": {},
+		"test-namespace.test-channel.retry.other-uid.1":  {},
+		"test-namespace.test-channel-2.retry.test-uid.1": {},
+	}
+	for _, deleteErr := range []error{nil, sarama.ErrUnknownTopicOrPartition} {
+		var deleted []string
+		admin := &commontesting.MockClusterAdmin{
+			MockListTopicsFunc: func() (map[string]sarama.TopicDetail, error) { return topics, nil },
+			MockDeleteTopicFunc: func(topic string) error {
+				deleted = append(deleted, topic)
+				return deleteErr
+			},
+		}
+		assert.Nil(t, DeleteTopics(admin, testTopic, "test-uid"))
+		assert.ElementsMatch(t, testRetryTopics, deleted)
+	}
+
+	admin := &commontesting.MockClusterAdmin{
+		MockListTopicsFunc:  func() (map[string]sarama.TopicDetail, error) { return topics, nil },
+		MockDeleteTopicFunc: func(string) error { return sarama.ErrNotController },
+	}
+	assert.NotNil(t, DeleteTopics(admin, testTopic, "test-uid"))
+
+	admin = &commontesting.MockClusterAdmin{
+		MockListTopicsFunc: func() (map[string]sarama.TopicDetail, error) { return nil, errors.New("list failed") },
+	}
+	assert.NotNil(t, DeleteTopics(admin, testTopic, "test-uid"))
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package topic contains the helpers shared by the channel implementations to manage their Kafka topics.
+package topic
+
+import (
+	"sort"
+
+	"github.com/Shopify/sarama"
+)
+
+// IsOverride returns whether a config entry of a topic is a topic-level override. The source of the
+// entries is unknown with older Kafka versions, in which case the non-default entries are overrides.
+func IsOverride(entry sarama.ConfigEntry) bool {
+	if entry.ReadOnly || entry.Sensitive {
+		return false
+	}
+	return entry.Source == sarama.SourceTopic || (entry.Source == sarama.SourceUnknown && !entry.Default)
+}
+
+// MergeConfigEntries merges the config entries into the topic-level overrides of the current config of
+// a topic, since AlterConfig resets the entries which are not specified. It returns the merged entries
+// and the (sorted) names of the entries which differ from the current config.
+func MergeConfigEntries(currentEntries []sarama.ConfigEntry, configEntries map[string]*string) (map[string]*string, []string) {
+	merged := make(map[string]*string)
+	for _, entry := range currentEntries {
+		if IsOverride(entry) {
+			value := entry.Value
+			merged[entry.Name] = &value
+		}
+	}
+	var changed []string
+	for name, value := range configEntries {
+		if current, ok := merged[name]; !ok || value == nil || *current != *value {
+			changed = append(changed, name)
+		}
+		merged[name] = value
+	}
+	sort.Strings(changed)
+	return merged, changed
+}
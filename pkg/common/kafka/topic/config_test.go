@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topic
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOverride(t *testing.T) {
+	assert.True(t, IsOverride(sarama.ConfigEntry{Source: sarama.SourceTopic}))
+	assert.True(t, IsOverride(sarama.ConfigEntry{Source: sarama.SourceUnknown}))
+	assert.False(t, IsOverride(sarama.ConfigEntry{Source: sarama.SourceUnknown, Default: true}))
+	assert.False(t, IsOverride(sarama.ConfigEntry{Source: sarama.SourceDefault}))
+	assert.False(t, IsOverride(sarama.ConfigEntry{Source: sarama.SourceTopic, ReadOnly: true}))
+	assert.False(t, IsOverride(sarama.ConfigEntry{Source: sarama.SourceTopic, Sensitive: true}))
+}
+
+func TestMergeConfigEntries(t *testing.T) {
+	retention := "86400000"
+	newRetention := "3600000"
+	compression := "zstd"
+	currentEntries := []sarama.ConfigEntry{
+		{Name: "retention.ms", Value: retention, Source: sarama.SourceTopic},
+		{Name: "cleanup.policy", Value: "compact", Source: sarama.SourceTopic},
+		{Name: "segment.bytes", Value: "1073741824", Source: sarama.SourceDefault, Default: true},
+	}
+
+	merged, changed := MergeConfigEntries(currentEntries, map[string]*string{"retention.ms": &retention})
+	assert.Empty(t, changed)
+	assert.Len(t, merged, 2)
+
+	merged, changed = MergeConfigEntries(currentEntries, map[string]*string{"retention.ms": &newRetention, "compression.type": &compression})
+	assert.Equal(t, []string{"compression.type", "retention.ms"}, changed)
+	assert.Equal(t, newRetention, *merged["retention.ms"])
+	assert.Equal(t, compression, *merged["compression.type"])
+	assert.Equal(t, "compact", *merged["cleanup.policy"])
+	assert.NotContains(t, merged, "segment.bytes")
+
+	merged, changed = MergeConfigEntries(currentEntries, map[string]*string{"cleanup.policy": nil})
+	assert.Equal(t, []string{"cleanup.policy"}, changed)
+	assert.Nil(t, merged["cleanup.policy"])
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"fmt"
+
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	messagingv1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+)
+
+// GetForChannel returns the KafkaCluster referenced by the KafkaChannel, which must be either in the
+// namespace of the channel or in the system namespace of the channel implementation.
+func GetForChannel(lister listers.KafkaClusterLister, channel *messagingv1beta1.KafkaChannel, systemNamespace string) (*v1alpha1.KafkaCluster, error) {
+	ref := channel.Spec.ClusterRef
+	if ref.Namespace != "" && ref.Namespace != channel.Namespace && ref.Namespace != systemNamespace {
+		return nil, fmt.Errorf("the KafkaCluster %s/%s is neither in the namespace of the channel nor in the system namespace %s", ref.Namespace, ref.Name, systemNamespace)
+	}
+	return Get(lister, ref, channel.Namespace)
+}
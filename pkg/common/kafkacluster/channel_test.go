@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	messagingv1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+)
+
+func TestGetForChannel(t *testing.T) {
+	const systemNamespace = "knative-eventing"
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(newTestCluster()))
+	assert.Nil(t, indexer.Add(newTestCluster(func(cluster *v1alpha1.KafkaCluster) {
+		cluster.Namespace = systemNamespace
+	})))
+	assert.Nil(t, indexer.Add(newTestCluster(func(cluster *v1alpha1.KafkaCluster) {
+		cluster.Namespace = "other-namespace"
+	})))
+	lister := listers.NewKafkaClusterLister(indexer)
+
+	tests := map[string]struct {
+		ref           bindingsv1beta1.KafkaClusterReference
+		wantNamespace string
+		wantErr       bool
+	}{
+		"default namespace": {
+			ref:           bindingsv1beta1.KafkaClusterReference{Name: testName},
+			wantNamespace: testNamespace,
+		},
+		"system namespace": {
+			ref:           bindingsv1beta1.KafkaClusterReference{Name: testName, Namespace: systemNamespace},
+			wantNamespace: systemNamespace,
+		},
+		"other namespace": {
+			ref:     bindingsv1beta1.KafkaClusterReference{Name: testName, Namespace: "other-namespace"},
+			wantErr: true,
+		},
+		"missing cluster": {
+			ref:     bindingsv1beta1.KafkaClusterReference{Name: "missing"},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ref := test.ref
+			channel := &messagingv1beta1.KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-channel"},
+				Spec:       messagingv1beta1.KafkaChannelSpec{ClusterRef: &ref},
+			}
+			cluster, err := GetForChannel(lister, channel, systemNamespace)
+			assert.Equal(t, test.wantErr, err != nil)
+			if !test.wantErr {
+				assert.Equal(t, test.wantNamespace, cluster.Namespace)
+			}
+		})
+	}
+}
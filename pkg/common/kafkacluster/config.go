@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/tracker"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/common/client"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+)
+
+// Get returns the KafkaCluster referenced by a resource of the specified namespace, which is the
+// default namespace of the reference.
+func Get(lister listers.KafkaClusterLister, ref *bindingsv1beta1.KafkaClusterReference, namespace string) (*v1alpha1.KafkaCluster, error) {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	cluster, err := lister.KafkaClusters(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the KafkaCluster %s/%s: %w", namespace, ref.Name, err)
+	}
+	return cluster, nil
+}
+
+// TrackerReference returns the tracker.Reference of the KafkaCluster referenced by a resource of the
+// specified namespace, so that the resource is reconciled again when the cluster changes.
+func TrackerReference(ref *bindingsv1beta1.KafkaClusterReference, namespace string) tracker.Reference {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	return tracker.Reference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       "KafkaCluster",
+		Namespace:  namespace,
+		Name:       ref.Name,
+	}
+}
+
+// Key identifies the connection settings of the KafkaCluster, it changes with the spec of the cluster (but
+// not with the content of its Secrets) so that its clients can be recreated.
+func Key(cluster *v1alpha1.KafkaCluster) string {
+	return fmt.Sprintf("%s/%d", cluster.UID, cluster.Generation)
+}
+
+// NewConfig returns the brokers and the Sarama config used to connect to the KafkaCluster.  The config
+// is a copy of the (optional) base config, typically from the config-kafka ConfigMap, without its TLS and
+// SASL settings, overridden with the Sarama settings and the authentication of the cluster.  The Secrets
+// of the authentication are read from the namespace of the cluster.
+func NewConfig(ctx context.Context, kubeClient kubernetes.Interface, cluster *v1alpha1.KafkaCluster, base *sarama.Config) ([]string, *sarama.Config, error) {
+	auth, err := newAuthConfig(ctx, kubeClient, cluster.Namespace, &cluster.Spec.Net)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builder := client.NewConfigBuilder()
+	if base != nil {
+		// The base config is shared, and its authentication is the one of another cluster
+		config := *base
+		defaults := sarama.NewConfig()
+		config.Net.TLS = defaults.Net.TLS
+		config.Net.SASL = defaults.Net.SASL
+		builder = builder.WithExisting(&config)
+	} else {
+		builder = builder.WithDefaults()
+	}
+
+	config, err := builder.
+		FromYaml(cluster.Spec.Sarama).
+		WithAuth(auth).
+		Build(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the Sarama config of the KafkaCluster %s/%s: %w", cluster.Namespace, cluster.Name, err)
+	}
+	return cluster.Spec.BootstrapServers, config, nil
+}
+
+// newAuthConfig returns the KafkaAuthConfig of the KafkaNetSpec, from the Secrets of the namespace.
+func newAuthConfig(ctx context.Context, kubeClient kubernetes.Interface, namespace string, net *bindingsv1beta1.KafkaNetSpec) (*client.KafkaAuthConfig, error) {
+	auth := &client.KafkaAuthConfig{}
+	var err error
+	resolve := func(value bindingsv1beta1.SecretValueFromSource) string {
+		if err != nil || value.SecretKeyRef == nil {
+			return ""
+		}
+		var resolved string
+		resolved, err = resolveSecret(ctx, kubeClient, namespace, value.SecretKeyRef)
+		return resolved
+	}
+
+	if net.TLS.Enable {
+		auth.TLS = &client.KafkaTlsConfig{
+			Cacert:   resolve(net.TLS.CACert),
+			Usercert: resolve(net.TLS.Cert),
+			Userkey:  resolve(net.TLS.Key),
+		}
+	}
+
+	if net.SASL.Enable {
+		auth.SASL = &client.KafkaSaslConfig{
+			User:                resolve(net.SASL.User),
+			Password:            resolve(net.SASL.Password),
+			SaslType:            resolve(net.SASL.Type),
+			TokenURL:            resolve(net.SASL.OAuth.TokenURL),
+			Scopes:              commonconfig.SplitScopes(resolve(net.SASL.OAuth.Scopes)),
+			KerberosServiceName: resolve(net.SASL.Kerberos.ServiceName),
+			KerberosRealm:       resolve(net.SASL.Kerberos.Realm),
+			KerberosKeytab:      resolve(net.SASL.Kerberos.Keytab),
+			KerberosConfig:      resolve(net.SASL.Kerberos.Config),
+		}
+	}
+
+	return auth, err
+}
+
+// resolveSecret returns the value of the key of the Secret of the namespace.
+func resolveSecret(ctx context.Context, kubeClient kubernetes.Interface, namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	if value, ok := secret.Data[ref.Key]; ok && len(value) > 0 {
+		return string(value), nil
+	}
+	return "", fmt.Errorf("missing secret key or empty secret value (%s/%s)", ref.Name, ref.Key)
+}
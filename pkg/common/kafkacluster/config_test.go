@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+)
+
+const (
+	testNamespace  = "test-namespace"
+	testName       = "test-cluster"
+	testSecretName = "test-secret"
+)
+
+func newTestCluster(options ...func(*v1alpha1.KafkaCluster)) *v1alpha1.KafkaCluster {
+	cluster := &v1alpha1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName},
+		Spec: v1alpha1.KafkaClusterSpec{
+			BootstrapServers: []string{"kafka-0:9092", "kafka-1:9092"},
+		},
+	}
+	for _, option := range options {
+		option(cluster)
+	}
+	return cluster
+}
+
+func withSASL(cluster *v1alpha1.KafkaCluster) {
+	cluster.Spec.Net.SASL = bindingsv1beta1.KafkaSASLSpec{
+		Enable:   true,
+		User:     secretValue("user"),
+		Password: secretValue("password"),
+		Type:     secretValue("saslType"),
+	}
+}
+
+func secretValue(key string) bindingsv1beta1.SecretValueFromSource {
+	return bindingsv1beta1.SecretValueFromSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+			Key:                  key,
+		},
+	}
+}
+
+func newTestSecret(namespace string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: testSecretName},
+		Data: map[string][]byte{
+			"user":     []byte("test-user"),
+			"password": []byte("test-password"),
+			"saslType": []byte(sarama.SASLTypeSCRAMSHA512),
+		},
+	}
+}
+
+func TestGet(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(newTestCluster()))
+	lister := listers.NewKafkaClusterLister(indexer)
+
+	cluster, err := Get(lister, &bindingsv1beta1.KafkaClusterReference{Name: testName}, testNamespace)
+	assert.Nil(t, err)
+	assert.Equal(t, testName, cluster.Name)
+
+	cluster, err = Get(lister, &bindingsv1beta1.KafkaClusterReference{Name: testName, Namespace: testNamespace}, "other-namespace")
+	assert.Nil(t, err)
+	assert.Equal(t, testName, cluster.Name)
+
+	_, err = Get(lister, &bindingsv1beta1.KafkaClusterReference{Name: testName}, "other-namespace")
+	assert.NotNil(t, err)
+}
+
+func TestTrackerReference(t *testing.T) {
+	ref := TrackerReference(&bindingsv1beta1.KafkaClusterReference{Name: testName}, testNamespace)
+	assert.Equal(t, "kafka.eventing.knative.dev/v1alpha1", ref.APIVersion)
+	assert.Equal(t, "KafkaCluster", ref.Kind)
+	assert.Equal(t, testNamespace, ref.Namespace)
+	assert.Equal(t, testName, ref.Name)
+
+	ref = TrackerReference(&bindingsv1beta1.KafkaClusterReference{Name: testName, Namespace: "other-namespace"}, testNamespace)
+	assert.Equal(t, "other-namespace", ref.Namespace)
+}
+
+func TestKey(t *testing.T) {
+	cluster := newTestCluster()
+	cluster.UID = "uid"
+	cluster.Generation = 2
+	assert.Equal(t, "uid/2", Key(cluster))
+
+	cluster.Generation = 3
+	assert.Equal(t, "uid/3", Key(cluster))
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := map[string]struct {
+		cluster     *v1alpha1.KafkaCluster
+		secret      *corev1.Secret
+		base        *sarama.Config
+		expectErr   bool
+		expectCheck func(t *testing.T, config *sarama.Config)
+	}{
+		"defaults": {
+			cluster: newTestCluster(),
+			expectCheck: func(t *testing.T, config *sarama.Config) {
+				assert.True(t, config.Consumer.Return.Errors)
+				assert.False(t, config.Net.SASL.Enable)
+			},
+		},
+		"sarama overrides": {
+			cluster: newTestCluster(func(cluster *v1alpha1.KafkaCluster) {
+				cluster.Spec.Sarama = "Version: 2.8.0\nClientID: cluster-client"
+			}),
+			expectCheck: func(t *testing.T, config *sarama.Config) {
+				assert.Equal(t, sarama.V2_8_0_0, config.Version)
+				assert.Equal(t, "cluster-client", config.ClientID)
+			},
+		},
+		"sasl": {
+			cluster: newTestCluster(withSASL),
+			secret:  newTestSecret(testNamespace),
+			expectCheck: func(t *testing.T, config *sarama.Config) {
+				assert.True(t, config.Net.SASL.Enable)
+				assert.Equal(t, "test-user", config.Net.SASL.User)
+				assert.Equal(t, "test-password", config.Net.SASL.Password)
+				assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), config.Net.SASL.Mechanism)
+			},
+		},
+		"secret of another namespace": {
+			cluster:   newTestCluster(withSASL),
+			secret:    newTestSecret("other-namespace"),
+			expectErr: true,
+		},
+		"base config without its authentication": {
+			cluster: newTestCluster(),
+			base: func() *sarama.Config {
+				config := sarama.NewConfig()
+				config.ClientID = "base-client"
+				config.Net.SASL.Enable = true
+				config.Net.SASL.User = "base-user"
+				config.Net.TLS.Enable = true
+				return config
+			}(),
+			expectCheck: func(t *testing.T, config *sarama.Config) {
+				assert.Equal(t, "base-client", config.ClientID)
+				assert.False(t, config.Net.SASL.Enable)
+				assert.Empty(t, config.Net.SASL.User)
+				assert.False(t, config.Net.TLS.Enable)
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset()
+			if test.secret != nil {
+				kubeClient = fake.NewSimpleClientset(test.secret)
+			}
+			var baseSASL sarama.Config
+			if test.base != nil {
+				baseSASL = *test.base
+			}
+
+			brokers, config, err := NewConfig(context.Background(), kubeClient, test.cluster, test.base)
+			assert.Equal(t, test.expectErr, err != nil)
+			if !test.expectErr {
+				assert.Equal(t, test.cluster.Spec.BootstrapServers, brokers)
+				test.expectCheck(t, config)
+			}
+			if test.base != nil {
+				// The base config is left untouched
+				assert.Equal(t, baseSASL.Net.SASL.User, test.base.Net.SASL.User)
+				assert.True(t, test.base.Net.SASL.Enable)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"context"
+
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+
+	"knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
+	kafkaclusterreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/kafkacluster"
+)
+
+// NewController returns the controller reporting the connectivity status of the KafkaClusters, which runs
+// in its own deployment (see cmd/kafkacluster/controller).
+func NewController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
+	kafkaclusterInformer := kafkacluster.Get(ctx)
+
+	r := &Reconciler{
+		kubeClient: kubeclient.Get(ctx),
+	}
+
+	impl := kafkaclusterreconciler.NewImpl(ctx, r)
+	r.enqueueAfter = impl.EnqueueAfter
+
+	kafkaclusterInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	return impl
+}
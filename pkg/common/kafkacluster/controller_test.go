@@ -0,0 +1,41 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+	_ "knative.dev/pkg/client/injection/kube/client/fake" // Knative Fake Client Injection
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	_ "knative.dev/eventing-kafka/pkg/client/injection/client/fake"                                // Force Fake Client Injection
+	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster/fake" // Force Fake Informer Injection
+)
+
+func TestNewController(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+	ctx, _ = injection.Fake.SetupInformers(ctx, &rest.Config{})
+
+	impl := NewController(ctx, configmap.NewStaticWatcher())
+	assert.NotNil(t, impl)
+}
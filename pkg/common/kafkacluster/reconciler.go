@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	kafkaclusterreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/kafkacluster"
+)
+
+// StatusRefreshInterval is the interval at which the connectivity of a KafkaCluster is re-evaluated, which
+// also accounts for the changes of its Secrets.
+const StatusRefreshInterval = 5 * time.Minute
+
+// newClusterAdmin is a wrapper of sarama.NewClusterAdmin, replaced by the tests
+var newClusterAdmin = sarama.NewClusterAdmin
+
+// Verify Reconciler Implements Interface
+var _ kafkaclusterreconciler.Interface = (*Reconciler)(nil)
+
+// Reconciler reports the connectivity status of the KafkaClusters.
+type Reconciler struct {
+	kubeClient kubernetes.Interface
+
+	// enqueueAfter enqueues the cluster again to refresh its status
+	enqueueAfter func(obj interface{}, after time.Duration)
+}
+
+// ReconcileKind connects to the KafkaCluster and records its brokers.
+func (r *Reconciler) ReconcileKind(ctx context.Context, cluster *v1alpha1.KafkaCluster) reconciler.Event {
+	logger := logging.FromContext(ctx)
+	cluster.Status.InitializeConditions()
+
+	brokers, config, err := NewConfig(ctx, r.kubeClient, cluster, nil)
+	if err != nil {
+		logger.Errorw("unable to build the Kafka configuration", zap.Error(err))
+		cluster.Status.MarkConnectionNotEstablished("InvalidConfiguration", err.Error())
+		return err
+	}
+
+	admin, err := newClusterAdmin(brokers, config)
+	if err != nil {
+		logger.Errorw("unable to connect to the Kafka cluster", zap.Error(err))
+		cluster.Status.MarkConnectionNotEstablished("ClientCreationFailed", err.Error())
+		return err
+	}
+	defer admin.Close()
+
+	clusterBrokers, controllerID, err := admin.DescribeCluster()
+	if err != nil {
+		logger.Errorw("unable to describe the Kafka cluster", zap.Error(err))
+		cluster.Status.MarkConnectionNotEstablished("DescribeClusterFailed", err.Error())
+		return err
+	}
+
+	addrs := make([]string, 0, len(clusterBrokers))
+	for _, broker := range clusterBrokers {
+		addrs = append(addrs, broker.Addr())
+	}
+	sort.Strings(addrs)
+	cluster.Status.MarkConnectionEstablished(addrs, controllerID)
+
+	if r.enqueueAfter != nil {
+		r.enqueueAfter(cluster, StatusRefreshInterval)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
+)
+
+func TestReconcileKind(t *testing.T) {
+	tests := map[string]struct {
+		cluster         *v1alpha1.KafkaCluster
+		adminErr        error
+		describeErr     error
+		expectReason    string
+		expectReady     bool
+		expectBrokers   []string
+		expectRequeued  bool
+		expectReconcile bool
+	}{
+		"connected": {
+			cluster:        newTestCluster(),
+			expectReady:    true,
+			expectBrokers:  []string{"kafka-0:9092", "kafka-1:9092"},
+			expectRequeued: true,
+		},
+		"missing secret": {
+			cluster:      newTestCluster(withSASL),
+			expectReason: "InvalidConfiguration",
+		},
+		"connection failed": {
+			cluster:      newTestCluster(),
+			adminErr:     errors.New("unable to connect"),
+			expectReason: "ClientCreationFailed",
+		},
+		"describe cluster failed": {
+			cluster:      newTestCluster(),
+			describeErr:  errors.New("unable to describe"),
+			expectReason: "DescribeClusterFailed",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			newClusterAdmin = func(addrs []string, conf *sarama.Config) (sarama.ClusterAdmin, error) {
+				if test.adminErr != nil {
+					return nil, test.adminErr
+				}
+				return &commontesting.MockClusterAdmin{
+					MockDescribeClusterFunc: func() ([]*sarama.Broker, int32, error) {
+						if test.describeErr != nil {
+							return nil, 0, test.describeErr
+						}
+						return []*sarama.Broker{sarama.NewBroker("kafka-1:9092"), sarama.NewBroker("kafka-0:9092")}, 1, nil
+					},
+				}, nil
+			}
+			defer func() { newClusterAdmin = sarama.NewClusterAdmin }()
+
+			var requeued bool
+			r := &Reconciler{
+				kubeClient: fake.NewSimpleClientset(),
+				enqueueAfter: func(obj interface{}, after time.Duration) {
+					requeued = true
+					assert.Equal(t, StatusRefreshInterval, after)
+				},
+			}
+
+			err := r.ReconcileKind(context.Background(), test.cluster)
+			assert.Equal(t, test.expectReady, err == nil)
+			assert.Equal(t, test.expectReady, test.cluster.Status.IsReady())
+			assert.Equal(t, test.expectBrokers, test.cluster.Status.Brokers)
+			assert.Equal(t, test.expectRequeued, requeued)
+
+			condition := test.cluster.Status.GetCondition(v1alpha1.KafkaClusterConditionConnectionEstablished)
+			if test.expectReady {
+				assert.Equal(t, corev1.ConditionTrue, condition.Status)
+			} else {
+				assert.Equal(t, corev1.ConditionFalse, condition.Status)
+				assert.Equal(t, test.expectReason, condition.Reason)
+			}
+		})
+	}
+}
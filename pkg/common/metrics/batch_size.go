@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// BatchSizeN is the name of the metric of the number of events of the batches sent to a sink
+	BatchSizeN = "kafka_batch_size"
+)
+
+var (
+	batchSizeStat = stats.Int64(
+		BatchSizeN,
+		"Number of events of the batches sent to the sink",
+		stats.UnitDimensionless)
+)
+
+func init() {
+	err := view.Register(
+		&view.View{
+			Description: batchSizeStat.Description(),
+			Measure:     batchSizeStat,
+			Aggregation: view.Distribution(1, 2, 5, 10, 20, 50, 100, 200, 500, 1000),
+			TagKeys:     []tag.Key{kindTagKey, namespaceTagKey, nameTagKey},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ReportBatchSize records the number of events of a batch sent to the sink of a resource (e.g. a KafkaSource).
+func ReportBatchSize(ctx context.Context, kind string, namespace string, name string, size int) error {
+	ctx, err := tag.New(ctx,
+		tag.Insert(kindTagKey, kind),
+		tag.Insert(namespaceTagKey, namespace),
+		tag.Insert(nameTagKey, name))
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, batchSizeStat.M(int64(size)))
+	return nil
+}
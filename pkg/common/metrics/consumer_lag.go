@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strconv"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+const (
+	// ConsumerGroupLagN is the name of the metric of the lag of a consumer group on a partition
+	ConsumerGroupLagN = "kafka_consumergroup_lag"
+
+	// ConsumerGroupTotalLagN is the name of the metric of the lag of a consumer group on all its partitions
+	ConsumerGroupTotalLagN = "kafka_consumergroup_lag_total"
+)
+
+var (
+	consumerGroupLagStat = stats.Int64(
+		ConsumerGroupLagN,
+		"Number of records of a partition not yet consumed by the consumer group",
+		stats.UnitDimensionless)
+
+	consumerGroupTotalLagStat = stats.Int64(
+		ConsumerGroupTotalLagN,
+		"Number of records of all the partitions not yet consumed by the consumer group",
+		stats.UnitDimensionless)
+
+	kindTagKey          = tag.MustNewKey("resource_kind")
+	namespaceTagKey     = tag.MustNewKey("namespace_name")
+	nameTagKey          = tag.MustNewKey("name")
+	consumerGroupTagKey = tag.MustNewKey("consumer_group")
+	topicTagKey         = tag.MustNewKey("topic")
+	partitionTagKey     = tag.MustNewKey("partition")
+)
+
+func init() {
+	err := view.Register(
+		&view.View{
+			Description: consumerGroupLagStat.Description(),
+			Measure:     consumerGroupLagStat,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{kindTagKey, namespaceTagKey, nameTagKey, consumerGroupTagKey, topicTagKey, partitionTagKey},
+		},
+		&view.View{
+			Description: consumerGroupTotalLagStat.Description(),
+			Measure:     consumerGroupTotalLagStat,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{kindTagKey, namespaceTagKey, nameTagKey, consumerGroupTagKey},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ReportConsumerGroupLag records the lag of the consumer group of a resource (e.g. a KafkaSource, or a
+// subscriber of a KafkaChannel), on each partition and in total.
+func ReportConsumerGroupLag(ctx context.Context, kind string, namespace string, name string, consumerGroup string, lags []offset.PartitionLag) error {
+	ctx, err := tag.New(ctx,
+		tag.Insert(kindTagKey, kind),
+		tag.Insert(namespaceTagKey, namespace),
+		tag.Insert(nameTagKey, name),
+		tag.Insert(consumerGroupTagKey, consumerGroup))
+	if err != nil {
+		return err
+	}
+
+	for _, lag := range lags {
+		partitionCtx, err := tag.New(ctx,
+			tag.Insert(topicTagKey, lag.Topic),
+			tag.Insert(partitionTagKey, strconv.Itoa(int(lag.Partition))))
+		if err != nil {
+			return err
+		}
+		metrics.Record(partitionCtx, consumerGroupLagStat.M(lag.Lag))
+	}
+	metrics.Record(ctx, consumerGroupTotalLagStat.M(offset.TotalLag(lags)))
+	return nil
+}
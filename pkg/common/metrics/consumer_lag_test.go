@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	_ "knative.dev/pkg/metrics/testing"
+
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+// Test The Reporting Of The Lag Of A Consumer Group
+func TestReportConsumerGroupLag(t *testing.T) {
+	lags := []offset.PartitionLag{
+		{Topic: "test-topic", Partition: 0, Offset: 10, LogEndOffset: 15, Lag: 5},
+		{Topic: "test-topic", Partition: 1, Offset: 3, LogEndOffset: 10, Lag: 7},
+	}
+
+	err := ReportConsumerGroupLag(context.Background(), "KafkaSource", "test-namespace", "test-source", "test-group", lags)
+	require.NoError(t, err)
+
+	rows, err := view.RetrieveData(ConsumerGroupLagN)
+	require.NoError(t, err)
+	values := make(map[string]float64)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key == partitionTagKey {
+				values[tag.Value] = row.Data.(*view.LastValueData).Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"0": 5, "1": 7}, values)
+
+	rows, err = view.RetrieveData(ConsumerGroupTotalLagN)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(12), rows[0].Data.(*view.LastValueData).Value)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// ProduceLatencyN is the name of the metric of the time taken to produce an event to a Kafka topic
+	ProduceLatencyN = "kafka_produce_latency"
+)
+
+var (
+	produceLatencyStat = stats.Float64(
+		ProduceLatencyN,
+		"Time from sending an event to the producer until its record is acknowledged by the brokers",
+		stats.UnitMilliseconds)
+)
+
+func init() {
+	err := view.Register(
+		&view.View{
+			Description: produceLatencyStat.Description(),
+			Measure:     produceLatencyStat,
+			Aggregation: view.Distribution(1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000),
+			TagKeys:     []tag.Key{kindTagKey, namespaceTagKey, nameTagKey},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ReportProduceLatency records the time taken to produce an event of a resource (e.g. a KafkaChannel) to its topic.
+func ReportProduceLatency(ctx context.Context, kind string, namespace string, name string, latency time.Duration) error {
+	ctx, err := tag.New(ctx,
+		tag.Insert(kindTagKey, kind),
+		tag.Insert(namespaceTagKey, namespace),
+		tag.Insert(nameTagKey, name))
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, produceLatencyStat.M(float64(latency)/float64(time.Millisecond)))
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+// Test The Reporting Of The Produce Latency
+func TestReportProduceLatency(t *testing.T) {
+	for _, latency := range []time.Duration{3 * time.Millisecond, 10 * time.Millisecond, 7 * time.Millisecond} {
+		err := ReportProduceLatency(context.Background(), "KafkaChannel", "test-namespace", "test-channel", latency)
+		require.NoError(t, err)
+	}
+
+	rows, err := view.RetrieveData(ProduceLatencyN)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	data := rows[0].Data.(*view.DistributionData)
+	assert.Equal(t, int64(3), data.Count)
+	assert.Equal(t, float64(3), data.Min)
+	assert.Equal(t, float64(10), data.Max)
+	assert.Equal(t, float64(20), data.Sum())
+}
@@ -8,6 +8,12 @@ type MockClusterAdmin struct {
 	MockCreateTopicFunc        func(topic string, detail *sarama.TopicDetail, validateOnly bool) error
 	MockDeleteTopicFunc        func(topic string) error
 	MockListConsumerGroupsFunc func() (map[string]string, error)
+	MockDescribeTopicsFunc     func(topics []string) ([]*sarama.TopicMetadata, error)
+	MockListTopicsFunc         func() (map[string]sarama.TopicDetail, error)
+	MockCreatePartitionsFunc   func(topic string, count int32, assignment [][]int32, validateOnly bool) error
+	MockDescribeConfigFunc     func(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error)
+	MockAlterConfigFunc        func(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error
+	MockDescribeClusterFunc    func() ([]*sarama.Broker, int32, error)
 }
 
 func (ca *MockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
@@ -65,14 +71,23 @@ func (ca *MockClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (ca *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
+	if ca.MockDescribeTopicsFunc != nil {
+		return ca.MockDescribeTopicsFunc(topics)
+	}
 	return nil, nil
 }
 
 func (ca *MockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	if ca.MockListTopicsFunc != nil {
+		return ca.MockListTopicsFunc()
+	}
 	return nil, nil
 }
 
 func (ca *MockClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+	if ca.MockCreatePartitionsFunc != nil {
+		return ca.MockCreatePartitionsFunc(topic, count, assignment, validateOnly)
+	}
 	return nil
 }
 
@@ -81,10 +96,16 @@ func (ca *MockClusterAdmin) DeleteRecords(topic string, partitionOffsets map[int
 }
 
 func (ca *MockClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	if ca.MockDescribeConfigFunc != nil {
+		return ca.MockDescribeConfigFunc(resource)
+	}
 	return nil, nil
 }
 
 func (ca *MockClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
+	if ca.MockAlterConfigFunc != nil {
+		return ca.MockAlterConfigFunc(resourceType, name, entries, validateOnly)
+	}
 	return nil
 }
 
@@ -120,6 +141,9 @@ func (ca *MockClusterAdmin) DeleteConsumerGroupOffset(group string, topic string
 }
 
 func (ca *MockClusterAdmin) DescribeCluster() (brokers []*sarama.Broker, controllerID int32, err error) {
+	if ca.MockDescribeClusterFunc != nil {
+		return ca.MockDescribeClusterFunc()
+	}
 	return nil, 0, nil
 }
 
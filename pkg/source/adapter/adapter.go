@@ -18,12 +18,15 @@ package kafka
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
@@ -31,24 +34,36 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "knative.dev/control-protocol/pkg"
 	ctrlnetwork "knative.dev/control-protocol/pkg/network"
+	ctrlservice "knative.dev/control-protocol/pkg/service"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/metrics/source"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
 
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/kncloudevents"
 
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol/commands"
 	"knative.dev/eventing-kafka/pkg/source/client"
 	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	"knative.dev/eventing-kafka/pkg/source/schemaregistry"
 )
 
 const (
 	resourceGroup = "kafkasources.sources.knative.dev"
+
+	// The bounds of the backoff between the attempts to decode a record while the schema registry is
+	// unavailable, or to send a reply while its destination is
+	retryMinBackoff = 100 * time.Millisecond
+	retryMaxBackoff = 30 * time.Second
 )
 
 type AdapterConfig struct {
@@ -56,10 +71,45 @@ type AdapterConfig struct {
 	client.KafkaEnvConfig
 
 	Topics        []string `envconfig:"KAFKA_TOPICS" required:"true"`
+	TopicPattern  string   `envconfig:"KAFKA_TOPIC_PATTERN" required:"false"`
 	ConsumerGroup string   `envconfig:"KAFKA_CONSUMER_GROUP" required:"true"`
 	Name          string   `envconfig:"NAME" required:"true"`
 	KeyType       string   `envconfig:"KEY_TYPE" required:"false"`
 
+	Ordering    string `envconfig:"KAFKA_DELIVERY_ORDERING" required:"false"`
+	MaxInFlight int    `envconfig:"KAFKA_MAX_IN_FLIGHT" required:"false"`
+
+	// Delivery is the JSON encoded DeliverySpec of the KafkaSource.
+	Delivery string `envconfig:"K_DELIVERY" required:"false"`
+	// DeadLetterSink is the resolved URI of the dead letter sink.
+	DeadLetterSink string `envconfig:"K_DEAD_LETTER_SINK" required:"false"`
+
+	// ReplyTopic is the topic where the events returned by the sink are produced.
+	ReplyTopic string `envconfig:"KAFKA_REPLY_TOPIC" required:"false"`
+	// ReplySink is the resolved URI of the addressable receiving the events returned by the sink.
+	ReplySink string `envconfig:"K_REPLY_SINK" required:"false"`
+
+	// SchemaRegistry is the schema registry decoding the records, if any.
+	SchemaRegistry client.SchemaRegistryEnvConfig
+
+	// CloudEventAttributes is the JSON encoded CloudEventAttributesSpec of the KafkaSource.
+	CloudEventAttributes string `envconfig:"KAFKA_CE_ATTRIBUTES" required:"false"`
+
+	// BatchMaxSize is the maximum number of records of the batches sent to the sink, batching being
+	// disabled when not set.
+	BatchMaxSize int `envconfig:"KAFKA_BATCH_MAX_SIZE" required:"false"`
+	// BatchMaxWaitMs is the maximum time in milliseconds a record waits for its batch to be full.
+	BatchMaxWaitMs int `envconfig:"KAFKA_BATCH_MAX_WAIT_MS" required:"false"`
+
+	// RateLimitEventsPerSecond is the maximum rate of the events sent to the sink by all the adapters of
+	// the source together, unlimited when not set.  Each adapter applies the share of its partitions.
+	RateLimitEventsPerSecond float64 `envconfig:"KAFKA_RATE_LIMIT_EPS" required:"false"`
+	// RateLimitBurst is the maximum number of events sent at once above the rate limit (shared likewise).
+	RateLimitBurst int `envconfig:"KAFKA_RATE_LIMIT_BURST" required:"false"`
+
+	// Paused suspends the consumption, the adapter doesn't join the consumer group.
+	Paused bool `envconfig:"KAFKA_PAUSED" required:"false"`
+
 	// Turn off the control server.
 	DisableControlServer bool
 }
@@ -78,7 +128,34 @@ type Adapter struct {
 	logger            *zap.SugaredLogger
 	keyTypeMapper     func([]byte) interface{}
 	rateLimiter       *rate.Limiter
-	extensions        map[string]string
+	sourceRateLimit   *sourceRateLimit
+	retryConfig       *kncloudevents.RetryConfig
+	ceAttributes      *sourcesv1beta1.CloudEventAttributesSpec
+
+	// replyProducer produces the events returned by the sink to the reply topic, if any.
+	replyProducer sarama.SyncProducer
+
+	// schemaRegistry decodes the keys and/or values of the records when configured.
+	schemaRegistry *schemaregistry.Client
+	decodeKey      bool
+	decodeValue    bool
+
+	// sink and extensions can be updated while the adapter runs (see SetSink and SetCloudEventOverrides),
+	// as well as the delivery settings of the config and the fields derived from them (see SetDeliveryConfig).
+	// The sink of the httpMessageSender is used when sink is empty.
+	mu         sync.RWMutex
+	sink       string
+	extensions map[string]string
+
+	// serverHandler receives the control-protocol commands stopping and starting the
+	// consumer group (e.g. from the ResetOffset controller).
+	serverHandler controlprotocol.ServerHandler
+
+	// claims are the partitions claimed by the current consumer group session, if any, and
+	// claimsTime the last time they changed.
+	claimsMu   sync.RWMutex
+	claims     kafkasourcecontrol.Claims
+	claimsTime time.Time
 }
 
 var (
@@ -93,14 +170,35 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, httpMe
 	logger := logging.FromContext(ctx)
 	config := processed.(*AdapterConfig)
 
-	return &Adapter{
+	a := &Adapter{
 		config:            config,
 		httpMessageSender: httpMessageSender,
 		reporter:          reporter,
 		logger:            logger,
 		keyTypeMapper:     getKeyTypeMapper(config.KeyType),
+		retryConfig:       getRetryConfig(logger, config.Delivery),
+		ceAttributes:      getCloudEventAttributes(logger, config.CloudEventAttributes),
+	}
+
+	if config.BatchMaxSize > 0 {
+		a.reporter = NewBatchStatsReporter(reporter)
 	}
+
+	if config.RateLimitEventsPerSecond > 0 {
+		burst := config.RateLimitBurst
+		if burst <= 0 {
+			burst = int(math.Max(1, math.Ceil(config.RateLimitEventsPerSecond)))
+		}
+		// The whole rate limit applies until the share of the claimed partitions is known (see Setup)
+		a.sourceRateLimit = &sourceRateLimit{eventsPerSecond: config.RateLimitEventsPerSecond, burst: burst}
+		a.SetRateLimits(rate.Limit(config.RateLimitEventsPerSecond), burst)
+	}
+
+	a.schemaRegistry, a.decodeKey, a.decodeValue = newSchemaRegistry(config.SchemaRegistry)
+
+	return a
 }
+
 func (a *Adapter) GetConsumerGroup() string {
 	return a.config.ConsumerGroup
 }
@@ -108,8 +206,14 @@ func (a *Adapter) GetConsumerGroup() string {
 func (a *Adapter) Start(ctx context.Context) (err error) {
 	a.logger.Infow("Starting with config: ",
 		zap.String("Topics", strings.Join(a.config.Topics, ",")),
+		zap.String("TopicPattern", a.config.TopicPattern),
 		zap.String("ConsumerGroup", a.config.ConsumerGroup),
+		zap.String("Ordering", a.config.Ordering),
 		zap.String("SinkURI", a.config.Sink),
+		zap.String("DeadLetterSinkURI", a.getDeadLetterSink()),
+		zap.String("ReplyTopic", a.config.ReplyTopic),
+		zap.String("ReplySinkURI", a.getReplySink()),
+		zap.Int("BatchMaxSize", a.config.BatchMaxSize),
 		zap.String("Name", a.config.Name),
 		zap.String("Namespace", a.config.Namespace),
 	)
@@ -120,9 +224,7 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 		if err != nil {
 			return err
 		}
-		if len(ceOverrides.Extensions) > 0 {
-			a.extensions = ceOverrides.Extensions
-		}
+		a.SetCloudEventOverrides(ceOverrides)
 	}
 
 	// Init control service
@@ -141,40 +243,141 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 	}
 	a.saramaConfig = config
 
-	options := []consumer.SaramaConsumerHandlerOption{consumer.WithSaramaConsumerLifecycleListener(a)}
-	consumerGroupFactory := consumer.NewConsumerGroupFactory(addrs, config, &consumer.NoopConsumerGroupOffsetsChecker{}, func(ref types.NamespacedName) {})
-	group, err := consumerGroupFactory.StartConsumerGroup(
-		ctx,
-		a.config.ConsumerGroup,
-		a.config.Topics,
-		a,
-		types.NamespacedName{Namespace: a.config.Namespace, Name: a.config.Name},
-		options...,
-	)
+	ordering, err := consumer.ParseDeliveryOrdering(a.config.Ordering)
 	if err != nil {
-		return fmt.Errorf("failed to start consumer group: %w", err)
+		return err
 	}
-	defer func() {
-		err := group.Close()
+
+	options := []consumer.SaramaConsumerHandlerOption{
+		consumer.WithSaramaConsumerLifecycleListener(a),
+		consumer.WithDeliveryOrdering(ordering, a.config.MaxInFlight),
+	}
+	if a.config.BatchMaxSize > 0 {
+		options = append(options, consumer.WithBatching(a.config.BatchMaxSize, time.Duration(a.config.BatchMaxWaitMs)*time.Millisecond))
+	}
+	ref := types.NamespacedName{Namespace: a.config.Namespace, Name: a.config.Name}
+
+	// Start the control-protocol server handling the consumer group commands, unless
+	// one is provided (multi-tenant adapter) or the control server is disabled.
+	serverHandler := a.serverHandler
+	if serverHandler == nil && !a.config.DisableControlServer {
+		serverHandler, err = controlprotocol.NewServerHandler(ctx, controlprotocol.ServerPort)
 		if err != nil {
-			a.logger.Errorw("Failed to close consumer group", zap.Error(err))
+			return fmt.Errorf("failed to start the control-protocol server: %w", err)
 		}
-	}()
+		defer serverHandler.Shutdown(5 * time.Second)
+	}
 
-	// Track errors
-	go func() {
-		for err := range group.Errors() {
-			a.logger.Errorw("Error while consuming messages", zap.Error(err))
+	// A paused adapter doesn't join the consumer group, leaving its committed offsets
+	// untouched. The consumer group commands are acknowledged as there is nothing to stop.
+	if a.config.Paused {
+		a.logger.Info("Paused, not consuming")
+		if serverHandler != nil {
+			registerPausedHandlers(serverHandler)
 		}
-	}()
+		<-ctx.Done()
+		a.logger.Info("Shutting down...")
+		return nil
+	}
+
+	if a.config.ReplyTopic != "" {
+		a.replyProducer, err = newReplyProducer(addrs, config)
+		if err != nil {
+			return fmt.Errorf("failed to create the reply producer: %w", err)
+		}
+		defer a.replyProducer.Close()
+	}
+
+	// startGroup joins the consumer group consuming the topics, and returns the function leaving it
+	var startGroup func(topics []string) (func() error, error)
+	if serverHandler != nil {
+		// The consumer group manager can stop and start the consumer group on demand
+		manager := consumer.NewConsumerGroupManager(a.logger.Desugar(), serverHandler, addrs, config, &consumer.NoopConsumerGroupOffsetsChecker{}, func(ref types.NamespacedName) {})
+		startGroup = func(topics []string) (func() error, error) {
+			err := manager.StartConsumerGroup(ctx, a.config.ConsumerGroup, topics, a, ref, options...)
+			if err != nil {
+				return nil, err
+			}
+			a.trackErrors(manager.Errors(a.config.ConsumerGroup))
+			return func() error { return manager.CloseConsumerGroup(a.config.ConsumerGroup) }, nil
+		}
+	} else {
+		consumerGroupFactory := consumer.NewConsumerGroupFactory(addrs, config, &consumer.NoopConsumerGroupOffsetsChecker{}, func(ref types.NamespacedName) {})
+		startGroup = func(topics []string) (func() error, error) {
+			group, err := consumerGroupFactory.StartConsumerGroup(ctx, a.config.ConsumerGroup, topics, a, ref, options...)
+			if err != nil {
+				return nil, err
+			}
+			a.trackErrors(group.Errors())
+			return group.Close, nil
+		}
+	}
+
+	// The share of the source rate limit is derived from the partitions of the consumed topics
+	if a.sourceRateLimit != nil {
+		kafkaClient, err := sarama.NewClient(addrs, config)
+		if err != nil {
+			return fmt.Errorf("failed to create the Kafka client: %w", err)
+		}
+		defer kafkaClient.Close()
+		a.sourceRateLimit.partitions = kafkaClient.Partitions
+
+		startGroupWithTopics := startGroup
+		startGroup = func(topics []string) (func() error, error) {
+			a.sourceRateLimit.setTopics(topics)
+			return startGroupWithTopics(topics)
+		}
+	}
+
+	if a.config.TopicPattern != "" {
+		return a.consumeTopicPattern(ctx, addrs, config, startGroup)
+	}
+
+	closeGroup, err := startGroup(a.config.Topics)
+	if err != nil {
+		return fmt.Errorf("failed to start consumer group: %w", err)
+	}
+	defer a.closeGroup(closeGroup)
 
 	<-ctx.Done()
 	a.logger.Info("Shutting down...")
 	return nil
 }
 
+func (a *Adapter) trackErrors(groupErrors <-chan error) {
+	go func() {
+		for err := range groupErrors {
+			a.logger.Errorw("Error while consuming messages", zap.Error(err))
+		}
+	}()
+}
+
+func (a *Adapter) closeGroup(closeGroup func() error) {
+	if err := closeGroup(); err != nil {
+		a.logger.Errorw("Failed to close consumer group", zap.Error(err))
+	}
+}
+
 func (a *Adapter) SetReady(int32, bool) {}
 
+// registerPausedHandlers acknowledges the stop and start consumer group commands without
+// consuming, so that the consumer group of a paused adapter can still be repositioned.
+func registerPausedHandlers(serverHandler controlprotocol.ServerHandler) {
+	acknowledge := func(ctx context.Context, commandMessage ctrlservice.AsyncCommandMessage) {
+		commandMessage.NotifySuccess()
+	}
+	serverHandler.AddAsyncHandler(
+		commands.StopConsumerGroupOpCode,
+		commands.StopConsumerGroupResultOpCode,
+		&commands.ConsumerGroupAsyncCommand{},
+		acknowledge)
+	serverHandler.AddAsyncHandler(
+		commands.StartConsumerGroupOpCode,
+		commands.StartConsumerGroupResultOpCode,
+		&commands.ConsumerGroupAsyncCommand{},
+		acknowledge)
+}
+
 func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool, error) {
 	if a.rateLimiter != nil {
 		a.rateLimiter.Wait(ctx)
@@ -184,32 +387,54 @@ func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool
 	ctx, span := tracing.StartTraceFromMessage(a.logger, ctx, message, "kafka-source-"+msg.Topic)
 	defer span.End()
 
-	req, err := a.httpMessageSender.NewCloudEventRequest(ctx)
+	req, err := a.newSinkRequest(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	err = a.ConsumerMessageToHttpRequest(ctx, msg, req)
+	err = a.whileRegistryUnavailable(ctx, func() error {
+		return a.ConsumerMessageToHttpRequest(ctx, msg, req)
+	})
 	if err != nil {
 		a.logger.Debug("failed to create request", zap.Error(err))
+		if errors.Is(err, schemaregistry.ErrUnavailable) {
+			// The session is closing, the record is consumed again by the next one
+			return false, err
+		}
 		return true, err
 	}
 
-	res, err := a.httpMessageSender.SendWithRetries(req, retryConfig)
-
-	if err != nil {
+	res, err := a.httpMessageSender.SendWithRetries(req, a.getRetryConfig())
+	var replyErr error
+	if err == nil {
+		if res.StatusCode/100 != 2 {
+			// Always try to read and close body so the connection can be reused afterwards
+			discardBody(res)
+			a.logger.Debug("Unexpected status code", zap.Int("status code", res.StatusCode))
+			err = fmt.Errorf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
+		} else if a.hasReply() {
+			replyErr = a.sendReply(ctx, msg, res)
+		} else {
+			discardBody(res)
+		}
+	} else {
 		a.logger.Debug("Error while sending the message", zap.Error(err))
-		return false, err // Error while sending, don't commit offset
 	}
-	// Always try to read and close body so the connection can be reused afterwards
-	if res.Body != nil {
-		io.Copy(ioutil.Discard, res.Body)
-		res.Body.Close()
+
+	if err != nil {
+		if a.getDeadLetterSink() == "" {
+			return false, err // Error while sending, don't commit offset
+		}
+		return a.sendToDeadLetterSink(ctx, msg, err)
 	}
 
-	if res.StatusCode/100 != 2 {
-		a.logger.Debug("Unexpected status code", zap.Int("status code", res.StatusCode))
-		return false, fmt.Errorf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
+	if replyErr != nil {
+		a.logger.Debug("Error while sending the reply", zap.Error(replyErr))
+		if a.getDeadLetterSink() == "" {
+			// The session is closing, the record is consumed (and sent to the sink) again by the next one
+			return false, replyErr
+		}
+		return a.sendToDeadLetterSink(ctx, msg, replyErr)
 	}
 
 	reportArgs := &source.ReportArgs{
@@ -222,11 +447,175 @@ func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool
 	return true, nil
 }
 
-// SetRateLimiter sets the global consumer rate limiter
+// sendToDeadLetterSink sends the message to the dead letter sink after all the
+// delivery attempts to the sink failed. The offset is committed only if the
+// dead letter sink accepted the message.
+func (a *Adapter) sendToDeadLetterSink(ctx context.Context, msg *sarama.ConsumerMessage, sinkErr error) (bool, error) {
+	a.logger.Debugw("Sending the message to the dead letter sink",
+		zap.String("topic", msg.Topic),
+		zap.Int32("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+		zap.Error(sinkErr))
+
+	req, err := a.httpMessageSender.NewCloudEventRequestWithTarget(ctx, a.getDeadLetterSink())
+	if err != nil {
+		return false, err
+	}
+
+	err = a.ConsumerMessageToHttpRequest(ctx, msg, req)
+	if err != nil {
+		return true, err
+	}
+
+	res, err := a.httpMessageSender.SendWithRetries(req, a.getRetryConfig())
+	if err != nil {
+		a.logger.Debug("Error while sending the message to the dead letter sink", zap.Error(err))
+		return false, fmt.Errorf("failed to send to the dead letter sink: %w (sink error: %v)", err, sinkErr)
+	}
+	discardBody(res)
+
+	if res.StatusCode/100 != 2 {
+		a.logger.Debug("Unexpected status code from the dead letter sink", zap.Int("status code", res.StatusCode))
+		return false, fmt.Errorf("dead letter sink responded with %d %s (sink error: %v)", res.StatusCode, http.StatusText(res.StatusCode), sinkErr)
+	}
+
+	return true, nil
+}
+
+// SetRateLimits sets the global consumer rate limiter.  Once set, the limits can be updated while
+// the adapter runs.
 func (a *Adapter) SetRateLimits(r rate.Limit, b int) {
+	if a.rateLimiter != nil {
+		a.rateLimiter.SetLimit(r)
+		a.rateLimiter.SetBurst(b)
+		return
+	}
 	a.rateLimiter = rate.NewLimiter(r, b)
 }
 
+// SetSink sets the URI of the sink receiving the events, in place of the sink of the httpMessageSender.
+func (a *Adapter) SetSink(sink string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sink = sink
+}
+
+// SetCloudEventOverrides sets the extensions added to the events.
+func (a *Adapter) SetCloudEventOverrides(ceOverrides *duckv1.CloudEventOverrides) {
+	var extensions map[string]string
+	if ceOverrides != nil && len(ceOverrides.Extensions) > 0 {
+		extensions = ceOverrides.Extensions
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.extensions = extensions
+}
+
+// getExtensions returns the extensions added to the events, which must not be modified.
+func (a *Adapter) getExtensions() map[string]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.extensions
+}
+
+// newSchemaRegistry returns the client of the schema registry decoding the records, nil when not
+// configured, and whether the keys and the values are decoded.
+func newSchemaRegistry(config client.SchemaRegistryEnvConfig) (*schemaregistry.Client, bool, bool) {
+	if config.URL == "" {
+		return nil, false, false
+	}
+	registry := schemaregistry.NewClient(config.URL, config.User, config.Password,
+		&http.Client{Timeout: schemaregistry.DefaultTimeout})
+	switch sourcesv1beta1.SchemaRegistryDecode(config.Decode) {
+	case sourcesv1beta1.DecodeKey:
+		return registry, true, false
+	case sourcesv1beta1.DecodeAll:
+		return registry, true, true
+	default:
+		return registry, false, true
+	}
+}
+
+// SetDeliveryConfig updates the settings of the config which apply to each record, and can therefore
+// change while consuming: the delivery retries, the dead letter and reply sinks, the key type, the
+// CloudEvent attributes and the schema registry.  The other settings of the config are ignored, they
+// only apply when joining the consumer group.
+func (a *Adapter) SetDeliveryConfig(config *AdapterConfig) {
+	keyTypeMapper := getKeyTypeMapper(config.KeyType)
+	retryConfig := getRetryConfig(a.logger, config.Delivery)
+	ceAttributes := getCloudEventAttributes(a.logger, config.CloudEventAttributes)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if config.SchemaRegistry != a.config.SchemaRegistry {
+		// The cached schemas are kept unless the registry changes
+		a.schemaRegistry, a.decodeKey, a.decodeValue = newSchemaRegistry(config.SchemaRegistry)
+	}
+	a.config.Delivery = config.Delivery
+	a.config.DeadLetterSink = config.DeadLetterSink
+	a.config.ReplySink = config.ReplySink
+	a.config.KeyType = config.KeyType
+	a.config.CloudEventAttributes = config.CloudEventAttributes
+	a.config.SchemaRegistry = config.SchemaRegistry
+	a.keyTypeMapper = keyTypeMapper
+	a.retryConfig = retryConfig
+	a.ceAttributes = ceAttributes
+}
+
+// getDeadLetterSink returns the resolved URI of the dead letter sink, empty when not set.
+func (a *Adapter) getDeadLetterSink() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.config.DeadLetterSink
+}
+
+// getReplySink returns the resolved URI of the reply sink, empty when not set.
+func (a *Adapter) getReplySink() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.config.ReplySink
+}
+
+// getKeyTypeMapper returns the function converting the record keys to the key extension.
+func (a *Adapter) getKeyTypeMapper() func([]byte) interface{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.keyTypeMapper
+}
+
+// getCloudEventAttributes returns the mapping of the records to the event attributes, nil when not set.
+func (a *Adapter) getCloudEventAttributes() *sourcesv1beta1.CloudEventAttributesSpec {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.ceAttributes
+}
+
+// getSchemaRegistry returns the schema registry decoding the records, nil when not configured, and
+// whether the keys and the values are decoded.
+func (a *Adapter) getSchemaRegistry() (*schemaregistry.Client, bool, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.schemaRegistry, a.decodeKey, a.decodeValue
+}
+
+// newSinkRequest returns a new request to the sink.
+func (a *Adapter) newSinkRequest(ctx context.Context) (*http.Request, error) {
+	a.mu.RLock()
+	sink := a.sink
+	a.mu.RUnlock()
+
+	if sink == "" {
+		return a.httpMessageSender.NewCloudEventRequest(ctx)
+	}
+	return a.httpMessageSender.NewCloudEventRequestWithTarget(ctx, sink)
+}
+
+// SetServerHandler sets the control-protocol server handler receiving the consumer group commands.
+func (a *Adapter) SetServerHandler(serverHandler controlprotocol.ServerHandler) {
+	a.serverHandler = serverHandler
+}
+
 func (a *Adapter) HandleServiceMessage(ctx context.Context, message ctrl.ServiceMessage) {
 	// In this first PR, there is only the RA sending messages to control plane,
 	// there is no message the control plane should send to the RA
@@ -235,6 +624,9 @@ func (a *Adapter) HandleServiceMessage(ctx context.Context, message ctrl.Service
 }
 
 func (a *Adapter) Setup(sess sarama.ConsumerGroupSession) {
+	a.setClaims(sess.Claims())
+	a.applyRateLimitShare(sess.Claims())
+
 	if a.controlServer != nil {
 		if err := a.controlServer.SendAndWaitForAck(kafkasourcecontrol.NotifySetupClaimsOpCode, kafkasourcecontrol.Claims(sess.Claims())); err != nil {
 			a.logger.Warnf("Cannot send the claims update: %v", err)
@@ -244,6 +636,8 @@ func (a *Adapter) Setup(sess sarama.ConsumerGroupSession) {
 }
 
 func (a *Adapter) Cleanup(sess sarama.ConsumerGroupSession) {
+	a.setClaims(nil)
+
 	if a.controlServer != nil {
 		if err := a.controlServer.SendAndWaitForAck(kafkasourcecontrol.NotifyCleanupClaimsOpCode, kafkasourcecontrol.Claims(sess.Claims())); err != nil {
 			a.logger.Warnf("Cannot send the claims update: %v", err)
@@ -251,6 +645,91 @@ func (a *Adapter) Cleanup(sess sarama.ConsumerGroupSession) {
 	}
 }
 
+func (a *Adapter) setClaims(claims map[string][]int32) {
+	a.claimsMu.Lock()
+	defer a.claimsMu.Unlock()
+	a.claims = claims
+	a.claimsTime = time.Now()
+}
+
+// Claims returns the partitions claimed by the consumer group session, if any, and the last time they changed.
+func (a *Adapter) Claims() (kafkasourcecontrol.Claims, time.Time) {
+	a.claimsMu.RLock()
+	defer a.claimsMu.RUnlock()
+	return a.claims, a.claimsTime
+}
+
+// getRetryConfig builds the retry configuration from the JSON encoded
+// DeliverySpec, falling back to the default retry configuration.
+func getRetryConfig(logger *zap.SugaredLogger, delivery string) *kncloudevents.RetryConfig {
+	if delivery == "" {
+		return retryConfig
+	}
+
+	spec := eventingduck.DeliverySpec{}
+	if err := json.Unmarshal([]byte(delivery), &spec); err != nil {
+		logger.Errorw("Failed to parse the delivery spec, using the default retry configuration", zap.Error(err))
+		return retryConfig
+	}
+
+	config, err := kncloudevents.RetryConfigFromDeliverySpec(spec)
+	if err != nil {
+		logger.Errorw("Failed to build the retry configuration from the delivery spec, using the default retry configuration", zap.Error(err))
+		return retryConfig
+	}
+	config.CheckRetry = kncloudevents.SelectiveRetry
+	return &config
+}
+
+func (a *Adapter) getRetryConfig() *kncloudevents.RetryConfig {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.retryConfig == nil {
+		return retryConfig
+	}
+	return a.retryConfig
+}
+
+func discardBody(res *http.Response) {
+	if res.Body != nil {
+		io.Copy(ioutil.Discard, res.Body)
+		res.Body.Close()
+	}
+}
+
+// whileRegistryUnavailable calls decode until it doesn't fail because the schema registry is unavailable,
+// or until the context is done.  The record being decoded can't be skipped meanwhile, as its offset would
+// be committed along with the ones of the later records.
+func (a *Adapter) whileRegistryUnavailable(ctx context.Context, decode func() error) error {
+	return a.retryWithBackoff(ctx, "decode the record", func(err error) bool {
+		return errors.Is(err, schemaregistry.ErrUnavailable)
+	}, decode)
+}
+
+// retryWithBackoff calls attempt until it doesn't fail with a retryable error, with an exponential backoff
+// between the attempts, or until the context is done, in which case the last error is returned.
+func (a *Adapter) retryWithBackoff(ctx context.Context, action string, retryable func(error) bool, attempt func() error) error {
+	backoff := retryMinBackoff
+	for {
+		err := attempt()
+		if err == nil || !retryable(err) {
+			return err
+		}
+		a.logger.Warnw("Failed to "+action+", retrying", zap.Duration("backoff", backoff), zap.Error(err))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		if backoff *= 2; backoff > retryMaxBackoff {
+			backoff = retryMaxBackoff
+		}
+	}
+}
+
 // Default retry configuration, 5 retries, exponential backoff with 50ms delay
 func defaultRetryConfig() *kncloudevents.RetryConfig {
 	return &kncloudevents.RetryConfig{
@@ -28,12 +28,25 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlmessage "knative.dev/control-protocol/pkg/message"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/metrics/source"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol/commands"
+	controltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+	"knative.dev/eventing-kafka/pkg/source/client"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	"knative.dev/eventing-kafka/pkg/source/schemaregistry"
+	registrytesting "knative.dev/eventing-kafka/pkg/source/schemaregistry/testing"
 )
 
 func TestPostMessage_ServeHTTP_binary_mode(t *testing.T) {
@@ -42,6 +55,7 @@ func TestPostMessage_ServeHTTP_binary_mode(t *testing.T) {
 	testCases := map[string]struct {
 		sink            func(http.ResponseWriter, *http.Request)
 		keyTypeMapper   string
+		ceAttributes    *sourcesv1beta1.CloudEventAttributesSpec
 		message         *sarama.ConsumerMessage
 		expectedHeaders map[string]string
 		expectedBody    string
@@ -202,6 +216,45 @@ func TestPostMessage_ServeHTTP_binary_mode(t *testing.T) {
 			expectedBody: `{"key":"value"}`,
 			error:        false,
 		},
+		"accepted_mapped_attributes": {
+			sink: sinkAccepted,
+			ceAttributes: &sourcesv1beta1.CloudEventAttributesSpec{
+				ID:      &sourcesv1beta1.AttributeMappingSpec{ValuePointer: "/order/id"},
+				Type:    &sourcesv1beta1.AttributeMappingSpec{Header: "Event-Type"},
+				Source:  &sourcesv1beta1.AttributeMappingSpec{Header: "missing"},
+				Subject: &sourcesv1beta1.AttributeMappingSpec{Key: true},
+				Time:    &sourcesv1beta1.AttributeMappingSpec{ValuePointer: "/order/created~1at"},
+				Headers: &sourcesv1beta1.HeadersPropagationSpec{Deny: []string{"authorization"}},
+			},
+			message: &sarama.ConsumerMessage{
+				Key:   []byte("customer-1"),
+				Topic: "topic1",
+				Headers: []*sarama.RecordHeader{
+					{
+						Key: []byte("event-type"), Value: []byte("com.example.order.created"),
+					},
+					{
+						Key: []byte("authorization"), Value: []byte("secret"),
+					},
+				},
+				Value:     []byte(`{"order":{"id":12345678901234567,"created/at":"2021-06-01T12:00:00Z"}}`),
+				Partition: 1,
+				Offset:    2,
+				Timestamp: aTimestamp,
+			},
+			expectedHeaders: map[string]string{
+				"ce-specversion":          "1.0",
+				"ce-id":                   "12345678901234567",
+				"ce-time":                 "2021-06-01T12:00:00Z",
+				"ce-type":                 "com.example.order.created",
+				"ce-source":               sourcesv1beta1.KafkaEventSource("test", "test", "topic1"),
+				"ce-subject":              "customer-1",
+				"ce-key":                  "customer-1",
+				"ce-kafkaheadereventtype": "com.example.order.created",
+			},
+			expectedBody: `{"order":{"id":12345678901234567,"created/at":"2021-06-01T12:00:00Z"}}`,
+			error:        false,
+		},
 		"accepted_structured": {
 			sink: sinkAccepted,
 			message: &sarama.ConsumerMessage{
@@ -352,6 +405,7 @@ func TestPostMessage_ServeHTTP_binary_mode(t *testing.T) {
 				logger:            zap.NewNop().Sugar(),
 				reporter:          statsReporter,
 				keyTypeMapper:     getKeyTypeMapper(tc.keyTypeMapper),
+				ceAttributes:      tc.ceAttributes,
 			}
 
 			_, err = a.Handle(context.TODO(), tc.message)
@@ -441,3 +495,512 @@ func TestAdapter_Start(t *testing.T) { // just increase code coverage
 	}
 	cancel()
 }
+
+func TestAdapter_StartPaused(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	server := controltesting.GetMockServerHandler()
+	server.On("AddAsyncHandler", commands.StopConsumerGroupOpCode, commands.StopConsumerGroupResultOpCode, mock.Anything, mock.Anything).Return()
+	server.On("AddAsyncHandler", commands.StartConsumerGroupOpCode, commands.StartConsumerGroupResultOpCode, mock.Anything, mock.Anything).Return()
+	server.Service.On("SendAndWaitForAck", mock.Anything, mock.Anything).Return(nil)
+
+	a := NewAdapter(ctx, &AdapterConfig{
+		KafkaEnvConfig:       client.KafkaEnvConfig{BootstrapServers: []string{"unreachable:9092"}},
+		Topics:               []string{"topic"},
+		ConsumerGroup:        "group",
+		Paused:               true,
+		DisableControlServer: true,
+	}, nil, nil).(*Adapter)
+	a.SetServerHandler(server)
+
+	// The paused adapter doesn't connect to the brokers and only stops when the context is done
+	errs := make(chan error)
+	go func() { errs <- a.Start(ctx) }()
+	select {
+	case err := <-errs:
+		t.Fatalf("paused adapter stopped: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The consumer group commands are acknowledged
+	command := commands.ConsumerGroupAsyncCommand{
+		Version:   commands.ConsumerGroupAsyncCommandVersion,
+		CommandId: 1,
+		GroupId:   "group",
+	}
+	payload, err := command.MarshalBinary()
+	require.NoError(t, err)
+	msg := ctrl.NewMessage([16]byte{1, 2, 3, 4, 1, 2, 3, 4, 1, 2, 3, 4, 1, 2, 3, 4}, uint8(commands.StopConsumerGroupOpCode), payload)
+	server.Router[commands.StopConsumerGroupOpCode].HandleServiceMessage(context.Background(), ctrl.NewServiceMessage(&msg, func(err error) {
+		assert.NoError(t, err)
+	}))
+	server.Service.AssertCalled(t, "SendAndWaitForAck", mock.Anything, mock.MatchedBy(func(result ctrlmessage.AsyncCommandResult) bool {
+		return !result.IsFailed()
+	}))
+
+	cancel()
+	assert.NoError(t, <-errs)
+	server.AssertExpectations(t)
+}
+
+func TestAdapter_HandleDeadLetterSink(t *testing.T) {
+	testCases := map[string]struct {
+		sink       func(http.ResponseWriter, *http.Request)
+		dls        func(http.ResponseWriter, *http.Request)
+		wantCommit bool
+		wantDLS    bool
+	}{
+		"sink accepted": {
+			sink:       sinkAccepted,
+			dls:        sinkAccepted,
+			wantCommit: true,
+			wantDLS:    false,
+		},
+		"sink rejected, dead letter sink accepted": {
+			sink:       sinkRejected,
+			dls:        sinkAccepted,
+			wantCommit: true,
+			wantDLS:    true,
+		},
+		"sink rejected, dead letter sink rejected": {
+			sink:       sinkRejected,
+			dls:        sinkRejected,
+			wantCommit: false,
+			wantDLS:    true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			sinkServer := httptest.NewServer(&fakeHandler{handler: tc.sink})
+			defer sinkServer.Close()
+
+			dlsHandler := &fakeHandler{handler: tc.dls}
+			dlsServer := httptest.NewServer(dlsHandler)
+			defer dlsServer.Close()
+
+			statsReporter, _ := source.NewStatsReporter()
+
+			s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := &AdapterConfig{
+				EnvConfig: adapter.EnvConfig{
+					Sink:      sinkServer.URL,
+					Namespace: "test",
+				},
+				Topics:         []string{"topic1"},
+				ConsumerGroup:  "group",
+				Name:           "test",
+				Delivery:       `{"retry":1,"backoffPolicy":"linear","backoffDelay":"PT0.01S"}`,
+				DeadLetterSink: dlsServer.URL,
+			}
+			a := &Adapter{
+				config:            config,
+				httpMessageSender: s,
+				logger:            zap.NewNop().Sugar(),
+				reporter:          statsReporter,
+				keyTypeMapper:     getKeyTypeMapper(""),
+				retryConfig:       getRetryConfig(zap.NewNop().Sugar(), config.Delivery),
+			}
+
+			commit, _ := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+				Key:       []byte("key"),
+				Topic:     "topic1",
+				Value:     mustJsonMarshal(t, map[string]string{"key": "value"}),
+				Partition: 1,
+				Offset:    2,
+				Timestamp: time.Now(),
+			})
+
+			if commit != tc.wantCommit {
+				t.Errorf("expected commit %v, got %v", tc.wantCommit, commit)
+			}
+			if gotDLS := dlsHandler.body != nil; gotDLS != tc.wantDLS {
+				t.Errorf("expected dead letter sink called %v, got %v", tc.wantDLS, gotDLS)
+			}
+		})
+	}
+}
+
+func TestAdapter_HandleSchemaRegistry(t *testing.T) {
+	registry := registrytesting.NewRegistry()
+	defer registry.Close()
+	registry.Register(1, registrytesting.Schema{Subject: "topic1-value", Version: 2, SchemaType: schemaregistry.SchemaTypeJSON, Schema: `{"type":"object"}`})
+	registry.Register(2, registrytesting.Schema{Subject: "topic1-key", Version: 1, Schema: `"string"`})
+
+	avroKey := registrytesting.Encode(2, []byte{12, 'm', 'y', '-', 'k', 'e', 'y'})
+
+	testCases := map[string]struct {
+		decode          string
+		key             []byte
+		value           []byte
+		registry        string
+		wantCommit      bool
+		expectedHeaders map[string]string
+		expectedBody    string
+	}{
+		"decode value": {
+			key:        []byte("key"),
+			value:      registrytesting.Encode(1, []byte(`{"id":"order-1"}`)),
+			wantCommit: true,
+			expectedHeaders: map[string]string{
+				"ce-key":        "key",
+				"ce-dataschema": registry.URL + "/subjects/topic1-value/versions/2",
+				"content-type":  "application/json",
+			},
+			expectedBody: `{"id":"order-1"}`,
+		},
+		"decode all": {
+			decode:     "all",
+			key:        avroKey,
+			value:      registrytesting.Encode(1, []byte(`{"id":"order-1"}`)),
+			wantCommit: true,
+			expectedHeaders: map[string]string{
+				"ce-key":        `"my-key"`,
+				"ce-dataschema": registry.URL + "/subjects/topic1-value/versions/2",
+			},
+			expectedBody: `{"id":"order-1"}`,
+		},
+		"decode key only": {
+			decode:     "key",
+			key:        avroKey,
+			value:      registrytesting.Encode(1, []byte(`{"id":"order-1"}`)),
+			wantCommit: true,
+			expectedHeaders: map[string]string{
+				"ce-key":        `"my-key"`,
+				"ce-dataschema": "",
+			},
+		},
+		"value not serialized with the wire format": {
+			key:        []byte("key"),
+			value:      []byte(`{"id":"order-1"}`),
+			wantCommit: true,
+			expectedHeaders: map[string]string{
+				"ce-dataschema": "",
+			},
+			expectedBody: `{"id":"order-1"}`,
+		},
+		"unknown schema": {
+			value:      registrytesting.Encode(99, []byte(`{"id":"order-1"}`)),
+			wantCommit: true,
+		},
+		"schema registry unavailable until the session is closed": {
+			value:      registrytesting.Encode(1, []byte(`{"id":"order-1"}`)),
+			registry:   "http://localhost:1",
+			wantCommit: false,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			h := &fakeHandler{handler: sinkAccepted}
+			sinkServer := httptest.NewServer(h)
+			defer sinkServer.Close()
+
+			statsReporter, _ := source.NewStatsReporter()
+
+			s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := &AdapterConfig{
+				EnvConfig: adapter.EnvConfig{
+					Sink:      sinkServer.URL,
+					Namespace: "test",
+				},
+				Topics:        []string{"topic1"},
+				ConsumerGroup: "group",
+				Name:          "test",
+			}
+			config.SchemaRegistry.URL = registry.URL
+			if tc.registry != "" {
+				config.SchemaRegistry.URL = tc.registry
+			}
+			config.SchemaRegistry.Decode = tc.decode
+			a := NewAdapter(context.TODO(), config, s, statsReporter).(*Adapter)
+			a.logger = zap.NewNop().Sugar()
+
+			ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+			defer cancel()
+			commit, _ := a.Handle(ctx, &sarama.ConsumerMessage{
+				Key:       tc.key,
+				Topic:     "topic1",
+				Value:     tc.value,
+				Partition: 1,
+				Offset:    2,
+				Timestamp: time.Now(),
+			})
+
+			if commit != tc.wantCommit {
+				t.Errorf("expected commit %v, got %v", tc.wantCommit, commit)
+			}
+			for k, expected := range tc.expectedHeaders {
+				if actual := h.header.Get(k); actual != expected {
+					t.Errorf("Expected header with key %s: '%q', but got '%q'", k, expected, actual)
+				}
+			}
+			if tc.expectedBody != "" && tc.expectedBody != string(h.body) {
+				t.Errorf("Expected request body '%q', but got '%q'", tc.expectedBody, h.body)
+			}
+		})
+	}
+}
+
+func TestAdapter_HandleSchemaRegistryRecovered(t *testing.T) {
+	registry := registrytesting.NewRegistry()
+	defer registry.Close()
+	registry.Register(1, registrytesting.Schema{Subject: "topic1-value", Version: 2, SchemaType: schemaregistry.SchemaTypeJSON, Schema: `{"type":"object"}`})
+	registry.SetUnavailable("")
+
+	h := &fakeHandler{handler: sinkAccepted}
+	sinkServer := httptest.NewServer(h)
+	defer sinkServer.Close()
+
+	statsReporter, _ := source.NewStatsReporter()
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &AdapterConfig{
+		EnvConfig: adapter.EnvConfig{
+			Sink:      sinkServer.URL,
+			Namespace: "test",
+		},
+		Topics:        []string{"topic1"},
+		ConsumerGroup: "group",
+		Name:          "test",
+	}
+	config.SchemaRegistry.URL = registry.URL
+	a := NewAdapter(context.TODO(), config, s, statsReporter).(*Adapter)
+	a.logger = zap.NewNop().Sugar()
+
+	// The record waits for the schema registry to be available again, instead of being skipped
+	type result struct {
+		commit bool
+		err    error
+	}
+	results := make(chan result)
+	go func() {
+		commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+			Topic:     "topic1",
+			Value:     registrytesting.Encode(1, []byte(`{"id":"order-1"}`)),
+			Partition: 1,
+			Offset:    2,
+			Timestamp: time.Now(),
+		})
+		results <- result{commit: commit, err: err}
+	}()
+	for registry.Requests() < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	registry.SetUnavailable()
+
+	select {
+	case r := <-results:
+		if !r.commit || r.err != nil {
+			t.Errorf("expected the record to be committed, got %v (%v)", r.commit, r.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the record was not sent once the schema registry recovered")
+	}
+	if string(h.body) != `{"id":"order-1"}` {
+		t.Errorf("Expected request body '{\"id\":\"order-1\"}', but got '%q'", h.body)
+	}
+	if actual := h.header.Get("ce-dataschema"); actual != registry.URL+"/subjects/topic1-value/versions/2" {
+		t.Errorf("Unexpected dataschema %q", actual)
+	}
+}
+
+func TestGetRetryConfig(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	if rc := getRetryConfig(logger, ""); rc != retryConfig {
+		t.Errorf("expected the default retry config, got %v", rc)
+	}
+	if rc := getRetryConfig(logger, "not json"); rc != retryConfig {
+		t.Errorf("expected the default retry config, got %v", rc)
+	}
+	if rc := getRetryConfig(logger, `{"retry":3}`); rc.RetryMax != 3 {
+		t.Errorf("expected 3 retries, got %d", rc.RetryMax)
+	}
+}
+
+func TestNewAdapterRateLimit(t *testing.T) {
+	testCases := map[string]struct {
+		eventsPerSecond float64
+		burst           int
+		wantLimit       rate.Limit
+		wantBurst       int
+	}{
+		"no rate limit": {},
+		"rate limit": {
+			eventsPerSecond: 10,
+			burst:           20,
+			wantLimit:       10,
+			wantBurst:       20,
+		},
+		"default burst": {
+			eventsPerSecond: 2.5,
+			wantLimit:       2.5,
+			wantBurst:       3,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			a := NewAdapter(context.TODO(), &AdapterConfig{
+				RateLimitEventsPerSecond: tc.eventsPerSecond,
+				RateLimitBurst:           tc.burst,
+			}, nil, nil).(*Adapter)
+
+			if tc.wantLimit == 0 {
+				if a.rateLimiter != nil {
+					t.Errorf("unexpected rate limiter")
+				}
+				return
+			}
+			if a.rateLimiter.Limit() != tc.wantLimit || a.rateLimiter.Burst() != tc.wantBurst {
+				t.Errorf("rate limiter = %v, %v, want %v, %v", a.rateLimiter.Limit(), a.rateLimiter.Burst(), tc.wantLimit, tc.wantBurst)
+			}
+		})
+	}
+}
+
+func TestAdapter_SetSinkAndCloudEventOverrides(t *testing.T) {
+	sinkServer := httptest.NewServer(&fakeHandler{handler: sinkRejected})
+	defer sinkServer.Close()
+
+	newSinkHandler := &fakeHandler{handler: sinkAccepted}
+	newSinkServer := httptest.NewServer(newSinkHandler)
+	defer newSinkServer.Close()
+
+	statsReporter, _ := source.NewStatsReporter()
+
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &Adapter{
+		config: &AdapterConfig{
+			EnvConfig: adapter.EnvConfig{
+				Namespace: "test",
+			},
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+			Name:          "test",
+		},
+		httpMessageSender: s,
+		logger:            zap.NewNop().Sugar(),
+		reporter:          statsReporter,
+		keyTypeMapper:     getKeyTypeMapper(""),
+	}
+
+	a.SetSink(newSinkServer.URL)
+	a.SetCloudEventOverrides(&duckv1.CloudEventOverrides{Extensions: map[string]string{"test": "value"}})
+
+	commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+		Topic:     "topic1",
+		Value:     mustJsonMarshal(t, map[string]string{"key": "value"}),
+		Partition: 1,
+		Offset:    2,
+		Timestamp: time.Now(),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, commit)
+	assert.Equal(t, "value", newSinkHandler.header.Get("ce-test"))
+
+	a.SetCloudEventOverrides(nil)
+	assert.Nil(t, a.getExtensions())
+}
+
+func TestAdapter_SetDeliveryConfig(t *testing.T) {
+	sinkServer := httptest.NewServer(&fakeHandler{handler: sinkRejected})
+	defer sinkServer.Close()
+
+	dlsHandler := &fakeHandler{handler: sinkAccepted}
+	dlsServer := httptest.NewServer(dlsHandler)
+	defer dlsServer.Close()
+
+	statsReporter, _ := source.NewStatsReporter()
+
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &Adapter{
+		config: &AdapterConfig{
+			EnvConfig: adapter.EnvConfig{
+				Namespace: "test",
+			},
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+			Name:          "test",
+			Delivery:      `{"retry":1,"backoffPolicy":"linear","backoffDelay":"PT0.01S"}`,
+		},
+		httpMessageSender: s,
+		logger:            zap.NewNop().Sugar(),
+		reporter:          statsReporter,
+		keyTypeMapper:     getKeyTypeMapper(""),
+	}
+	a.retryConfig = getRetryConfig(a.logger, a.config.Delivery)
+
+	msg := &sarama.ConsumerMessage{
+		Key:       []byte{0, 0, 0, 1},
+		Topic:     "topic1",
+		Value:     mustJsonMarshal(t, map[string]string{"key": "value"}),
+		Partition: 1,
+		Offset:    2,
+		Timestamp: time.Now(),
+	}
+
+	commit, _ := a.Handle(context.TODO(), msg)
+	assert.False(t, commit)
+
+	a.SetDeliveryConfig(&AdapterConfig{
+		Topics:         []string{"topic2"},
+		ConsumerGroup:  "group2",
+		Delivery:       `{"retry":1,"backoffPolicy":"linear","backoffDelay":"PT0.01S"}`,
+		DeadLetterSink: dlsServer.URL,
+		KeyType:        "int",
+	})
+
+	commit, err = a.Handle(context.TODO(), msg)
+	require.NoError(t, err)
+	assert.True(t, commit)
+	assert.Equal(t, "1", dlsHandler.header.Get("ce-key"))
+
+	// The settings applying when joining the consumer group are unchanged
+	assert.Equal(t, []string{"topic1"}, a.config.Topics)
+	assert.Equal(t, "group", a.GetConsumerGroup())
+}
+
+// claimsSession is a consumer group session only providing its claims
+type claimsSession struct {
+	sarama.ConsumerGroupSession
+	claims map[string][]int32
+}
+
+func (s claimsSession) Claims() map[string][]int32 {
+	return s.claims
+}
+
+func TestAdapter_Claims(t *testing.T) {
+	a := &Adapter{logger: zap.NewNop().Sugar()}
+	sess := claimsSession{claims: map[string][]int32{"topic1": {0, 1, 2}, "topic2": {3}}}
+
+	a.Setup(sess)
+	claims, setupTime := a.Claims()
+	assert.Equal(t, kafkasourcecontrol.Claims(sess.claims), claims)
+	assert.False(t, setupTime.IsZero())
+
+	a.Cleanup(sess)
+	claims, cleanupTime := a.Claims()
+	assert.Empty(t, claims)
+	assert.False(t, cleanupTime.Before(setupTime))
+}
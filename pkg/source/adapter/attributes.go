@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"go.uber.org/zap"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+// getCloudEventAttributes parses the JSON encoded CloudEventAttributesSpec of the KafkaSource.
+func getCloudEventAttributes(logger *zap.SugaredLogger, attributes string) *sourcesv1beta1.CloudEventAttributesSpec {
+	if attributes == "" {
+		return nil
+	}
+
+	spec := &sourcesv1beta1.CloudEventAttributesSpec{}
+	if err := json.Unmarshal([]byte(attributes), spec); err != nil {
+		logger.Errorw("Failed to parse the cloud event attributes, using the default attributes", zap.Error(err))
+		return nil
+	}
+	return spec
+}
+
+// propagatedHeaders returns the headers propagated as extensions of the events.
+func (a *Adapter) propagatedHeaders() *sourcesv1beta1.HeadersPropagationSpec {
+	spec := a.getCloudEventAttributes()
+	if spec == nil {
+		return nil
+	}
+	return spec.Headers
+}
+
+// mapEventAttributes sets the attributes of the event mapped from the record, whose value is
+// the (possibly decoded) value.  The attributes whose mapped value is missing are left unchanged.
+func (a *Adapter) mapEventAttributes(event *cloudevents.Event, cm *sarama.ConsumerMessage, value []byte) {
+	spec := a.getCloudEventAttributes()
+	if spec == nil {
+		return
+	}
+
+	record := &mappedRecord{cm: cm, value: value}
+	if id, ok := record.lookup(spec.ID); ok {
+		event.SetID(id)
+	}
+	if eventType, ok := record.lookup(spec.Type); ok {
+		event.SetType(eventType)
+	}
+	if source, ok := record.lookup(spec.Source); ok {
+		event.SetSource(source)
+	}
+	if subject, ok := record.lookup(spec.Subject); ok {
+		event.SetSubject(subject)
+	}
+	if eventTime, ok := record.lookup(spec.Time); ok {
+		t, err := types.ParseTime(eventTime)
+		if err != nil {
+			a.logger.Debugw("Invalid time attribute, using the record timestamp", zap.String("time", eventTime), zap.Error(err))
+		} else {
+			event.SetTime(t)
+		}
+	}
+}
+
+// mappedRecord looks up the values of the attributes in a record, parsing its JSON value once.
+type mappedRecord struct {
+	cm     *sarama.ConsumerMessage
+	value  []byte
+	parsed bool
+	doc    interface{}
+}
+
+// lookup returns the value of the record mapped to an attribute, or false if it is missing or empty.
+func (r *mappedRecord) lookup(mapping *sourcesv1beta1.AttributeMappingSpec) (string, bool) {
+	switch {
+	case mapping == nil:
+		return "", false
+	case mapping.Header != "":
+		for _, header := range r.cm.Headers {
+			if strings.EqualFold(string(header.Key), mapping.Header) {
+				return string(header.Value), len(header.Value) > 0
+			}
+		}
+		return "", false
+	case mapping.Key:
+		return string(r.cm.Key), len(r.cm.Key) > 0
+	case mapping.ValuePointer != "":
+		if !r.parsed {
+			r.parsed = true
+			decoder := json.NewDecoder(bytes.NewReader(r.value))
+			decoder.UseNumber() // Keep the numeric ids as they are
+			if err := decoder.Decode(&r.doc); err != nil {
+				r.doc = nil
+			}
+		}
+		return resolvePointer(r.doc, mapping.ValuePointer)
+	}
+	return "", false
+}
+
+var unescapePointerToken = strings.NewReplacer("~1", "/", "~0", "~")
+
+// resolvePointer returns the string, number or boolean of the JSON document at the JSON pointer (RFC 6901).
+func resolvePointer(doc interface{}, pointer string) (string, bool) {
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = unescapePointerToken.Replace(token)
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if doc, ok = node[token]; !ok {
+				return "", false
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", false
+			}
+			doc = node[index]
+		default:
+			return "", false
+		}
+	}
+
+	switch value := doc.(type) {
+	case string:
+		return value, value != ""
+	case json.Number:
+		return value.String(), true
+	case bool:
+		return strconv.FormatBool(value), true
+	default:
+		return "", false
+	}
+}
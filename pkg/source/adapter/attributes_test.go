@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestGetCloudEventAttributes(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	assert.Nil(t, getCloudEventAttributes(logger, ""))
+	assert.Nil(t, getCloudEventAttributes(logger, "{"))
+	assert.Equal(t, &sourcesv1beta1.CloudEventAttributesSpec{
+		Type: &sourcesv1beta1.AttributeMappingSpec{Header: "event-type"},
+	}, getCloudEventAttributes(logger, `{"type":{"header":"event-type"}}`))
+}
+
+func TestMappedRecordLookup(t *testing.T) {
+	record := &mappedRecord{
+		value: []byte(`{"a":{"b":["x",{"c":"y"}],"m~n":true,"empty":"","n":1.5,"o":{}}}`),
+	}
+
+	testCases := map[string]struct {
+		pointer string
+		want    string
+		found   bool
+	}{
+		"string":       {pointer: "/a/b/0", want: "x", found: true},
+		"nested":       {pointer: "/a/b/1/c", want: "y", found: true},
+		"escaped":      {pointer: "/a/m~0n", want: "true", found: true},
+		"number":       {pointer: "/a/n", want: "1.5", found: true},
+		"empty":        {pointer: "/a/empty"},
+		"object":       {pointer: "/a/o"},
+		"missing":      {pointer: "/a/missing"},
+		"out of range": {pointer: "/a/b/2"},
+		"not an index": {pointer: "/a/b/c"},
+		"not a node":   {pointer: "/a/n/c"},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got, found := record.lookup(&sourcesv1beta1.AttributeMappingSpec{ValuePointer: tc.pointer})
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	// The value isn't JSON
+	record = &mappedRecord{value: []byte("not json")}
+	_, found := record.lookup(&sourcesv1beta1.AttributeMappingSpec{ValuePointer: "/a"})
+	assert.False(t, found)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	nethttp "net/http"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/metrics/source"
+
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+	"knative.dev/eventing-kafka/pkg/source/schemaregistry"
+)
+
+var _ consumer.KafkaBatchConsumerHandler = (*Adapter)(nil)
+
+// BatchStatsReporter is a StatsReporter also reporting the size of the batches sent to the sink
+type BatchStatsReporter interface {
+	source.StatsReporter
+
+	// ReportBatchSize records the number of events of a batch accepted by the sink
+	ReportBatchSize(args *source.ReportArgs, size int) error
+}
+
+type batchStatsReporter struct {
+	source.StatsReporter
+}
+
+// NewBatchStatsReporter returns a BatchStatsReporter reporting the events with the given reporter
+func NewBatchStatsReporter(reporter source.StatsReporter) BatchStatsReporter {
+	if r, ok := reporter.(BatchStatsReporter); ok {
+		return r
+	}
+	return &batchStatsReporter{StatsReporter: reporter}
+}
+
+func (r *batchStatsReporter) ReportBatchSize(args *source.ReportArgs, size int) error {
+	return metrics.ReportBatchSize(context.Background(), "KafkaSource", args.Namespace, args.Name, size)
+}
+
+// HandleBatch sends the records to the sink as a single CloudEvents batch request.  The records which
+// can't be translated to events are skipped.  When the sink doesn't accept the batch, each record is
+// sent to the dead letter sink, if any.
+func (a *Adapter) HandleBatch(ctx context.Context, msgs []*sarama.ConsumerMessage) (bool, error) {
+	if a.rateLimiter != nil {
+		for range msgs {
+			a.rateLimiter.Wait(ctx)
+		}
+	}
+
+	events := make([]*cloudevents.Event, 0, len(msgs))
+	for _, msg := range msgs {
+		var event *cloudevents.Event
+		err := a.whileRegistryUnavailable(ctx, func() (err error) {
+			event, err = a.consumerMessageToEvent(ctx, msg)
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, schemaregistry.ErrUnavailable) {
+				// The session is closing, the records are consumed again by the next one
+				return false, err
+			}
+			a.logger.Debugw("Skipping the record which can't be translated to an event",
+				zap.String("topic", msg.Topic),
+				zap.Int32("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err))
+			continue
+		}
+		if !event.DataBase64 && len(event.DataEncoded) > 0 && !json.Valid(event.DataEncoded) && isJSONMediaType(event.DataMediaType()) {
+			// The data isn't valid JSON and can't be embedded as is in the batch
+			event.DataBase64 = true
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return true, nil
+	}
+
+	res, err := a.sendBatch(ctx, events)
+	if err != nil {
+		if a.getDeadLetterSink() == "" {
+			return false, err // Error while sending, don't commit offsets
+		}
+		for _, msg := range msgs {
+			if mustMark, dlsErr := a.sendToDeadLetterSink(ctx, msg, err); !mustMark {
+				return false, dlsErr
+			}
+		}
+		return true, nil
+	}
+
+	reportArgs := &source.ReportArgs{
+		Namespace:     a.config.Namespace,
+		Name:          a.config.Name,
+		ResourceGroup: resourceGroup,
+	}
+	for range events {
+		_ = a.reporter.ReportEventCount(reportArgs, res.StatusCode)
+	}
+	if reporter, ok := a.reporter.(BatchStatsReporter); ok {
+		_ = reporter.ReportBatchSize(reportArgs, len(events))
+	}
+	return true, nil
+}
+
+// sendBatch sends the events to the sink in the structured batch mode, and returns the response of the
+// sink, whose body is closed, if it accepted the batch.
+func (a *Adapter) sendBatch(ctx context.Context, events []*cloudevents.Event) (*nethttp.Response, error) {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the batch: %w", err)
+	}
+
+	req, err := a.newSinkRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", cloudevents.ApplicationCloudEventsBatchJSON)
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	res, err := a.httpMessageSender.SendWithRetries(req, a.getRetryConfig())
+	if err != nil {
+		a.logger.Debug("Error while sending the batch", zap.Error(err))
+		return nil, err
+	}
+	discardBody(res)
+
+	if res.StatusCode/100 != 2 {
+		a.logger.Debug("Unexpected status code", zap.Int("status code", res.StatusCode))
+		return nil, fmt.Errorf("%d %s", res.StatusCode, nethttp.StatusText(res.StatusCode))
+	}
+	return res, nil
+}
+
+// isJSONMediaType returns whether the data of an event with the media type is written as is in the JSON format
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "" || mediaType == cloudevents.ApplicationJSON || mediaType == event.TextJSON
+}
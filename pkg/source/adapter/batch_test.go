@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/metrics/source"
+)
+
+// batchSizeReporter records the sizes of the batches reported
+type batchSizeReporter struct {
+	source.StatsReporter
+	sizes []int
+}
+
+func (r *batchSizeReporter) ReportBatchSize(_ *source.ReportArgs, size int) error {
+	r.sizes = append(r.sizes, size)
+	return nil
+}
+
+func TestAdapter_HandleBatch(t *testing.T) {
+	testCases := map[string]struct {
+		sink       func(http.ResponseWriter, *http.Request)
+		dls        func(http.ResponseWriter, *http.Request)
+		wantCommit bool
+		wantSizes  []int
+		wantDLS    bool
+	}{
+		"sink accepted": {
+			sink:       sinkAccepted,
+			wantCommit: true,
+			wantSizes:  []int{3},
+		},
+		"sink rejected": {
+			sink:       sinkRejected,
+			wantCommit: false,
+		},
+		"sink rejected, dead letter sink accepted": {
+			sink:       sinkRejected,
+			dls:        sinkAccepted,
+			wantCommit: true,
+			wantDLS:    true,
+		},
+		"sink rejected, dead letter sink rejected": {
+			sink:       sinkRejected,
+			dls:        sinkRejected,
+			wantCommit: false,
+			wantDLS:    true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			sinkHandler := &fakeHandler{handler: tc.sink}
+			sinkServer := httptest.NewServer(sinkHandler)
+			defer sinkServer.Close()
+
+			statsReporter, _ := source.NewStatsReporter()
+			reporter := &batchSizeReporter{StatsReporter: statsReporter}
+
+			s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := &AdapterConfig{
+				EnvConfig: adapter.EnvConfig{
+					Sink:      sinkServer.URL,
+					Namespace: "test",
+				},
+				Topics:        []string{"topic1"},
+				ConsumerGroup: "group",
+				Name:          "test",
+				Delivery:      `{"retry":1,"backoffPolicy":"linear","backoffDelay":"PT0.01S"}`,
+				BatchMaxSize:  10,
+			}
+			dlsHandler := &fakeHandler{handler: tc.dls}
+			if tc.dls != nil {
+				dlsServer := httptest.NewServer(dlsHandler)
+				defer dlsServer.Close()
+				config.DeadLetterSink = dlsServer.URL
+			}
+			a := &Adapter{
+				config:            config,
+				httpMessageSender: s,
+				logger:            zap.NewNop().Sugar(),
+				reporter:          reporter,
+				keyTypeMapper:     getKeyTypeMapper(""),
+				retryConfig:       getRetryConfig(zap.NewNop().Sugar(), config.Delivery),
+				extensions:        map[string]string{"test": "test"},
+			}
+
+			commit, _ := a.HandleBatch(context.TODO(), []*sarama.ConsumerMessage{{
+				Key:       []byte("key"),
+				Topic:     "topic1",
+				Value:     mustJsonMarshal(t, map[string]string{"key": "value"}),
+				Partition: 1,
+				Offset:    2,
+				Timestamp: time.Now(),
+			}, {
+				Topic:     "topic1",
+				Value:     []byte("not json"),
+				Partition: 1,
+				Offset:    3,
+				Timestamp: time.Now(),
+			}, {
+				Topic: "topic1",
+				Headers: []*sarama.RecordHeader{
+					{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+					{Key: []byte("ce_id"), Value: []byte("event-id")},
+					{Key: []byte("ce_type"), Value: []byte("com.example")},
+					{Key: []byte("ce_source"), Value: []byte("/example")},
+					{Key: []byte("content-type"), Value: []byte("application/json")},
+				},
+				Value:     []byte(`{"ce":true}`),
+				Partition: 1,
+				Offset:    4,
+				Timestamp: time.Now(),
+			}})
+
+			assert.Equal(t, tc.wantCommit, commit)
+			assert.Equal(t, tc.wantSizes, reporter.sizes)
+			if tc.dls != nil {
+				assert.Equal(t, tc.wantDLS, dlsHandler.body != nil)
+			}
+
+			assert.Equal(t, cloudevents.ApplicationCloudEventsBatchJSON, sinkHandler.header.Get("Content-Type"))
+			var events []cloudevents.Event
+			require.NoError(t, json.Unmarshal(sinkHandler.body, &events))
+			require.Len(t, events, 3)
+
+			assert.Equal(t, makeEventId(1, 2), events[0].ID())
+			assert.Equal(t, "key", events[0].Extensions()["key"])
+			assert.Equal(t, `{"key":"value"}`, string(events[0].Data()))
+
+			assert.Equal(t, makeEventId(1, 3), events[1].ID())
+			assert.Equal(t, "not json", string(events[1].Data()))
+
+			assert.Equal(t, "event-id", events[2].ID())
+			assert.Equal(t, "com.example", events[2].Type())
+			assert.Equal(t, `{"ce":true}`, string(events[2].Data()))
+
+			for _, event := range events {
+				assert.Equal(t, "test", event.Extensions()["test"])
+			}
+		})
+	}
+}
+
+func TestNewBatchStatsReporter(t *testing.T) {
+	statsReporter, _ := source.NewStatsReporter()
+
+	reporter := NewBatchStatsReporter(statsReporter)
+	assert.NoError(t, reporter.ReportBatchSize(&source.ReportArgs{Namespace: "test", Name: "test"}, 5))
+
+	// A reporter already reporting the batch sizes is used as is
+	batchReporter := &batchSizeReporter{StatsReporter: statsReporter}
+	assert.Same(t, batchReporter, NewBatchStatsReporter(batchReporter))
+}
@@ -18,6 +18,8 @@ package kafka
 import (
 	"context"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"math"
 	nethttp "net/http"
 	"regexp"
@@ -31,6 +33,7 @@ import (
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
 	"go.uber.org/zap"
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/source/schemaregistry"
 )
 
 func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.ConsumerMessage, req *nethttp.Request) error {
@@ -45,12 +48,45 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.C
 
 	if msg.ReadEncoding() != binding.EncodingUnknown {
 		// Message is a CloudEvent -> Encode directly to HTTP
-		return http.WriteRequest(cloudevents.WithEncodingBinary(ctx), msg, req, extensionAsTransformer(a.extensions))
+		return http.WriteRequest(cloudevents.WithEncodingBinary(ctx), msg, req, extensionAsTransformer(a.getExtensions()))
 	}
 
 	a.logger.Debug("Message is not a CloudEvent -> We need to translate it to a valid CloudEvent")
-	kafkaMsg := msg
+	event, err := a.makeEvent(ctx, cm, msg)
+	if err != nil {
+		return err
+	}
+
+	return http.WriteRequest(ctx, binding.ToMessage(event), req, extensionAsTransformer(a.getExtensions()))
+}
+
+// consumerMessageToEvent returns the event of the consumer message, with the extensions of the adapter.
+func (a *Adapter) consumerMessageToEvent(ctx context.Context, cm *sarama.ConsumerMessage) (*cloudevents.Event, error) {
+	msg := protocolkafka.NewMessageFromConsumerMessage(cm)
+
+	defer func() {
+		err := msg.Finish(nil)
+		if err != nil {
+			a.logger.Warnw("Something went wrong while trying to finalizing the message", zap.Error(err))
+		}
+	}()
+
+	if msg.ReadEncoding() != binding.EncodingUnknown {
+		return binding.ToEvent(ctx, msg, extensionAsTransformer(a.getExtensions()))
+	}
+
+	event, err := a.makeEvent(ctx, cm, msg)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range a.getExtensions() {
+		event.SetExtension(k, v)
+	}
+	return event, nil
+}
 
+// makeEvent translates the consumer message which isn't a CloudEvent to an event.
+func (a *Adapter) makeEvent(ctx context.Context, cm *sarama.ConsumerMessage, kafkaMsg *protocolkafka.Message) (*cloudevents.Event, error) {
 	event := cloudevents.NewEvent()
 
 	event.SetID(makeEventId(cm.Partition, cm.Offset))
@@ -59,7 +95,20 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.C
 	event.SetSource(sourcesv1beta1.KafkaEventSource(a.config.Namespace, a.config.Name, cm.Topic))
 	event.SetSubject(makeEventSubject(cm.Partition, cm.Offset))
 
-	dumpKafkaMetaToEvent(&event, a.keyTypeMapper, cm.Key, kafkaMsg)
+	dumpKafkaMetaToEvent(&event, a.getKeyTypeMapper(), cm.Key, kafkaMsg, a.propagatedHeaders())
+
+	if registry, decodeKey, decodeValue := a.getSchemaRegistry(); registry != nil {
+		decoded, err := decodeWithSchemaRegistry(ctx, registry, decodeKey, decodeValue, &event, cm)
+		if err != nil {
+			return nil, err
+		}
+		if decoded {
+			a.mapEventAttributes(&event, cm, event.Data())
+			return &event, nil
+		}
+	}
+
+	a.mapEventAttributes(&event, cm, cm.Value)
 
 	if kafkaMsg.ContentType == "" {
 		// This avoids base64 encoding when sending as json structured
@@ -67,11 +116,40 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.C
 	} else {
 		err := event.SetData(kafkaMsg.ContentType, kafkaMsg.Value)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return http.WriteRequest(ctx, binding.ToMessage(&event), req, extensionAsTransformer(a.extensions))
+	return &event, nil
+}
+
+// decodeWithSchemaRegistry decodes the key and/or value of the message serialized with the schema registry
+// wire format, and returns whether the event data has been set to the decoded value.  The keys and values
+// which aren't serialized with the wire format are left unchanged.
+func decodeWithSchemaRegistry(ctx context.Context, registry *schemaregistry.Client, decodeKey, decodeValue bool, event *cloudevents.Event, cm *sarama.ConsumerMessage) (bool, error) {
+	if decodeKey && len(cm.Key) > 0 {
+		key, _, err := registry.Decode(ctx, cm.Key)
+		if err == nil {
+			event.SetExtension("key", string(key))
+		} else if !errors.Is(err, schemaregistry.ErrNotWireFormat) {
+			return false, fmt.Errorf("failed to decode the key: %w", err)
+		}
+	}
+
+	if !decodeValue {
+		return false, nil
+	}
+	value, dataSchema, err := registry.Decode(ctx, cm.Value)
+	if errors.Is(err, schemaregistry.ErrNotWireFormat) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to decode the value: %w", err)
+	}
+
+	event.SetDataContentType(cloudevents.ApplicationJSON)
+	event.SetDataSchema(dataSchema)
+	event.DataEncoded = value
+	return true, nil
 }
 
 func makeEventId(partition int32, offset int64) string {
@@ -95,13 +173,13 @@ func makeEventSubject(partition int32, offset int64) string {
 
 var replaceBadCharacters = regexp.MustCompile(`[^a-zA-Z0-9]`).ReplaceAllString
 
-func dumpKafkaMetaToEvent(event *cloudevents.Event, keyTypeMapper func([]byte) interface{}, key []byte, msg *protocolkafka.Message) {
+func dumpKafkaMetaToEvent(event *cloudevents.Event, keyTypeMapper func([]byte) interface{}, key []byte, msg *protocolkafka.Message, headers *sourcesv1beta1.HeadersPropagationSpec) {
 	if len(key) > 0 {
 		event.SetExtension("key", keyTypeMapper(key))
 	}
 	for k, v := range msg.Headers {
 		// Let's skip the content-type, we already transport it with datacontenttype field
-		if k != "content-type" {
+		if k != "content-type" && headers.Propagates(k) {
 			event.SetExtension("kafkaheader"+replaceBadCharacters(k, ""), string(v))
 		}
 	}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// sourceRateLimit is the rate limit of all the receive adapters of a source together.  Each adapter
+// applies the share of the partitions it claims among all the partitions of the consumed topics, so
+// that its share follows the rebalances of the consumer group (e.g. when the adapter is scaled)
+// without the adapter pods being reconfigured.
+type sourceRateLimit struct {
+	eventsPerSecond float64
+	burst           int
+
+	// partitions returns the partitions of a topic, the share is unknown until it is set
+	partitions func(topic string) ([]int32, error)
+
+	topicsMu sync.RWMutex
+	topics   []string
+}
+
+// setTopics sets the topics consumed by the consumer group
+func (l *sourceRateLimit) setTopics(topics []string) {
+	l.topicsMu.Lock()
+	defer l.topicsMu.Unlock()
+	l.topics = topics
+}
+
+// share returns the limits of the share of the claimed partitions among all the partitions of the
+// consumed topics.  The burst is at least 1.
+func (l *sourceRateLimit) share(claims map[string][]int32) (rate.Limit, int, error) {
+	l.topicsMu.RLock()
+	topics := l.topics
+	l.topicsMu.RUnlock()
+
+	claimed := 0
+	for _, partitions := range claims {
+		claimed += len(partitions)
+	}
+	total := 0
+	for _, topic := range topics {
+		partitions, err := l.partitions(topic)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get the partitions of topic %s: %w", topic, err)
+		}
+		total += len(partitions)
+	}
+	if total == 0 || claimed >= total {
+		return rate.Limit(l.eventsPerSecond), l.burst, nil
+	}
+
+	ratio := float64(claimed) / float64(total)
+	return rate.Limit(l.eventsPerSecond * ratio), int(math.Max(1, math.Ceil(float64(l.burst)*ratio))), nil
+}
+
+// applyRateLimitShare sets the rate limits of the adapter to its share of the source rate limit for
+// the claimed partitions.  The current limits are kept when the share can't be computed.
+func (a *Adapter) applyRateLimitShare(claims map[string][]int32) {
+	if a.sourceRateLimit == nil || a.sourceRateLimit.partitions == nil {
+		return
+	}
+	limit, burst, err := a.sourceRateLimit.share(claims)
+	if err != nil {
+		a.logger.Warnw("Failed to compute the share of the source rate limit, keeping the current limits", zap.Error(err))
+		return
+	}
+	a.logger.Infow("Applying the share of the source rate limit", zap.Float64("eventsPerSecond", float64(limit)), zap.Int("burst", burst))
+	a.SetRateLimits(limit, burst)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestAdapter_ApplyRateLimitShare(t *testing.T) {
+	partitions := map[string][]int32{
+		"orders":   {0, 1, 2, 3, 4, 5},
+		"payments": {0, 1},
+	}
+	partitionsOf := func(topic string) ([]int32, error) {
+		if p, ok := partitions[topic]; ok {
+			return p, nil
+		}
+		return nil, errors.New("unknown topic")
+	}
+
+	testCases := map[string]struct {
+		topics     []string
+		partitions func(topic string) ([]int32, error)
+		claims     map[string][]int32
+		wantLimit  rate.Limit
+		wantBurst  int
+	}{
+		"partitions unknown": {
+			topics: []string{"orders"},
+			claims: map[string][]int32{"orders": {0}},
+			// The whole rate limit applies
+			wantLimit: 10,
+			wantBurst: 20,
+		},
+		"all partitions claimed": {
+			topics:     []string{"orders", "payments"},
+			partitions: partitionsOf,
+			claims:     map[string][]int32{"orders": {0, 1, 2, 3, 4, 5}, "payments": {0, 1}},
+			wantLimit:  10,
+			wantBurst:  20,
+		},
+		"quarter of the partitions claimed": {
+			topics:     []string{"orders", "payments"},
+			partitions: partitionsOf,
+			claims:     map[string][]int32{"orders": {0}, "payments": {1}},
+			wantLimit:  2.5,
+			wantBurst:  5,
+		},
+		"single partition of a topic not claimed": {
+			topics:     []string{"orders", "payments"},
+			partitions: partitionsOf,
+			claims:     map[string][]int32{"orders": {3}},
+			wantLimit:  1.25,
+			wantBurst:  3,
+		},
+		"partitions of a topic not found": {
+			topics:     []string{"orders", "refunds"},
+			partitions: partitionsOf,
+			claims:     map[string][]int32{"orders": {0}},
+			// The current limits are kept
+			wantLimit: 10,
+			wantBurst: 20,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			a := NewAdapter(context.TODO(), &AdapterConfig{
+				RateLimitEventsPerSecond: 10,
+				RateLimitBurst:           20,
+			}, nil, nil).(*Adapter)
+			a.sourceRateLimit.partitions = tc.partitions
+			a.sourceRateLimit.setTopics(tc.topics)
+
+			a.applyRateLimitShare(tc.claims)
+
+			assert.Equal(t, tc.wantLimit, a.rateLimiter.Limit())
+			assert.Equal(t, tc.wantBurst, a.rateLimiter.Burst())
+		})
+	}
+}
+
+func TestAdapter_ApplyRateLimitShareRebalance(t *testing.T) {
+	a := NewAdapter(context.TODO(), &AdapterConfig{RateLimitEventsPerSecond: 12}, nil, nil).(*Adapter)
+	a.sourceRateLimit.partitions = func(topic string) ([]int32, error) { return []int32{0, 1, 2, 3}, nil }
+	a.sourceRateLimit.setTopics([]string{"orders"})
+
+	// Half of the partitions, then a single one once another adapter joined the consumer group
+	a.applyRateLimitShare(map[string][]int32{"orders": {0, 1}})
+	assert.Equal(t, rate.Limit(6), a.rateLimiter.Limit())
+	assert.Equal(t, 6, a.rateLimiter.Burst())
+
+	a.applyRateLimitShare(map[string][]int32{"orders": {1}})
+	assert.Equal(t, rate.Limit(3), a.rateLimiter.Limit())
+	assert.Equal(t, 3, a.rateLimiter.Burst())
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+
+	"github.com/Shopify/sarama"
+	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.uber.org/zap"
+)
+
+// newReplyProducer creates the producer of the events returned by the sink to the reply topic.
+func newReplyProducer(addrs []string, config *sarama.Config) (sarama.SyncProducer, error) {
+	producerConfig := *config
+	producerConfig.Producer.Return.Successes = true // Required by the SyncProducer
+	return sarama.NewSyncProducer(addrs, &producerConfig)
+}
+
+// hasReply returns true if the events returned by the sink are sent to a reply topic or sink.
+func (a *Adapter) hasReply() bool {
+	return a.replyProducer != nil || a.getReplySink() != ""
+}
+
+// sendReply sends the event returned by the sink in its response, if any, to the reply topic or sink,
+// and closes the response.  The invalid events are dropped, as sending them again would fail again.
+// Without a dead letter sink to receive the failures, the event is sent until it succeeds or the
+// context is done, as the record can't be skipped.
+func (a *Adapter) sendReply(ctx context.Context, msg *sarama.ConsumerMessage, res *nethttp.Response) error {
+	defer discardBody(res)
+
+	message := http.NewMessageFromHttpResponse(res)
+	if message.ReadEncoding() == binding.EncodingUnknown {
+		return nil // The sink didn't return an event
+	}
+	event, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		a.logger.Warnw("Dropping the invalid event returned by the sink", zap.Error(err))
+		return nil
+	}
+
+	send := func() error {
+		if a.replyProducer != nil {
+			return a.produceReply(ctx, msg, event)
+		}
+		return a.postReply(ctx, event)
+	}
+	if a.getDeadLetterSink() != "" {
+		return send()
+	}
+	return a.retryWithBackoff(ctx, "send the reply", func(error) bool { return true }, send)
+}
+
+// produceReply produces the event to the reply topic with the binary encoding, keyed as the record it replies to.
+func (a *Adapter) produceReply(ctx context.Context, msg *sarama.ConsumerMessage, event *cloudevents.Event) error {
+	producerMessage := &sarama.ProducerMessage{Topic: a.config.ReplyTopic}
+	err := protocolkafka.WriteProducerMessage(cloudevents.WithEncodingBinary(ctx), binding.ToMessage(event), producerMessage)
+	if err != nil {
+		a.logger.Warnw("Dropping the reply which can't be encoded", zap.Error(err))
+		return nil
+	}
+	if len(msg.Key) > 0 {
+		producerMessage.Key = sarama.ByteEncoder(msg.Key)
+	}
+
+	if _, _, err := a.replyProducer.SendMessage(producerMessage); err != nil {
+		return fmt.Errorf("failed to produce the reply: %w", err)
+	}
+	return nil
+}
+
+// postReply sends the event to the reply sink.
+func (a *Adapter) postReply(ctx context.Context, event *cloudevents.Event) error {
+	req, err := a.httpMessageSender.NewCloudEventRequestWithTarget(ctx, a.getReplySink())
+	if err != nil {
+		return err
+	}
+	err = http.WriteRequest(cloudevents.WithEncodingBinary(ctx), binding.ToMessage(event), req)
+	if err != nil {
+		a.logger.Warnw("Dropping the reply which can't be encoded", zap.Error(err))
+		return nil
+	}
+
+	res, err := a.httpMessageSender.SendWithRetries(req, a.getRetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to send the reply: %w", err)
+	}
+	discardBody(res)
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("reply sink responded with %d %s", res.StatusCode, nethttp.StatusText(res.StatusCode))
+	}
+	return nil
+}
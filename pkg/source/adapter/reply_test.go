@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/metrics/source"
+)
+
+func sinkReplied(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("ce-specversion", "1.0")
+	writer.Header().Set("ce-id", "reply-id")
+	writer.Header().Set("ce-type", "com.example.reply")
+	writer.Header().Set("ce-source", "/sink")
+	writer.Header().Set("content-type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write([]byte(`{"reply":true}`))
+}
+
+func TestAdapter_HandleReply(t *testing.T) {
+	testCases := map[string]struct {
+		sink           func(http.ResponseWriter, *http.Request)
+		replySink      func(http.ResponseWriter, *http.Request)
+		deadLetterSink func(http.ResponseWriter, *http.Request)
+		replyTopic     bool
+		produceErr     error
+		produceRetried bool
+		wantCommit     bool
+		wantReply      bool
+		wantProduced   bool
+		wantDeadLetter bool
+	}{
+		"reply sent to the reply sink": {
+			sink:       sinkReplied,
+			replySink:  sinkAccepted,
+			wantCommit: true,
+			wantReply:  true,
+		},
+		"reply rejected by the reply sink until the session is closed": {
+			sink:       sinkReplied,
+			replySink:  sinkRejected,
+			wantCommit: false,
+			wantReply:  true,
+		},
+		"reply rejected by the reply sink, record sent to the dead letter sink": {
+			sink:           sinkReplied,
+			replySink:      sinkRejected,
+			deadLetterSink: sinkAccepted,
+			wantCommit:     true,
+			wantReply:      true,
+			wantDeadLetter: true,
+		},
+		"no reply": {
+			sink:       sinkAccepted,
+			replySink:  sinkAccepted,
+			wantCommit: true,
+			wantReply:  false,
+		},
+		"reply produced to the reply topic": {
+			sink:         sinkReplied,
+			replyTopic:   true,
+			wantCommit:   true,
+			wantProduced: true,
+		},
+		"reply produced to the reply topic once it recovers": {
+			sink:           sinkReplied,
+			replyTopic:     true,
+			produceErr:     sarama.ErrNotLeaderForPartition,
+			produceRetried: true,
+			wantCommit:     true,
+			wantProduced:   true,
+		},
+		"reply not produced to the reply topic, record sent to the dead letter sink": {
+			sink:           sinkReplied,
+			replyTopic:     true,
+			produceErr:     sarama.ErrNotLeaderForPartition,
+			deadLetterSink: sinkAccepted,
+			wantCommit:     true,
+			wantProduced:   true,
+			wantDeadLetter: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			sinkServer := httptest.NewServer(&fakeHandler{handler: tc.sink})
+			defer sinkServer.Close()
+
+			statsReporter, _ := source.NewStatsReporter()
+
+			s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := &AdapterConfig{
+				EnvConfig: adapter.EnvConfig{
+					Sink:      sinkServer.URL,
+					Namespace: "test",
+				},
+				Topics:        []string{"topic1"},
+				ConsumerGroup: "group",
+				Name:          "test",
+				Delivery:      `{"retry":1,"backoffPolicy":"linear","backoffDelay":"PT0.01S"}`,
+			}
+			a := &Adapter{
+				config:            config,
+				httpMessageSender: s,
+				logger:            zap.NewNop().Sugar(),
+				reporter:          statsReporter,
+				keyTypeMapper:     getKeyTypeMapper(""),
+				retryConfig:       getRetryConfig(zap.NewNop().Sugar(), config.Delivery),
+			}
+
+			replyHandler := &fakeHandler{handler: tc.replySink}
+			if tc.replySink != nil {
+				replyServer := httptest.NewServer(replyHandler)
+				defer replyServer.Close()
+				config.ReplySink = replyServer.URL
+			}
+
+			deadLetterHandler := &fakeHandler{handler: tc.deadLetterSink}
+			if tc.deadLetterSink != nil {
+				deadLetterServer := httptest.NewServer(deadLetterHandler)
+				defer deadLetterServer.Close()
+				config.DeadLetterSink = deadLetterServer.URL
+			}
+
+			var produced *sarama.ProducerMessage
+			if tc.replyTopic {
+				config.ReplyTopic = "replies"
+				producer := mocks.NewSyncProducer(t, nil)
+				defer producer.Close()
+				if tc.produceErr != nil {
+					producer.ExpectSendMessageAndFail(tc.produceErr)
+				}
+				if tc.produceErr == nil || tc.produceRetried {
+					producer.ExpectSendMessageAndSucceed()
+				}
+				a.replyProducer = &recordingSyncProducer{SyncProducer: producer, produced: &produced}
+			}
+
+			ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+			defer cancel()
+			commit, _ := a.Handle(ctx, &sarama.ConsumerMessage{
+				Key:       []byte("key"),
+				Topic:     "topic1",
+				Value:     mustJsonMarshal(t, map[string]string{"key": "value"}),
+				Partition: 1,
+				Offset:    2,
+				Timestamp: time.Now(),
+			})
+
+			assert.Equal(t, tc.wantCommit, commit)
+			if tc.deadLetterSink != nil {
+				assert.Equal(t, tc.wantDeadLetter, deadLetterHandler.body != nil)
+			}
+			if tc.replySink != nil {
+				assert.Equal(t, tc.wantReply, replyHandler.body != nil)
+			}
+			if tc.wantReply {
+				assert.Equal(t, "reply-id", replyHandler.header.Get("ce-id"))
+				assert.Equal(t, `{"reply":true}`, string(replyHandler.body))
+			}
+			if tc.wantProduced {
+				assert.Equal(t, "replies", produced.Topic)
+				assert.Equal(t, sarama.ByteEncoder("key"), produced.Key)
+				assert.Equal(t, sarama.ByteEncoder(`{"reply":true}`), produced.Value)
+				headers := make(map[string]string)
+				for _, header := range produced.Headers {
+					headers[string(header.Key)] = string(header.Value)
+				}
+				assert.Equal(t, "reply-id", headers["ce_id"])
+				assert.Equal(t, "com.example.reply", headers["ce_type"])
+			}
+		})
+	}
+}
+
+// recordingSyncProducer records the last message sent to the producer.
+type recordingSyncProducer struct {
+	sarama.SyncProducer
+	produced **sarama.ProducerMessage
+}
+
+func (p *recordingSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	*p.produced = msg
+	return p.SyncProducer.SendMessage(msg)
+}
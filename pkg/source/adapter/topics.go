@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+// topicPatternRefreshInterval is the interval at which the topics matching the topic pattern are refreshed
+// (var to facilitate unit testing)
+var topicPatternRefreshInterval = 30 * time.Second
+
+// consumeTopicPattern consumes the topics matching the topic pattern until the context is done, refreshing
+// them periodically.  When the matching topics change, their offsets are initialized and the consumer
+// group is joined again with the new topics.
+func (a *Adapter) consumeTopicPattern(ctx context.Context, addrs []string, config *sarama.Config, startGroup func(topics []string) (func() error, error)) error {
+	pattern, err := sourcesv1beta1.CompileTopicPattern(a.config.TopicPattern)
+	if err != nil {
+		return fmt.Errorf("invalid topic pattern: %w", err)
+	}
+
+	kafkaClient, err := sarama.NewClient(addrs, config)
+	if err != nil {
+		return fmt.Errorf("failed to create the Kafka client: %w", err)
+	}
+	kafkaAdminClient, err := sarama.NewClusterAdminFromClient(kafkaClient)
+	if err != nil {
+		_ = kafkaClient.Close()
+		return fmt.Errorf("failed to create the Kafka admin client: %w", err)
+	}
+	defer kafkaAdminClient.Close() // Also closes the Kafka client
+
+	initialOffset, err := a.config.InitialOffset.ParseSaramaOffsetTime()
+	if err != nil {
+		return fmt.Errorf("invalid initial offset: %w", err)
+	}
+
+	var topics []string
+	var closeGroup func() error
+	defer func() {
+		if closeGroup != nil {
+			a.closeGroup(closeGroup)
+		}
+	}()
+
+	ticker := time.NewTicker(topicPatternRefreshInterval)
+	defer ticker.Stop()
+	for {
+		matched, err := offset.MatchTopics(kafkaClient, pattern)
+		if err != nil {
+			a.logger.Warnw("Failed to refresh the topics matching the pattern", zap.Error(err))
+		} else if !equalTopics(topics, matched) {
+			a.logger.Infow("Topics matching the pattern changed", zap.Strings("topics", matched))
+
+			// The consumer group isn't joined until the offsets of the new topics are initialized,
+			// otherwise the events sent before the first commit could be skipped.
+			_, err = offset.InitOffsets(ctx, kafkaClient, kafkaAdminClient, matched, a.config.ConsumerGroup, initialOffset)
+			if err != nil {
+				a.logger.Warnw("Failed to initialize the offsets of the topics matching the pattern", zap.Error(err))
+			} else {
+				if closeGroup != nil {
+					a.closeGroup(closeGroup)
+					closeGroup = nil
+				}
+				topics = matched
+				if len(topics) > 0 {
+					closeGroup, err = startGroup(topics)
+					if err != nil {
+						return fmt.Errorf("failed to start consumer group: %w", err)
+					}
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			a.logger.Info("Shutting down...")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// equalTopics returns true if the sorted lists of topics are equal.
+func equalTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
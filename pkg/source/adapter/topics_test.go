@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestAdapter_ConsumeTopicPattern(t *testing.T) {
+	defer func(interval time.Duration) { topicPatternRefreshInterval = interval }(topicPatternRefreshInterval)
+	topicPatternRefreshInterval = 10 * time.Millisecond
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "my-group"
+	metadata := func(topics ...string) *sarama.MockMetadataResponse {
+		response := sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID())
+		for _, topic := range topics {
+			response = response.SetLeader(topic, 0, broker.BrokerID())
+		}
+		return response
+	}
+	handlers := func(topics ...string) map[string]sarama.MockResponse {
+		return map[string]sarama.MockResponse{
+			"MetadataRequest": metadata(topics...),
+			"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+				SetOffset("orders-a", 0, -1, 10).
+				SetOffset("orders-b", 0, -1, 10),
+			"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).SetError(sarama.ErrNoError).
+				SetOffset(group, "orders-a", 0, 5, "", sarama.ErrNoError).
+				SetOffset(group, "orders-b", 0, 5, "", sarama.ErrNoError),
+			"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+				SetCoordinator(sarama.CoordinatorGroup, group, broker),
+			"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		}
+	}
+	broker.SetHandlerByMap(handlers("orders-a", "payments"))
+
+	config := sarama.NewConfig()
+	config.Version = sarama.MaxVersion
+
+	a := &Adapter{
+		config: &AdapterConfig{TopicPattern: "orders-.*", ConsumerGroup: group},
+		logger: logtesting.TestLogger(t),
+	}
+
+	started := make(chan []string, 10)
+	closed := make(chan struct{}, 10)
+	startGroup := func(topics []string) (func() error, error) {
+		started <- topics
+		return func() error {
+			closed <- struct{}{}
+			return nil
+		}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() { errs <- a.consumeTopicPattern(ctx, []string{broker.Addr()}, config, startGroup) }()
+
+	// The consumer group is joined with the matching topics
+	assert.Equal(t, []string{"orders-a"}, receiveTopics(t, started))
+
+	// The consumer group is joined again when a new topic matches
+	broker.SetHandlerByMap(handlers("orders-a", "orders-b", "payments"))
+	assert.Equal(t, []string{"orders-a", "orders-b"}, receiveTopics(t, started))
+	assert.Len(t, closed, 1)
+
+	// The consumer group is left when the context is done
+	cancel()
+	assert.NoError(t, <-errs)
+	assert.Len(t, closed, 2)
+	assert.Len(t, started, 0)
+}
+
+func receiveTopics(t *testing.T, started <-chan []string) []string {
+	select {
+	case topics := <-started:
+		return topics
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "consumer group not started")
+		return nil
+	}
+}
+
+func TestAdapter_ConsumeTopicPatternInvalid(t *testing.T) {
+	a := &Adapter{
+		config: &AdapterConfig{TopicPattern: "orders-(", ConsumerGroup: "my-group"},
+		logger: logtesting.TestLogger(t),
+	}
+	err := a.consumeTopicPattern(context.Background(), []string{"unreachable:9092"}, sarama.NewConfig(), nil)
+	assert.Error(t, err)
+}
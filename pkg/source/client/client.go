@@ -29,7 +29,10 @@ import (
 	"github.com/kelseyhightower/envconfig"
 
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/common/client"
+	commonconfig "knative.dev/eventing-kafka/pkg/common/config"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 )
 
 type AdapterSASL struct {
@@ -37,6 +40,20 @@ type AdapterSASL struct {
 	User     string `envconfig:"KAFKA_NET_SASL_USER" required:"false"`
 	Password string `envconfig:"KAFKA_NET_SASL_PASSWORD" required:"false"`
 	Type     string `envconfig:"KAFKA_NET_SASL_TYPE" required:"false"`
+	OAuth    AdapterSASLOAuth
+	Kerberos AdapterSASLKerberos
+}
+
+type AdapterSASLOAuth struct {
+	TokenURL string `envconfig:"KAFKA_NET_SASL_OAUTH_TOKEN_URL" required:"false"`
+	Scopes   string `envconfig:"KAFKA_NET_SASL_OAUTH_SCOPES" required:"false"`
+}
+
+type AdapterSASLKerberos struct {
+	ServiceName string `envconfig:"KAFKA_NET_SASL_KERBEROS_SERVICE_NAME" required:"false"`
+	Realm       string `envconfig:"KAFKA_NET_SASL_KERBEROS_REALM" required:"false"`
+	Keytab      string `envconfig:"KAFKA_NET_SASL_KERBEROS_KEYTAB" required:"false"`
+	Config      string `envconfig:"KAFKA_NET_SASL_KERBEROS_CONFIG" required:"false"`
 }
 
 type AdapterTLS struct {
@@ -51,6 +68,14 @@ type AdapterNet struct {
 	TLS  AdapterTLS
 }
 
+// SchemaRegistryEnvConfig is the configuration of the schema registry decoding the records
+type SchemaRegistryEnvConfig struct {
+	URL      string `envconfig:"SCHEMA_REGISTRY_URL" required:"false"`
+	Decode   string `envconfig:"SCHEMA_REGISTRY_DECODE" required:"false"`
+	User     string `envconfig:"SCHEMA_REGISTRY_USER" required:"false"`
+	Password string `envconfig:"SCHEMA_REGISTRY_PASSWORD" required:"false"`
+}
+
 type KafkaConfig struct {
 	SaramaYamlString string
 }
@@ -62,6 +87,10 @@ type KafkaEnvConfig struct {
 	BootstrapServers []string              `envconfig:"KAFKA_BOOTSTRAP_SERVERS" required:"true"`
 	InitialOffset    sourcesv1beta1.Offset `envconfig:"KAFKA_INITIAL_OFFSET" `
 	Net              AdapterNet
+
+	// ClusterSaramaConfig is the YAML string of the Sarama settings of the KafkaCluster of the source,
+	// which override the ones of the Kafka configmap.
+	ClusterSaramaConfig string `envconfig:"KAFKA_CLUSTER_SARAMA_CONFIG" required:"false"`
 }
 
 // NewConfig extracts the Kafka configuration from the environment.
@@ -88,9 +117,15 @@ func NewConfigWithEnv(ctx context.Context, env *KafkaEnvConfig) ([]string, *sara
 
 	if env.Net.SASL.Enable {
 		kafkaAuthConfig.SASL = &client.KafkaSaslConfig{
-			User:     env.Net.SASL.User,
-			Password: env.Net.SASL.Password,
-			SaslType: env.Net.SASL.Type,
+			User:                env.Net.SASL.User,
+			Password:            env.Net.SASL.Password,
+			SaslType:            env.Net.SASL.Type,
+			TokenURL:            env.Net.SASL.OAuth.TokenURL,
+			Scopes:              commonconfig.SplitScopes(env.Net.SASL.OAuth.Scopes),
+			KerberosServiceName: env.Net.SASL.Kerberos.ServiceName,
+			KerberosRealm:       env.Net.SASL.Kerberos.Realm,
+			KerberosKeytab:      env.Net.SASL.Kerberos.Keytab,
+			KerberosConfig:      env.Net.SASL.Kerberos.Config,
 		}
 	}
 
@@ -114,20 +149,55 @@ func NewConfigWithEnv(ctx context.Context, env *KafkaEnvConfig) ([]string, *sara
 		return nil, nil, fmt.Errorf("error creating Sarama config: %w", err)
 	}
 
+	if env.ClusterSaramaConfig != "" {
+		cfg, err = client.NewConfigBuilder().
+			WithExisting(cfg).
+			FromYaml(env.ClusterSaramaConfig).
+			Build(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error applying the Sarama config of the KafkaCluster: %w", err)
+		}
+	}
+
 	return env.BootstrapServers, cfg, nil
 }
 
 // NewConfig extracts the Kafka configuration from a KafkaSource spec.
-func NewConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sourcesv1beta1.KafkaSource) ([]string, *sarama.Config, error) {
-	envConfig, err := NewEnvConfigFromSpec(ctx, kc, obj)
+func NewConfigFromSpec(ctx context.Context, kc kubernetes.Interface, kcl kafkalisters.KafkaClusterLister, obj *sourcesv1beta1.KafkaSource) ([]string, *sarama.Config, error) {
+	envConfig, err := NewEnvConfigFromSpec(ctx, kc, kcl, obj)
 	if err != nil {
 		return nil, nil, err
 	}
 	return NewConfigWithEnv(ctx, &envConfig)
 }
 
-// NewEnvConfigFromSpec validates and creates a KafkaEnvConfig from a KafkaSource
-func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sourcesv1beta1.KafkaSource) (KafkaEnvConfig, error) {
+// ResolveKafkaCluster returns a copy of the KafkaSource with the bootstrap servers and authentication
+// of the KafkaCluster it references, along with the Sarama settings of the cluster.  The KafkaSource
+// itself is returned if it doesn't reference a KafkaCluster.
+func ResolveKafkaCluster(kcl kafkalisters.KafkaClusterLister, obj *sourcesv1beta1.KafkaSource) (*sourcesv1beta1.KafkaSource, string, error) {
+	if obj.Spec.ClusterRef == nil {
+		return obj, "", nil
+	}
+	if kcl == nil {
+		return nil, "", fmt.Errorf("unable to resolve the KafkaCluster %q without a lister", obj.Spec.ClusterRef.Name)
+	}
+	cluster, err := kafkacluster.Get(kcl, obj.Spec.ClusterRef, obj.Namespace)
+	if err != nil {
+		return nil, "", err
+	}
+	resolved := obj.DeepCopy()
+	resolved.Spec.KafkaAuthSpec = cluster.Spec.KafkaAuthSpec()
+	return resolved, cluster.Spec.Sarama, nil
+}
+
+// NewEnvConfigFromSpec validates and creates a KafkaEnvConfig from a KafkaSource, resolving the
+// KafkaCluster it references, if any.
+func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, kcl kafkalisters.KafkaClusterLister, obj *sourcesv1beta1.KafkaSource) (KafkaEnvConfig, error) {
+	obj, clusterSaramaConfig, err := ResolveKafkaCluster(kcl, obj)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
 	saslUser, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.User.SecretKeyRef)
 	if err != nil {
 		return KafkaEnvConfig{}, err
@@ -143,6 +213,36 @@ func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sou
 		return KafkaEnvConfig{}, err
 	}
 
+	saslTokenURL, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.OAuth.TokenURL.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	saslScopes, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.OAuth.Scopes.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	saslKerberosServiceName, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.Kerberos.ServiceName.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	saslKerberosRealm, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.Kerberos.Realm.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	saslKerberosKeytab, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.Kerberos.Keytab.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
+	saslKerberosConfig, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.SASL.Kerberos.Config.SecretKeyRef)
+	if err != nil {
+		return KafkaEnvConfig{}, err
+	}
+
 	tlsCert, err := resolveSecret(ctx, kc, obj.Namespace, obj.Spec.Net.TLS.Cert.SecretKeyRef)
 	if err != nil {
 		return KafkaEnvConfig{}, err
@@ -159,14 +259,25 @@ func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sou
 	}
 
 	config := KafkaEnvConfig{
-		BootstrapServers: obj.Spec.BootstrapServers,
-		InitialOffset:    obj.Spec.InitialOffset,
+		BootstrapServers:    obj.Spec.BootstrapServers,
+		InitialOffset:       obj.Spec.InitialOffset,
+		ClusterSaramaConfig: clusterSaramaConfig,
 		Net: AdapterNet{
 			SASL: AdapterSASL{
 				Enable:   obj.Spec.Net.SASL.Enable,
 				User:     saslUser,
 				Password: saslPassword,
 				Type:     saslType,
+				OAuth: AdapterSASLOAuth{
+					TokenURL: saslTokenURL,
+					Scopes:   saslScopes,
+				},
+				Kerberos: AdapterSASLKerberos{
+					ServiceName: saslKerberosServiceName,
+					Realm:       saslKerberosRealm,
+					Keytab:      saslKerberosKeytab,
+					Config:      saslKerberosConfig,
+				},
 			},
 			TLS: AdapterTLS{
 				Enable: obj.Spec.Net.TLS.Enable,
@@ -180,6 +291,31 @@ func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sou
 	return config, nil
 }
 
+// NewSchemaRegistryEnvConfigFromSpec creates a SchemaRegistryEnvConfig from the schema registry of a KafkaSource
+func NewSchemaRegistryEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sourcesv1beta1.KafkaSource) (SchemaRegistryEnvConfig, error) {
+	spec := obj.Spec.SchemaRegistry
+	if spec == nil {
+		return SchemaRegistryEnvConfig{}, nil
+	}
+
+	user, err := resolveSecret(ctx, kc, obj.Namespace, spec.User.SecretKeyRef)
+	if err != nil {
+		return SchemaRegistryEnvConfig{}, err
+	}
+
+	password, err := resolveSecret(ctx, kc, obj.Namespace, spec.Password.SecretKeyRef)
+	if err != nil {
+		return SchemaRegistryEnvConfig{}, err
+	}
+
+	return SchemaRegistryEnvConfig{
+		URL:      spec.URL,
+		Decode:   string(spec.Decode),
+		User:     user,
+		Password: password,
+	}, nil
+}
+
 // NewProducer is a helper method for constructing a client for producing kafka methods.
 func NewProducer(ctx context.Context) (sarama.Client, error) {
 	bs, cfg, err := NewConfigFromEnv(ctx)
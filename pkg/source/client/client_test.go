@@ -26,8 +26,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/common/client"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -393,7 +396,7 @@ sny569QyyWHk2+FZoWDfjxFZ7CvIdgLJBHc3qUXLsg==
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
-			servers, config, err := NewConfigFromSpec(context.Background(), fake.NewSimpleClientset((tc.runtimeObjects)...), tc.src)
+			servers, config, err := NewConfigFromSpec(context.Background(), fake.NewSimpleClientset((tc.runtimeObjects)...), nil, tc.src)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -432,6 +435,145 @@ sny569QyyWHk2+FZoWDfjxFZ7CvIdgLJBHc3qUXLsg==
 	}
 }
 
+func TestNewEnvConfigFromSpecSASLMechanisms(t *testing.T) {
+	secretRef := func(name, key string) bindingsv1beta1.SecretValueFromSource {
+		return bindingsv1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				Key:                  key,
+			},
+		}
+	}
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "source-name", Namespace: "source-namespace"},
+		Spec: v1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+				Net: bindingsv1beta1.KafkaNetSpec{
+					SASL: bindingsv1beta1.KafkaSASLSpec{
+						Enable: true,
+						OAuth: bindingsv1beta1.KafkaSASLOAuthSpec{
+							TokenURL: secretRef("the-tokenurl-secret", "tokenurl"),
+							Scopes:   secretRef("the-scopes-secret", "scopes"),
+						},
+						Kerberos: bindingsv1beta1.KafkaSASLKerberosSpec{
+							ServiceName: secretRef("the-servicename-secret", "servicename"),
+							Realm:       secretRef("the-realm-secret", "realm"),
+							Keytab:      secretRef("the-keytab-secret", "keytab"),
+							Config:      secretRef("the-config-secret", "config"),
+						},
+					},
+				},
+			},
+		},
+	}
+	kc := fake.NewSimpleClientset(
+		constructSecret("the-tokenurl-secret", "tokenurl", "https://oauth.example.com/token"),
+		constructSecret("the-scopes-secret", "scopes", "scope-a,scope-b"),
+		constructSecret("the-servicename-secret", "servicename", "kafka"),
+		constructSecret("the-realm-secret", "realm", "EXAMPLE.COM"),
+		constructSecret("the-keytab-secret", "keytab", "a2V5dGFi"),
+		constructSecret("the-config-secret", "config", "[libdefaults]"),
+	)
+
+	config, err := NewEnvConfigFromSpec(context.Background(), kc, nil, src)
+	require.NoError(t, err)
+	require.Equal(t, AdapterSASLOAuth{TokenURL: "https://oauth.example.com/token", Scopes: "scope-a,scope-b"}, config.Net.SASL.OAuth)
+	require.Equal(t, AdapterSASLKerberos{ServiceName: "kafka", Realm: "EXAMPLE.COM", Keytab: "a2V5dGFi", Config: "[libdefaults]"}, config.Net.SASL.Kerberos)
+}
+
+func TestNewConfigFromSpecKafkaCluster(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(&kafkav1alpha1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-name", Namespace: "source-namespace"},
+		Spec: kafkav1alpha1.KafkaClusterSpec{
+			BootstrapServers: []string{"cluster-server1", "cluster-server2"},
+			Net: bindingsv1beta1.KafkaNetSpec{
+				SASL: bindingsv1beta1.KafkaSASLSpec{
+					Enable: true,
+					User: bindingsv1beta1.SecretValueFromSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "the-user-secret"},
+							Key:                  "user",
+						},
+					},
+				},
+			},
+			Sarama: "ClientID: cluster-client",
+		},
+	}))
+	kcl := kafkalisters.NewKafkaClusterLister(indexer)
+	kc := fake.NewSimpleClientset(constructSecret("the-user-secret", "user", "cluster-user"))
+
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "source-name", Namespace: "source-namespace"},
+		Spec: v1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				ClusterRef: &bindingsv1beta1.KafkaClusterReference{Name: "cluster-name"},
+			},
+		},
+	}
+
+	resolved, saramaConfig, err := ResolveKafkaCluster(kcl, src)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cluster-server1", "cluster-server2"}, resolved.Spec.BootstrapServers)
+	require.Equal(t, "ClientID: cluster-client", saramaConfig)
+	require.Empty(t, src.Spec.BootstrapServers, "the source must not be modified")
+
+	servers, config, err := NewConfigFromSpec(context.Background(), kc, kcl, src)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cluster-server1", "cluster-server2"}, servers)
+	require.Equal(t, "cluster-client", config.ClientID)
+	require.True(t, config.Net.SASL.Enable)
+	require.Equal(t, "cluster-user", config.Net.SASL.User)
+
+	// The KafkaCluster must exist, and can't be resolved without a lister
+	src.Spec.ClusterRef.Name = "missing-cluster"
+	_, _, err = NewConfigFromSpec(context.Background(), kc, kcl, src)
+	require.Error(t, err)
+	_, _, err = NewConfigFromSpec(context.Background(), kc, nil, src)
+	require.Error(t, err)
+}
+
+func TestNewSchemaRegistryEnvConfigFromSpec(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "source-name", Namespace: "source-namespace"},
+	}
+	config, err := NewSchemaRegistryEnvConfigFromSpec(context.Background(), fake.NewSimpleClientset(), src)
+	require.NoError(t, err)
+	require.Equal(t, SchemaRegistryEnvConfig{}, config)
+
+	src.Spec.SchemaRegistry = &v1beta1.SchemaRegistrySpec{
+		URL:    "https://schema-registry.example.com",
+		Decode: v1beta1.DecodeKey,
+		User: bindingsv1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "the-registry-secret"},
+				Key:                  "user",
+			},
+		},
+		Password: bindingsv1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "the-registry-secret"},
+				Key:                  "password",
+			},
+		},
+	}
+	secret := constructSecret("the-registry-secret", "user", "registry-user")
+	secret.Data["password"] = []byte("registry-password")
+	config, err = NewSchemaRegistryEnvConfigFromSpec(context.Background(), fake.NewSimpleClientset(secret), src)
+	require.NoError(t, err)
+	require.Equal(t, SchemaRegistryEnvConfig{
+		URL:      "https://schema-registry.example.com",
+		Decode:   "key",
+		User:     "registry-user",
+		Password: "registry-password",
+	}, config)
+
+	_, err = NewSchemaRegistryEnvConfigFromSpec(context.Background(), fake.NewSimpleClientset(), src)
+	require.Error(t, err)
+}
+
 func constructSecret(name, key, secret string) *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
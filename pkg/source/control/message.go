@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -14,22 +15,29 @@ import (
 const (
 	NotifySetupClaimsOpCode   ctrl.OpCode = 1
 	NotifyCleanupClaimsOpCode ctrl.OpCode = 2
+	NotifyResourceUsageOpCode ctrl.OpCode = 3
 )
 
 type Claims map[string][]int32
 
+// ClaimsNotification are the claims notified by a receive adapter pod, along with the time they were received.
+type ClaimsNotification struct {
+	Claims Claims
+	Time   time.Time
+}
+
 func ClaimsParser(payload []byte) (interface{}, error) {
 	var claims Claims
 	err := (&claims).UnmarshalBinary(payload)
 	if err != nil {
 		return nil, err
 	}
-	return claims, nil
+	return ClaimsNotification{Claims: claims, Time: time.Now()}, nil
 }
 
 func ClaimsMerger(old interface{}, new interface{}) interface{} {
-	oldClaims := old.(Claims)
-	newClaims := new.(Claims)
+	oldClaims := old.(ClaimsNotification).Claims
+	newClaims := new.(ClaimsNotification).Claims
 	result := oldClaims.copy()
 
 	for topic, partitions := range result {
@@ -42,12 +50,12 @@ func ClaimsMerger(old interface{}, new interface{}) interface{} {
 		result[newTopic] = newPartitions
 	}
 
-	return result
+	return ClaimsNotification{Claims: result, Time: new.(ClaimsNotification).Time}
 }
 
 func ClaimsDifference(old interface{}, new interface{}) interface{} {
-	oldClaims := old.(Claims)
-	cleanedClaims := new.(Claims)
+	oldClaims := old.(ClaimsNotification).Claims
+	cleanedClaims := new.(ClaimsNotification).Claims
 	result := oldClaims.copy()
 
 	for topic, partitions := range result {
@@ -61,7 +69,7 @@ func ClaimsDifference(old interface{}, new interface{}) interface{} {
 		}
 	}
 
-	return result
+	return ClaimsNotification{Claims: result, Time: new.(ClaimsNotification).Time}
 }
 
 func (c Claims) String() string {
@@ -72,6 +80,10 @@ func (c Claims) String() string {
 	return strings.Join(strs, ", ")
 }
 
+func (n ClaimsNotification) String() string {
+	return n.Claims.String()
+}
+
 func (c Claims) MarshalBinary() (data []byte, err error) {
 	return json.Marshal(c)
 }
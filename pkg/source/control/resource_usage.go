@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ResourceUsage is the resource usage of the sources consumed by a multi-tenant receive adapter pod.
+type ResourceUsage struct {
+	// PodName is the name of the pod, as found in the placements of the sources.
+	PodName string `json:"podName"`
+
+	// VReplicaMemoryLimit is the memory budget of a vreplica, in bytes.
+	VReplicaMemoryLimit int64 `json:"vreplicaMemoryLimit"`
+
+	// Sources is the usage of each source consumed by the pod, indexed by namespace/name.
+	Sources map[string]SourceResourceUsage `json:"sources"`
+}
+
+// SourceResourceUsage is the resource usage of the vreplicas of a source placed on a pod.
+type SourceResourceUsage struct {
+	VReplicas int32 `json:"vreplicas"`
+
+	// Partitions is the number of partitions claimed by the consumer of the source.
+	Partitions int32 `json:"partitions"`
+
+	// Claims are the partitions claimed by the consumer of the source, and
+	// ClaimsTime the last time they changed.
+	Claims     Claims    `json:"claims,omitempty"`
+	ClaimsTime time.Time `json:"claimsTime,omitempty"`
+
+	// FetchSize is the fetch size of each partition, in bytes.
+	FetchSize int32 `json:"fetchSize"`
+
+	// Memory is the estimated memory consumed by the partition buffers, in bytes.
+	Memory int64 `json:"memory"`
+}
+
+// OverBudgetSources returns the sources whose partition buffers consume more memory than the budget
+// of their vreplicas placed on the pod.
+func (u ResourceUsage) OverBudgetSources() []string {
+	if u.VReplicaMemoryLimit <= 0 {
+		return nil
+	}
+
+	var sources []string
+	for key, usage := range u.Sources {
+		if usage.Memory > int64(usage.VReplicas)*u.VReplicaMemoryLimit {
+			sources = append(sources, key)
+		}
+	}
+	return sources
+}
+
+func (u ResourceUsage) MarshalBinary() (data []byte, err error) {
+	return json.Marshal(u)
+}
+
+func (u *ResourceUsage) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, u)
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"math"
+	"reflect"
 	"strconv"
 	"sync"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"go.uber.org/zap"
@@ -30,14 +32,20 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"knative.dev/eventing/pkg/adapter/v2"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/metrics/source"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
 
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkaclusterinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
 	stadapter "knative.dev/eventing-kafka/pkg/source/adapter"
 	"knative.dev/eventing-kafka/pkg/source/client"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
 	"knative.dev/eventing/pkg/scheduler"
 )
 
@@ -56,15 +64,53 @@ func NewEnvConfig() adapter.EnvConfigAccessor {
 type cancelContext struct {
 	fn      context.CancelFunc
 	stopped chan bool
+	groupId string
+
+	// config is the configuration the adapter was started with
+	config    stadapter.AdapterConfig
+	fetchSize int
+	adapter   adapter.MessageAdapter
+
+	// ctx, source and placement are used to periodically re-evaluate the fetch sizes of the source
+	ctx       context.Context
+	source    *v1beta1.KafkaSource
+	placement duckv1alpha1.Placement
+}
+
+// reconfigurableAdapter is an adapter whose rate limits, sink, CloudEvent overrides and delivery
+// settings can be updated while it runs.
+type reconfigurableAdapter interface {
+	SetRateLimits(r rate.Limit, b int)
+	SetSink(sink string)
+	SetCloudEventOverrides(ceOverrides *duckv1.CloudEventOverrides)
+	SetDeliveryConfig(config *stadapter.AdapterConfig)
+}
+
+var _ reconfigurableAdapter = (*stadapter.Adapter)(nil)
+
+// claimsAdapter is an adapter reporting the partitions claimed by its consumer.
+type claimsAdapter interface {
+	Claims() (kafkasourcecontrol.Claims, time.Time)
 }
 
+var _ claimsAdapter = (*stadapter.Adapter)(nil)
+
+// resourceEvaluationPeriod is the period at which the fetch sizes of the sources are re-evaluated,
+// and the resource usage of the pod is reported to the controller (var to facilitate unit testing)
+var resourceEvaluationPeriod = time.Minute
+
+// newServerHandler starts the control-protocol server (var to facilitate unit testing)
+var newServerHandler = controlprotocol.NewServerHandler
+
 type Adapter struct {
 	config      *AdapterConfig
 	logger      *zap.SugaredLogger
 	client      cloudevents.Client
 	adapterCtor adapter.MessageAdapterConstructor
 	kubeClient  kubernetes.Interface
+	kcLister    kafkalisters.KafkaClusterLister
 	memLimit    int32
+	groupRouter *consumerGroupRouter
 
 	sourcesMu sync.RWMutex
 	sources   map[string]cancelContext
@@ -87,13 +133,25 @@ func newAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClie
 		logger:      logger,
 		adapterCtor: adapterCtor,
 		kubeClient:  kubeclient.Get(ctx),
+		kcLister:    kafkaclusterinformer.Get(ctx).Lister(),
 		memLimit:    int32(ml.Value()),
+		groupRouter: newConsumerGroupRouter(logger),
 		sourcesMu:   sync.RWMutex{},
 		sources:     make(map[string]cancelContext),
 	}
 }
 
 func (a *Adapter) Start(ctx context.Context) error {
+	// Route the consumer group commands (e.g. from the ResetOffset controller) to the sources
+	serverHandler, err := newServerHandler(ctx, controlprotocol.ServerPort)
+	if err != nil {
+		return err
+	}
+	defer serverHandler.Shutdown(5 * time.Second)
+	a.groupRouter.register(serverHandler)
+
+	go a.reportResourceUsage(ctx, serverHandler)
+
 	<-ctx.Done()
 	a.logger.Info("Shutting down...")
 	return nil
@@ -112,38 +170,228 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 
 	cancel, ok := a.sources[key]
 
-	if ok {
-		// TODO: do not stop if the only thing that changes is the number of vreplicas
-		logger.Info("stopping adapter")
-		cancel.fn()
-
-		// Wait for the adapter to stop
-		<-cancel.stopped
-
-		// Nothing to stop anymore
-		delete(a.sources, key)
-	}
-
 	placement := scheduler.GetPlacementForPod(obj.GetPlacements(), a.config.PodName)
 	if placement == nil || placement.VReplicas == 0 {
+		if ok {
+			logger.Info("stopping adapter")
+			a.stop(key, cancel)
+		}
 		// this pod does not handle this source. Skipping
 		logger.Info("no replicas assigned to this source. skipping")
 		return nil
 	}
 
-	kafkaEnvConfig, err := client.NewEnvConfigFromSpec(ctx, a.kubeClient, obj)
+	config, fetchSize, err := a.adapterConfig(ctx, logger, obj, placement)
+
+	if ok {
+		// The adapter keeps consuming, without rebalancing the consumer group, when only
+		// the settings it can update while running change.
+		if running, canUpdate := cancel.adapter.(reconfigurableAdapter); err == nil && canUpdate && equalRestartConfig(cancel.config, config) {
+			logger.Info("updating adapter in place")
+			a.reconfigure(running, obj, placement, &config)
+
+			// Smaller fetch sizes, if any, are applied by the next evaluation of the resources,
+			// and larger ones the next time the adapter restarts (see evaluateFetchSizes)
+			config.KafkaConfigJson = cancel.config.KafkaConfigJson
+			cancel.config = config
+			cancel.source = obj
+			cancel.placement = *placement
+			a.sources[key] = cancel
+			return nil
+		}
+
+		logger.Info("stopping adapter")
+		a.stop(key, cancel)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return a.start(ctx, key, obj, placement, config, fetchSize)
+}
+
+// start starts the adapter of the source with the given configuration, along with the periodic
+// evaluation of its resources.
+func (a *Adapter) start(ctx context.Context, key string, obj *v1beta1.KafkaSource, placement *duckv1alpha1.Placement, config stadapter.AdapterConfig, fetchSize int) error {
+	reporter, err := source.NewStatsReporter()
+	if err != nil {
+		a.logger.Error("error building statsreporter", zap.Error(err))
+		return err
+	}
+
+	httpBindingsSender, err := kncloudevents.NewHTTPMessageSenderWithTarget(obj.Status.SinkURI.String())
 	if err != nil {
+		a.logger.Errorw("error building cloud event client", zap.Error(err))
 		return err
 	}
 
-	// Enforce memory limits
+	adapter := a.adapterCtor(ctx, &config, httpBindingsSender, reporter)
+
+	if running, ok := adapter.(reconfigurableAdapter); ok {
+		a.reconfigure(running, obj, placement, &config)
+	}
+	if sta, ok := adapter.(*stadapter.Adapter); ok {
+		sta.SetServerHandler(a.groupRouter.forGroup(obj.Spec.ConsumerGroup))
+	}
+
+	adapterCtx, cancelFn := context.WithCancel(ctx)
+
+	cancel := cancelContext{
+		fn:        cancelFn,
+		stopped:   make(chan bool),
+		groupId:   obj.Spec.ConsumerGroup,
+		config:    config,
+		fetchSize: fetchSize,
+		adapter:   adapter,
+		ctx:       ctx,
+		source:    obj,
+		placement: *placement,
+	}
+
+	a.sources[key] = cancel
+
+	go func(ctx context.Context) {
+		err := adapter.Start(ctx)
+		if err != nil {
+			a.logger.Errorw("adapter failed to start", zap.Error(err))
+		}
+		cancel.stopped <- true
+	}(adapterCtx)
+
+	if a.memLimit > 0 {
+		go a.evaluateResources(adapterCtx, key, cancel.stopped)
+	}
+
+	a.logger.Infow("source added", "name", obj.Name)
+	return nil
+}
+
+// evaluateResources periodically re-evaluates the fetch sizes of the source, as partitions can be
+// added to its topics, until the adapter of the source is stopped.
+func (a *Adapter) evaluateResources(ctx context.Context, key string, stopped chan bool) {
+	ticker := time.NewTicker(resourceEvaluationPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.evaluateFetchSizes(ctx, key, stopped)
+		}
+	}
+}
+
+// evaluateFetchSizes recomputes the fetch sizes of the source and restarts its adapter when they must
+// decrease to keep the memory of the partitions within the budget of the vreplicas.  The consumers read
+// the fetch sizes from their Sarama configuration while fetching, so changing them in place would race
+// with the running consumers.  Larger fetch sizes (e.g. when vreplicas are added) don't justify
+// rebalancing the consumer group, and are applied the next time the adapter restarts.
+func (a *Adapter) evaluateFetchSizes(ctx context.Context, key string, stopped chan bool) {
+	logger := a.logger.With("key", key)
+
+	a.sourcesMu.RLock()
+	cancel, ok := a.sources[key]
+	a.sourcesMu.RUnlock()
+	if !ok || cancel.stopped != stopped {
+		return
+	}
+
+	config, fetchSize, err := a.adapterConfig(ctx, logger, cancel.source, &cancel.placement)
+	if err != nil {
+		logger.Warnw("cannot evaluate the fetch sizes", zap.Error(err))
+		return
+	}
+	if fetchSize >= cancel.fetchSize {
+		return
+	}
+
+	a.sourcesMu.Lock()
+	defer a.sourcesMu.Unlock()
+
+	// The source was updated or removed in the meantime
+	cancel, ok = a.sources[key]
+	if !ok || cancel.stopped != stopped {
+		return
+	}
+
+	logger.Infow("restarting adapter to apply the fetch sizes", zap.Int("fetchSize", fetchSize))
+	a.stop(key, cancel)
+	if err := a.start(cancel.ctx, key, cancel.source, &cancel.placement, config, fetchSize); err != nil {
+		logger.Errorw("cannot restart the adapter", zap.Error(err))
+	}
+}
+
+// reportResourceUsage periodically sends the resource usage of the pod to the controller.
+func (a *Adapter) reportResourceUsage(ctx context.Context, serverHandler controlprotocol.ServerHandler) {
+	ticker := time.NewTicker(resourceEvaluationPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := serverHandler.SendAndWaitForAck(kafkasourcecontrol.NotifyResourceUsageOpCode, a.resourceUsage()); err != nil {
+				a.logger.Debugw("cannot send the resource usage", zap.Error(err))
+			}
+		}
+	}
+}
+
+// resourceUsage returns the partitions claimed by the sources running on this pod, and the memory
+// consumed by their partition buffers.
+func (a *Adapter) resourceUsage() kafkasourcecontrol.ResourceUsage {
+	a.sourcesMu.RLock()
+	defer a.sourcesMu.RUnlock()
+
+	usage := kafkasourcecontrol.ResourceUsage{
+		PodName:             a.config.PodName,
+		VReplicaMemoryLimit: int64(a.memLimit),
+		Sources:             make(map[string]kafkasourcecontrol.SourceResourceUsage, len(a.sources)),
+	}
+	for key, cancel := range a.sources {
+		var claims kafkasourcecontrol.Claims
+		var claimsTime time.Time
+		if running, ok := cancel.adapter.(claimsAdapter); ok {
+			claims, claimsTime = running.Claims()
+		}
+
+		var partitions int32
+		for _, claimed := range claims {
+			partitions += int32(len(claimed))
+		}
+
+		// A partition consumes about 2 * fetch partition size (see partitionFetchSize)
+		usage.Sources[key] = kafkasourcecontrol.SourceResourceUsage{
+			VReplicas:  cancel.placement.VReplicas,
+			Partitions: partitions,
+			Claims:     claims,
+			ClaimsTime: claimsTime,
+			FetchSize:  int32(cancel.fetchSize),
+			Memory:     2 * int64(partitions) * int64(cancel.fetchSize),
+		}
+	}
+	return usage
+}
+
+// adapterConfig returns the configuration of the adapter of the source, with the fetch sizes of
+// the vreplicas of the placement, and the fetch size of each partition.
+func (a *Adapter) adapterConfig(ctx context.Context, logger *zap.SugaredLogger, obj *v1beta1.KafkaSource, placement *duckv1alpha1.Placement) (stadapter.AdapterConfig, int, error) {
+	kafkaEnvConfig, err := client.NewEnvConfigFromSpec(ctx, a.kubeClient, a.kcLister, obj)
+	if err != nil {
+		return stadapter.AdapterConfig{}, 0, err
+	}
+
+	// Enforce memory limits, periodically re-evaluated by evaluateResources
+	fetchSize := 0
 	if a.memLimit > 0 {
-		// TODO: periodically enforce limits as the number of partitions can dynamically change
-		fetchSizePerVReplica, err := a.partitionFetchSize(ctx, logger, &kafkaEnvConfig, obj.Spec.Topics, scheduler.GetPodCount(obj.Status.Placements))
+		fetchSizePerVReplica, err := a.partitionFetchSize(ctx, logger, &kafkaEnvConfig, obj.ConsumedTopics(), scheduler.GetPodCount(obj.Status.Placements))
 		if err != nil {
-			return err
+			return stadapter.AdapterConfig{}, 0, err
 		}
-		fetchSize := fetchSizePerVReplica * int(placement.VReplicas)
+		fetchSize = fetchSizePerVReplica * int(placement.VReplicas)
 
 		// Must handle at least 64k messages to the compliant with the CloudEvent spec
 		maxFetchSize := fetchSize
@@ -167,59 +415,73 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 		},
 		KafkaEnvConfig:       kafkaEnvConfig,
 		Topics:               obj.Spec.Topics,
+		TopicPattern:         obj.Spec.TopicPattern,
 		ConsumerGroup:        obj.Spec.ConsumerGroup,
 		Name:                 obj.Name,
+		Ordering:             string(obj.Spec.Ordering),
+		Paused:               obj.Spec.Paused,
 		DisableControlServer: true,
 	}
 
+	if obj.Spec.MaxInFlight != nil {
+		config.MaxInFlight = int(*obj.Spec.MaxInFlight)
+	}
+
 	if val, ok := obj.GetLabels()[v1beta1.KafkaKeyTypeLabel]; ok {
 		config.KeyType = val
 	}
 
-	if obj.Spec.CloudEventOverrides != nil {
+	if obj.Spec.Delivery != nil {
 		// Cannot fail here.
-		ceJson, _ := json.Marshal(obj.Spec.CloudEventOverrides)
-		config.CEOverrides = string(ceJson)
+		deliveryJson, _ := json.Marshal(obj.Spec.Delivery)
+		config.Delivery = string(deliveryJson)
 	}
 
-	reporter, err := source.NewStatsReporter()
-	if err != nil {
-		a.logger.Error("error building statsreporter", zap.Error(err))
-		return err
+	if obj.Spec.CloudEventAttributes != nil {
+		// Cannot fail here.
+		attributesJson, _ := json.Marshal(obj.Spec.CloudEventAttributes)
+		config.CloudEventAttributes = string(attributesJson)
 	}
 
-	httpBindingsSender, err := kncloudevents.NewHTTPMessageSenderWithTarget(obj.Status.SinkURI.String())
-	if err != nil {
-		a.logger.Errorw("error building cloud event client", zap.Error(err))
-		return err
+	if obj.Spec.Batching != nil {
+		config.BatchMaxSize = int(obj.Spec.Batching.MaxSize)
+		config.BatchMaxWaitMs = int(obj.Spec.Batching.MaxWaitMs)
 	}
 
-	adapter := a.adapterCtor(ctx, &config, httpBindingsSender, reporter)
-
-	// TODO: define Limit interface.
-	if sta, ok := adapter.(*stadapter.Adapter); ok {
-		sta.SetRateLimits(rate.Limit(a.config.MPSLimit*int(placement.VReplicas)), 2*a.config.MPSLimit*int(placement.VReplicas))
+	if obj.Status.DeadLetterSinkURI != nil {
+		config.DeadLetterSink = obj.Status.DeadLetterSinkURI.String()
 	}
 
-	ctx, cancelFn := context.WithCancel(ctx)
+	if obj.Spec.Reply != nil {
+		config.ReplyTopic = obj.Spec.Reply.Topic
+	}
 
-	cancel = cancelContext{
-		fn:      cancelFn,
-		stopped: make(chan bool),
+	if obj.Status.ReplyURI != nil {
+		config.ReplySink = obj.Status.ReplyURI.String()
 	}
 
-	a.sources[key] = cancel
+	config.SchemaRegistry, err = client.NewSchemaRegistryEnvConfigFromSpec(ctx, a.kubeClient, obj)
+	return config, fetchSize, err
+}
 
-	go func(ctx context.Context) {
-		err := adapter.Start(ctx)
-		if err != nil {
-			a.logger.Errorw("adapter failed to start", zap.Error(err))
-		}
-		cancel.stopped <- true
-	}(ctx)
+// reconfigure updates the settings of the adapter which can change while it runs.
+func (a *Adapter) reconfigure(running reconfigurableAdapter, obj *v1beta1.KafkaSource, placement *duckv1alpha1.Placement, config *stadapter.AdapterConfig) {
+	running.SetRateLimits(rateLimits(a.config.MPSLimit, obj, placement.VReplicas))
+	running.SetSink(obj.Status.SinkURI.String())
+	running.SetCloudEventOverrides(obj.Spec.CloudEventOverrides)
+	running.SetDeliveryConfig(config)
+}
 
-	a.logger.Infow("source added", "name", obj.Name)
-	return nil
+// stop stops the adapter of the source and waits for it to be stopped.
+func (a *Adapter) stop(key string, cancel cancelContext) {
+	cancel.fn()
+
+	// Wait for the adapter to stop
+	<-cancel.stopped
+	a.groupRouter.remove(cancel.groupId)
+
+	// Nothing to stop anymore
+	delete(a.sources, key)
 }
 
 func (a *Adapter) Remove(name, namespace string) {
@@ -236,10 +498,7 @@ func (a *Adapter) Remove(name, namespace string) {
 		return
 	}
 
-	cancel.fn()
-	<-cancel.stopped
-
-	delete(a.sources, key)
+	a.stop(key, cancel)
 
 	a.logger.Infow("source removed", "name", name, "remaining", len(a.sources))
 }
@@ -256,7 +515,6 @@ func (a *Adapter) partitionFetchSize(ctx context.Context,
 	podCount int) (int, error) {
 
 	// Compute the number of partitions handled by this source
-	// TODO: periodically check for # of resources. Need control-protocol.
 	adminClient, err := client.MakeAdminClient(ctx, kafkaEnvConfig)
 	if err != nil {
 		logger.Errorw("cannot create admin client", zap.Error(err))
@@ -296,3 +554,52 @@ func (a *Adapter) partitionFetchSize(ctx context.Context,
 	// see https://github.com/Shopify/sarama/blob/83d633e6e4f71b402df5e9c53ad5c1c334b7065d/consumer.go#L649
 	return int(math.Floor(float64(a.memLimit) / float64(handledPartitions) / 2.0)), nil
 }
+
+// rateLimits returns the rate limit of the vreplicas of the source placed on this pod, the lowest of
+// the limit of the vreplicas and their share of the rate limit of the source, if any.
+func rateLimits(mpsLimit int, obj *v1beta1.KafkaSource, vreplicas int32) (rate.Limit, int) {
+	limit, burst := rate.Limit(mpsLimit*int(vreplicas)), 2*mpsLimit*int(vreplicas)
+	if obj.Spec.RateLimit == nil {
+		return limit, burst
+	}
+
+	sourceLimit, sourceBurst := obj.Spec.RateLimit.LimitsFor(vreplicas, scheduler.GetTotalVReplicas(obj.GetPlacements()))
+	if mpsLimit <= 0 || rate.Limit(sourceLimit) < limit {
+		limit = rate.Limit(sourceLimit)
+	}
+	if mpsLimit <= 0 || sourceBurst < burst {
+		burst = sourceBurst
+	}
+	return limit, burst
+}
+
+// equalRestartConfig returns whether the configurations of an adapter only differ by the settings which
+// don't require restarting the adapter (see restartConfig).
+func equalRestartConfig(running stadapter.AdapterConfig, updated stadapter.AdapterConfig) bool {
+	return reflect.DeepEqual(restartConfig(running), restartConfig(updated))
+}
+
+// restartConfig returns the settings of the configuration which only apply when the adapter joins the
+// consumer group: the cluster and its authentication, the consumed topics and the consumer group, and
+// the options of the consumer group handler (ordering, batching, pause and reply topic, whose producer
+// is created on start).  The delivery settings are updated while the adapter runs (see reconfigure), and
+// the fetch sizes are handled by evaluateFetchSizes.
+func restartConfig(config stadapter.AdapterConfig) stadapter.AdapterConfig {
+	return stadapter.AdapterConfig{
+		KafkaEnvConfig: client.KafkaEnvConfig{
+			BootstrapServers:    config.BootstrapServers,
+			InitialOffset:       config.InitialOffset,
+			Net:                 config.Net,
+			ClusterSaramaConfig: config.ClusterSaramaConfig,
+		},
+		Topics:         config.Topics,
+		TopicPattern:   config.TopicPattern,
+		ConsumerGroup:  config.ConsumerGroup,
+		Ordering:       config.Ordering,
+		MaxInFlight:    config.MaxInFlight,
+		BatchMaxSize:   config.BatchMaxSize,
+		BatchMaxWaitMs: config.BatchMaxWaitMs,
+		Paused:         config.Paused,
+		ReplyTopic:     config.ReplyTopic,
+	}
+}
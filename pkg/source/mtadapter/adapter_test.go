@@ -18,15 +18,22 @@ package mtadapter
 
 import (
 	"context"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"knative.dev/eventing/pkg/metrics/source"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/ptr"
 	pkgtesting "knative.dev/pkg/reconciler/testing"
 
 	"knative.dev/eventing/pkg/adapter/v2"
@@ -35,6 +42,11 @@ import (
 
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster/fake"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	stadapter "knative.dev/eventing-kafka/pkg/source/adapter"
+	"knative.dev/eventing-kafka/pkg/source/client"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
 	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 )
 
@@ -48,6 +60,7 @@ const (
 )
 
 func TestUpdateRemoveSources(t *testing.T) {
+	defer stubServerHandler()()
 	ctx, _ := pkgtesting.SetupFakeContext(t)
 	ctx, cancelAdapter := context.WithCancel(ctx)
 
@@ -330,6 +343,7 @@ func TestSourceMTAdapter(t *testing.T) {
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
+			defer stubServerHandler()()
 			ctx, _ := pkgtesting.SetupFakeContext(t)
 
 			if tc.objects != nil {
@@ -372,8 +386,28 @@ func TestSourceMTAdapter(t *testing.T) {
 	}
 }
 
+// stubServerHandler replaces the control-protocol server with a mock and returns the restore function
+func stubServerHandler() func() {
+	original := newServerHandler
+	newServerHandler = func(ctx context.Context, port int) (controlprotocol.ServerHandler, error) {
+		server := getMockServerHandler()
+		server.On("Shutdown", mock.Anything).Return()
+		return server, nil
+	}
+	return func() { newServerHandler = original }
+}
+
 type sampleAdapter struct {
 	running bool
+
+	mu          sync.Mutex
+	limit       rate.Limit
+	burst       int
+	sink        string
+	ceOverrides *duckv1.CloudEventOverrides
+	config      *stadapter.AdapterConfig
+	claims      kafkasourcecontrol.Claims
+	claimsTime  time.Time
 }
 
 func newSampleAdapter(ctx context.Context, env adapter.EnvConfigAccessor, adapter *kncloudevents.HTTPMessageSender, reporter source.StatsReporter) adapter.MessageAdapter {
@@ -391,3 +425,290 @@ func (d *sampleAdapter) Start(ctx context.Context) error {
 
 	return nil
 }
+
+func (d *sampleAdapter) SetRateLimits(r rate.Limit, b int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.limit, d.burst = r, b
+}
+
+func (d *sampleAdapter) SetSink(sink string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sink = sink
+}
+
+func (d *sampleAdapter) SetCloudEventOverrides(ceOverrides *duckv1.CloudEventOverrides) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ceOverrides = ceOverrides
+}
+
+func (d *sampleAdapter) SetDeliveryConfig(config *stadapter.AdapterConfig) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.config = config
+}
+
+func (d *sampleAdapter) Claims() (kafkasourcecontrol.Claims, time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.claims, d.claimsTime
+}
+
+func TestUpdateInPlace(t *testing.T) {
+	defer stubServerHandler()()
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, cancelAdapter := context.WithCancel(ctx)
+	defer cancelAdapter()
+
+	// Let the sample adapters start and stop
+	go func() {
+		for {
+			select {
+			case <-runningAdapterChan:
+			case <-stoppingAdapterChan:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	env := &AdapterConfig{PodName: podName, MemoryLimit: "0", MPSLimit: 10}
+	mtadapter := newAdapter(ctx, env, adaptertest.NewTestClient(), newSampleAdapter).(*Adapter)
+	go mtadapter.Start(ctx)
+
+	src := &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+		},
+		Status: sourcesv1beta1.KafkaSourceStatus{
+			Placeable: duckv1alpha1.Placeable{
+				Placements: []duckv1alpha1.Placement{
+					{PodName: podName, VReplicas: int32(1)},
+				}},
+		},
+	}
+	if err := mtadapter.Update(ctx, src); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	running := mtadapter.sources["test-ns/test-name"].adapter.(*sampleAdapter)
+
+	// The vreplicas, sink, CloudEvent overrides and delivery settings are updated without restarting the adapter
+	updated := src.DeepCopy()
+	updated.Status.Placements[0].VReplicas = 2
+	updated.Status.SinkURI = apis.HTTP("new-sink")
+	updated.Spec.CloudEventOverrides = &duckv1.CloudEventOverrides{Extensions: map[string]string{"ext": "value"}}
+	updated.Status.DeadLetterSinkURI = apis.HTTP("new-dls")
+	if err := mtadapter.Update(ctx, updated); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if mtadapter.sources["test-ns/test-name"].adapter != running {
+		t.Fatal("Expected adapter not to be restarted")
+	}
+	running.mu.Lock()
+	if running.limit != 20 || running.burst != 40 {
+		t.Errorf("Expected rate limits 20, 40, got %v, %v", running.limit, running.burst)
+	}
+	if running.sink != "http://new-sink" {
+		t.Errorf("Expected sink http://new-sink, got %s", running.sink)
+	}
+	if running.ceOverrides.Extensions["ext"] != "value" {
+		t.Errorf("Expected CloudEvent overrides to be updated, got %v", running.ceOverrides)
+	}
+	if running.config == nil || running.config.DeadLetterSink != "http://new-dls" {
+		t.Errorf("Expected dead letter sink http://new-dls, got %v", running.config)
+	}
+	running.mu.Unlock()
+	if mtadapter.sources["test-ns/test-name"].config.DeadLetterSink != "http://new-dls" {
+		t.Error("Expected the configuration of the running adapter to be updated")
+	}
+
+	// The adapter is restarted when the topics change
+	updated = updated.DeepCopy()
+	updated.Spec.Topics = []string{"topic2"}
+	if err := mtadapter.Update(ctx, updated); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if mtadapter.sources["test-ns/test-name"].adapter == running {
+		t.Fatal("Expected adapter to be restarted")
+	}
+}
+
+func TestEqualRestartConfig(t *testing.T) {
+	running := stadapter.AdapterConfig{
+		KafkaEnvConfig: client.KafkaEnvConfig{
+			KafkaConfigJson:  `{"SaramaYamlString": "Consumer:\n  Fetch:\n    Default: 1024"}`,
+			BootstrapServers: []string{"server1"},
+		},
+		Topics: []string{"topic1"},
+	}
+
+	updated := running
+	updated.KafkaConfigJson = `{"SaramaYamlString": "Consumer:\n  Fetch:\n    Default: 2048"}`
+	if !equalRestartConfig(running, updated) {
+		t.Error("Expected fetch size changes not to require a restart")
+	}
+
+	updated = running
+	updated.BootstrapServers = []string{"server2"}
+	if equalRestartConfig(running, updated) {
+		t.Error("Expected bootstrap servers changes to require a restart")
+	}
+
+	updated = running
+	updated.Net.SASL.Enable = true
+	if equalRestartConfig(running, updated) {
+		t.Error("Expected auth changes to require a restart")
+	}
+
+	updated = running
+	updated.Topics = []string{"topic2"}
+	if equalRestartConfig(running, updated) {
+		t.Error("Expected topics changes to require a restart")
+	}
+
+	updated = running
+	updated.BatchMaxSize = 10
+	if equalRestartConfig(running, updated) {
+		t.Error("Expected batching changes to require a restart")
+	}
+
+	updated = running
+	updated.Delivery = `{"retry":3}`
+	updated.DeadLetterSink = "http://dls"
+	updated.ReplySink = "http://reply"
+	updated.KeyType = "string"
+	updated.CloudEventAttributes = `{"type":{"value":"type"}}`
+	updated.SchemaRegistry.URL = "http://registry"
+	if !equalRestartConfig(running, updated) {
+		t.Error("Expected delivery changes not to require a restart")
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	testCases := map[string]struct {
+		mpsLimit  int
+		rateLimit *sourcesv1beta1.RateLimitSpec
+		wantLimit rate.Limit
+		wantBurst int
+	}{
+		"vreplica limit": {
+			mpsLimit:  10,
+			wantLimit: 20,
+			wantBurst: 40,
+		},
+		"source limit lower than the vreplica limit": {
+			mpsLimit:  10,
+			rateLimit: &sourcesv1beta1.RateLimitSpec{EventsPerSecond: 8, Burst: ptr.Int32(16)},
+			wantLimit: 4,
+			wantBurst: 8,
+		},
+		"source limit higher than the vreplica limit": {
+			mpsLimit:  10,
+			rateLimit: &sourcesv1beta1.RateLimitSpec{EventsPerSecond: 100, Burst: ptr.Int32(200)},
+			wantLimit: 20,
+			wantBurst: 40,
+		},
+		"source limit without vreplica limit": {
+			mpsLimit:  -1,
+			rateLimit: &sourcesv1beta1.RateLimitSpec{EventsPerSecond: 100},
+			wantLimit: 50,
+			wantBurst: 50,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			obj := &sourcesv1beta1.KafkaSource{
+				Spec: sourcesv1beta1.KafkaSourceSpec{RateLimit: tc.rateLimit},
+				Status: sourcesv1beta1.KafkaSourceStatus{
+					Placeable: duckv1alpha1.Placeable{
+						Placements: []duckv1alpha1.Placement{
+							{PodName: podName, VReplicas: 2},
+							{PodName: "other-podname", VReplicas: 2},
+						},
+					},
+				},
+			}
+
+			limit, burst := rateLimits(tc.mpsLimit, obj, 2)
+			if limit != tc.wantLimit || burst != tc.wantBurst {
+				t.Errorf("rateLimits() = %v, %v, want %v, %v", limit, burst, tc.wantLimit, tc.wantBurst)
+			}
+		})
+	}
+}
+
+func TestResourceUsage(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	env := &AdapterConfig{PodName: podName, MemoryLimit: "1Mi"}
+	mtadapter := newAdapter(ctx, env, adaptertest.NewTestClient(), newSampleAdapter).(*Adapter)
+
+	claims := kafkasourcecontrol.Claims{"topic1": {0, 1, 2}, "topic2": {0}}
+	claimsTime := time.Now()
+	mtadapter.sources["test-ns/source1"] = cancelContext{
+		fetchSize: 1024,
+		adapter:   &sampleAdapter{claims: claims, claimsTime: claimsTime},
+		placement: duckv1alpha1.Placement{PodName: podName, VReplicas: 2},
+	}
+	mtadapter.sources["test-ns/source2"] = cancelContext{
+		adapter:   &sampleAdapter{},
+		placement: duckv1alpha1.Placement{PodName: podName, VReplicas: 1},
+	}
+
+	usage := mtadapter.resourceUsage()
+
+	want := kafkasourcecontrol.ResourceUsage{
+		PodName:             podName,
+		VReplicaMemoryLimit: 1024 * 1024,
+		Sources: map[string]kafkasourcecontrol.SourceResourceUsage{
+			"test-ns/source1": {VReplicas: 2, Partitions: 4, Claims: claims, ClaimsTime: claimsTime, FetchSize: 1024, Memory: 8 * 1024},
+			"test-ns/source2": {VReplicas: 1},
+		},
+	}
+	if !reflect.DeepEqual(want, usage) {
+		t.Errorf("Expected resource usage %v, got %v", want, usage)
+	}
+	if over := usage.OverBudgetSources(); len(over) != 0 {
+		t.Errorf("Expected resource usage to be within budget, got %v", over)
+	}
+}
+
+func TestReportResourceUsage(t *testing.T) {
+	original := resourceEvaluationPeriod
+	resourceEvaluationPeriod = 10 * time.Millisecond
+	defer func() { resourceEvaluationPeriod = original }()
+
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	env := &AdapterConfig{PodName: podName, MemoryLimit: "0"}
+	mtadapter := newAdapter(ctx, env, adaptertest.NewTestClient(), newSampleAdapter).(*Adapter)
+
+	reported := make(chan kafkasourcecontrol.ResourceUsage, 10)
+	server := getMockServerHandler()
+	server.On("SendAndWaitForAck", kafkasourcecontrol.NotifyResourceUsageOpCode, mock.Anything).
+		Run(func(args mock.Arguments) {
+			reported <- args.Get(1).(kafkasourcecontrol.ResourceUsage)
+		}).
+		Return(nil)
+
+	go mtadapter.reportResourceUsage(ctx, server)
+
+	select {
+	case usage := <-reported:
+		if usage.PodName != podName {
+			t.Errorf("Expected pod name %s, got %s", podName, usage.PodName)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the resource usage to be reported")
+	}
+}
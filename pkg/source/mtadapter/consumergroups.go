@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtadapter
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	ctrl "knative.dev/control-protocol/pkg"
+	"knative.dev/control-protocol/pkg/message"
+	ctrlservice "knative.dev/control-protocol/pkg/service"
+
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol/commands"
+)
+
+// consumerGroupRouter dispatches the consumer group commands received by the control-protocol
+// server of the mt adapter to the st adapter consuming with the requested group.
+type consumerGroupRouter struct {
+	logger   *zap.SugaredLogger
+	lock     sync.RWMutex
+	handlers map[string]map[ctrl.OpCode]controlprotocol.AsyncHandlerFunc
+	server   controlprotocol.ServerHandler
+}
+
+func newConsumerGroupRouter(logger *zap.SugaredLogger) *consumerGroupRouter {
+	return &consumerGroupRouter{
+		logger:   logger,
+		handlers: make(map[string]map[ctrl.OpCode]controlprotocol.AsyncHandlerFunc),
+	}
+}
+
+// register adds the stop and start consumer group handlers to the given server.
+func (r *consumerGroupRouter) register(server controlprotocol.ServerHandler) {
+	r.lock.Lock()
+	r.server = server
+	r.lock.Unlock()
+
+	server.AddAsyncHandler(
+		commands.StopConsumerGroupOpCode,
+		commands.StopConsumerGroupResultOpCode,
+		&commands.ConsumerGroupAsyncCommand{},
+		r.route(commands.StopConsumerGroupOpCode))
+	server.AddAsyncHandler(
+		commands.StartConsumerGroupOpCode,
+		commands.StartConsumerGroupResultOpCode,
+		&commands.ConsumerGroupAsyncCommand{},
+		r.route(commands.StartConsumerGroupOpCode))
+}
+
+// route returns a handler forwarding the command to the handler registered for its group.
+// Commands for groups not consumed by this pod are acknowledged as there is nothing to do.
+func (r *consumerGroupRouter) route(opcode ctrl.OpCode) controlprotocol.AsyncHandlerFunc {
+	return func(ctx context.Context, commandMessage ctrlservice.AsyncCommandMessage) {
+		cmd, ok := commandMessage.ParsedCommand().(*commands.ConsumerGroupAsyncCommand)
+		if !ok {
+			return
+		}
+
+		r.lock.RLock()
+		handler := r.handlers[cmd.GroupId][opcode]
+		r.lock.RUnlock()
+
+		if handler == nil {
+			r.logger.Debugw("consumer group not handled by this pod", zap.String("groupId", cmd.GroupId))
+			commandMessage.NotifySuccess()
+			return
+		}
+		handler(ctx, commandMessage)
+	}
+}
+
+// forGroup returns the ServerHandler given to the st adapter consuming with the given group.
+func (r *consumerGroupRouter) forGroup(groupId string) controlprotocol.ServerHandler {
+	return &groupServerHandler{router: r, groupId: groupId}
+}
+
+// remove deletes all the handlers registered for the given group.
+func (r *consumerGroupRouter) remove(groupId string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.handlers, groupId)
+}
+
+// groupServerHandler is the ServerHandler of a single consumer group, recording
+// the async handlers into the consumerGroupRouter.
+type groupServerHandler struct {
+	router  *consumerGroupRouter
+	groupId string
+}
+
+var _ controlprotocol.ServerHandler = (*groupServerHandler)(nil)
+
+func (h *groupServerHandler) Shutdown(time.Duration) {
+	h.router.remove(h.groupId)
+}
+
+func (h *groupServerHandler) AddAsyncHandler(opcode ctrl.OpCode, _ ctrl.OpCode, _ message.AsyncCommand, handler controlprotocol.AsyncHandlerFunc) {
+	h.router.lock.Lock()
+	defer h.router.lock.Unlock()
+	if _, ok := h.router.handlers[h.groupId]; !ok {
+		h.router.handlers[h.groupId] = make(map[ctrl.OpCode]controlprotocol.AsyncHandlerFunc)
+	}
+	h.router.handlers[h.groupId][opcode] = handler
+}
+
+func (h *groupServerHandler) AddSyncHandler(opcode ctrl.OpCode, _ ctrl.MessageHandlerFunc) {
+	h.router.logger.Warnw("sync handlers are not supported", zap.Any("opcode", opcode))
+}
+
+func (h *groupServerHandler) RemoveHandler(opcode ctrl.OpCode) {
+	h.router.lock.Lock()
+	defer h.router.lock.Unlock()
+	delete(h.router.handlers[h.groupId], opcode)
+}
+
+func (h *groupServerHandler) SendAndWaitForAck(opcode ctrl.OpCode, payload encoding.BinaryMarshaler) error {
+	h.router.lock.RLock()
+	server := h.router.server
+	h.router.lock.RUnlock()
+
+	if server == nil {
+		return errors.New("the control-protocol server is not started")
+	}
+	return server.SendAndWaitForAck(opcode, payload)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlmessage "knative.dev/control-protocol/pkg/message"
+	ctrlservice "knative.dev/control-protocol/pkg/service"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol/commands"
+	controltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+)
+
+func TestConsumerGroupRouter(t *testing.T) {
+	testCases := map[string]struct {
+		opcode        ctrl.OpCode
+		groupId       string
+		removeGroup   bool
+		expectHandled bool
+	}{
+		"stop handled group": {
+			opcode:        commands.StopConsumerGroupOpCode,
+			groupId:       "group-a",
+			expectHandled: true,
+		},
+		"start handled group": {
+			opcode:        commands.StartConsumerGroupOpCode,
+			groupId:       "group-a",
+			expectHandled: true,
+		},
+		"stop unknown group": {
+			opcode:  commands.StopConsumerGroupOpCode,
+			groupId: "group-b",
+		},
+		"stop removed group": {
+			opcode:      commands.StopConsumerGroupOpCode,
+			groupId:     "group-a",
+			removeGroup: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			server := getMockServerHandler()
+			router := newConsumerGroupRouter(logtesting.TestLogger(t))
+			router.register(server)
+
+			// Register the handlers of the source consuming with group-a
+			handled := make(map[ctrl.OpCode]bool)
+			groupServer := router.forGroup("group-a")
+			for _, opcode := range []ctrl.OpCode{commands.StopConsumerGroupOpCode, commands.StartConsumerGroupOpCode} {
+				opcode := opcode
+				groupServer.AddAsyncHandler(opcode, opcode, &commands.ConsumerGroupAsyncCommand{}, func(ctx context.Context, commandMessage ctrlservice.AsyncCommandMessage) {
+					handled[opcode] = true
+					commandMessage.NotifySuccess()
+				})
+			}
+			if tc.removeGroup {
+				groupServer.Shutdown(0)
+			}
+
+			command := commands.ConsumerGroupAsyncCommand{
+				Version:   commands.ConsumerGroupAsyncCommandVersion,
+				CommandId: 1,
+				GroupId:   tc.groupId,
+			}
+			payload, err := command.MarshalBinary()
+			assert.Nil(t, err)
+
+			msg := ctrl.NewMessage([16]byte{1, 2, 3, 4, 1, 2, 3, 4, 1, 2, 3, 4, 1, 2, 3, 4}, uint8(tc.opcode), payload)
+			server.Router[tc.opcode].HandleServiceMessage(context.Background(), ctrl.NewServiceMessage(&msg, func(err error) {
+				assert.Nil(t, err)
+			}))
+
+			assert.Equal(t, tc.expectHandled, handled[tc.opcode])
+			server.AssertExpectations(t)
+			server.Service.AssertCalled(t, "SendAndWaitForAck", mock.Anything, mock.MatchedBy(func(result ctrlmessage.AsyncCommandResult) bool {
+				return !result.IsFailed()
+			}))
+		})
+	}
+}
+
+func TestGroupServerHandlerSendAndWaitForAck(t *testing.T) {
+	router := newConsumerGroupRouter(logtesting.TestLogger(t))
+	groupServer := router.forGroup("group-a")
+
+	// Nothing to send to until the control-protocol server is started
+	assert.NotNil(t, groupServer.SendAndWaitForAck(ctrl.OpCode(1), nil))
+
+	server := getMockServerHandler()
+	server.On("SendAndWaitForAck", ctrl.OpCode(1), nil).Return(nil)
+	router.register(server)
+
+	assert.Nil(t, groupServer.SendAndWaitForAck(ctrl.OpCode(1), nil))
+	server.AssertExpectations(t)
+}
+
+func getMockServerHandler() *controltesting.MockServerHandler {
+	server := controltesting.GetMockServerHandler()
+	server.On("AddAsyncHandler", commands.StopConsumerGroupOpCode, commands.StopConsumerGroupResultOpCode, mock.Anything, mock.Anything).Return()
+	server.On("AddAsyncHandler", commands.StartConsumerGroupOpCode, commands.StartConsumerGroupResultOpCode, mock.Anything, mock.Anything).Return()
+	server.Service.On("SendAndWaitForAck", mock.Anything, mock.Anything).Return(nil)
+	return server
+}
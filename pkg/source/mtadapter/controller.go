@@ -27,7 +27,9 @@ import (
 
 	"knative.dev/eventing/pkg/adapter/v2"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkaclusterinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
 	kakfasourceinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource"
 	kafkasourcereconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
 )
@@ -88,5 +90,10 @@ func NewController(ctx context.Context, adapter adapter.Adapter) *controller.Imp
 			},
 			DeleteFunc: r.deleteFunc,
 		})
+
+	r.clusterTracker = impl.Tracker
+	kafkaclusterinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster")),
+	))
 	return impl
 }
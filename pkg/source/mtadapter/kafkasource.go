@@ -22,15 +22,20 @@ import (
 	"go.uber.org/zap"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
 
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	kafkasourcereconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 )
 
 // Reconciler updates the internal Adapter cache kafkaSources
 type Reconciler struct {
 	mtadapter MTAdapter
 	logger    *zap.SugaredLogger
+
+	// clusterTracker updates the adapters of the sources when the KafkaCluster they reference changes
+	clusterTracker tracker.Interface
 }
 
 // Check that our Reconciler implements ReconcileKind.
@@ -42,6 +47,12 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1beta1.KafkaSou
 		return nil
 	}
 
+	if source.Spec.ClusterRef != nil {
+		if err := r.clusterTracker.TrackReference(kafkacluster.TrackerReference(source.Spec.ClusterRef, source.Namespace), source); err != nil {
+			return err
+		}
+	}
+
 	// Update the adapter state
 	return r.mtadapter.Update(ctx, source)
 }
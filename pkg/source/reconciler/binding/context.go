@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/webhook/psbinding"
+
+	"knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	bindinglisters "knative.dev/eventing-kafka/pkg/client/listers/bindings/v1beta1"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
+)
+
+// NewBindableContext returns the BindableContext noting on the context the bootstrap servers and
+// authentication of the KafkaCluster referenced by a KafkaBinding, if any.
+func NewBindableContext(lister kafkalisters.KafkaClusterLister) psbinding.BindableContext {
+	return func(ctx context.Context, b psbinding.Bindable) (context.Context, error) {
+		kfb, ok := b.(*v1beta1.KafkaBinding)
+		// Undoing a binding doesn't depend on its cluster, which may have been deleted first
+		if !ok || kfb.Spec.ClusterRef == nil || kfb.GetDeletionTimestamp() != nil {
+			return ctx, nil
+		}
+		cluster, err := kafkacluster.Get(lister, kfb.Spec.ClusterRef, kfb.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		auth := cluster.Spec.KafkaAuthSpec()
+		return v1beta1.WithKafkaAuth(ctx, &auth), nil
+	}
+}
+
+// enqueueBindingsOfCluster returns a handler enqueuing the KafkaBindings referencing a KafkaCluster,
+// which are in the namespace of the cluster.
+func enqueueBindingsOfCluster(lister bindinglisters.KafkaBindingLister, enqueue func(types.NamespacedName)) func(obj interface{}) {
+	return func(obj interface{}) {
+		cluster, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			return
+		}
+		kfbs, err := lister.KafkaBindings(cluster.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			return
+		}
+		for _, kfb := range kfbs {
+			if ref := kfb.Spec.ClusterRef; ref != nil && ref.Name == cluster.GetName() {
+				enqueue(types.NamespacedName{Namespace: kfb.Namespace, Name: kfb.Name})
+			}
+		}
+	}
+}
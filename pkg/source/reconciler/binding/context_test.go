@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	bindinglisters "knative.dev/eventing-kafka/pkg/client/listers/bindings/v1beta1"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+)
+
+const (
+	testNamespace   = "test-namespace"
+	testClusterName = "test-cluster"
+)
+
+func newTestBinding(name string, ref *v1beta1.KafkaClusterReference) *v1beta1.KafkaBinding {
+	return &v1beta1.KafkaBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+		Spec: v1beta1.KafkaBindingSpec{
+			KafkaAuthSpec: v1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"binding:9092"},
+				ClusterRef:       ref,
+			},
+		},
+	}
+}
+
+func newTestClusterLister(t *testing.T) kafkalisters.KafkaClusterLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(&v1alpha1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testClusterName},
+		Spec: v1alpha1.KafkaClusterSpec{
+			BootstrapServers: []string{"kafka-0:9092", "kafka-1:9092"},
+		},
+	}))
+	return kafkalisters.NewKafkaClusterLister(indexer)
+}
+
+// boundBootstrapServers returns the bootstrap servers the binding applies to a pod with the context
+func boundBootstrapServers(ctx context.Context, kfb *v1beta1.KafkaBinding) string {
+	ps := &duckv1.WithPod{}
+	ps.Spec.Template.Spec.Containers = []corev1.Container{{Name: "test"}}
+	kfb.Do(ctx, ps)
+	for _, env := range ps.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "KAFKA_BOOTSTRAP_SERVERS" {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+func TestNewBindableContext(t *testing.T) {
+	withContext := NewBindableContext(newTestClusterLister(t))
+
+	kfb := newTestBinding("no-cluster", nil)
+	ctx, err := withContext(context.Background(), kfb)
+	assert.Nil(t, err)
+	assert.Equal(t, "binding:9092", boundBootstrapServers(ctx, kfb))
+
+	kfb = newTestBinding("cluster", &v1beta1.KafkaClusterReference{Name: testClusterName})
+	ctx, err = withContext(context.Background(), kfb)
+	assert.Nil(t, err)
+	assert.Equal(t, "kafka-0:9092,kafka-1:9092", boundBootstrapServers(ctx, kfb))
+
+	kfb = newTestBinding("missing-cluster", &v1beta1.KafkaClusterReference{Name: "missing"})
+	_, err = withContext(context.Background(), kfb)
+	assert.NotNil(t, err)
+
+	// Deleted bindings are undone without resolving their cluster
+	now := metav1.Now()
+	kfb.DeletionTimestamp = &now
+	_, err = withContext(context.Background(), kfb)
+	assert.Nil(t, err)
+}
+
+func TestEnqueueBindingsOfCluster(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(newTestBinding("no-cluster", nil)))
+	assert.Nil(t, indexer.Add(newTestBinding("cluster", &v1beta1.KafkaClusterReference{Name: testClusterName})))
+	assert.Nil(t, indexer.Add(newTestBinding("other-cluster", &v1beta1.KafkaClusterReference{Name: "other"})))
+
+	var enqueued []types.NamespacedName
+	handler := enqueueBindingsOfCluster(bindinglisters.NewKafkaBindingLister(indexer), func(key types.NamespacedName) {
+		enqueued = append(enqueued, key)
+	})
+
+	handler(&v1alpha1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testClusterName}})
+	assert.Equal(t, []types.NamespacedName{{Namespace: testNamespace, Name: "cluster"}}, enqueued)
+
+	// The tombstones of the deleted clusters are handled too
+	enqueued = nil
+	handler(cache.DeletedFinalStateUnknown{Obj: &v1alpha1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testClusterName}}})
+	assert.Equal(t, []types.NamespacedName{{Namespace: testNamespace, Name: "cluster"}}, enqueued)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	kfkinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/bindings/v1beta1/kafkabinding"
+	kafkaclusterinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
 	"knative.dev/pkg/client/injection/ducks/duck/v1/podspecable"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	"knative.dev/pkg/reconciler"
@@ -51,6 +52,7 @@ func NewController(
 	logger := logging.FromContext(ctx)
 
 	kfkInformer := kfkinformer.Get(ctx)
+	kafkaClusterInformer := kafkaclusterinformer.Get(ctx)
 	dc := dynamicclient.Get(ctx)
 	psInformerFactory := podspecable.Get(ctx)
 	namespaceInformer := namespace.Get(ctx)
@@ -79,6 +81,7 @@ func NewController(
 		Recorder: record.NewBroadcaster().NewRecorder(
 			scheme.Scheme, corev1.EventSource{Component: controllerAgentName}),
 		NamespaceLister: namespaceInformer.Lister(),
+		WithContext:     NewBindableContext(kafkaClusterInformer.Lister()),
 	}
 	impl := controller.NewContext(ctx, c, controller.ControllerOptions{
 		Logger:        logger,
@@ -87,6 +90,9 @@ func NewController(
 
 	kfkInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 	namespaceInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+	kafkaClusterInformer.Informer().AddEventHandler(controller.HandleAll(
+		enqueueBindingsOfCluster(kfkInformer.Lister(), impl.EnqueueKey),
+	))
 
 	c.Tracker = impl.Tracker
 	c.Factory = &duck.CachedInformerFactory{
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/config"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+// AutoscalingOptions are the options of the built-in autoscaler, set by the annotations of a KafkaSource
+type AutoscalingOptions struct {
+	MinScale        int32
+	MaxScale        int32
+	PollingInterval time.Duration
+	CooldownPeriod  time.Duration
+	LagThreshold    int64
+}
+
+// GetAutoscalingOptions returns the options of the built-in autoscaler, or false if the KafkaSource
+// doesn't use it.  The annotations which are missing or invalid take the default values.
+func GetAutoscalingOptions(src *v1beta1.KafkaSource) (AutoscalingOptions, bool) {
+	annotations := src.GetAnnotations()
+	if annotations[v1beta1.AutoscalingClassAnnotation] != config.KafkaAutoscalingClass {
+		return AutoscalingOptions{}, false
+	}
+
+	return AutoscalingOptions{
+		MinScale:        int32(parseAnnotation(annotations, v1beta1.AutoscalingMinScaleAnnotation, config.DefaultMinScaleValue)),
+		MaxScale:        int32(parseAnnotation(annotations, v1beta1.AutoscalingMaxScaleAnnotation, config.DefaultMaxScaleValue)),
+		PollingInterval: time.Duration(parseAnnotation(annotations, v1beta1.AutoscalingPollingIntervalAnnotation, config.DefaultPollingIntervalValue)) * time.Second,
+		CooldownPeriod:  time.Duration(parseAnnotation(annotations, v1beta1.AutoscalingCooldownPeriodAnnotation, config.DefaultCooldownPeriodValue)) * time.Second,
+		LagThreshold:    parseAnnotation(annotations, v1beta1.AutoscalingLagThresholdAnnotation, config.DefaultKafkaLagThresholdValue),
+	}, true
+}
+
+func parseAnnotation(annotations map[string]string, key string, defaultValue int64) int64 {
+	value, err := strconv.ParseInt(annotations[key], 10, 32)
+	if err != nil || value < 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// ReconcileAutoscaling evaluates the lag of the consumer group of a KafkaSource using the built-in
+// autoscaler and records the chosen scale in its status.  It returns the interval after which the
+// scale must be evaluated again, or 0 if the KafkaSource doesn't use the built-in autoscaler.
+func ReconcileAutoscaling(ctx context.Context, src *v1beta1.KafkaSource, lags []offset.PartitionLag) time.Duration {
+	options, ok := GetAutoscalingOptions(src)
+	if !ok {
+		src.Status.Autoscaling = nil
+		return 0
+	}
+
+	lag, partitions := offset.TotalLag(lags), int32(len(lags))
+	status := autoscale(src, options, lag, partitions, time.Now())
+	if src.Status.Autoscaling == nil || status.Scale != src.Status.Autoscaling.Scale {
+		logging.FromContext(ctx).Infow("scaling the KafkaSource",
+			zap.Int32("scale", status.Scale), zap.Int64("lag", lag), zap.Int32("partitions", partitions))
+	}
+	src.Status.Autoscaling = status
+	return options.PollingInterval
+}
+
+// autoscale returns the scale handling the lag at the threshold of each replica, between the
+// minimum and maximum scales and capped at the number of partitions.  The scale goes up as soon
+// as the lag grows, but only goes down once the cooldown period since the last scaling elapsed.
+func autoscale(src *v1beta1.KafkaSource, options AutoscalingOptions, lag int64, partitions int32, now time.Time) *v1beta1.KafkaSourceAutoscalingStatus {
+	maxScale := options.MaxScale
+	if partitions < maxScale {
+		maxScale = partitions
+	}
+
+	scale := int64(options.MinScale)
+	if options.LagThreshold > 0 {
+		if byLag := (lag + options.LagThreshold - 1) / options.LagThreshold; byLag > scale {
+			scale = byLag
+		}
+	}
+	if scale > int64(maxScale) {
+		scale = int64(maxScale)
+	}
+	desired := int32(scale)
+
+	status := &v1beta1.KafkaSourceAutoscalingStatus{Lag: lag, Partitions: partitions}
+	current := src.Status.Autoscaling
+	if current == nil {
+		status.Scale = desired
+		status.LastScaleTime = &metav1.Time{Time: now}
+		return status
+	}
+
+	status.Scale = current.Scale
+	status.LastScaleTime = current.LastScaleTime
+	switch {
+	case desired > current.Scale,
+		// The maximum scale or the number of partitions decreased
+		current.Scale > maxScale,
+		// Scale down after the cooldown period
+		desired < current.Scale && (current.LastScaleTime == nil || now.Sub(current.LastScaleTime.Time) >= options.CooldownPeriod):
+		status.Scale = desired
+		status.LastScaleTime = &metav1.Time{Time: now}
+	}
+	return status
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestGetAutoscalingOptions(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		want        AutoscalingOptions
+		enabled     bool
+	}{
+		"no annotations": {},
+		"keda class": {
+			annotations: map[string]string{v1beta1.AutoscalingClassAnnotation: "keda.autoscaling.knative.dev"},
+		},
+		"built-in class": {
+			annotations: map[string]string{
+				v1beta1.AutoscalingClassAnnotation:           "kafka.autoscaling.knative.dev",
+				v1beta1.AutoscalingMinScaleAnnotation:        "2",
+				v1beta1.AutoscalingMaxScaleAnnotation:        "8",
+				v1beta1.AutoscalingPollingIntervalAnnotation: "10",
+				v1beta1.AutoscalingCooldownPeriodAnnotation:  "60",
+				v1beta1.AutoscalingLagThresholdAnnotation:    "100",
+			},
+			want: AutoscalingOptions{
+				MinScale:        2,
+				MaxScale:        8,
+				PollingInterval: 10 * time.Second,
+				CooldownPeriod:  time.Minute,
+				LagThreshold:    100,
+			},
+			enabled: true,
+		},
+		"built-in class, invalid annotations": {
+			annotations: map[string]string{
+				v1beta1.AutoscalingClassAnnotation:    "kafka.autoscaling.knative.dev",
+				v1beta1.AutoscalingMaxScaleAnnotation: "-3",
+				v1beta1.AutoscalingMinScaleAnnotation: "one",
+			},
+			want: AutoscalingOptions{
+				MinScale:        1,
+				MaxScale:        1,
+				PollingInterval: 30 * time.Second,
+				CooldownPeriod:  5 * time.Minute,
+				LagThreshold:    10,
+			},
+			enabled: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			options, enabled := GetAutoscalingOptions(src)
+			assert.Equal(t, tc.enabled, enabled)
+			assert.Equal(t, tc.want, options)
+		})
+	}
+}
+
+func TestAutoscale(t *testing.T) {
+	now := time.Now()
+	recently := &metav1.Time{Time: now.Add(-time.Minute)}
+	longAgo := &metav1.Time{Time: now.Add(-time.Hour)}
+	options := AutoscalingOptions{
+		MinScale:       1,
+		MaxScale:       10,
+		CooldownPeriod: 5 * time.Minute,
+		LagThreshold:   100,
+	}
+
+	testCases := map[string]struct {
+		options    AutoscalingOptions
+		current    *v1beta1.KafkaSourceAutoscalingStatus
+		lag        int64
+		partitions int32
+		want       *v1beta1.KafkaSourceAutoscalingStatus
+	}{
+		"first evaluation": {
+			options:    options,
+			lag:        250,
+			partitions: 20,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 3, Lag: 250, Partitions: 20, LastScaleTime: &metav1.Time{Time: now}},
+		},
+		"no lag": {
+			options:    options,
+			lag:        0,
+			partitions: 20,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 1, Lag: 0, Partitions: 20, LastScaleTime: &metav1.Time{Time: now}},
+		},
+		"scale to zero": {
+			options:    AutoscalingOptions{MinScale: 0, MaxScale: 10, LagThreshold: 100},
+			lag:        0,
+			partitions: 20,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 0, Lag: 0, Partitions: 20, LastScaleTime: &metav1.Time{Time: now}},
+		},
+		"capped at max scale": {
+			options:    options,
+			lag:        5000,
+			partitions: 20,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 10, Lag: 5000, Partitions: 20, LastScaleTime: &metav1.Time{Time: now}},
+		},
+		"capped at partitions": {
+			options:    options,
+			lag:        5000,
+			partitions: 4,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 4, Lag: 5000, Partitions: 4, LastScaleTime: &metav1.Time{Time: now}},
+		},
+		"scale up during cooldown": {
+			options:    options,
+			current:    &v1beta1.KafkaSourceAutoscalingStatus{Scale: 2, LastScaleTime: recently},
+			lag:        450,
+			partitions: 20,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 5, Lag: 450, Partitions: 20, LastScaleTime: &metav1.Time{Time: now}},
+		},
+		"no scale down during cooldown": {
+			options:    options,
+			current:    &v1beta1.KafkaSourceAutoscalingStatus{Scale: 5, LastScaleTime: recently},
+			lag:        50,
+			partitions: 20,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 5, Lag: 50, Partitions: 20, LastScaleTime: recently},
+		},
+		"scale down after cooldown": {
+			options:    options,
+			current:    &v1beta1.KafkaSourceAutoscalingStatus{Scale: 5, LastScaleTime: longAgo},
+			lag:        50,
+			partitions: 20,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 1, Lag: 50, Partitions: 20, LastScaleTime: &metav1.Time{Time: now}},
+		},
+		"partitions decreased during cooldown": {
+			options:    options,
+			current:    &v1beta1.KafkaSourceAutoscalingStatus{Scale: 8, LastScaleTime: recently},
+			lag:        5000,
+			partitions: 6,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 6, Lag: 5000, Partitions: 6, LastScaleTime: &metav1.Time{Time: now}},
+		},
+		"unchanged": {
+			options:    options,
+			current:    &v1beta1.KafkaSourceAutoscalingStatus{Scale: 3, LastScaleTime: longAgo},
+			lag:        300,
+			partitions: 20,
+			want:       &v1beta1.KafkaSourceAutoscalingStatus{Scale: 3, Lag: 300, Partitions: 20, LastScaleTime: longAgo},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{Status: v1beta1.KafkaSourceStatus{Autoscaling: tc.current}}
+			assert.Equal(t, tc.want, autoscale(src, tc.options, tc.lag, tc.partitions, now))
+		})
+	}
+}
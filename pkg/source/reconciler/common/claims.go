@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+// PodClaims are the partitions claimed by the consumers of a receive adapter pod, and the last time they changed.
+type PodClaims struct {
+	Claims map[string][]int32
+	Time   time.Time
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// ConsumerClaimsStatus returns the partitions claimed by the consumers of each receive adapter pod, with the
+// committed offsets found in the consumer lag status of the source.  The pods, topics and partitions are sorted
+// so that the status only changes along with the claims and offsets.
+func ConsumerClaimsStatus(src *v1beta1.KafkaSource, pods map[string]PodClaims) []v1beta1.PodClaimsStatus {
+	if len(pods) == 0 {
+		return nil
+	}
+
+	offsets := committedOffsets(src)
+	status := make([]v1beta1.PodClaimsStatus, 0, len(pods))
+	for pod, claims := range pods {
+		podStatus := v1beta1.PodClaimsStatus{Pod: pod}
+		for topic, partitions := range claims.Claims {
+			for _, partition := range partitions {
+				committed, ok := offsets[topicPartition{topic: topic, partition: partition}]
+				if !ok {
+					committed = -1
+				}
+				podStatus.Partitions = append(podStatus.Partitions, v1beta1.PartitionClaimStatus{
+					Topic:           topic,
+					Partition:       partition,
+					CommittedOffset: committed,
+				})
+			}
+		}
+		sort.Slice(podStatus.Partitions, func(i, j int) bool {
+			if podStatus.Partitions[i].Topic != podStatus.Partitions[j].Topic {
+				return podStatus.Partitions[i].Topic < podStatus.Partitions[j].Topic
+			}
+			return podStatus.Partitions[i].Partition < podStatus.Partitions[j].Partition
+		})
+		if !claims.Time.IsZero() {
+			rebalanceTime := metav1.NewTime(claims.Time)
+			podStatus.LastRebalanceTime = &rebalanceTime
+		}
+		status = append(status, podStatus)
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Pod < status[j].Pod
+	})
+	return status
+}
+
+// UpdateConsumerClaimsOffsets sets the committed offsets of the partitions claimed by the consumers of a
+// KafkaSource to the ones found in its consumer lag status.
+func UpdateConsumerClaimsOffsets(src *v1beta1.KafkaSource) {
+	offsets := committedOffsets(src)
+	for i := range src.Status.ConsumerClaims {
+		partitions := src.Status.ConsumerClaims[i].Partitions
+		for j := range partitions {
+			committed, ok := offsets[topicPartition{topic: partitions[j].Topic, partition: partitions[j].Partition}]
+			if !ok {
+				committed = -1
+			}
+			partitions[j].CommittedOffset = committed
+		}
+	}
+}
+
+// committedOffsets returns the committed offsets found in the consumer lag status of the source.
+func committedOffsets(src *v1beta1.KafkaSource) map[topicPartition]int64 {
+	offsets := make(map[topicPartition]int64)
+	if src.Status.ConsumerLag != nil {
+		for _, lag := range src.Status.ConsumerLag.Partitions {
+			offsets[topicPartition{topic: lag.Topic, partition: lag.Partition}] = lag.Offset
+		}
+	}
+	return offsets
+}
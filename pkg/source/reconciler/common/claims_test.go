@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestConsumerClaimsStatus(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		Status: v1beta1.KafkaSourceStatus{
+			ConsumerLag: &v1beta1.ConsumerLagStatus{
+				Partitions: []v1beta1.PartitionLagStatus{
+					{Topic: "orders", Partition: 0, Offset: 10},
+					{Topic: "orders", Partition: 1, Offset: 20},
+				},
+			},
+		},
+	}
+	rebalanceTime := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	status := ConsumerClaimsStatus(src, map[string]PodClaims{
+		"pod-1": {Claims: map[string][]int32{"payments": {0}, "orders": {2, 1}}},
+		"pod-0": {Claims: map[string][]int32{"orders": {0}}, Time: rebalanceTime},
+	})
+
+	wantTime := metav1.NewTime(rebalanceTime)
+	assert.Equal(t, []v1beta1.PodClaimsStatus{
+		{
+			Pod:               "pod-0",
+			Partitions:        []v1beta1.PartitionClaimStatus{{Topic: "orders", Partition: 0, CommittedOffset: 10}},
+			LastRebalanceTime: &wantTime,
+		},
+		{
+			Pod: "pod-1",
+			Partitions: []v1beta1.PartitionClaimStatus{
+				{Topic: "orders", Partition: 1, CommittedOffset: 20},
+				{Topic: "orders", Partition: 2, CommittedOffset: -1},
+				{Topic: "payments", Partition: 0, CommittedOffset: -1},
+			},
+		},
+	}, status)
+
+	assert.Nil(t, ConsumerClaimsStatus(src, nil))
+}
+
+func TestUpdateConsumerClaimsOffsets(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		Status: v1beta1.KafkaSourceStatus{
+			ConsumerLag: &v1beta1.ConsumerLagStatus{
+				Partitions: []v1beta1.PartitionLagStatus{{Topic: "orders", Partition: 0, Offset: 15}},
+			},
+			ConsumerClaims: []v1beta1.PodClaimsStatus{{
+				Pod: "pod-0",
+				Partitions: []v1beta1.PartitionClaimStatus{
+					{Topic: "orders", Partition: 0, CommittedOffset: 10},
+					{Topic: "orders", Partition: 1, CommittedOffset: 20},
+				},
+			}},
+		},
+	}
+
+	UpdateConsumerClaimsOffsets(src)
+	assert.Equal(t, []v1beta1.PartitionClaimStatus{
+		{Topic: "orders", Partition: 0, CommittedOffset: 15},
+		{Topic: "orders", Partition: 1, CommittedOffset: -1},
+	}, src.Status.ConsumerClaims[0].Partitions)
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
+	"knative.dev/eventing-kafka/pkg/common/metrics"
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+const (
+	// ConsumerLagRefreshPeriod is the period after which the lag of the consumer group of a KafkaSource is computed again
+	ConsumerLagRefreshPeriod = 30 * time.Second
+
+	// consumerLagRefreshTick is the period after which the KafkaSources whose lag must be computed again are looked up
+	consumerLagRefreshTick = time.Second
+)
+
+// ReconcileConsumerLag computes the lag of the consumer group of a KafkaSource, reports it in its status and as
+// metrics, and evaluates its scale with the built-in autoscaler if enabled.  It returns the interval after which
+// the lag must be computed again.  The last lag and scale are kept when the lag cannot be retrieved.
+func ReconcileConsumerLag(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, src *v1beta1.KafkaSource) (time.Duration, error) {
+	lags, err := offset.GetConsumerGroupPartitionLags(kafkaClient, kafkaAdminClient, src.ConsumedTopics(), src.Spec.ConsumerGroup)
+	if err != nil {
+		return ConsumerLagRefreshPeriod, fmt.Errorf("unable to compute the consumer group lag: %w", err)
+	}
+
+	src.Status.ConsumerLag = consumerLagStatus(lags)
+	if err := metrics.ReportConsumerGroupLag(ctx, "KafkaSource", src.Namespace, src.Name, src.Spec.ConsumerGroup, lags); err != nil {
+		logging.FromContext(ctx).Warnw("unable to report the consumer group lag", zap.Error(err))
+	}
+
+	if interval := ReconcileAutoscaling(ctx, src, lags); interval > 0 && interval < ConsumerLagRefreshPeriod {
+		return interval, nil
+	}
+	return ConsumerLagRefreshPeriod, nil
+}
+
+// ConsumerLagRefresher computes the lag of the consumer group of the KafkaSources and evaluates their scale
+// with the built-in autoscaler, patching them in their status, without reconciling the KafkaSources.  The
+// Kafka clients of each KafkaSource are kept until its config changes.
+type ConsumerLagRefresher struct {
+	kubeClientSet      kubernetes.Interface
+	kafkaClientSet     versioned.Interface
+	kafkaLister        listers.KafkaSourceLister
+	kafkaClusterLister kafkalisters.KafkaClusterLister
+
+	// isLeaderFor returns whether the KafkaSource is reconciled by this controller
+	isLeaderFor func(key types.NamespacedName) bool
+	// enqueue enqueues the KafkaSource to reconcile it, when the topics matching its pattern changed
+	enqueue func(key types.NamespacedName)
+
+	clients *offset.ClientsCache
+	// nextRefresh is the time after which the lag of each KafkaSource is computed again
+	nextRefresh map[string]time.Time
+}
+
+// NewConsumerLagRefresher returns a refresher of the KafkaSources reconciled by the controller for which
+// isLeaderFor returns true, enqueuing them with enqueue when they must be reconciled again.
+func NewConsumerLagRefresher(
+	kubeClientSet kubernetes.Interface,
+	kafkaClientSet versioned.Interface,
+	kafkaLister listers.KafkaSourceLister,
+	kafkaClusterLister kafkalisters.KafkaClusterLister,
+	isLeaderFor func(key types.NamespacedName) bool,
+	enqueue func(key types.NamespacedName),
+) *ConsumerLagRefresher {
+	return &ConsumerLagRefresher{
+		kubeClientSet:      kubeClientSet,
+		kafkaClientSet:     kafkaClientSet,
+		kafkaLister:        kafkaLister,
+		kafkaClusterLister: kafkaClusterLister,
+		isLeaderFor:        isLeaderFor,
+		enqueue:            enqueue,
+		clients:            offset.NewClientsCache(nil),
+		nextRefresh:        make(map[string]time.Time),
+	}
+}
+
+// Run refreshes the lag of the KafkaSources when it's due until the context is done.
+func (r *ConsumerLagRefresher) Run(ctx context.Context) {
+	defer r.clients.Close()
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		r.refresh(ctx, time.Now())
+	}, consumerLagRefreshTick)
+}
+
+// refresh refreshes the lag of the KafkaSources whose offsets are committed and whose lag is due, and closes
+// the Kafka clients of the other KafkaSources.
+func (r *ConsumerLagRefresher) refresh(ctx context.Context, now time.Time) {
+	logger := logging.FromContext(ctx)
+
+	sources, err := r.kafkaLister.List(labels.Everything())
+	if err != nil {
+		logger.Errorw("Unable to list the KafkaSources to refresh their consumer lag", zap.Error(err))
+		return
+	}
+
+	refreshed := sets.NewString()
+	for _, src := range sources {
+		key := types.NamespacedName{Namespace: src.Namespace, Name: src.Name}
+		if src.DeletionTimestamp != nil || !r.isLeaderFor(key) ||
+			!src.Status.GetCondition(v1beta1.KafkaConditionInitialOffsetsCommitted).IsTrue() {
+			continue
+		}
+		refreshed.Insert(key.String())
+
+		if next, ok := r.nextRefresh[key.String()]; ok && now.Before(next) {
+			continue
+		}
+		interval, err := r.refreshSource(ctx, key, src)
+		if err != nil {
+			logger.Warnw("Unable to refresh the consumer lag", zap.String("source", key.String()), zap.Error(err))
+		}
+		r.nextRefresh[key.String()] = now.Add(interval)
+	}
+
+	for key := range r.nextRefresh {
+		if !refreshed.Has(key) {
+			delete(r.nextRefresh, key)
+		}
+	}
+	r.clients.Retain(refreshed)
+}
+
+// refreshSource computes the lag of the KafkaSource and patches its status when it changed, returning the
+// interval after which it must be computed again.  The Kafka clients of the KafkaSource are created again
+// by the next refresh when the lag can't be computed.
+func (r *ConsumerLagRefresher) refreshSource(ctx context.Context, key types.NamespacedName, src *v1beta1.KafkaSource) (time.Duration, error) {
+	configKey := strconv.FormatInt(src.Generation, 10)
+	if src.Spec.ClusterRef != nil {
+		cluster, err := kafkacluster.Get(r.kafkaClusterLister, src.Spec.ClusterRef, src.Namespace)
+		if err != nil {
+			return ConsumerLagRefreshPeriod, err
+		}
+		configKey += "/" + cluster.ResourceVersion
+	}
+	kafkaClient, kafkaAdminClient, err := r.clients.Get(key.String(), configKey, func() ([]string, *sarama.Config, error) {
+		return client.NewConfigFromSpec(ctx, r.kubeClientSet, r.kafkaClusterLister, src)
+	})
+	if err != nil {
+		return ConsumerLagRefreshPeriod, fmt.Errorf("failed to create the Kafka clients: %w", err)
+	}
+
+	updated := src.DeepCopy()
+	if err := ResolveTopics(kafkaClient, updated); err != nil {
+		r.clients.Invalidate(key.String())
+		return ConsumerLagRefreshPeriod, fmt.Errorf("unable to resolve the topics matching the topic pattern: %w", err)
+	}
+	if !equality.Semantic.DeepEqual(updated.Status.Topics, src.Status.Topics) {
+		// The offsets of the new topics are initialized by the reconciliation, which computes the lag again
+		r.enqueue(key)
+		return ConsumerLagRefreshPeriod, nil
+	}
+
+	interval, err := ReconcileConsumerLag(ctx, kafkaClient, kafkaAdminClient, updated)
+	if err != nil {
+		r.clients.Invalidate(key.String())
+		return interval, err
+	}
+	UpdateConsumerClaimsOffsets(updated)
+	if equality.Semantic.DeepEqual(updated.Status, src.Status) {
+		return interval, nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"consumerLag":    updated.Status.ConsumerLag,
+			"autoscaling":    updated.Status.Autoscaling,
+			"consumerClaims": updated.Status.ConsumerClaims,
+		},
+	})
+	if err == nil {
+		_, err = r.kafkaClientSet.SourcesV1beta1().KafkaSources(src.Namespace).
+			Patch(ctx, src.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	}
+	if err != nil {
+		return interval, fmt.Errorf("failed to patch the status of the KafkaSource: %w", err)
+	}
+	return interval, nil
+}
+
+// ConsumerLagUpdate returns whether the update of a KafkaSource only changes its consumer lag and the offsets
+// committed by its consumers, and not the scale chosen by the built-in autoscaler, which doesn't need the
+// KafkaSource to be reconciled.
+func ConsumerLagUpdate(oldObj, newObj interface{}) bool {
+	oldSource, ok := oldObj.(*v1beta1.KafkaSource)
+	if !ok {
+		return false
+	}
+	newSource, ok := newObj.(*v1beta1.KafkaSource)
+	if !ok {
+		return false
+	}
+
+	withoutLag := func(src *v1beta1.KafkaSource) *v1beta1.KafkaSource {
+		src = src.DeepCopy()
+		src.ResourceVersion = ""
+		src.ManagedFields = nil
+		src.Status.ConsumerLag = nil
+		for i := range src.Status.ConsumerClaims {
+			for j := range src.Status.ConsumerClaims[i].Partitions {
+				src.Status.ConsumerClaims[i].Partitions[j].CommittedOffset = 0
+			}
+		}
+		if src.Status.Autoscaling != nil {
+			src.Status.Autoscaling.Lag = 0
+			src.Status.Autoscaling.Partitions = 0
+		}
+		return src
+	}
+	return !equality.Semantic.DeepEqual(oldSource.Status, newSource.Status) &&
+		equality.Semantic.DeepEqual(withoutLag(oldSource), withoutLag(newSource))
+}
+
+func consumerLagStatus(lags []offset.PartitionLag) *v1beta1.ConsumerLagStatus {
+	status := &v1beta1.ConsumerLagStatus{Total: offset.TotalLag(lags)}
+	for _, lag := range lags {
+		status.Partitions = append(status.Partitions, v1beta1.PartitionLagStatus{
+			Topic:     lag.Topic,
+			Partition: lag.Partition,
+			Offset:    lag.Offset,
+			Lag:       lag.Lag,
+		})
+	}
+	return status
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	logtesting "knative.dev/pkg/logging/testing"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	fakekafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned/fake"
+	listers "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
+)
+
+func TestReconcileConsumerLag(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "my-group"
+	topic := "my-topic"
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()).
+			SetLeader(topic, 1, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+			SetOffset(topic, 0, -1, 50).
+			SetOffset(topic, 1, -1, 20),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).SetError(sarama.ErrNoError).
+			SetOffset(group, topic, 0, 10, "", sarama.ErrNoError).
+			SetOffset(group, topic, 1, 20, "", sarama.ErrNoError),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, group, broker),
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.MaxVersion
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	require.NoError(t, err)
+	defer client.Close()
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	require.NoError(t, err)
+	defer admin.Close()
+
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-source",
+			Namespace: "my-namespace",
+			Annotations: map[string]string{
+				v1beta1.AutoscalingClassAnnotation:           "kafka.autoscaling.knative.dev",
+				v1beta1.AutoscalingMaxScaleAnnotation:        "10",
+				v1beta1.AutoscalingPollingIntervalAnnotation: "10",
+			},
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics:        []string{topic},
+			ConsumerGroup: group,
+		},
+	}
+
+	interval, err := ReconcileConsumerLag(logtesting.TestContextWithLogger(t), client, admin, src)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, interval)
+	assert.Equal(t, &v1beta1.ConsumerLagStatus{
+		Total: 40,
+		Partitions: []v1beta1.PartitionLagStatus{
+			{Topic: topic, Partition: 0, Offset: 10, Lag: 40},
+			{Topic: topic, Partition: 1, Offset: 20, Lag: 0},
+		},
+	}, src.Status.ConsumerLag)
+	// The scale is capped at the number of partitions
+	require.NotNil(t, src.Status.Autoscaling)
+	assert.Equal(t, int32(2), src.Status.Autoscaling.Scale)
+
+	// Without the built-in autoscaler
+	src.Annotations = nil
+	interval, err = ReconcileConsumerLag(logtesting.TestContextWithLogger(t), client, admin, src)
+	require.NoError(t, err)
+	assert.Equal(t, ConsumerLagRefreshPeriod, interval)
+	assert.Nil(t, src.Status.Autoscaling)
+	assert.Equal(t, int64(40), src.Status.ConsumerLag.Total)
+}
+
+func TestConsumerLagRefresher(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "my-group"
+	topic := "my-topic"
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).SetVersion(1).
+			SetOffset(topic, 0, -1, 50),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).SetError(sarama.ErrNoError).
+			SetOffset(group, topic, 0, 10, "", sarama.ErrNoError),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, group, broker),
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+	})
+
+	newSource := func(name string, committed bool) *v1beta1.KafkaSource {
+		src := &v1beta1.KafkaSource{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-namespace"},
+			Spec: v1beta1.KafkaSourceSpec{
+				KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{BootstrapServers: []string{broker.Addr()}},
+				Topics:        []string{topic},
+				ConsumerGroup: group,
+			},
+		}
+		src.Status.InitializeConditions()
+		if committed {
+			src.Status.MarkInitialOffsetCommitted()
+			src.Status.ConsumerClaims = []v1beta1.PodClaimsStatus{{
+				Pod:        "pod-0",
+				Partitions: []v1beta1.PartitionClaimStatus{{Topic: topic, Partition: 0, CommittedOffset: 5}},
+			}}
+		}
+		return src
+	}
+	src := newSource("my-source", true)
+	pending := newSource("pending-source", false)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(src))
+	require.NoError(t, indexer.Add(pending))
+	kafkaClientSet := fakekafkaclientset.NewSimpleClientset(src, pending)
+
+	var enqueued []types.NamespacedName
+	refresher := NewConsumerLagRefresher(fakekubeclientset.NewSimpleClientset(), kafkaClientSet,
+		listers.NewKafkaSourceLister(indexer), nil,
+		func(types.NamespacedName) bool { return true },
+		func(key types.NamespacedName) { enqueued = append(enqueued, key) })
+	defer refresher.clients.Close()
+
+	ctx := logtesting.TestContextWithLogger(t)
+	now := time.Now()
+	refresher.refresh(ctx, now)
+
+	refreshed, err := kafkaClientSet.SourcesV1beta1().KafkaSources("my-namespace").Get(context.Background(), "my-source", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, &v1beta1.ConsumerLagStatus{
+		Total:      40,
+		Partitions: []v1beta1.PartitionLagStatus{{Topic: topic, Partition: 0, Offset: 10, Lag: 40}},
+	}, refreshed.Status.ConsumerLag)
+	// The offsets committed by the consumers follow the lag
+	assert.Equal(t, int64(10), refreshed.Status.ConsumerClaims[0].Partitions[0].CommittedOffset)
+	assert.True(t, refreshed.Status.GetCondition(v1beta1.KafkaConditionInitialOffsetsCommitted).IsTrue())
+	assert.Empty(t, enqueued)
+
+	// The lag of the sources whose offsets aren't committed isn't computed
+	refreshedPending, err := kafkaClientSet.SourcesV1beta1().KafkaSources("my-namespace").Get(context.Background(), "pending-source", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, refreshedPending.Status.ConsumerLag)
+
+	// The lag isn't computed again before the refresh period, nor patched again while it is unchanged
+	require.NoError(t, indexer.Update(refreshed))
+	kafkaClientSet.ClearActions()
+	refresher.refresh(ctx, now.Add(time.Second))
+	assert.Empty(t, kafkaClientSet.Actions())
+	refresher.refresh(ctx, now.Add(ConsumerLagRefreshPeriod))
+	assert.Empty(t, kafkaClientSet.Actions())
+
+	// The source is reconciled when the topics matching its pattern change
+	withPattern := refreshed.DeepCopy()
+	withPattern.Spec.Topics = nil
+	withPattern.Spec.TopicPattern = "my-.*"
+	require.NoError(t, indexer.Update(withPattern))
+	refresher.refresh(ctx, now.Add(2*ConsumerLagRefreshPeriod))
+	assert.Equal(t, []types.NamespacedName{{Namespace: "my-namespace", Name: "my-source"}}, enqueued)
+	assert.Empty(t, kafkaClientSet.Actions())
+}
+
+func TestConsumerLagUpdate(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-source", Namespace: "my-namespace", ResourceVersion: "1"},
+		Status: v1beta1.KafkaSourceStatus{
+			Autoscaling: &v1beta1.KafkaSourceAutoscalingStatus{Scale: 1, Lag: 10, Partitions: 2},
+			ConsumerClaims: []v1beta1.PodClaimsStatus{{
+				Pod:        "pod-0",
+				Partitions: []v1beta1.PartitionClaimStatus{{Topic: "my-topic", Partition: 0, CommittedOffset: 5}},
+			}},
+		},
+	}
+
+	lagged := src.DeepCopy()
+	lagged.ResourceVersion = "2"
+	lagged.Status.ConsumerLag = &v1beta1.ConsumerLagStatus{Total: 20}
+	lagged.Status.Autoscaling.Lag = 20
+	lagged.Status.ConsumerClaims[0].Partitions[0].CommittedOffset = 8
+	assert.True(t, ConsumerLagUpdate(src, lagged))
+
+	// Any other change is reconciled
+	assert.False(t, ConsumerLagUpdate(src, src.DeepCopy()))
+	scaled := lagged.DeepCopy()
+	scaled.Status.Autoscaling.Scale = 2
+	assert.False(t, ConsumerLagUpdate(src, scaled))
+	paused := lagged.DeepCopy()
+	paused.Spec.Paused = true
+	assert.False(t, ConsumerLagUpdate(src, paused))
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/resolver"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+// ReconcileDeadLetterSink resolves the dead letter sink of the delivery spec of the source, if any.
+func ReconcileDeadLetterSink(ctx context.Context, sinkResolver *resolver.URIResolver, src *v1beta1.KafkaSource) error {
+	if src.Spec.Delivery == nil || src.Spec.Delivery.DeadLetterSink == nil {
+		src.Status.DeadLetterSinkURI = nil
+		return nil
+	}
+
+	dest := src.Spec.Delivery.DeadLetterSink.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = src.GetNamespace()
+	}
+	dls, err := sinkResolver.URIFromDestinationV1(ctx, *dest, src)
+	if err != nil {
+		src.Status.DeadLetterSinkURI = nil
+		return fmt.Errorf("failed to resolve spec.delivery.deadLetterSink: %w", err)
+	}
+	src.Status.DeadLetterSinkURI = dls
+	return nil
+}
+
+// ReconcileReply resolves the destination of the reply spec of the source, if any.
+func ReconcileReply(ctx context.Context, sinkResolver *resolver.URIResolver, src *v1beta1.KafkaSource) error {
+	if src.Spec.Reply == nil || src.Spec.Reply.Destination == nil {
+		src.Status.ReplyURI = nil
+		return nil
+	}
+
+	dest := src.Spec.Reply.Destination.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = src.GetNamespace()
+	}
+	replyURI, err := sinkResolver.URIFromDestinationV1(ctx, *dest, src)
+	if err != nil {
+		src.Status.ReplyURI = nil
+		return fmt.Errorf("failed to resolve spec.reply.destination: %w", err)
+	}
+	src.Status.ReplyURI = replyURI
+	return nil
+}
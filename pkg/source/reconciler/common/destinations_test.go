@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/resolver"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestReconcileDeadLetterSink(t *testing.T) {
+	dls := apis.HTTP("dls.example.com")
+	testCases := map[string]struct {
+		delivery *eventingduck.DeliverySpec
+		want     *apis.URL
+		wantErr  bool
+	}{
+		"no delivery":         {},
+		"no dead letter sink": {delivery: &eventingduck.DeliverySpec{}},
+		"dead letter sink":    {delivery: &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: dls}}, want: dls},
+		"relative dead letter sink": {
+			delivery: &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: &apis.URL{Path: "/dls"}}},
+			wantErr:  true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{Spec: v1beta1.KafkaSourceSpec{Delivery: tc.delivery}}
+			src.Status.DeadLetterSinkURI = apis.HTTP("stale.example.com")
+			err := ReconcileDeadLetterSink(context.TODO(), &resolver.URIResolver{}, src)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.want, src.Status.DeadLetterSinkURI)
+		})
+	}
+}
+
+func TestReconcileReply(t *testing.T) {
+	destination := apis.HTTP("reply.example.com")
+	testCases := map[string]struct {
+		reply   *v1beta1.ReplySpec
+		want    *apis.URL
+		wantErr bool
+	}{
+		"no reply":    {},
+		"reply topic": {reply: &v1beta1.ReplySpec{Topic: "replies"}},
+		"reply destination": {
+			reply: &v1beta1.ReplySpec{Destination: &duckv1.Destination{URI: destination}},
+			want:  destination,
+		},
+		"relative reply destination": {
+			reply:   &v1beta1.ReplySpec{Destination: &duckv1.Destination{URI: &apis.URL{Path: "/reply"}}},
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{Spec: v1beta1.KafkaSourceSpec{Reply: tc.reply}}
+			src.Status.ReplyURI = apis.HTTP("stale.example.com")
+			err := ReconcileReply(context.TODO(), &resolver.URIResolver{}, src)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.want, src.Status.ReplyURI)
+		})
+	}
+}
@@ -28,11 +28,12 @@ import (
 	"knative.dev/pkg/reconciler"
 
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/source/client"
 )
 
-func FinalizeKind(ctx context.Context, kubeClient kubernetes.Interface, src *v1beta1.KafkaSource) reconciler.Event {
-	bs, config, err := client.NewConfigFromSpec(ctx, kubeClient, src)
+func FinalizeKind(ctx context.Context, kubeClient kubernetes.Interface, kcl kafkalisters.KafkaClusterLister, src *v1beta1.KafkaSource) reconciler.Event {
+	bs, config, err := client.NewConfigFromSpec(ctx, kubeClient, kcl, src)
 	if err != nil {
 		// Secrets are no longer valid. Not automatically recoverable.
 		return nil
@@ -60,7 +60,7 @@ func TestFinalizer(t *testing.T) {
 			ConsumerGroup: group,
 		},
 	}
-	err := FinalizeKind(ctx, fakekubeclient.Get(ctx), src)
+	err := FinalizeKind(ctx, fakekubeclient.Get(ctx), nil, src)
 	if err != nil {
 		t.Error("unexpected error: ", err)
 	}
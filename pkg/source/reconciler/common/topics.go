@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"github.com/Shopify/sarama"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+// ResolveTopics records the topics matching the topic pattern of a KafkaSource in its status, so that
+// they are returned by its ConsumedTopics.  The status is cleared for a KafkaSource without pattern.
+func ResolveTopics(kafkaClient sarama.Client, src *v1beta1.KafkaSource) error {
+	pattern, err := src.Spec.TopicRegexp()
+	if err != nil {
+		return err
+	}
+	if pattern == nil {
+		src.Status.Topics = nil
+		return nil
+	}
+
+	topics, err := offset.MatchTopics(kafkaClient, pattern)
+	if err != nil {
+		return err
+	}
+	src.Status.Topics = topics
+	return nil
+}
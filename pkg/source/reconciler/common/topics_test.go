@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestResolveTopics(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("orders-b", 0, broker.BrokerID()).
+			SetLeader("orders-a", 0, broker.BrokerID()).
+			SetLeader("payments", 0, broker.BrokerID()),
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.MaxVersion
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	src := &v1beta1.KafkaSource{Spec: v1beta1.KafkaSourceSpec{TopicPattern: "orders-.*"}}
+	require.NoError(t, ResolveTopics(client, src))
+	assert.Equal(t, []string{"orders-a", "orders-b"}, src.Status.Topics)
+	assert.Equal(t, []string{"orders-a", "orders-b"}, src.ConsumedTopics())
+
+	// Without topic pattern
+	src.Spec = v1beta1.KafkaSourceSpec{Topics: []string{"payments"}}
+	require.NoError(t, ResolveTopics(client, src))
+	assert.Nil(t, src.Status.Topics)
+	assert.Equal(t, []string{"payments"}, src.ConsumedTopics())
+}
@@ -26,21 +26,28 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 	"knative.dev/pkg/apis/duck"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	nodeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/node"
+	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/system"
 
 	"knative.dev/eventing/pkg/reconciler/source"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	kafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client"
+	kafkaclusterinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
 	kafkainformer "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource"
 	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
 	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	scheduler "knative.dev/eventing/pkg/scheduler"
 	stsscheduler "knative.dev/eventing/pkg/scheduler/statefulset"
@@ -55,9 +62,19 @@ type envConfig struct {
 	DeSchedulerPolicyConfigMap    string `envconfig:"DESCHEDULER_CONFIG" required:"true"`
 }
 
-func NewController(
+// NewControllerFactory returns the constructor of the KafkaSource controller, receiving the resource
+// usage of the multi-tenant receive adapter pods through the given connection pool and store.
+func NewControllerFactory(connectionPool ctrlreconciler.ControlPlaneConnectionPool, resourceUsage *ResourceUsageStore) injection.ControllerConstructor {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		return newController(ctx, cmw, connectionPool, resourceUsage)
+	}
+}
+
+func newController(
 	ctx context.Context,
 	cmw configmap.Watcher,
+	connectionPool ctrlreconciler.ControlPlaneConnectionPool,
+	resourceUsage *ResourceUsageStore,
 ) *controller.Impl {
 	logger := logging.FromContext(ctx)
 
@@ -67,20 +84,28 @@ func NewController(
 	}
 
 	kafkaInformer := kafkainformer.Get(ctx)
+	kafkaClusterInformer := kafkaclusterinformer.Get(ctx)
 	nodeInformer := nodeinformer.Get(ctx)
 
 	c := &Reconciler{
 		KubeClientSet:                 kubeclient.Get(ctx),
 		kafkaClientSet:                kafkaclient.Get(ctx),
 		kafkaLister:                   kafkaInformer.Lister(),
+		kafkaClusterLister:            kafkaClusterInformer.Lister(),
 		configs:                       source.WatchConfigurations(ctx, component, cmw),
 		VReplicaMPS:                   env.VReplicaMPS,
 		MaxEventPerSecondPerPartition: env.MaxEventPerSecondPerPartition,
+		podLister:                     podinformer.Get(ctx).Lister(),
+		connectionPool:                connectionPool,
+		resourceUsage:                 resourceUsage,
 	}
 
 	impl := kafkasource.NewImpl(ctx, c)
 
 	c.sinkResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+	c.clusterTracker = impl.Tracker
+
+	resourceUsage.setEnqueueKey(impl.EnqueueKey)
 
 	// Use a different set of conditions
 	sourcesv1beta1.RegisterAlternateKafkaConditionSet(sourcesv1beta1.KafkaMTSourceCondSet)
@@ -179,7 +204,25 @@ func NewController(
 		removalpolicy)
 
 	logging.FromContext(ctx).Info("Setting up kafka event handlers")
-	kafkaInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+	kafkaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: impl.Enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// The consumer lag is refreshed without reconciling the source
+			if !common.ConsumerLagUpdate(oldObj, newObj) {
+				impl.Enqueue(newObj)
+			}
+		},
+		DeleteFunc: impl.Enqueue,
+	})
+
+	isLeaderFor := impl.Reconciler.(interface {
+		IsLeaderFor(types.NamespacedName) bool
+	}).IsLeaderFor
+	go common.NewConsumerLagRefresher(c.KubeClientSet, c.kafkaClientSet, c.kafkaLister, c.kafkaClusterLister, isLeaderFor, impl.EnqueueKey).Run(ctx)
+
+	kafkaClusterInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster")),
+	))
 
 	return impl
 }
@@ -29,7 +29,9 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/utils/pointer"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -38,16 +40,21 @@ import (
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/system"
+	"knative.dev/pkg/tracker"
 
 	"knative.dev/eventing/pkg/reconciler/source"
 
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	reconcilerkafkasource "knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
 	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 	"knative.dev/eventing-kafka/pkg/source/client"
 	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	"knative.dev/eventing/pkg/scheduler"
 )
 
@@ -57,16 +64,25 @@ const (
 )
 
 type Reconciler struct {
-	KubeClientSet  kubernetes.Interface
-	kafkaLister    listers.KafkaSourceLister
-	kafkaClientSet versioned.Interface
+	KubeClientSet      kubernetes.Interface
+	kafkaLister        listers.KafkaSourceLister
+	kafkaClusterLister kafkalisters.KafkaClusterLister
+	kafkaClientSet     versioned.Interface
 
 	sinkResolver *resolver.URIResolver
 	configs      source.ConfigAccessor
 	scheduler    scheduler.Scheduler
 
+	// clusterTracker reconciles the sources again when the KafkaCluster they reference changes
+	clusterTracker tracker.Interface
+
 	VReplicaMPS                   int32
 	MaxEventPerSecondPerPartition int32
+
+	// The connections to the multi-tenant receive adapter pods reporting their resource usage
+	podLister      corev1listers.PodLister
+	connectionPool ctrlreconciler.ControlPlaneConnectionPool
+	resourceUsage  *ResourceUsageStore
 }
 
 // Check that our Reconciler implements Interface
@@ -75,6 +91,12 @@ var _ reconcilerkafkasource.Interface = (*Reconciler)(nil)
 func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource) pkgreconciler.Event {
 	src.Status.InitializeConditions()
 
+	if src.Spec.Paused {
+		src.Status.MarkPaused()
+	} else {
+		src.Status.MarkNotPaused()
+	}
+
 	if (src.Spec.Sink == duckv1.Destination{}) {
 		src.Status.MarkNoSink("SinkMissing", "")
 		return fmt.Errorf("spec.sink missing")
@@ -96,6 +118,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	src.Status.MarkSink(sinkURI)
 
+	if err := common.ReconcileDeadLetterSink(ctx, r.sinkResolver, src); err != nil {
+		return err
+	}
+
+	if err := common.ReconcileReply(ctx, r.sinkResolver, src); err != nil {
+		return err
+	}
+
 	src.Status.Selector = "control-plane=kafkasource-mt-adapter"
 
 	if val, ok := src.GetLabels()[v1beta1.KafkaKeyTypeLabel]; ok {
@@ -114,8 +144,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 		}
 	}
 
+	if src.Spec.ClusterRef != nil {
+		if err := r.clusterTracker.TrackReference(kafkacluster.TrackerReference(src.Spec.ClusterRef, src.Namespace), src); err != nil {
+			return fmt.Errorf("failed to track the KafkaCluster: %w", err)
+		}
+	}
+
 	// Validate configuration and offsets
-	bs, config, err := client.NewConfigFromSpec(ctx, r.KubeClientSet, src)
+	bs, config, err := client.NewConfigFromSpec(ctx, r.KubeClientSet, r.kafkaClusterLister, src)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("unable to build Kafka configuration", zap.Error(err))
 		src.Status.MarkConnectionNotEstablished("InvalidConfiguration", err.Error())
@@ -141,18 +177,33 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	defer kafkaAdminClient.Close()
 
-	totalPartitions, err := offset.InitOffsets(ctx, c, kafkaAdminClient, src.Spec.Topics, src.Spec.ConsumerGroup)
+	if err := common.ResolveTopics(c, src); err != nil {
+		logging.FromContext(ctx).Errorw("unable to resolve the topics matching the topic pattern", zap.Error(err))
+		src.Status.MarkInitialOffsetNotCommitted("TopicsNotResolved", "Unable to resolve the topics matching the topic pattern: %v", err)
+		return err
+	}
+
+	initialOffset, err := src.Spec.InitialOffset.ParseSaramaOffsetTime()
+	if err != nil {
+		src.Status.MarkInitialOffsetNotCommitted("InvalidInitialOffset", "Invalid initial offset %q: %v", src.Spec.InitialOffset, err)
+		return err
+	}
+
+	totalPartitions, err := offset.InitOffsets(ctx, c, kafkaAdminClient, src.ConsumedTopics(), src.Spec.ConsumerGroup, initialOffset)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("unable to initialize consumergroup offsets", zap.Error(err))
 		src.Status.MarkInitialOffsetNotCommitted("OffsetsNotCommitted", "Unable to initialize consumergroup offsets: %v", err)
 		return err
 	}
 	src.Status.MarkInitialOffsetCommitted()
+
 	if r.MaxEventPerSecondPerPartition != -1 && r.VReplicaMPS != -1 {
 		maxVReplicas := totalPartitions*r.MaxEventPerSecondPerPartition/r.VReplicaMPS + 1
 		src.Status.MaxAllowedVReplicas = &maxVReplicas
 	}
 
+	r.reconcileResourceUsage(ctx, src)
+
 	// Finally, schedule the source
 	if err := r.reconcileMTReceiveAdapter(src); err != nil {
 		return err
@@ -165,6 +216,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 
 func (r *Reconciler) FinalizeKind(ctx context.Context, src *v1beta1.KafkaSource) reconciler.Event {
 	src.Spec.Consumers = pointer.Int32Ptr(0)
+	src.Status.Autoscaling = nil
 	placements, err := r.scheduler.Schedule(src) //de-schedule placements
 
 	if placements != nil || err != nil {
@@ -174,7 +226,7 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, src *v1beta1.KafkaSource)
 		return errors.New("placement list was not empty")
 	}
 
-	return common.FinalizeKind(ctx, r.KubeClientSet, src)
+	return common.FinalizeKind(ctx, r.KubeClientSet, r.kafkaClusterLister, src)
 }
 
 func (r *Reconciler) reconcileMTReceiveAdapter(src *v1beta1.KafkaSource) error {
@@ -197,6 +249,48 @@ func (r *Reconciler) reconcileMTReceiveAdapter(src *v1beta1.KafkaSource) error {
 	return nil
 }
 
+// reconcileResourceUsage keeps the connections to the multi-tenant receive adapter pods reporting
+// their resource usage, updates the partitions claimed by the source on each pod, and removes the
+// placements of the source on the pods where its partition buffers consume more memory than the
+// budget of its vreplicas, for the scheduler to place these vreplicas again.
+func (r *Reconciler) reconcileResourceUsage(ctx context.Context, src *v1beta1.KafkaSource) {
+	if r.connectionPool == nil || r.resourceUsage == nil {
+		return
+	}
+	logger := logging.FromContext(ctx)
+
+	podIPs, err := ctrlreconciler.PodIpGetter{Lister: r.podLister}.GetAllPodsIp(system.Namespace(), labels.Set{"control-plane": mtadapterName}.AsSelector())
+	if err != nil {
+		logger.Warnw("Failed to get the receive adapter pod IPs", zap.Error(err))
+		return
+	}
+	for i, podIP := range podIPs {
+		podIPs[i] = fmt.Sprintf("%s:%d", podIP, controlprotocol.ServerPort)
+	}
+
+	// Same key as the ResetOffset controller, see ConnectionPoolKeyMapper. The ResetOffset controller sets
+	// its own message handler on the connections before using them, whichever controller dialed them.
+	if _, err := r.connectionPool.ReconcileConnections(ctx, mtadapterName, podIPs, nil, nil); err != nil {
+		logger.Warnw("Failed to connect to the receive adapter pods", zap.Error(err))
+	}
+
+	key := src.Namespace + "/" + src.Name
+	placements := src.GetPlacements()
+	src.Status.UpdateConsumerClaims(common.ConsumerClaimsStatus(src, r.resourceUsage.claims(key, placements)))
+	if len(placements) == 0 {
+		return
+	}
+	kept := make([]duckv1alpha1.Placement, 0, len(placements))
+	for _, placement := range placements {
+		if r.resourceUsage.evict(placement.PodName, key) {
+			logger.Infow("Rebalancing the vreplicas of the pod over its memory budget", zap.String("pod", placement.PodName), zap.Int32("vreplicas", placement.VReplicas))
+			continue
+		}
+		kept = append(kept, placement)
+	}
+	src.Status.Placements = kept
+}
+
 func (r *Reconciler) vpodLister() ([]scheduler.VPod, error) {
 	sources, err := r.kafkaLister.List(labels.Everything())
 	if err != nil {
@@ -210,9 +304,10 @@ func (r *Reconciler) vpodLister() ([]scheduler.VPod, error) {
 }
 
 func (r *Reconciler) createCloudEventAttributes(src *v1beta1.KafkaSource) []duckv1.CloudEventAttributes {
-	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(src.Spec.Topics))
-	for i := range src.Spec.Topics {
-		topics := strings.Split(src.Spec.Topics[i], ",")
+	consumedTopics := src.ConsumedTopics()
+	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(consumedTopics))
+	for i := range consumedTopics {
+		topics := strings.Split(consumedTopics[i], ",")
 		for _, topic := range topics {
 			ceAttributes = append(ceAttributes, duckv1.CloudEventAttributes{
 				Type:   v1beta1.KafkaEventType,
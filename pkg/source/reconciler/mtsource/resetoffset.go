@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtsource
+
+import (
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+)
+
+// NewResetOffsetRefMapperFactory returns the RefMapper factory of the ResetOffsets referencing
+// KafkaSources, which are all consumed by the pods of the multi-tenant receive adapter.
+func NewResetOffsetRefMapperFactory() refmappers.ResetOffsetRefMapperFactory {
+	return refmappers.NewKafkaSourceRefMapperFactory(ConnectionPoolKeyMapper, DataPlaneNamespaceMapper, DataPlaneLabelsMapper)
+}
+
+// ConnectionPoolKeyMapper uses the same ConnectionPool Key for all the KafkaSources since the
+// multi-tenant receive adapter pods only accept a single control-protocol connection.
+func ConnectionPoolKeyMapper(_ *v1beta1.KafkaSource) (string, error) {
+	return mtadapterName, nil
+}
+
+// DataPlaneNamespaceMapper returns the namespace of the multi-tenant receive adapter.
+func DataPlaneNamespaceMapper(_ *v1beta1.KafkaSource) (string, error) {
+	return system.Namespace(), nil
+}
+
+// DataPlaneLabelsMapper returns the labels of the multi-tenant receive adapter pods.
+func DataPlaneLabelsMapper(_ *v1beta1.KafkaSource) (map[string]string, error) {
+	return map[string]string{"control-plane": mtadapterName}, nil
+}
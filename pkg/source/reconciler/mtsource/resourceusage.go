@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtsource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	ctrl "knative.dev/control-protocol/pkg"
+	"knative.dev/pkg/logging"
+
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+)
+
+// ResourceUsageStore keeps the resource usage last reported by each multi-tenant receive adapter
+// pod, and enqueues the sources consuming more memory than the budget of their vreplicas.
+type ResourceUsageStore struct {
+	lock       sync.RWMutex
+	pods       map[string]kafkasourcecontrol.ResourceUsage
+	enqueueKey func(name types.NamespacedName)
+}
+
+func NewResourceUsageStore() *ResourceUsageStore {
+	return &ResourceUsageStore{
+		pods: make(map[string]kafkasourcecontrol.ResourceUsage),
+	}
+}
+
+// ServiceWrapper wraps the control-protocol services of the connection pool shared with the
+// ResetOffset controller, as the multi-tenant receive adapter pods only accept a single connection.
+// The resource usage reports are handled by the store, the other messages by the handler set on
+// the service, if any.
+func (s *ResourceUsageStore) ServiceWrapper(service ctrl.Service) ctrl.Service {
+	wrapped := &resourceUsageService{Service: service, store: s}
+	wrapped.MessageHandler(nil)
+	return wrapped
+}
+
+// setEnqueueKey sets the function enqueuing the sources consuming too much memory.
+func (s *ResourceUsageStore) setEnqueueKey(enqueueKey func(name types.NamespacedName)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.enqueueKey = enqueueKey
+}
+
+func (s *ResourceUsageStore) HandleServiceMessage(ctx context.Context, message ctrl.ServiceMessage) {
+	var usage kafkasourcecontrol.ResourceUsage
+	if err := usage.UnmarshalBinary(message.Payload()); err != nil {
+		logging.FromContext(ctx).Errorw("Cannot parse the resource usage", zap.Error(err))
+		message.AckWithError(err)
+		return
+	}
+	message.Ack()
+
+	s.lock.Lock()
+	s.pods[usage.PodName] = usage
+	enqueueKey := s.enqueueKey
+	s.lock.Unlock()
+
+	if enqueueKey == nil {
+		return
+	}
+	for _, key := range usage.OverBudgetSources() {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			continue
+		}
+		enqueueKey(types.NamespacedName{Namespace: namespace, Name: name})
+	}
+}
+
+// claims returns the partitions claimed by the source on the pods of the placements, as last reported.
+func (s *ResourceUsageStore) claims(key string, placements []duckv1alpha1.Placement) map[string]common.PodClaims {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	claims := make(map[string]common.PodClaims, len(placements))
+	for _, placement := range placements {
+		if usage, ok := s.pods[placement.PodName].Sources[key]; ok {
+			claims[placement.PodName] = common.PodClaims{Claims: usage.Claims, Time: usage.ClaimsTime}
+		}
+	}
+	return claims
+}
+
+// evict returns whether the vreplicas of the source placed on the pod consume more memory than
+// their budget, in which case the usage of the source is forgotten until the next report of the pod.
+func (s *ResourceUsageStore) evict(podName string, key string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	usage, ok := s.pods[podName]
+	if !ok {
+		return false
+	}
+	for _, overBudget := range usage.OverBudgetSources() {
+		if overBudget == key {
+			delete(usage.Sources, key)
+			return true
+		}
+	}
+	return false
+}
+
+// resourceUsageService routes the resource usage reports to the ResourceUsageStore.
+type resourceUsageService struct {
+	ctrl.Service
+	store *ResourceUsageStore
+}
+
+func (r *resourceUsageService) MessageHandler(handler ctrl.MessageHandler) {
+	r.Service.MessageHandler(ctrl.MessageHandlerFunc(func(ctx context.Context, message ctrl.ServiceMessage) {
+		opcode := ctrl.OpCode(message.Headers().OpCode())
+		switch {
+		case opcode == kafkasourcecontrol.NotifyResourceUsageOpCode:
+			r.store.HandleServiceMessage(ctx, message)
+		case handler != nil:
+			handler.HandleServiceMessage(ctx, message)
+		default:
+			message.AckWithError(fmt.Errorf("received an unknown opcode '%d', I don't know what to do with it", opcode))
+		}
+	}))
+}
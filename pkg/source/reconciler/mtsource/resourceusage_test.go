@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtsource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
+
+	controltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+)
+
+func TestResourceUsageStore(t *testing.T) {
+	// Capture the handler set on the wrapped service
+	var handler ctrl.MessageHandler
+	service := &controltesting.MockService{}
+	service.On("MessageHandler", mock.Anything).Run(func(args mock.Arguments) {
+		handler = args.Get(0).(ctrl.MessageHandler)
+	}).Return()
+
+	store := NewResourceUsageStore()
+	var enqueued []types.NamespacedName
+	store.setEnqueueKey(func(name types.NamespacedName) {
+		enqueued = append(enqueued, name)
+	})
+	wrapped := store.ServiceWrapper(service)
+
+	// The other messages are handled by the handler set on the service
+	var handled []ctrl.OpCode
+	wrapped.MessageHandler(ctrl.MessageHandlerFunc(func(ctx context.Context, message ctrl.ServiceMessage) {
+		handled = append(handled, ctrl.OpCode(message.Headers().OpCode()))
+		message.Ack()
+	}))
+
+	usage := kafkasourcecontrol.ResourceUsage{
+		PodName:             "pod-0",
+		VReplicaMemoryLimit: 1000,
+		Sources: map[string]kafkasourcecontrol.SourceResourceUsage{
+			"ns/within-budget": {VReplicas: 1, Partitions: 2, Claims: kafkasourcecontrol.Claims{"topic": {0, 1}}, FetchSize: 250, Memory: 1000},
+			"ns/over-budget":   {VReplicas: 1, Partitions: 4, FetchSize: 250, Memory: 2000},
+		},
+	}
+	sendMessage(t, handler, kafkasourcecontrol.NotifyResourceUsageOpCode, usage)
+	sendMessage(t, handler, ctrl.OpCode(10), usage)
+
+	assert.Equal(t, []ctrl.OpCode{10}, handled)
+	assert.Equal(t, []types.NamespacedName{{Namespace: "ns", Name: "over-budget"}}, enqueued)
+
+	// The claims are reported for the pods of the placements
+	claims := store.claims("ns/within-budget", []duckv1alpha1.Placement{{PodName: "pod-0"}, {PodName: "pod-1"}})
+	assert.Equal(t, map[string]common.PodClaims{"pod-0": {Claims: map[string][]int32{"topic": {0, 1}}}}, claims)
+
+	// The vreplicas over budget are evicted once per report
+	assert.False(t, store.evict("pod-0", "ns/within-budget"))
+	assert.False(t, store.evict("pod-1", "ns/over-budget"))
+	assert.True(t, store.evict("pod-0", "ns/over-budget"))
+	assert.False(t, store.evict("pod-0", "ns/over-budget"))
+}
+
+func sendMessage(t *testing.T, handler ctrl.MessageHandler, opcode ctrl.OpCode, usage kafkasourcecontrol.ResourceUsage) {
+	payload, err := usage.MarshalBinary()
+	assert.Nil(t, err)
+
+	msg := ctrl.NewMessage([16]byte{1, 2, 3, 4, 1, 2, 3, 4, 1, 2, 3, 4, 1, 2, 3, 4}, uint8(opcode), payload)
+	handler.HandleServiceMessage(context.Background(), ctrl.NewServiceMessage(&msg, func(err error) {
+		assert.Nil(t, err)
+	}))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -32,11 +33,14 @@ import (
 
 	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
 
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	kafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client"
+	kafkaclusterinformer "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/kafkacluster"
 	kafkainformer "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource"
 	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
 	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
 )
 
 func NewController(
@@ -51,6 +55,7 @@ func NewController(
 	}
 
 	kafkaInformer := kafkainformer.Get(ctx)
+	kafkaClusterInformer := kafkaclusterinformer.Get(ctx)
 	deploymentInformer := deploymentinformer.Get(ctx)
 	podInformer := podinformer.Get(ctx)
 
@@ -58,6 +63,7 @@ func NewController(
 		KubeClientSet:       kubeclient.Get(ctx),
 		kafkaClientSet:      kafkaclient.Get(ctx),
 		kafkaLister:         kafkaInformer.Lister(),
+		kafkaClusterLister:  kafkaClusterInformer.Lister(),
 		deploymentLister:    deploymentInformer.Lister(),
 		receiveAdapterImage: raImage,
 		loggingContext:      ctx,
@@ -68,10 +74,29 @@ func NewController(
 
 	impl := kafkasource.NewImpl(ctx, c)
 	c.sinkResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+	c.clusterTracker = impl.Tracker
 
 	c.claimsNotificationStore = ctrlreconciler.NewNotificationStore(impl.EnqueueKey, kafkasourcecontrol.ClaimsParser)
 
-	kafkaInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+	kafkaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: impl.Enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// The consumer lag is refreshed without reconciling the source
+			if !common.ConsumerLagUpdate(oldObj, newObj) {
+				impl.Enqueue(newObj)
+			}
+		},
+		DeleteFunc: impl.Enqueue,
+	})
+
+	isLeaderFor := impl.Reconciler.(interface {
+		IsLeaderFor(types.NamespacedName) bool
+	}).IsLeaderFor
+	go common.NewConsumerLagRefresher(c.KubeClientSet, c.kafkaClientSet, c.kafkaLister, c.kafkaClusterLister, isLeaderFor, impl.EnqueueKey).Run(ctx)
+
+	kafkaClusterInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, kafkav1alpha1.SchemeGroupVersion.WithKind("KafkaCluster")),
+	))
 
 	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterController(&v1beta1.KafkaSource{}),
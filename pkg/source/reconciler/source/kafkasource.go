@@ -53,10 +53,13 @@ import (
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
 
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	reconcilerkafkasource "knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
+	kafkalisters "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafkacluster"
 	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
 )
 
@@ -100,14 +103,18 @@ type Reconciler struct {
 
 	receiveAdapterImage string
 
-	kafkaLister      listers.KafkaSourceLister
-	deploymentLister appsv1listers.DeploymentLister
+	kafkaLister        listers.KafkaSourceLister
+	kafkaClusterLister kafkalisters.KafkaClusterLister
+	deploymentLister   appsv1listers.DeploymentLister
 
 	kafkaClientSet versioned.Interface
 	loggingContext context.Context
 
 	sinkResolver *resolver.URIResolver
 
+	// clusterTracker reconciles the sources again when the KafkaCluster they reference changes
+	clusterTracker tracker.Interface
+
 	configs KafkaSourceConfigAccessor
 
 	podIpGetter             ctrlreconciler.PodIpGetter
@@ -121,6 +128,12 @@ var _ reconcilerkafkasource.Interface = (*Reconciler)(nil)
 func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource) pkgreconciler.Event {
 	src.Status.InitializeConditions()
 
+	if src.Spec.Paused {
+		src.Status.MarkPaused()
+	} else {
+		src.Status.MarkNotPaused()
+	}
+
 	if (src.Spec.Sink == duckv1.Destination{}) {
 		src.Status.MarkNoSink("SinkMissing", "")
 		return fmt.Errorf("spec.sink missing")
@@ -146,6 +159,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	src.Status.MarkSink(sinkURI)
 
+	if err := common.ReconcileDeadLetterSink(ctx, r.sinkResolver, src); err != nil {
+		return err
+	}
+
+	if err := common.ReconcileReply(ctx, r.sinkResolver, src); err != nil {
+		return err
+	}
+
 	selector, err := resources.GetLabelsAsSelector(src.Name)
 	if err != nil {
 		return fmt.Errorf("getting labels as selector: %v", err)
@@ -168,8 +189,16 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 		}
 	}
 
+	// The receive adapter connects to the KafkaCluster referenced by the source, if any
+	resolved, clusterSaramaConfig, err := r.resolveKafkaCluster(src)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("unable to resolve the KafkaCluster", zap.Error(err))
+		src.Status.MarkConnectionNotEstablished("KafkaClusterNotFound", err.Error())
+		return err
+	}
+
 	// Validate configuration and offsets
-	bs, config, err := client.NewConfigFromSpec(ctx, r.KubeClientSet, src)
+	bs, config, err := client.NewConfigFromSpec(ctx, r.KubeClientSet, r.kafkaClusterLister, src)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("unable to build Kafka configuration", zap.Error(err))
 		src.Status.MarkConnectionNotEstablished("InvalidConfiguration", err.Error())
@@ -195,7 +224,19 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	defer kafkaAdminClient.Close()
 
-	_, err = offset.InitOffsets(ctx, c, kafkaAdminClient, src.Spec.Topics, src.Spec.ConsumerGroup)
+	if err := common.ResolveTopics(c, src); err != nil {
+		logging.FromContext(ctx).Errorw("unable to resolve the topics matching the topic pattern", zap.Error(err))
+		src.Status.MarkInitialOffsetNotCommitted("TopicsNotResolved", "Unable to resolve the topics matching the topic pattern: %v", err)
+		return err
+	}
+
+	initialOffset, err := src.Spec.InitialOffset.ParseSaramaOffsetTime()
+	if err != nil {
+		src.Status.MarkInitialOffsetNotCommitted("InvalidInitialOffset", "Invalid initial offset %q: %v", src.Spec.InitialOffset, err)
+		return err
+	}
+
+	_, err = offset.InitOffsets(ctx, c, kafkaAdminClient, src.ConsumedTopics(), src.Spec.ConsumerGroup, initialOffset)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("unable to initialize consumergroup offsets", zap.Error(err))
 		src.Status.MarkInitialOffsetNotCommitted("OffsetsNotCommitted", "Unable to initialize consumergroup offsets: %v", err)
@@ -205,7 +246,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 
 	// TODO(mattmoor): create KafkaBinding for the receive adapter.
 
-	ra, err := r.createReceiveAdapter(ctx, src, sinkURI)
+	ra, err := r.createReceiveAdapter(ctx, resolved, clusterSaramaConfig, sinkURI)
 	if err != nil {
 		var event *pkgreconciler.ReconcilerEvent
 		isReconcilerEvent := pkgreconciler.EventAs(err, &event)
@@ -282,6 +323,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	lastClaimStatus, ok := r.claimsNotificationStore.GetPodsNotifications(srcNamespacedName)
 	if ok {
 		src.Status.UpdateConsumerGroupStatus(stringifyClaimsStatus(lastClaimStatus))
+		src.Status.UpdateConsumerClaims(common.ConsumerClaimsStatus(src, podClaims(lastClaimStatus)))
 	}
 
 	return nil
@@ -300,16 +342,34 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, src *v1beta1.KafkaSource)
 		return err
 	}
 
-	return common.FinalizeKind(ctx, r.KubeClientSet, src)
+	return common.FinalizeKind(ctx, r.KubeClientSet, r.kafkaClusterLister, src)
 }
 
-func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.KafkaSource, sinkURI *apis.URL) (*appsv1.Deployment, error) {
+// resolveKafkaCluster tracks the KafkaCluster referenced by the source, if any, and returns a copy of
+// the source using its bootstrap servers and authentication, along with its Sarama settings.
+func (r *Reconciler) resolveKafkaCluster(src *v1beta1.KafkaSource) (*v1beta1.KafkaSource, string, error) {
+	if src.Spec.ClusterRef != nil {
+		if err := r.clusterTracker.TrackReference(kafkacluster.TrackerReference(src.Spec.ClusterRef, src.Namespace), src); err != nil {
+			return nil, "", fmt.Errorf("failed to track the KafkaCluster: %w", err)
+		}
+	}
+	return client.ResolveKafkaCluster(r.kafkaClusterLister, src)
+}
+
+func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.KafkaSource, clusterSaramaConfig string, sinkURI *apis.URL) (*appsv1.Deployment, error) {
 	raArgs := resources.ReceiveAdapterArgs{
-		Image:          r.receiveAdapterImage,
-		Source:         src,
-		Labels:         resources.GetLabels(src.Name),
-		SinkURI:        sinkURI.String(),
-		AdditionalEnvs: r.configs.ToEnvVars(),
+		Image:               r.receiveAdapterImage,
+		Source:              src,
+		Labels:              resources.GetLabels(src.Name),
+		SinkURI:             sinkURI.String(),
+		ClusterSaramaConfig: clusterSaramaConfig,
+		AdditionalEnvs:      r.configs.ToEnvVars(),
+	}
+	if src.Status.DeadLetterSinkURI != nil {
+		raArgs.DeadLetterSinkURI = src.Status.DeadLetterSinkURI.String()
+	}
+	if src.Status.ReplyURI != nil {
+		raArgs.ReplyURI = src.Status.ReplyURI.String()
 	}
 	expected := resources.MakeReceiveAdapter(&raArgs)
 
@@ -396,9 +456,10 @@ func podSpecChanged(oldPodSpec corev1.PodSpec, newPodSpec corev1.PodSpec) bool {
 }
 
 func (r *Reconciler) createCloudEventAttributes(src *v1beta1.KafkaSource) []duckv1.CloudEventAttributes {
-	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(src.Spec.Topics))
-	for i := range src.Spec.Topics {
-		topics := strings.Split(src.Spec.Topics[i], ",")
+	consumedTopics := src.ConsumedTopics()
+	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(consumedTopics))
+	for i := range consumedTopics {
+		topics := strings.Split(consumedTopics[i], ",")
 		for _, topic := range topics {
 			ceAttributes = append(ceAttributes, duckv1.CloudEventAttributes{
 				Type:   v1beta1.KafkaEventType,
@@ -424,6 +485,17 @@ func stringifyClaimsStatus(status map[string]interface{}) string {
 	return strings.Join(strs, "\n")
 }
 
+// podClaims returns the claims notified by each receive adapter pod.
+func podClaims(status map[string]interface{}) map[string]common.PodClaims {
+	claims := make(map[string]common.PodClaims, len(status))
+	for podIp, notification := range status {
+		if notification, ok := notification.(kafkasourcecontrol.ClaimsNotification); ok {
+			claims[podIp] = common.PodClaims{Claims: notification.Claims, Time: notification.Time}
+		}
+	}
+	return claims
+}
+
 // GetDeploymentCondition returns the condition with the provided type.
 func GetDeploymentCondition(status appsv1.DeploymentStatus, condType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
 	for i := range status.Conditions {
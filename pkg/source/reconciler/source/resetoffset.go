@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/source/resources"
+)
+
+// NewResetOffsetRefMapperFactory returns the RefMapper factory of the ResetOffsets referencing
+// KafkaSources, whose receive adapter is deployed in the namespace of the source.
+func NewResetOffsetRefMapperFactory() refmappers.ResetOffsetRefMapperFactory {
+	return refmappers.NewKafkaSourceRefMapperFactory(ConnectionPoolKeyMapper, DataPlaneNamespaceMapper, DataPlaneLabelsMapper)
+}
+
+// ConnectionPoolKeyMapper uses the KafkaSource UID as ConnectionPool Key since each source has its own receive adapter.
+func ConnectionPoolKeyMapper(src *v1beta1.KafkaSource) (string, error) {
+	return string(src.UID), nil
+}
+
+// DataPlaneNamespaceMapper returns the namespace of the receive adapter of the KafkaSource.
+func DataPlaneNamespaceMapper(src *v1beta1.KafkaSource) (string, error) {
+	return src.Namespace, nil
+}
+
+// DataPlaneLabelsMapper returns the labels of the receive adapter pods of the KafkaSource.
+func DataPlaneLabelsMapper(src *v1beta1.KafkaSource) (map[string]string, error) {
+	return resources.GetLabels(src.Name), nil
+}
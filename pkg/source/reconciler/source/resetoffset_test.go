@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/source/resources"
+)
+
+func TestResetOffsetMappers(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-ns",
+			Name:      "test-name",
+			UID:       "test-uid",
+		},
+	}
+
+	key, err := ConnectionPoolKeyMapper(src)
+	assert.Nil(t, err)
+	assert.Equal(t, "test-uid", key)
+
+	namespace, err := DataPlaneNamespaceMapper(src)
+	assert.Nil(t, err)
+	assert.Equal(t, "test-ns", namespace)
+
+	labels, err := DataPlaneLabelsMapper(src)
+	assert.Nil(t, err)
+	assert.Equal(t, resources.GetLabels("test-name"), labels)
+
+	assert.NotNil(t, NewResetOffsetRefMapperFactory())
+}
@@ -26,17 +26,21 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/pkg/kmeta"
 )
 
 type ReceiveAdapterArgs struct {
-	Image          string
-	Source         *v1beta1.KafkaSource
-	Labels         map[string]string
-	SinkURI        string
-	AdditionalEnvs []corev1.EnvVar
+	Image               string
+	Source              *v1beta1.KafkaSource
+	Labels              map[string]string
+	SinkURI             string
+	DeadLetterSinkURI   string
+	ReplyURI            string
+	ClusterSaramaConfig string
+	AdditionalEnvs      []corev1.EnvVar
 }
 
 func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
@@ -86,9 +90,108 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 		env = append(env, corev1.EnvVar{Name: adapter.EnvConfigCEOverrides, Value: string(ceJson)})
 	}
 
+	if args.Source.Spec.Ordering != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_DELIVERY_ORDERING",
+			Value: string(args.Source.Spec.Ordering),
+		})
+	}
+
+	if args.Source.Spec.MaxInFlight != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_MAX_IN_FLIGHT",
+			Value: strconv.Itoa(int(*args.Source.Spec.MaxInFlight)),
+		})
+	}
+
+	if args.Source.Spec.TopicPattern != "" {
+		env = append(env, corev1.EnvVar{Name: "KAFKA_TOPIC_PATTERN", Value: args.Source.Spec.TopicPattern})
+	}
+
+	// The paused state is read by the adapter before joining the consumer group, so that the pods
+	// started while paused (scaling, restarts) don't consume. Pausing and resuming rolls the pods.
+	if args.Source.Spec.Paused {
+		env = append(env, corev1.EnvVar{Name: "KAFKA_PAUSED", Value: "true"})
+	}
+
+	if args.Source.Spec.Delivery != nil {
+		// Cannot fail.
+		deliveryJson, _ := json.Marshal(args.Source.Spec.Delivery)
+		env = append(env, corev1.EnvVar{Name: "K_DELIVERY", Value: string(deliveryJson)})
+	}
+
+	if args.Source.Spec.CloudEventAttributes != nil {
+		// Cannot fail.
+		attributesJson, _ := json.Marshal(args.Source.Spec.CloudEventAttributes)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_CE_ATTRIBUTES", Value: string(attributesJson)})
+	}
+
+	if args.Source.Spec.Batching != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_BATCH_MAX_SIZE",
+			Value: strconv.Itoa(int(args.Source.Spec.Batching.MaxSize)),
+		})
+		if args.Source.Spec.Batching.MaxWaitMs > 0 {
+			env = append(env, corev1.EnvVar{
+				Name:  "KAFKA_BATCH_MAX_WAIT_MS",
+				Value: strconv.Itoa(int(args.Source.Spec.Batching.MaxWaitMs)),
+			})
+		}
+	}
+
+	replicas := args.Source.Spec.Consumers
+	if args.Source.Status.Autoscaling != nil {
+		// The built-in autoscaler overrides the number of consumers
+		replicas = pointer.Int32Ptr(args.Source.Status.Autoscaling.Scale)
+	}
+
+	if args.Source.Spec.RateLimit != nil {
+		// Each replica derives its share of the rate limit from the partitions it claims, so that
+		// scaling the adapter doesn't change its pod template
+		eventsPerSecond, burst := args.Source.Spec.RateLimit.LimitsFor(1, 1)
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_RATE_LIMIT_EPS",
+			Value: strconv.FormatFloat(eventsPerSecond, 'f', -1, 64),
+		}, corev1.EnvVar{
+			Name:  "KAFKA_RATE_LIMIT_BURST",
+			Value: strconv.Itoa(burst),
+		})
+	}
+
+	if args.DeadLetterSinkURI != "" {
+		env = append(env, corev1.EnvVar{Name: "K_DEAD_LETTER_SINK", Value: args.DeadLetterSinkURI})
+	}
+
+	if args.Source.Spec.Reply != nil && args.Source.Spec.Reply.Topic != "" {
+		env = append(env, corev1.EnvVar{Name: "KAFKA_REPLY_TOPIC", Value: args.Source.Spec.Reply.Topic})
+	}
+
+	if args.ReplyURI != "" {
+		env = append(env, corev1.EnvVar{Name: "K_REPLY_SINK", Value: args.ReplyURI})
+	}
+
+	if args.ClusterSaramaConfig != "" {
+		env = append(env, corev1.EnvVar{Name: "KAFKA_CLUSTER_SARAMA_CONFIG", Value: args.ClusterSaramaConfig})
+	}
+
+	if args.Source.Spec.SchemaRegistry != nil {
+		env = append(env, corev1.EnvVar{Name: "SCHEMA_REGISTRY_URL", Value: args.Source.Spec.SchemaRegistry.URL})
+		if args.Source.Spec.SchemaRegistry.Decode != "" {
+			env = append(env, corev1.EnvVar{Name: "SCHEMA_REGISTRY_DECODE", Value: string(args.Source.Spec.SchemaRegistry.Decode)})
+		}
+		env = appendEnvFromSecretKeyRef(env, "SCHEMA_REGISTRY_USER", args.Source.Spec.SchemaRegistry.User.SecretKeyRef)
+		env = appendEnvFromSecretKeyRef(env, "SCHEMA_REGISTRY_PASSWORD", args.Source.Spec.SchemaRegistry.Password.SecretKeyRef)
+	}
+
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_USER", args.Source.Spec.Net.SASL.User.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_PASSWORD", args.Source.Spec.Net.SASL.Password.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_TYPE", args.Source.Spec.Net.SASL.Type.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_OAUTH_TOKEN_URL", args.Source.Spec.Net.SASL.OAuth.TokenURL.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_OAUTH_SCOPES", args.Source.Spec.Net.SASL.OAuth.Scopes.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_KERBEROS_SERVICE_NAME", args.Source.Spec.Net.SASL.Kerberos.ServiceName.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_KERBEROS_REALM", args.Source.Spec.Net.SASL.Kerberos.Realm.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_KERBEROS_KEYTAB", args.Source.Spec.Net.SASL.Kerberos.Keytab.SecretKeyRef)
+	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_KERBEROS_CONFIG", args.Source.Spec.Net.SASL.Kerberos.Config.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_CERT", args.Source.Spec.Net.TLS.Cert.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_KEY", args.Source.Spec.Net.TLS.Key.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_CA_CERT", args.Source.Spec.Net.TLS.CACert.SecretKeyRef)
@@ -106,7 +209,7 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 			Selector: &metav1.LabelSelector{
 				MatchLabels: args.Labels,
 			},
-			Replicas: args.Source.Spec.Consumers,
+			Replicas: replicas,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: args.Labels,
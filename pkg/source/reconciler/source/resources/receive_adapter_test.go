@@ -613,3 +613,306 @@ func TestMakeReceiveAdapterKeyType(t *testing.T) {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
 }
+
+func TestMakeReceiveAdapterSchemaRegistry(t *testing.T) {
+	passwordRef := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "registry-secret"},
+		Key:                  "password",
+	}
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			SchemaRegistry: &v1beta1.SchemaRegistrySpec{
+				URL:      "https://schema-registry.example.com",
+				Decode:   v1beta1.DecodeAll,
+				Password: bindingsv1beta1.SecretValueFromSource{SecretKeyRef: passwordRef},
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]corev1.EnvVar{}
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e
+	}
+	if env["SCHEMA_REGISTRY_URL"].Value != "https://schema-registry.example.com" {
+		t.Errorf("unexpected SCHEMA_REGISTRY_URL %v", env["SCHEMA_REGISTRY_URL"])
+	}
+	if env["SCHEMA_REGISTRY_DECODE"].Value != "all" {
+		t.Errorf("unexpected SCHEMA_REGISTRY_DECODE %v", env["SCHEMA_REGISTRY_DECODE"])
+	}
+	if _, ok := env["SCHEMA_REGISTRY_USER"]; ok {
+		t.Errorf("unexpected SCHEMA_REGISTRY_USER without secret reference")
+	}
+	if password := env["SCHEMA_REGISTRY_PASSWORD"]; password.ValueFrom == nil || password.ValueFrom.SecretKeyRef != passwordRef {
+		t.Errorf("unexpected SCHEMA_REGISTRY_PASSWORD %v", password)
+	}
+}
+
+func TestMakeReceiveAdapterAutoscaling(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			Consumers:     ptr.Int32(1),
+		},
+		Status: v1beta1.KafkaSourceStatus{
+			Autoscaling: &v1beta1.KafkaSourceAutoscalingStatus{Scale: 4, Lag: 400},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	if got.Spec.Replicas == nil || *got.Spec.Replicas != 4 {
+		t.Errorf("unexpected replicas %v, want 4", got.Spec.Replicas)
+	}
+}
+
+func TestMakeReceiveAdapterPaused(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			Consumers:     ptr.Int32(2),
+			Paused:        true,
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	// The paused receive adapter keeps its replicas
+	if got.Spec.Replicas == nil || *got.Spec.Replicas != 2 {
+		t.Errorf("unexpected replicas %v, want 2", got.Spec.Replicas)
+	}
+	found := false
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == "KAFKA_PAUSED" {
+			found = e.Value == "true"
+		}
+	}
+	if !found {
+		t.Errorf("missing KAFKA_PAUSED=true")
+	}
+}
+
+func TestMakeReceiveAdapterCloudEventAttributes(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			CloudEventAttributes: &v1beta1.CloudEventAttributesSpec{
+				Type:    &v1beta1.AttributeMappingSpec{Header: "event-type"},
+				Headers: &v1beta1.HeadersPropagationSpec{Deny: []string{"authorization"}},
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	want := `{"type":{"header":"event-type"},"headers":{"deny":["authorization"]}}`
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == "KAFKA_CE_ATTRIBUTES" {
+			if e.Value != want {
+				t.Errorf("unexpected KAFKA_CE_ATTRIBUTES %s, want %s", e.Value, want)
+			}
+			return
+		}
+	}
+	t.Errorf("missing KAFKA_CE_ATTRIBUTES")
+}
+
+func TestMakeReceiveAdapterReply(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			Reply:         &v1beta1.ReplySpec{Topic: "replies"},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:    "test-image",
+		Source:   src,
+		SinkURI:  "sink-uri",
+		ReplyURI: "reply-uri",
+	})
+
+	env := make(map[string]string)
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["KAFKA_REPLY_TOPIC"] != "replies" {
+		t.Errorf("unexpected KAFKA_REPLY_TOPIC %q, want replies", env["KAFKA_REPLY_TOPIC"])
+	}
+	if env["K_REPLY_SINK"] != "reply-uri" {
+		t.Errorf("unexpected K_REPLY_SINK %q, want reply-uri", env["K_REPLY_SINK"])
+	}
+}
+
+func TestMakeReceiveAdapterClusterSaramaConfig(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+		},
+	}
+
+	saramaConfig := "Net:\n  MaxOpenRequests: 1\n"
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:               "test-image",
+		Source:              src,
+		SinkURI:             "sink-uri",
+		ClusterSaramaConfig: saramaConfig,
+	})
+
+	env := make(map[string]string)
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["KAFKA_CLUSTER_SARAMA_CONFIG"] != saramaConfig {
+		t.Errorf("unexpected KAFKA_CLUSTER_SARAMA_CONFIG %q, want %q", env["KAFKA_CLUSTER_SARAMA_CONFIG"], saramaConfig)
+	}
+}
+
+func TestMakeReceiveAdapterBatching(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			Batching:      &v1beta1.BatchingSpec{MaxSize: 100, MaxWaitMs: 250},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := make(map[string]string)
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["KAFKA_BATCH_MAX_SIZE"] != "100" {
+		t.Errorf("unexpected KAFKA_BATCH_MAX_SIZE %q, want 100", env["KAFKA_BATCH_MAX_SIZE"])
+	}
+	if env["KAFKA_BATCH_MAX_WAIT_MS"] != "250" {
+		t.Errorf("unexpected KAFKA_BATCH_MAX_WAIT_MS %q, want 250", env["KAFKA_BATCH_MAX_WAIT_MS"])
+	}
+}
+
+func TestMakeReceiveAdapterRateLimit(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1"},
+			},
+			ConsumerGroup: "group",
+			Consumers:     ptr.Int32(4),
+			RateLimit:     &v1beta1.RateLimitSpec{EventsPerSecond: 10, Burst: ptr.Int32(20)},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := make(map[string]string)
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	// The replicas derive their share of the rate limit of the source at runtime
+	if env["KAFKA_RATE_LIMIT_EPS"] != "10" {
+		t.Errorf("unexpected KAFKA_RATE_LIMIT_EPS %q, want 10", env["KAFKA_RATE_LIMIT_EPS"])
+	}
+	if env["KAFKA_RATE_LIMIT_BURST"] != "20" {
+		t.Errorf("unexpected KAFKA_RATE_LIMIT_BURST %q, want 20", env["KAFKA_RATE_LIMIT_BURST"])
+	}
+
+	// Scaling the adapter doesn't change its pod template
+	src.Spec.Consumers = ptr.Int32(8)
+	scaled := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+	if diff, err := kmp.SafeDiff(got.Spec.Template, scaled.Spec.Template); err != nil {
+		t.Errorf("unexpected pod template diff error: %v", err)
+	} else if diff != "" {
+		t.Errorf("unexpected pod template change (-want, +got) = %v", diff)
+	}
+}
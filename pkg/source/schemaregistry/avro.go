@@ -0,0 +1,428 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The Avro primitive and complex types
+const (
+	avroNull    = "null"
+	avroBoolean = "boolean"
+	avroInt     = "int"
+	avroLong    = "long"
+	avroFloat   = "float"
+	avroDouble  = "double"
+	avroBytes   = "bytes"
+	avroString  = "string"
+	avroRecord  = "record"
+	avroError   = "error"
+	avroEnum    = "enum"
+	avroArray   = "array"
+	avroMap     = "map"
+	avroFixed   = "fixed"
+	avroUnion   = "union"
+)
+
+var errAvroTruncated = errors.New("truncated Avro data")
+
+// avroType is a parsed Avro schema
+type avroType struct {
+	kind    string
+	fields  []avroField // record
+	symbols []string    // enum
+	items   *avroType   // array
+	values  *avroType   // map
+	size    int         // fixed
+	union   []*avroType // union
+}
+
+// avroField is a field of an Avro record
+type avroField struct {
+	name string
+	typ  *avroType
+}
+
+// avroDecoder decodes the Avro binary encoding to JSON.  Unions are decoded to the value of their
+// branch, bytes and fixed are decoded to base64 strings, and the logical types are decoded as their
+// underlying type.
+//
+// The decoder is written here rather than using an Avro library (e.g. goavro or hamba/avro) as the
+// libraries either decode to Go maps, losing the order of the record fields, or to the Avro JSON
+// encoding, which wraps the union values in an object keyed by their type name, and neither is the
+// plain JSON expected by the sinks.  Only the decoding of the binary encoding is needed, so the
+// schema resolution and encoding of a library would only add to the vendored dependencies.
+type avroDecoder struct {
+	typ *avroType
+}
+
+// newAvroDecoder parses the Avro schema, whose named types may be defined by the referenced schemas
+func newAvroDecoder(schema string, references []referencedSchema) (*avroDecoder, error) {
+	parser := &avroParser{names: make(map[string]*avroType)}
+	for _, ref := range references {
+		if _, err := parser.parseSchema(ref.schema); err != nil {
+			return nil, fmt.Errorf("invalid referenced schema %s: %w", ref.name, err)
+		}
+	}
+	typ, err := parser.parseSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return &avroDecoder{typ: typ}, nil
+}
+
+func (d *avroDecoder) decode(data []byte) ([]byte, error) {
+	reader := &avroReader{data: data}
+	buffer := &bytes.Buffer{}
+	if err := d.typ.decode(reader, buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// avroParser parses Avro schemas, resolving the references to the named types
+type avroParser struct {
+	names map[string]*avroType
+}
+
+func (p *avroParser) parseSchema(schema string) (*avroType, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(schema), &value); err != nil {
+		// A schema may also be the name of a primitive or named type without quotes
+		value = strings.TrimSpace(schema)
+	}
+	return p.parse(value, "")
+}
+
+func (p *avroParser) parse(value interface{}, namespace string) (*avroType, error) {
+	switch v := value.(type) {
+	case string:
+		return p.parseName(v, namespace)
+	case []interface{}:
+		union := &avroType{kind: avroUnion}
+		for _, branch := range v {
+			typ, err := p.parse(branch, namespace)
+			if err != nil {
+				return nil, err
+			}
+			union.union = append(union.union, typ)
+		}
+		return union, nil
+	case map[string]interface{}:
+		return p.parseComplex(v, namespace)
+	default:
+		return nil, fmt.Errorf("invalid Avro type %v", value)
+	}
+}
+
+func (p *avroParser) parseName(name string, namespace string) (*avroType, error) {
+	switch name {
+	case avroNull, avroBoolean, avroInt, avroLong, avroFloat, avroDouble, avroBytes, avroString:
+		return &avroType{kind: name}, nil
+	}
+	if typ, ok := p.names[fullName(name, namespace)]; ok {
+		return typ, nil
+	}
+	if typ, ok := p.names[name]; ok {
+		return typ, nil
+	}
+	return nil, fmt.Errorf("unknown Avro type %q", name)
+}
+
+func (p *avroParser) parseComplex(v map[string]interface{}, namespace string) (*avroType, error) {
+	kind, ok := v["type"].(string)
+	if !ok {
+		// e.g. {"type": {"type": "array", ...}}
+		return p.parse(v["type"], namespace)
+	}
+
+	switch kind {
+	case avroRecord, avroError, avroEnum, avroFixed:
+		typ := &avroType{kind: kind}
+		if kind == avroError {
+			typ.kind = avroRecord
+		}
+		name, err := p.register(typ, v, namespace)
+		if err != nil {
+			return nil, err
+		}
+		// The nested types are in the namespace of their enclosing named type
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			namespace = name[:i]
+		} else {
+			namespace = ""
+		}
+		switch typ.kind {
+		case avroRecord:
+			fields, _ := v["fields"].([]interface{})
+			for _, f := range fields {
+				field, _ := f.(map[string]interface{})
+				fieldName, _ := field["name"].(string)
+				if fieldName == "" {
+					return nil, fmt.Errorf("invalid field of Avro record %s", name)
+				}
+				fieldType, err := p.parse(field["type"], namespace)
+				if err != nil {
+					return nil, err
+				}
+				typ.fields = append(typ.fields, avroField{name: fieldName, typ: fieldType})
+			}
+		case avroEnum:
+			symbols, _ := v["symbols"].([]interface{})
+			for _, symbol := range symbols {
+				s, _ := symbol.(string)
+				typ.symbols = append(typ.symbols, s)
+			}
+		case avroFixed:
+			size, _ := v["size"].(float64)
+			typ.size = int(size)
+		}
+		return typ, nil
+	case avroArray:
+		items, err := p.parse(v["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return &avroType{kind: avroArray, items: items}, nil
+	case avroMap:
+		values, err := p.parse(v["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return &avroType{kind: avroMap, values: values}, nil
+	default:
+		// A primitive type, possibly annotated with a logicalType which is decoded as its underlying type
+		return p.parseName(kind, namespace)
+	}
+}
+
+// register registers the named type before parsing it, so that it may reference itself
+func (p *avroParser) register(typ *avroType, v map[string]interface{}, namespace string) (string, error) {
+	name, _ := v["name"].(string)
+	if name == "" {
+		return "", fmt.Errorf("missing name of Avro %s", typ.kind)
+	}
+	if ns, ok := v["namespace"].(string); ok && !strings.Contains(name, ".") {
+		namespace = ns
+	}
+	name = fullName(name, namespace)
+	p.names[name] = typ
+	return name, nil
+}
+
+// fullName returns the full name of the Avro name in the namespace
+func fullName(name string, namespace string) string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// avroReader reads the Avro binary encoding
+type avroReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *avroReader) readLong() (int64, error) {
+	value, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errAvroTruncated
+	}
+	r.pos += n
+	return value, nil
+}
+
+func (r *avroReader) readBytes(size int64) ([]byte, error) {
+	if size < 0 || size > int64(len(r.data)-r.pos) {
+		return nil, errAvroTruncated
+	}
+	value := r.data[r.pos : r.pos+int(size)]
+	r.pos += int(size)
+	return value, nil
+}
+
+// decode reads the value of the type and writes its JSON representation to the buffer
+func (t *avroType) decode(r *avroReader, w *bytes.Buffer) error {
+	switch t.kind {
+	case avroNull:
+		w.WriteString("null")
+	case avroBoolean:
+		b, err := r.readBytes(1)
+		if err != nil {
+			return err
+		}
+		w.WriteString(strconv.FormatBool(b[0] != 0))
+	case avroInt, avroLong:
+		value, err := r.readLong()
+		if err != nil {
+			return err
+		}
+		w.WriteString(strconv.FormatInt(value, 10))
+	case avroFloat:
+		b, err := r.readBytes(4)
+		if err != nil {
+			return err
+		}
+		writeFloat(w, float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), 32)
+	case avroDouble:
+		b, err := r.readBytes(8)
+		if err != nil {
+			return err
+		}
+		writeFloat(w, math.Float64frombits(binary.LittleEndian.Uint64(b)), 64)
+	case avroBytes, avroString:
+		size, err := r.readLong()
+		if err != nil {
+			return err
+		}
+		b, err := r.readBytes(size)
+		if err != nil {
+			return err
+		}
+		if t.kind == avroString {
+			return writeJSON(w, string(b))
+		}
+		return writeJSON(w, b)
+	case avroFixed:
+		b, err := r.readBytes(int64(t.size))
+		if err != nil {
+			return err
+		}
+		return writeJSON(w, b)
+	case avroEnum:
+		index, err := r.readLong()
+		if err != nil {
+			return err
+		}
+		if index < 0 || index >= int64(len(t.symbols)) {
+			return fmt.Errorf("invalid Avro enum index %d", index)
+		}
+		return writeJSON(w, t.symbols[index])
+	case avroUnion:
+		index, err := r.readLong()
+		if err != nil {
+			return err
+		}
+		if index < 0 || index >= int64(len(t.union)) {
+			return fmt.Errorf("invalid Avro union index %d", index)
+		}
+		return t.union[index].decode(r, w)
+	case avroRecord:
+		w.WriteByte('{')
+		for i, field := range t.fields {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSON(w, field.name); err != nil {
+				return err
+			}
+			w.WriteByte(':')
+			if err := field.typ.decode(r, w); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+	case avroArray:
+		w.WriteByte('[')
+		err := r.readBlocks(func(i int) error {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			return t.items.decode(r, w)
+		})
+		if err != nil {
+			return err
+		}
+		w.WriteByte(']')
+	case avroMap:
+		w.WriteByte('{')
+		err := r.readBlocks(func(i int) error {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := (&avroType{kind: avroString}).decode(r, w); err != nil {
+				return err
+			}
+			w.WriteByte(':')
+			return t.values.decode(r, w)
+		})
+		if err != nil {
+			return err
+		}
+		w.WriteByte('}')
+	default:
+		return fmt.Errorf("unsupported Avro type %s", t.kind)
+	}
+	return nil
+}
+
+// readBlocks reads the blocks of items of an array or map, calling readItem for each item
+func (r *avroReader) readBlocks(readItem func(i int) error) error {
+	i := 0
+	for {
+		count, err := r.readLong()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+		if count < 0 {
+			// A negative count is followed by the size in bytes of the block
+			count = -count
+			if _, err := r.readLong(); err != nil {
+				return err
+			}
+		}
+		if count > int64(len(r.data)) {
+			return errAvroTruncated
+		}
+		for ; count > 0; count-- {
+			if err := readItem(i); err != nil {
+				return err
+			}
+			i++
+		}
+	}
+}
+
+// writeFloat writes the JSON number, or a string for the values which JSON cannot represent
+func writeFloat(w *bytes.Buffer, value float64, bitSize int) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		w.WriteString(strconv.Quote(strconv.FormatFloat(value, 'g', -1, bitSize)))
+		return
+	}
+	w.WriteString(strconv.FormatFloat(value, 'g', -1, bitSize))
+}
+
+func writeJSON(w *bytes.Buffer, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	w.Write(b)
+	return nil
+}
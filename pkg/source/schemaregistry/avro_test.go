@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvroDecoder(t *testing.T) {
+	testCases := []struct {
+		name   string
+		schema string
+		data   []byte
+		want   string
+	}{
+		{
+			name:   "null branch of an optional union",
+			schema: `["null", "string"]`,
+			data:   avroEncoder{}.long(0),
+			want:   `null`,
+		},
+		{
+			name:   "value branch of an optional union",
+			schema: `["null", "string"]`,
+			data:   avroEncoder{}.long(1).string("value"),
+			want:   `"value"`,
+		},
+		{
+			name:   "record branch of a union",
+			schema: `["null", {"type": "record", "name": "Point", "fields": [{"name": "x", "type": "int"}]}]`,
+			data:   avroEncoder{}.long(1).long(-3),
+			want:   `{"x": -3}`,
+		},
+		{
+			name:   "union of an array",
+			schema: `["null", {"type": "array", "items": ["null", "long"]}]`,
+			data:   avroEncoder{}.long(1).long(2).long(0).long(1).long(5).long(0),
+			want:   `[null, 5]`,
+		},
+		{
+			name: "recursive record through a union",
+			schema: `{"type": "record", "name": "Node", "fields": [
+				{"name": "value", "type": "int"},
+				{"name": "next", "type": ["null", "Node"]}
+			]}`,
+			data: avroEncoder{}.long(1).long(1).long(2).long(0),
+			want: `{"value": 1, "next": {"value": 2, "next": null}}`,
+		},
+		{
+			name:   "date logical type",
+			schema: `{"type": "int", "logicalType": "date"}`,
+			data:   avroEncoder{}.long(18628),
+			want:   `18628`,
+		},
+		{
+			name:   "timestamp-micros logical type",
+			schema: `{"type": "long", "logicalType": "timestamp-micros"}`,
+			data:   avroEncoder{}.long(1600000000000000),
+			want:   `1600000000000000`,
+		},
+		{
+			name:   "uuid logical type",
+			schema: `{"type": "string", "logicalType": "uuid"}`,
+			data:   avroEncoder{}.string("4b8e6a1c-0d3f-4a57-9a8e-2f2f5b6d7c10"),
+			want:   `"4b8e6a1c-0d3f-4a57-9a8e-2f2f5b6d7c10"`,
+		},
+		{
+			name:   "bytes decimal logical type",
+			schema: `{"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2}`,
+			data:   append(avroEncoder{}.long(2), 0x04, 0xd2),
+			want:   `"BNI="`,
+		},
+		{
+			name:   "fixed decimal logical type",
+			schema: `{"type": "fixed", "name": "Amount", "size": 2, "logicalType": "decimal", "precision": 4, "scale": 2}`,
+			data:   []byte{0x04, 0xd2},
+			want:   `"BNI="`,
+		},
+		{
+			name:   "NaN double",
+			schema: `"double"`,
+			data:   avroEncoder{}.double(math.NaN()),
+			want:   `"NaN"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoder, err := newAvroDecoder(tc.schema, nil)
+			require.NoError(t, err)
+			got, err := decoder.decode(tc.data)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestAvroDecoderInvalidData(t *testing.T) {
+	testCases := []struct {
+		name   string
+		schema string
+		data   []byte
+	}{
+		{name: "union index out of range", schema: `["null", "string"]`, data: avroEncoder{}.long(2)},
+		{name: "negative union index", schema: `["null", "string"]`, data: avroEncoder{}.long(-1)},
+		{name: "enum index out of range", schema: `{"type": "enum", "name": "Status", "symbols": ["NEW"]}`, data: avroEncoder{}.long(1)},
+		{name: "empty data", schema: `"long"`, data: nil},
+		{name: "truncated varint", schema: `"long"`, data: []byte{0x80}},
+		{name: "truncated double", schema: `"double"`, data: []byte{0, 0, 0}},
+		{name: "truncated fixed", schema: `{"type": "fixed", "name": "Hash", "size": 4}`, data: []byte{1, 2}},
+		{name: "string longer than the data", schema: `"string"`, data: avroEncoder{}.long(10).string("abc")},
+		{name: "negative string size", schema: `"string"`, data: avroEncoder{}.long(-1)},
+		{name: "array count larger than the data", schema: `{"type": "array", "items": "null"}`, data: avroEncoder{}.long(1 << 40)},
+		{name: "truncated union branch", schema: `["null", "string"]`, data: avroEncoder{}.long(1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoder, err := newAvroDecoder(tc.schema, nil)
+			require.NoError(t, err)
+			_, err = decoder.decode(tc.data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAvroDecoderTruncated(t *testing.T) {
+	decoder, err := newAvroDecoder(orderSchema, []referencedSchema{{name: "com.example.Customer", schema: customerSchema}})
+	require.NoError(t, err)
+
+	data := encodedOrder()
+	for i := 0; i < len(data); i++ {
+		_, err := decoder.decode(data[:i])
+		assert.ErrorIs(t, err, errAvroTruncated, "decoding the first %d bytes", i)
+	}
+}
+
+func FuzzAvroDecoder(f *testing.F) {
+	decoder, err := newAvroDecoder(orderSchema, []referencedSchema{{name: "com.example.Customer", schema: customerSchema}})
+	require.NoError(f, err)
+
+	f.Add(encodedOrder())
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded, err := decoder.decode(data)
+		if err == nil && !json.Valid(decoded) {
+			t.Errorf("invalid JSON %q decoded from %v", decoded, data)
+		}
+	})
+}
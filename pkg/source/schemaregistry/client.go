@@ -0,0 +1,313 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schemaregistry decodes the Kafka records serialized with the Confluent Schema Registry
+// wire format (a magic byte and a schema ID followed by the Avro, Protobuf or JSON encoded data).
+package schemaregistry
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// magicByte is the first byte of the Confluent wire format
+	magicByte = 0
+
+	// headerSize is the size of the magic byte and the schema ID
+	headerSize = 5
+
+	// DefaultTimeout is the default timeout of the requests to the schema registry
+	DefaultTimeout = 10 * time.Second
+
+	// The Schema Types of the registry (Avro schemas have no explicit type)
+	SchemaTypeAvro     = "AVRO"
+	SchemaTypeProtobuf = "PROTOBUF"
+	SchemaTypeJSON     = "JSON"
+)
+
+var (
+	// ErrNotWireFormat is returned when decoding data which isn't serialized with the Confluent wire format
+	ErrNotWireFormat = errors.New("data is not serialized with the schema registry wire format")
+
+	// ErrUnavailable is returned when the schema registry cannot be reached, as opposed to the
+	// errors caused by the data itself, so that callers can retry decoding it later.
+	ErrUnavailable = errors.New("schema registry unavailable")
+)
+
+// Client fetches the schemas from a Confluent compatible schema registry and decodes the data
+// serialized with them to JSON.  The schemas are cached for the lifetime of the Client, as the
+// schema registry never changes the schema of an ID.
+type Client struct {
+	url        string
+	user       string
+	password   string
+	httpClient *http.Client
+
+	schemasMutex sync.RWMutex
+	schemas      map[int32]*schema
+}
+
+// schema is a decoder for the data serialized with a schema, along with its URI
+type schema struct {
+	uri    string
+	decode func(data []byte) ([]byte, error)
+}
+
+// reference is a reference of a schema to another one
+type reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// referencedSchema is a schema referenced by another one, with the name of the reference
+type referencedSchema struct {
+	name   string
+	schema string
+}
+
+// schemaResponse is the schema returned by the schema registry
+type schemaResponse struct {
+	Schema     string      `json:"schema"`
+	SchemaType string      `json:"schemaType"`
+	References []reference `json:"references"`
+}
+
+// subjectVersion is a subject and version of a schema ID
+type subjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// NewClient returns a Client for the schema registry of the specified URL, using the basic
+// authentication credentials if the user is not empty.  An HTTP client with the DefaultTimeout
+// is used when the httpClient is nil.
+func NewClient(registryURL string, user string, password string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &Client{
+		url:        strings.TrimSuffix(registryURL, "/"),
+		user:       user,
+		password:   password,
+		httpClient: httpClient,
+		schemas:    make(map[int32]*schema),
+	}
+}
+
+// Decode returns the JSON representation of the data serialized with the Confluent wire format,
+// and the URI of its schema (i.e. the subject version, or the schema ID if it has no subject).
+func (c *Client) Decode(ctx context.Context, data []byte) ([]byte, string, error) {
+	if len(data) < headerSize || data[0] != magicByte {
+		return nil, "", ErrNotWireFormat
+	}
+	id := int32(binary.BigEndian.Uint32(data[1:headerSize]))
+
+	s, err := c.getSchema(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	decoded, err := s.decode(data[headerSize:])
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode the data of schema %d: %w", id, err)
+	}
+	return decoded, s.uri, nil
+}
+
+// getSchema returns the cached schema of the ID, fetching it from the registry on first use
+func (c *Client) getSchema(ctx context.Context, id int32) (*schema, error) {
+	c.schemasMutex.RLock()
+	s, ok := c.schemas[id]
+	c.schemasMutex.RUnlock()
+	if ok {
+		return s, nil
+	}
+
+	s, err := c.fetchSchema(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.schemasMutex.Lock()
+	c.schemas[id] = s
+	c.schemasMutex.Unlock()
+	return s, nil
+}
+
+// fetchSchema fetches the schema of the ID and its references, and builds its decoder
+func (c *Client) fetchSchema(ctx context.Context, id int32) (*schema, error) {
+	path := fmt.Sprintf("/schemas/ids/%d", id)
+	response := &schemaResponse{}
+	if err := c.get(ctx, path, response); err != nil {
+		return nil, err
+	}
+
+	var decode func([]byte) ([]byte, error)
+	switch response.SchemaType {
+	case "", SchemaTypeAvro:
+		references, err := c.fetchReferences(ctx, response.References, false)
+		if err != nil {
+			return nil, err
+		}
+		decoder, err := newAvroDecoder(response.Schema, references)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Avro schema %d: %w", id, err)
+		}
+		decode = decoder.decode
+	case SchemaTypeProtobuf:
+		// Fetch the binary file descriptor rather than parsing the .proto text
+		response = &schemaResponse{}
+		if err := c.get(ctx, path+"?format=serialized", response); err != nil {
+			return nil, err
+		}
+		references, err := c.fetchReferences(ctx, response.References, true)
+		if err != nil {
+			return nil, err
+		}
+		decoder, err := newProtobufDecoder(fmt.Sprintf("schema-%d.proto", id), response.Schema, references)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Protobuf schema %d: %w", id, err)
+		}
+		decode = decoder.decode
+	case SchemaTypeJSON:
+		decode = decodeJSON
+	default:
+		return nil, fmt.Errorf("unsupported type %q of schema %d", response.SchemaType, id)
+	}
+
+	uri, err := c.schemaURI(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &schema{uri: uri, decode: decode}, nil
+}
+
+// fetchReferences returns the schemas (recursively) referenced by a schema, each one after
+// the schemas it references itself
+func (c *Client) fetchReferences(ctx context.Context, references []reference, serialized bool) ([]referencedSchema, error) {
+	var schemas []referencedSchema
+	fetched := make(map[string]bool)
+
+	var fetch func(references []reference) error
+	fetch = func(references []reference) error {
+		for _, ref := range references {
+			if fetched[ref.Name] {
+				continue
+			}
+			fetched[ref.Name] = true
+
+			path := fmt.Sprintf("/subjects/%s/versions/%d", url.PathEscape(ref.Subject), ref.Version)
+			if serialized {
+				path += "?format=serialized"
+			}
+			response := &schemaResponse{}
+			if err := c.get(ctx, path, response); err != nil {
+				return err
+			}
+			if err := fetch(response.References); err != nil {
+				return err
+			}
+			schemas = append(schemas, referencedSchema{name: ref.Name, schema: response.Schema})
+		}
+		return nil
+	}
+
+	if err := fetch(references); err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+// schemaURI returns the URI of the first subject version of the schema ID, or the URI of the
+// schema ID itself if the registry doesn't know any of its subject versions.  The errors of an
+// unavailable registry are returned rather than falling back, as the URI is cached with the schema.
+func (c *Client) schemaURI(ctx context.Context, id int32) (string, error) {
+	var versions []subjectVersion
+	err := c.get(ctx, fmt.Sprintf("/schemas/ids/%d/versions", id), &versions)
+	if errors.Is(err, ErrUnavailable) {
+		return "", err
+	}
+	var statusErr *statusError
+	if (err == nil && len(versions) == 0) || (errors.As(err, &statusErr) && statusErr.statusCode == http.StatusNotFound) {
+		return fmt.Sprintf("%s/schemas/ids/%d", c.url, id), nil
+	} else if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/subjects/%s/versions/%d", c.url, url.PathEscape(versions[0].Subject), versions[0].Version), nil
+}
+
+// statusError is returned when the schema registry responds with an unexpected status code
+type statusError struct {
+	path       string
+	statusCode int
+	message    string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("schema registry responded to %s with %d: %s", e.path, e.statusCode, e.message)
+}
+
+// get unmarshals the JSON response of the schema registry to the specified path
+func (c *Client) get(ctx context.Context, path string, response interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json, application/json")
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: %v", ErrUnavailable, &statusError{path: path, statusCode: res.StatusCode, message: string(body)})
+	} else if res.StatusCode != http.StatusOK {
+		return &statusError{path: path, statusCode: res.StatusCode, message: string(body)}
+	}
+
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("invalid schema registry response to %s: %w", path, err)
+	}
+	return nil
+}
+
+// decodeJSON returns the JSON Schema serialized data, which is already JSON
+func decodeJSON(data []byte) ([]byte, error) {
+	if !json.Valid(data) {
+		return nil, errors.New("invalid JSON data")
+	}
+	return data, nil
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	registrytesting "knative.dev/eventing-kafka/pkg/source/schemaregistry/testing"
+)
+
+const orderSchema = `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "id", "type": "string"},
+    {"name": "quantity", "type": "int"},
+    {"name": "price", "type": "double"},
+    {"name": "paid", "type": "boolean"},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "SHIPPED"]}},
+    {"name": "tags", "type": {"type": "array", "items": "string"}},
+    {"name": "attributes", "type": {"type": "map", "values": "long"}},
+    {"name": "note", "type": ["null", "string"]},
+    {"name": "customer", "type": "Customer"}
+  ]
+}`
+
+const customerSchema = `{
+  "type": "record",
+  "name": "Customer",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "name", "type": "string"},
+    {"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+  ]
+}`
+
+// avroEncoder encodes the Avro binary encoding of the test data
+type avroEncoder []byte
+
+func (e avroEncoder) long(value int64) avroEncoder {
+	buffer := make([]byte, binary.MaxVarintLen64)
+	return append(e, buffer[:binary.PutVarint(buffer, value)]...)
+}
+
+func (e avroEncoder) string(value string) avroEncoder {
+	return append(e.long(int64(len(value))), value...)
+}
+
+func (e avroEncoder) double(value float64) avroEncoder {
+	buffer := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buffer, math.Float64bits(value))
+	return append(e, buffer...)
+}
+
+func (e avroEncoder) boolean(value bool) avroEncoder {
+	if value {
+		return append(e, 1)
+	}
+	return append(e, 0)
+}
+
+func encodedOrder() []byte {
+	return avroEncoder{}.
+		string("order-1").
+		long(3).
+		double(9.5).
+		boolean(true).
+		long(1).                                      // SHIPPED
+		long(2).string("a").string("b").long(0).      // tags
+		long(-1).long(4).string("x").long(7).long(0). // attributes, in a block with its size
+		long(1).string("fragile").                    // note
+		string("Jane").long(1600000000000)            // customer
+}
+
+func TestDecodeAvro(t *testing.T) {
+	registry := registrytesting.NewRegistry()
+	defer registry.Close()
+	registry.Register(1, registrytesting.Schema{Subject: "customer", Version: 1, Schema: customerSchema})
+	registry.Register(2, registrytesting.Schema{
+		Subject:    "orders-value",
+		Version:    3,
+		Schema:     orderSchema,
+		References: []registrytesting.Reference{{Name: "com.example.Customer", Subject: "customer", Version: 1}},
+	})
+
+	client := NewClient(registry.URL, "", "", nil)
+	data, uri, err := client.Decode(context.Background(), registrytesting.Encode(2, encodedOrder()))
+	require.NoError(t, err)
+	assert.Equal(t, registry.URL+"/subjects/orders-value/versions/3", uri)
+	assert.JSONEq(t, `{
+		"id": "order-1",
+		"quantity": 3,
+		"price": 9.5,
+		"paid": true,
+		"status": "SHIPPED",
+		"tags": ["a", "b"],
+		"attributes": {"x": 7},
+		"note": "fragile",
+		"customer": {"name": "Jane", "created": 1600000000000}
+	}`, string(data))
+
+	// The schema is cached
+	requests := registry.Requests()
+	_, _, err = client.Decode(context.Background(), registrytesting.Encode(2, encodedOrder()))
+	require.NoError(t, err)
+	assert.Equal(t, requests, registry.Requests())
+
+	// Truncated data
+	_, _, err = client.Decode(context.Background(), registrytesting.Encode(2, encodedOrder()[:10]))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrUnavailable))
+}
+
+func TestDecodeAvroPrimitive(t *testing.T) {
+	registry := registrytesting.NewRegistry()
+	defer registry.Close()
+	registry.Register(5, registrytesting.Schema{Schema: `"string"`})
+
+	client := NewClient(registry.URL, "", "", nil)
+	data, uri, err := client.Decode(context.Background(), registrytesting.Encode(5, avroEncoder{}.string("my-key")))
+	require.NoError(t, err)
+	assert.Equal(t, `"my-key"`, string(data))
+	assert.Equal(t, registry.URL+"/schemas/ids/5", uri)
+}
+
+func TestDecodeProtobuf(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("order.proto"),
+		Package:    proto.String("example"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Ignored"),
+		}, {
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("id"),
+				JsonName: proto.String("id"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			}},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("Line"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:     proto.String("quantity"),
+					JsonName: proto.String("quantity"),
+					Number:   proto.Int32(1),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				}, {
+					Name:     proto.String("created"),
+					JsonName: proto.String("created"),
+					Number:   proto.Int32(2),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					TypeName: proto.String(".google.protobuf.Timestamp"),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				}},
+			}},
+		}},
+	}
+	serialized, err := proto.Marshal(file)
+	require.NoError(t, err)
+
+	descriptor, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	line := dynamicpb.NewMessage(descriptor.Messages().ByName("Order").Messages().ByName("Line"))
+	line.Set(line.Descriptor().Fields().ByName("quantity"), protoreflect.ValueOfInt64(42))
+	data, err := proto.Marshal(line)
+	require.NoError(t, err)
+
+	registry := registrytesting.NewRegistry()
+	defer registry.Close()
+	registry.Register(7, registrytesting.Schema{
+		Subject:    "orders-value",
+		Version:    1,
+		SchemaType: SchemaTypeProtobuf,
+		Schema:     "syntax = \"proto3\"; ...",
+		Serialized: base64.StdEncoding.EncodeToString(serialized),
+	})
+
+	client := NewClient(registry.URL, "", "", nil)
+	decoded, uri, err := client.Decode(context.Background(), registrytesting.EncodeProtobuf(7, []int64{1, 0}, data))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"quantity": "42"}`, string(decoded))
+	assert.Equal(t, registry.URL+"/subjects/orders-value/versions/1", uri)
+
+	// The first message shortcut
+	decoded, _, err = client.Decode(context.Background(), registrytesting.EncodeProtobuf(7, nil, nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(decoded))
+
+	// Invalid message index
+	_, _, err = client.Decode(context.Background(), registrytesting.EncodeProtobuf(7, []int64{5}, data))
+	assert.Error(t, err)
+}
+
+func TestDecodeJSON(t *testing.T) {
+	registry := registrytesting.NewRegistry()
+	defer registry.Close()
+	registry.Register(3, registrytesting.Schema{Subject: "orders-value", Version: 2, SchemaType: SchemaTypeJSON, Schema: `{"type": "object"}`})
+
+	client := NewClient(registry.URL, "", "", nil)
+	data, uri, err := client.Decode(context.Background(), registrytesting.Encode(3, []byte(`{"id": "order-1"}`)))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "order-1"}`, string(data))
+	assert.Equal(t, registry.URL+"/subjects/orders-value/versions/2", uri)
+
+	_, _, err = client.Decode(context.Background(), registrytesting.Encode(3, []byte(`{"id"`)))
+	assert.Error(t, err)
+}
+
+func TestDecodeUnavailableSubjectVersions(t *testing.T) {
+	registry := registrytesting.NewRegistry()
+	defer registry.Close()
+	registry.Register(3, registrytesting.Schema{Subject: "orders-value", Version: 2, SchemaType: SchemaTypeJSON, Schema: `{"type": "object"}`})
+	client := NewClient(registry.URL, "", "", nil)
+
+	// A failing subject versions lookup doesn't fall back to (and cache) the URI of the schema ID
+	registry.SetUnavailable("/versions")
+	_, _, err := client.Decode(context.Background(), registrytesting.Encode(3, []byte(`{"id": "order-1"}`)))
+	assert.True(t, errors.Is(err, ErrUnavailable))
+
+	registry.SetUnavailable()
+	_, uri, err := client.Decode(context.Background(), registrytesting.Encode(3, []byte(`{"id": "order-1"}`)))
+	require.NoError(t, err)
+	assert.Equal(t, registry.URL+"/subjects/orders-value/versions/2", uri)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	registry := registrytesting.NewRegistry()
+	client := NewClient(registry.URL, "", "", nil)
+
+	_, _, err := client.Decode(context.Background(), []byte(`{"id": "order-1"}`))
+	assert.Equal(t, ErrNotWireFormat, err)
+
+	_, _, err = client.Decode(context.Background(), registrytesting.Encode(99, nil))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrUnavailable))
+
+	registry.Close()
+	_, _, err = client.Decode(context.Background(), registrytesting.Encode(99, nil))
+	assert.True(t, errors.Is(err, ErrUnavailable))
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	// Register the well-known types commonly imported by the schemas
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// protobufDecoder decodes the Protobuf messages of a file descriptor to their canonical JSON
+type protobufDecoder struct {
+	file protoreflect.FileDescriptor
+}
+
+// newProtobufDecoder builds the file descriptor of the base64 serialized FileDescriptorProto returned by the
+// schema registry.  Its dependencies are the referenced schemas, or the well-known types registered globally.
+func newProtobufDecoder(name string, schema string, references []referencedSchema) (*protobufDecoder, error) {
+	fileSet := &descriptorpb.FileDescriptorSet{}
+	files := make(map[string]bool)
+
+	var addFile func(file *descriptorpb.FileDescriptorProto) error
+	addFile = func(file *descriptorpb.FileDescriptorProto) error {
+		if files[file.GetName()] {
+			return nil
+		}
+		files[file.GetName()] = true
+		fileSet.File = append(fileSet.File, file)
+		// Add the dependencies which aren't referenced schemas from the global registry
+		for _, dependency := range file.GetDependency() {
+			if files[dependency] || isReference(dependency, references) {
+				continue
+			}
+			descriptor, err := protoregistry.GlobalFiles.FindFileByPath(dependency)
+			if err != nil {
+				return fmt.Errorf("unknown Protobuf dependency %s: %w", dependency, err)
+			}
+			if err := addFile(protodesc.ToFileDescriptorProto(descriptor)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, ref := range references {
+		file, err := unmarshalFileDescriptor(ref.name, ref.schema)
+		if err != nil {
+			return nil, fmt.Errorf("invalid referenced schema %s: %w", ref.name, err)
+		}
+		// The schemas import the references by their name
+		file.Name = proto.String(ref.name)
+		if err := addFile(file); err != nil {
+			return nil, err
+		}
+	}
+	file, err := unmarshalFileDescriptor(name, schema)
+	if err != nil {
+		return nil, err
+	}
+	if err := addFile(file); err != nil {
+		return nil, err
+	}
+
+	registry, err := protodesc.NewFiles(fileSet)
+	if err != nil {
+		return nil, err
+	}
+	descriptor, err := registry.FindFileByPath(file.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &protobufDecoder{file: descriptor}, nil
+}
+
+// decode decodes the message whose indexes precede the Protobuf data
+func (d *protobufDecoder) decode(data []byte) ([]byte, error) {
+	indexes, data, err := readMessageIndexes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := d.file.Messages()
+	var descriptor protoreflect.MessageDescriptor
+	for _, index := range indexes {
+		if index < 0 || index >= int64(messages.Len()) {
+			return nil, fmt.Errorf("invalid Protobuf message index %d", index)
+		}
+		descriptor = messages.Get(int(index))
+		messages = descriptor.Messages()
+	}
+
+	message := dynamicpb.NewMessage(descriptor)
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(message)
+}
+
+// readMessageIndexes reads the indexes of the message (and its nested messages) in the file descriptor, which
+// are encoded as an array of zig-zag varints.  The single 0 byte is the shortcut of the first message.
+func readMessageIndexes(data []byte) ([]int64, []byte, error) {
+	count, n := binary.Varint(data)
+	if n <= 0 || count < 0 || count > int64(len(data)) {
+		return nil, nil, errors.New("invalid Protobuf message indexes")
+	}
+	data = data[n:]
+	if count == 0 {
+		return []int64{0}, data, nil
+	}
+
+	indexes := make([]int64, count)
+	for i := range indexes {
+		indexes[i], n = binary.Varint(data)
+		if n <= 0 {
+			return nil, nil, errors.New("invalid Protobuf message indexes")
+		}
+		data = data[n:]
+	}
+	return indexes, data, nil
+}
+
+// unmarshalFileDescriptor unmarshals the base64 serialized FileDescriptorProto, naming it if it has no name
+func unmarshalFileDescriptor(name string, schema string) (*descriptorpb.FileDescriptorProto, error) {
+	data, err := base64.StdEncoding.DecodeString(schema)
+	if err != nil {
+		return nil, err
+	}
+	file := &descriptorpb.FileDescriptorProto{}
+	if err := proto.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	if file.GetName() == "" {
+		file.Name = proto.String(name)
+	}
+	return file, nil
+}
+
+func isReference(name string, references []referencedSchema) bool {
+	for _, ref := range references {
+		if ref.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Reference is a reference of a Schema to the Schema of another subject version
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// Schema is a schema served by the stub Registry
+type Schema struct {
+	Subject    string
+	Version    int
+	SchemaType string
+	Schema     string
+	// Serialized is the base64 FileDescriptorProto of the Protobuf schemas
+	Serialized string
+	References []Reference
+}
+
+// Registry is a local stub of the Confluent schema registry, serving the registered schemas
+type Registry struct {
+	*httptest.Server
+
+	mutex       sync.Mutex
+	schemas     map[int32]Schema
+	requests    int
+	unavailable []string
+}
+
+// NewRegistry starts a stub Registry, which must be closed after use
+func NewRegistry() *Registry {
+	registry := &Registry{schemas: make(map[int32]Schema)}
+	registry.Server = httptest.NewServer(http.HandlerFunc(registry.serveHTTP))
+	return registry
+}
+
+// Register registers the schema of the ID
+func (r *Registry) Register(id int32, schema Schema) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.schemas[id] = schema
+}
+
+// SetUnavailable makes the Registry respond with a 503 to the requests whose path ends with one of
+// the suffixes (all of them for an empty suffix), and to none once called without suffixes
+func (r *Registry) SetUnavailable(suffixes ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.unavailable = suffixes
+}
+
+// Requests returns the number of requests served so far
+func (r *Registry) Requests() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.requests
+}
+
+func (r *Registry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.requests++
+
+	for _, suffix := range r.unavailable {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			writeJSON(w, map[string]interface{}{"error_code": 50301, "message": "Unavailable"})
+			return
+		}
+	}
+
+	serialized := req.URL.Query().Get("format") == "serialized"
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[0] == "schemas" && parts[1] == "ids":
+		if schema, ok := r.schemaOfID(parts[2]); ok {
+			writeJSON(w, schemaResponse(schema, serialized))
+			return
+		}
+	case len(parts) == 4 && parts[0] == "schemas" && parts[1] == "ids" && parts[3] == "versions":
+		if schema, ok := r.schemaOfID(parts[2]); ok && schema.Subject != "" {
+			writeJSON(w, []map[string]interface{}{{"subject": schema.Subject, "version": schema.Version}})
+			return
+		}
+	case len(parts) == 4 && parts[0] == "subjects" && parts[2] == "versions":
+		for _, schema := range r.schemas {
+			if schema.Subject == parts[1] && strconv.Itoa(schema.Version) == parts[3] {
+				writeJSON(w, schemaResponse(schema, serialized))
+				return
+			}
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+	writeJSON(w, map[string]interface{}{"error_code": 40403, "message": "Schema not found"})
+}
+
+func (r *Registry) schemaOfID(id string) (Schema, bool) {
+	parsed, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return Schema{}, false
+	}
+	schema, ok := r.schemas[int32(parsed)]
+	return schema, ok
+}
+
+func schemaResponse(schema Schema, serialized bool) map[string]interface{} {
+	response := map[string]interface{}{"schema": schema.Schema}
+	if serialized && schema.Serialized != "" {
+		response["schema"] = schema.Serialized
+	}
+	if schema.SchemaType != "" {
+		response["schemaType"] = schema.SchemaType
+	}
+	if len(schema.References) > 0 {
+		response["references"] = schema.References
+	}
+	return response
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Encode returns the data serialized with the Confluent wire format for the schema ID
+func Encode(id int32, data []byte) []byte {
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header[1:], uint32(id))
+	return append(header, data...)
+}
+
+// EncodeProtobuf returns the Protobuf data serialized with the Confluent wire format for the
+// schema ID, and the message indexes of its message type in the schema
+func EncodeProtobuf(id int32, indexes []int64, data []byte) []byte {
+	buffer := make([]byte, binary.MaxVarintLen64)
+	var encoded []byte
+	for _, value := range append([]int64{int64(len(indexes))}, indexes...) {
+		n := binary.PutVarint(buffer, value)
+		encoded = append(encoded, buffer[:n]...)
+	}
+	return Encode(id, append(encoded, data...))
+}